@@ -7,6 +7,7 @@ import (
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -43,6 +44,14 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			var allowedTools, deniedTools []string
+			if err := viper.UnmarshalKey("allowed_tools", &allowedTools); err != nil {
+				return fmt.Errorf("failed to unmarshal allowed tools: %w", err)
+			}
+			if err := viper.UnmarshalKey("denied_tools", &deniedTools); err != nil {
+				return fmt.Errorf("failed to unmarshal denied tools: %w", err)
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
 				Version:              version,
 				Host:                 viper.GetString("host"),
@@ -50,7 +59,17 @@ var (
 				EnabledToolsets:      enabledToolsets,
 				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
 				ReadOnly:             viper.GetBool("read-only"),
+				AllowedTools:         allowedTools,
+				DeniedTools:          deniedTools,
+				ToolPrefix:           viper.GetString("tool_prefix"),
+				MaxResourceBlobBytes: viper.GetInt64("max_resource_blob_bytes"),
+				MaxResultBytes:       viper.GetInt("max_result_bytes"),
+				IndentResults:        viper.GetBool("indent_results"),
+				MemoizeTTL:           viper.GetDuration("memoize_ttl"),
+				MemoizeMaxEntries:    viper.GetInt("memoize_max_entries"),
+				MaxRetries:           viper.GetInt("max_retries"),
 				ExportTranslations:   viper.GetBool("export-translations"),
+				TranslationsFile:     viper.GetString("translations-file"),
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
 			}
@@ -69,18 +88,38 @@ func init() {
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().StringSlice("allowed-tools", nil, "An optional comma separated list of tools to allow, all tools of enabled toolsets are allowed if empty")
+	rootCmd.PersistentFlags().StringSlice("denied-tools", nil, "An optional comma separated list of tools to exclude")
+	rootCmd.PersistentFlags().String("tool-prefix", "", "An optional prefix for all tool names, useful when composing with other MCP servers")
+	rootCmd.PersistentFlags().Int64("max-resource-blob-bytes", github.DefaultMaxResourceBlobBytes, "Size in bytes above which binary files read through repository resources are described instead of returned")
+	rootCmd.PersistentFlags().Int("max-result-bytes", 0, "Size in bytes above which JSON tool results are truncated, unlimited if 0")
+	rootCmd.PersistentFlags().Bool("indent-results", false, "Pretty-print JSON tool results")
+	rootCmd.PersistentFlags().Duration("memoize-ttl", 0, "How long the results of identical read-only tool calls are reused, for example 30s, disabled if 0. Writes of the session forget them, other changes can be seen late")
+	rootCmd.PersistentFlags().Int("memoize-max-entries", toolsets.DefaultMemoizeMaxEntries, "Maximum number of memoized read-only tool results")
+	rootCmd.PersistentFlags().Int("max-retries", github.DefaultRetryPolicy().MaxRetries, "Number of retries of GitHub API requests failing with a transient error, disabled if 0")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
+	rootCmd.PersistentFlags().String("translations-file", "", "Path to a JSON file of translation overrides, also used as the target of --export-translations")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("allowed_tools", rootCmd.PersistentFlags().Lookup("allowed-tools"))
+	_ = viper.BindPFlag("denied_tools", rootCmd.PersistentFlags().Lookup("denied-tools"))
+	_ = viper.BindPFlag("tool_prefix", rootCmd.PersistentFlags().Lookup("tool-prefix"))
+	_ = viper.BindPFlag("max_resource_blob_bytes", rootCmd.PersistentFlags().Lookup("max-resource-blob-bytes"))
+	_ = viper.BindPFlag("max_result_bytes", rootCmd.PersistentFlags().Lookup("max-result-bytes"))
+	_ = viper.BindPFlag("indent_results", rootCmd.PersistentFlags().Lookup("indent-results"))
+	_ = viper.BindPFlag("memoize_ttl", rootCmd.PersistentFlags().Lookup("memoize-ttl"))
+	_ = viper.BindPFlag("memoize_max_entries", rootCmd.PersistentFlags().Lookup("memoize-max-entries"))
+	_ = viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
+	_ = viper.BindPFlag("translations-file", rootCmd.PersistentFlags().Lookup("translations-file"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 
 	// Add subcommands
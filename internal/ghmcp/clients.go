@@ -0,0 +1,154 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/raw"
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// maxCachedClients bounds the number of clients kept for tokens and hosts supplied in the request context.
+const maxCachedClients = 64
+
+// githubClients are the REST, GraphQL and raw content clients for one token and host.
+type githubClients struct {
+	rest    *gogithub.Client
+	gqlHTTP *http.Client
+	gql     *githubv4.Client
+	raw     *raw.Client
+}
+
+func newGitHubClients(host apiHost, token string, userAgent string, retry github.RetryPolicy) *githubClients {
+	restHTTPClient := &http.Client{
+		Transport: &github.RetryTransport{
+			Transport: &apiResponseRecorderTransport{
+				transport: http.DefaultTransport,
+			},
+			Policy: retry,
+		},
+	}
+	restClient := gogithub.NewClient(restHTTPClient).WithAuthToken(token)
+	restClient.UserAgent = userAgent
+	restClient.BaseURL = host.baseRESTURL
+	restClient.UploadURL = host.uploadURL
+
+	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
+	// did the necessary API host parsing so that github.com will return the correct URL anyway.
+	gqlHTTPClient := &http.Client{
+		Transport: &bearerAuthTransport{
+			transport: &github.GQLResponseTransport{
+				// GraphQL requests are POSTs, only queries are safe to retry
+				Transport: &github.RetryTransport{
+					Transport: &apiResponseRecorderTransport{
+						transport: http.DefaultTransport,
+					},
+					Policy:    retry,
+					RetryPOST: github.IsGraphQLQuery,
+				},
+			},
+			token: token,
+		},
+	}
+	gqlClient := githubv4.NewEnterpriseClient(host.graphqlURL.String(), gqlHTTPClient)
+
+	return &githubClients{
+		rest:    restClient,
+		gqlHTTP: gqlHTTPClient,
+		gql:     gqlClient,
+		raw:     raw.NewClient(restClient, host.rawURL),
+	}
+}
+
+type clientCacheKey struct {
+	host  string
+	token string
+}
+
+// clientCache returns the server's clients, or clients for the token and host found in the request
+// context. Those are cached, so that transports are not rebuilt on every tool call.
+type clientCache struct {
+	defaults     *githubClients
+	defaultHost  apiHost
+	defaultToken string
+	retry        github.RetryPolicy
+
+	mu        sync.Mutex
+	userAgent string
+	clients   map[clientCacheKey]*githubClients
+	// order holds the cache keys from the oldest to the newest, to evict the oldest when the cache is full
+	order []clientCacheKey
+}
+
+func newClientCache(host apiHost, token string, userAgent string, retry github.RetryPolicy) *clientCache {
+	return &clientCache{
+		defaults:     newGitHubClients(host, token, userAgent, retry),
+		defaultHost:  host,
+		defaultToken: token,
+		retry:        retry,
+		userAgent:    userAgent,
+		clients:      make(map[clientCacheKey]*githubClients),
+	}
+}
+
+// setUserAgent sets the user agent of the server's clients and of clients created from now on.
+func (c *clientCache) setUserAgent(userAgent string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userAgent = userAgent
+
+	c.defaults.rest.UserAgent = userAgent
+	c.defaults.gqlHTTP.Transport = &userAgentTransport{
+		transport: c.defaults.gqlHTTP.Transport,
+		agent:     userAgent,
+	}
+}
+
+func (c *clientCache) forContext(ctx context.Context) (*githubClients, error) {
+	token, hasToken := github.TokenFromContext(ctx)
+	hostOverride, hasHost := github.APIHostFromContext(ctx)
+	if !hasToken && !hasHost {
+		return c.defaults, nil
+	}
+	if !hasToken {
+		// The server token must not be sent to a host chosen per request
+		return nil, fmt.Errorf("an API host set in the request context requires a token in the context as well")
+	}
+
+	key := clientCacheKey{host: hostOverride, token: token}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if clients, ok := c.clients[key]; ok {
+		return clients, nil
+	}
+
+	host := c.defaultHost
+	if hasHost {
+		var err error
+		host, err = parseAPIHost(hostOverride)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse API host from context: %w", err)
+		}
+	}
+
+	if len(c.order) >= maxCachedClients {
+		delete(c.clients, c.order[0])
+		c.order = c.order[1:]
+	}
+
+	clients := newGitHubClients(host, token, c.userAgent, c.retry)
+	clients.gqlHTTP.Transport = &userAgentTransport{
+		transport: clients.gqlHTTP.Transport,
+		agent:     c.userAgent,
+	}
+	c.clients[key] = clients
+	c.order = append(c.order, key)
+
+	return clients, nil
+}
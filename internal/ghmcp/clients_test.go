@@ -0,0 +1,180 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// authRecorder is a fake GitHub API that records the Authorization header of each request
+// and echoes it back as the login of the authenticated user.
+type authRecorder struct {
+	mu      sync.Mutex
+	headers []string
+}
+
+func (a *authRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.headers = append(a.headers, r.Header.Get("Authorization"))
+	a.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/api/graphql" {
+		_, _ = w.Write([]byte(`{"data": {"viewer": {"login": "` + r.Header.Get("Authorization") + `"}}}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"login": "` + r.Header.Get("Authorization") + `"}`))
+}
+
+func newTestAPIHost(t *testing.T, serverURL string) apiHost {
+	t.Helper()
+	parse := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return u
+	}
+	return apiHost{
+		baseRESTURL: parse(serverURL + "/api/v3/"),
+		graphqlURL:  parse(serverURL + "/api/graphql"),
+		uploadURL:   parse(serverURL + "/api/uploads/"),
+		rawURL:      parse(serverURL + "/raw/"),
+	}
+}
+
+func Test_clientCache_ContextTokens(t *testing.T) {
+	recorder := &authRecorder{}
+	ts := httptest.NewServer(recorder)
+	defer ts.Close()
+
+	clients := newClientCache(newTestAPIHost(t, ts.URL), "server-token", "test", github.RetryPolicy{})
+
+	tokens := []string{"alice-token", "bob-token"}
+	logins := make([]string, len(tokens))
+	viewers := make([]string, len(tokens))
+
+	var wg sync.WaitGroup
+	for i, token := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := github.ContextWithToken(context.Background(), token)
+
+			c, err := clients.forContext(ctx)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			user, _, err := c.rest.Users.Get(ctx, "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			logins[i] = user.GetLogin()
+
+			var q struct {
+				Viewer struct {
+					Login githubv4.String
+				}
+			}
+			if !assert.NoError(t, c.gql.Query(ctx, &q, nil)) {
+				return
+			}
+			viewers[i] = string(q.Viewer.Login)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"Bearer alice-token", "Bearer bob-token"}, logins)
+	assert.Equal(t, []string{"Bearer alice-token", "Bearer bob-token"}, viewers)
+	assert.Len(t, recorder.headers, 4)
+}
+
+func Test_clientCache_Reuse(t *testing.T) {
+	host := newTestAPIHost(t, "http://localhost")
+	clients := newClientCache(host, "server-token", "test", github.RetryPolicy{})
+
+	defaults, err := clients.forContext(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, clients.defaults, defaults)
+
+	ctx := github.ContextWithToken(context.Background(), "alice-token")
+	first, err := clients.forContext(ctx)
+	require.NoError(t, err)
+	second, err := clients.forContext(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.NotSame(t, defaults, first)
+
+	other, err := clients.forContext(github.ContextWithToken(context.Background(), "bob-token"))
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+
+	_, err = clients.forContext(github.ContextWithAPIHost(ctx, "not a url"))
+	assert.Error(t, err)
+}
+
+func Test_clientCache_EvictsOldest(t *testing.T) {
+	clients := newClientCache(newTestAPIHost(t, "http://localhost"), "server-token", "test", github.RetryPolicy{})
+
+	first, err := clients.forContext(github.ContextWithToken(context.Background(), "token-0"))
+	require.NoError(t, err)
+	for i := 1; i <= maxCachedClients; i++ {
+		_, err := clients.forContext(github.ContextWithToken(context.Background(), fmt.Sprintf("token-%d", i)))
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, clients.clients, maxCachedClients)
+	again, err := clients.forContext(github.ContextWithToken(context.Background(), "token-0"))
+	require.NoError(t, err)
+	assert.NotSame(t, first, again)
+}
+
+// hostAuthTransport records the Authorization header sent to each host and answers every request itself,
+// so that hosts parsed without a port, as context hosts are, can be observed.
+type hostAuthTransport struct {
+	mu      sync.Mutex
+	headers map[string][]string
+}
+
+func (h *hostAuthTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	h.mu.Lock()
+	h.headers[r.URL.Host] = append(h.headers[r.URL.Host], r.Header.Get("Authorization"))
+	h.mu.Unlock()
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	_, _ = rec.WriteString(`{"login": "octocat"}`)
+	return rec.Result(), nil
+}
+
+func Test_clientCache_HostWithoutToken(t *testing.T) {
+	transport := &hostAuthTransport{headers: map[string][]string{}}
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	clients := newClientCache(newTestAPIHost(t, "http://server.example.com"), "server-token", "test", github.RetryPolicy{})
+
+	ctx := github.ContextWithAPIHost(context.Background(), "https://github.attacker.example")
+	_, err := clients.forContext(ctx)
+	require.Error(t, err)
+	assert.Empty(t, clients.clients)
+
+	// With a token in the context, only that token reaches the overridden host
+	c, err := clients.forContext(github.ContextWithToken(ctx, "alice-token"))
+	require.NoError(t, err)
+	_, _, err = c.rest.Users.Get(ctx, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"github.attacker.example": {"Bearer alice-token"},
+	}, transport.headers)
+}
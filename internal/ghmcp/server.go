@@ -2,6 +2,9 @@ package ghmcp
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,10 +14,12 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -44,6 +49,38 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// AllowedTools, if non-empty, restricts the registered tools to the listed tool names
+	AllowedTools []string
+
+	// DeniedTools is a list of tool names that should never be registered
+	DeniedTools []string
+
+	// ToolPrefix is prepended to the names of all registered tools and resource templates
+	ToolPrefix string
+
+	// Instrumenter, if set, is notified around every tool invocation
+	Instrumenter toolsets.Instrumenter
+
+	// AuditLogger, if set, receives an entry for every write tool invocation
+	AuditLogger toolsets.AuditLogger
+
+	// AuditActor, if set, returns who made a tool call, e.g. from an identity stored in its context,
+	// recorded as the actor of audit entries
+	AuditActor toolsets.AuditActorFunc
+
+	// MemoizeTTL, if positive, is how long the results of identical read-only tool calls are reused
+	MemoizeTTL time.Duration
+
+	// MemoizeMaxEntries is the number of memoized results kept, zero selects toolsets.DefaultMemoizeMaxEntries
+	MemoizeMaxEntries int
+
+	// MaxRetries is the number of retries of GitHub API requests failing with a transient error, within
+	// the delays of github.DefaultRetryPolicy. Zero disables retries.
+	MaxRetries int
+
+	// ContentLimits bounds the size of the content returned by tools and resources
+	ContentLimits github.ContentLimits
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 }
@@ -54,22 +91,11 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
-	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
-	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
-	restClient.BaseURL = apiHost.baseRESTURL
-	restClient.UploadURL = apiHost.uploadURL
-
-	// Construct our GraphQL client
-	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
-	// did the necessary API host parsing so that github.com will return the correct URL anyway.
-	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
-		},
-	} // We're going to wrap the Transport later in beforeInit
-	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+	// Construct our REST, GraphQL and raw clients. Requests that carry their own token or host in
+	// the context get clients of their own, see github.ContextWithToken.
+	retry := github.DefaultRetryPolicy()
+	retry.MaxRetries = cfg.MaxRetries
+	clients := newClientCache(apiHost, cfg.Token, fmt.Sprintf("github-mcp-server/%s", cfg.Version), retry)
 
 	// When a client send an initialize request, update the user agent to include the client info.
 	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
@@ -80,12 +106,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 			message.Params.ClientInfo.Version,
 		)
 
-		restClient.UserAgent = userAgent
-
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
-		}
+		clients.setUserAgent(userAgent)
 	}
 
 	hooks := &server.Hooks{
@@ -105,24 +126,48 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return restClient, nil // closing over client
+	getClient := func(ctx context.Context) (*gogithub.Client, error) {
+		c, err := clients.forContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return c.rest, nil
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
-		return gqlClient, nil // closing over client
+	getGQLClient := func(ctx context.Context) (*githubv4.Client, error) {
+		c, err := clients.forContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return c.gql, nil
 	}
 
 	getRawClient := func(ctx context.Context) (*raw.Client, error) {
-		client, err := getClient(ctx)
+		c, err := clients.forContext(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			return nil, err
 		}
-		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
+		return c.raw, nil
 	}
 
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator)
+	toolsetOpts := []toolsets.ToolsetGroupOption{
+		toolsets.WithToolFilter(cfg.AllowedTools, cfg.DeniedTools),
+		toolsets.WithToolPrefix(cfg.ToolPrefix),
+		toolsets.WithInstrumenter(cfg.Instrumenter),
+	}
+	if cfg.AuditLogger != nil {
+		toolsetOpts = append(toolsetOpts, toolsets.WithAuditLogger(cfg.AuditLogger), toolsets.WithAuditActor(cfg.AuditActor))
+	}
+	if cfg.MemoizeTTL > 0 {
+		memoizer := toolsets.NewMemoizer(cfg.MemoizeTTL, cfg.MemoizeMaxEntries, toolsets.WithMemoizeScope(memoizeScope))
+		toolsetOpts = append(toolsetOpts, toolsets.WithMemoizer(memoizer))
+	}
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator, cfg.ContentLimits, toolsetOpts...)
+	if err := tsg.ValidateToolFilter(); err != nil {
+		return nil, fmt.Errorf("invalid tool filter: %w", err)
+	}
+
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
@@ -131,6 +176,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 
 	// Register all mcp functionality with the server
 	tsg.RegisterAll(ghServer)
+	github.RegisterPrompts(ghServer, tsg, cfg.Translator)
 
 	if cfg.DynamicToolsets {
 		dynamic := github.InitDynamicToolset(ghServer, tsg, cfg.Translator)
@@ -140,6 +186,18 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	return ghServer, nil
 }
 
+// memoizeScope separates the memoized tool results of requests carrying their own token or host, see
+// github.ContextWithToken. The token is hashed so that it is not kept in the memoization keys.
+func memoizeScope(ctx context.Context) string {
+	token, hasToken := github.TokenFromContext(ctx)
+	host, hasHost := github.APIHostFromContext(ctx)
+	if !hasToken && !hasHost {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(host + "\x00" + token))
+	return hex.EncodeToString(sum[:])
+}
+
 type StdioServerConfig struct {
 	// Version of the server
 	Version string
@@ -161,10 +219,43 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// AllowedTools, if non-empty, restricts the registered tools to the listed tool names
+	AllowedTools []string
+
+	// DeniedTools is a list of tool names that should never be registered
+	DeniedTools []string
+
+	// ToolPrefix is prepended to the names of all registered tools and resource templates
+	ToolPrefix string
+
+	// MaxResourceBlobBytes is the size above which binary repository resources are described instead of
+	// returned, zero selects github.DefaultMaxResourceBlobBytes
+	MaxResourceBlobBytes int64
+
+	// MaxResultBytes, if positive, caps the size of JSON tool results, larger results are truncated
+	MaxResultBytes int
+
+	// IndentResults pretty-prints JSON tool results
+	IndentResults bool
+
+	// MemoizeTTL, if positive, is how long the results of identical read-only tool calls are reused
+	MemoizeTTL time.Duration
+
+	// MemoizeMaxEntries is the number of memoized results kept, zero selects toolsets.DefaultMemoizeMaxEntries
+	MemoizeMaxEntries int
+
+	// MaxRetries is the number of retries of GitHub API requests failing with a transient error, within
+	// the delays of github.DefaultRetryPolicy. Zero disables retries.
+	MaxRetries int
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
+	// TranslationsFile is the path to a JSON file of translation overrides. When set, translations are
+	// loaded from and exported to this file instead of github-mcp-server-config.json
+	TranslationsFile string
+
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
@@ -179,15 +270,41 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	defer stop()
 
 	t, dumpTranslations := translations.TranslationHelper()
+	if cfg.TranslationsFile != "" {
+		overrides, err := translations.LoadOverridesFile(cfg.TranslationsFile)
+		// A missing file is fine when we are about to export a template to it
+		if err != nil && (!cfg.ExportTranslations || !errors.Is(err, os.ErrNotExist)) {
+			return fmt.Errorf("failed to load translations: %w", err)
+		}
+
+		var resolvedTranslations func() map[string]string
+		t, resolvedTranslations = translations.OverrideTranslationHelper(overrides)
+		dumpTranslations = func() {
+			if err := translations.ExportTranslationsFile(cfg.TranslationsFile, resolvedTranslations()); err != nil {
+				log.Fatalf("Could not export translations: %v", err)
+			}
+		}
+	}
 
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
+		Version:           cfg.Version,
+		Host:              cfg.Host,
+		Token:             cfg.Token,
+		EnabledToolsets:   cfg.EnabledToolsets,
+		DynamicToolsets:   cfg.DynamicToolsets,
+		ReadOnly:          cfg.ReadOnly,
+		AllowedTools:      cfg.AllowedTools,
+		DeniedTools:       cfg.DeniedTools,
+		ToolPrefix:        cfg.ToolPrefix,
+		MemoizeTTL:        cfg.MemoizeTTL,
+		MemoizeMaxEntries: cfg.MemoizeMaxEntries,
+		MaxRetries:        cfg.MaxRetries,
+		ContentLimits: github.ContentLimits{
+			MaxResourceBlobBytes: cfg.MaxResourceBlobBytes,
+			MaxResultBytes:       cfg.MaxResultBytes,
+			IndentResults:        cfg.IndentResults,
+		},
+		Translator: t,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -397,3 +514,17 @@ func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	req.Header.Set("Authorization", "Bearer "+t.token)
 	return t.transport.RoundTrip(req)
 }
+
+// apiResponseRecorderTransport records the status code and rate limit of every GitHub API response
+// against the tool invocation in progress, so that they can be reported to the configured instrumenter.
+type apiResponseRecorderTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *apiResponseRecorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err == nil {
+		toolsets.RecordAPIResponse(req.Context(), resp)
+	}
+	return resp, err
+}
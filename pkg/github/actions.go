@@ -1,14 +1,24 @@
 package github
 
 import (
+	"archive/zip"
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -20,6 +30,17 @@ const (
 	DescriptionRepositoryName  = "Repository name"
 )
 
+// defaultJobLogTailLines is the number of lines get_job_logs returns from the end of each log unless
+// tail_lines is given
+const defaultJobLogTailLines = 500
+
+// jobLogConcurrency is the number of job logs get_job_logs retrieves at the same time for the jobs of a run
+const jobLogConcurrency = 5
+
+// defaultJobLogMaxBytes is the number of bytes get_job_logs keeps from the end of each downloaded log
+// unless max_bytes is given, so a huge log can't exhaust the memory of the server
+const defaultJobLogMaxBytes = 256 * 1024
+
 // ListWorkflows creates a tool to list workflows in a repository
 func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflows",
@@ -54,11 +75,7 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 
 			// Get optional pagination parameters
-			perPage, err := OptionalIntParam(request, "per_page")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			page, err := OptionalIntParam(request, "page")
+			pagination, err := OptionalNamedPaginationParams(request, "page", "per_page")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -70,8 +87,8 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 
 			// Set up list options
 			opts := &github.ListOptions{
-				PerPage: perPage,
-				Page:    page,
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
 			}
 
 			workflows, resp, err := client.Actions.ListWorkflows(ctx, owner, repo, opts)
@@ -156,12 +173,22 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Returns workflow runs with the check run status"),
 				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
 			),
+			mcp.WithString("created",
+				mcp.Description("Returns workflow runs created in a date range, using the GitHub search syntax such as >=2024-01-01 or 2024-01-01..2024-01-31"),
+			),
+			mcp.WithString("head_sha",
+				mcp.Description("Returns workflow runs of a commit. Use the full SHA of the commit."),
+			),
+			mcp.WithBoolean("exclude_pull_requests",
+				mcp.Description("Omits the pull_requests array of each run, which makes the results smaller"),
+			),
 			mcp.WithNumber("per_page",
 				mcp.Description("The number of results per page (max 100)"),
 			),
 			mcp.WithNumber("page",
 				mcp.Description("The page number of the results to fetch"),
 			),
+			WithOutputFormat(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -194,13 +221,25 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			created, err := OptionalParam[string](request, "created")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			headSHA, err := OptionalParam[string](request, "head_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludePullRequests, err := OptionalParam[bool](request, "exclude_pull_requests")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			// Get optional pagination parameters
-			perPage, err := OptionalIntParam(request, "per_page")
+			pagination, err := OptionalNamedPaginationParams(request, "page", "per_page")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			page, err := OptionalIntParam(request, "page")
+			format, err := OptionalOutputFormat(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -212,13 +251,16 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 
 			// Set up list options
 			opts := &github.ListWorkflowRunsOptions{
-				Actor:  actor,
-				Branch: branch,
-				Event:  event,
-				Status: status,
+				Actor:               actor,
+				Branch:              branch,
+				Event:               event,
+				Status:              status,
+				Created:             created,
+				HeadSHA:             headSHA,
+				ExcludePullRequests: excludePullRequests,
 				ListOptions: github.ListOptions{
-					PerPage: perPage,
-					Page:    page,
+					PerPage: pagination.perPage,
+					Page:    pagination.page,
 				},
 			}
 
@@ -228,6 +270,10 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			if format == OutputFormatMarkdown {
+				return mcp.NewToolResultText(renderWorkflowRunListMarkdown(workflowRuns)), nil
+			}
+
 			r, err := json.Marshal(workflowRuns)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -237,6 +283,28 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// renderWorkflowRunListMarkdown renders workflow runs as a markdown table for the markdown format of list_workflow_runs.
+func renderWorkflowRunListMarkdown(runs *github.WorkflowRuns) string {
+	if len(runs.WorkflowRuns) == 0 {
+		return "_No workflow runs found._\n"
+	}
+	rows := make([][]string, 0, len(runs.WorkflowRuns))
+	for _, run := range runs.WorkflowRuns {
+		rows = append(rows, []string{
+			strconv.FormatInt(run.GetID(), 10),
+			fmt.Sprintf("#%d", run.GetRunNumber()),
+			orDash(run.GetDisplayTitle()),
+			run.GetStatus(),
+			orDash(run.GetConclusion()),
+			run.GetHeadBranch(),
+			run.GetEvent(),
+			formatListTime(run.GetCreatedAt()),
+		})
+	}
+	return fmt.Sprintf("Showing %d of %d workflow runs.\n\n", len(runs.WorkflowRuns), runs.GetTotalCount()) +
+		markdownTable([]string{"ID", "Run", "Title", "Status", "Conclusion", "Branch", "Event", "Created"}, rows)
+}
+
 // RunWorkflow creates a tool to run an Actions workflow
 func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("run_workflow",
@@ -262,7 +330,7 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("The git reference for the workflow. The reference can be a branch or tag name."),
 			),
 			mcp.WithObject("inputs",
-				mcp.Description("Inputs the workflow accepts"),
+				mcp.Description("Inputs the workflow accepts, use get_workflow_dispatch_inputs to find their names and allowed values"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -301,17 +369,14 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Inputs: inputs,
 			}
 
-			var resp *github.Response
-			var workflowType string
-
-			if workflowIDInt, parseErr := strconv.ParseInt(workflowID, 10, 64); parseErr == nil {
-				resp, err = client.Actions.CreateWorkflowDispatchEventByID(ctx, owner, repo, workflowIDInt, event)
-				workflowType = "workflow_id"
-			} else {
-				resp, err = client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflowID, event)
-				workflowType = "workflow_file"
-			}
-
+			resp, workflowType, err := resolveWorkflow(workflowID,
+				func(id int64) (*github.Response, error) {
+					return client.Actions.CreateWorkflowDispatchEventByID(ctx, owner, repo, id, event)
+				},
+				func(fileName string) (*github.Response, error) {
+					return client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, fileName, event)
+				},
+			)
 			if err != nil {
 				return nil, fmt.Errorf("failed to run workflow: %w", err)
 			}
@@ -336,6 +401,132 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// resolveWorkflow calls byID when the workflow_id parameter of a tool is a numeric workflow ID, and
+// byFileName when it is a workflow file name such as main.yml. It returns the response of the call
+// and the workflow_type reported in tool results, "workflow_id" or "workflow_file".
+func resolveWorkflow(workflowID string, byID func(id int64) (*github.Response, error), byFileName func(fileName string) (*github.Response, error)) (*github.Response, string, error) {
+	if id, err := strconv.ParseInt(workflowID, 10, 64); err == nil {
+		resp, err := byID(id)
+		return resp, "workflow_id", err
+	}
+	resp, err := byFileName(workflowID)
+	return resp, "workflow_file", err
+}
+
+// EnableWorkflow creates a tool to enable a disabled workflow
+func EnableWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_workflow",
+			mcp.WithDescription(t("TOOL_ENABLE_WORKFLOW_DESCRIPTION", "Enable an Actions workflow by workflow ID or filename, so that its triggers run it again")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ENABLE_WORKFLOW_USER_TITLE", "Enable workflow"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setWorkflowEnabled(ctx, getClient, request, true)
+		}
+}
+
+// DisableWorkflow creates a tool to disable a workflow
+func DisableWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_workflow",
+			mcp.WithDescription(t("TOOL_DISABLE_WORKFLOW_DESCRIPTION", "Disable an Actions workflow by workflow ID or filename, so that none of its triggers run it until it is enabled again")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISABLE_WORKFLOW_USER_TITLE", "Disable workflow"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setWorkflowEnabled(ctx, getClient, request, false)
+		}
+}
+
+// setWorkflowEnabled handles enable_workflow and disable_workflow, the workflow is identified by ID
+// or file name as for run_workflow.
+func setWorkflowEnabled(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, enable bool) (*mcp.CallToolResult, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	workflowID, err := RequiredParam[string](request, "workflow_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	byID, byFileName := client.Actions.DisableWorkflowByID, client.Actions.DisableWorkflowByFileName
+	if enable {
+		byID, byFileName = client.Actions.EnableWorkflowByID, client.Actions.EnableWorkflowByFileName
+	}
+	resp, workflowType, err := resolveWorkflow(workflowID,
+		func(id int64) (*github.Response, error) {
+			return byID(ctx, owner, repo, id)
+		},
+		func(fileName string) (*github.Response, error) {
+			return byFileName(ctx, owner, repo, fileName)
+		},
+	)
+
+	action := "disable"
+	message := "Workflow has been disabled"
+	if enable {
+		action = "enable"
+		message = "Workflow has been enabled"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s workflow: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result := map[string]any{
+		"message":       message,
+		"action":        action,
+		"workflow_type": workflowType,
+		"workflow_id":   workflowID,
+		"status":        resp.Status,
+		"status_code":   resp.StatusCode,
+	}
+
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
 // GetWorkflowRun creates a tool to get details of a specific workflow run
 func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_workflow_run",
@@ -395,7 +586,7 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 // GetWorkflowRunLogs creates a tool to download logs for a specific workflow run
 func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_workflow_run_logs",
-			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_LOGS_DESCRIPTION", "Download logs for a specific workflow run (EXPENSIVE: downloads ALL logs as ZIP. Consider using get_job_logs with failed_only=true for debugging failed jobs)")),
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_LOGS_DESCRIPTION", "Download logs for a specific workflow run (EXPENSIVE: downloads ALL logs as ZIP. Consider using get_job_logs with failed_only=true for debugging failed jobs). Returns the URL of the ZIP archive, or the log content of each job with return_content")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_WORKFLOW_RUN_LOGS_USER_TITLE", "Get workflow run logs"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -412,6 +603,17 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithBoolean("return_content",
+				mcp.Description("Downloads the ZIP archive and returns the log content of each job instead of the URL"),
+			),
+			mcp.WithNumber("tail_lines",
+				mcp.Description(fmt.Sprintf("With return_content, the number of lines to keep from the end of each job log, or 0 for the whole log (default %d)", defaultJobLogTailLines)),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("With return_content, the number of bytes to keep from the end of each job log, earlier bytes are skipped (default %d). Applied before tail_lines", defaultJobLogMaxBytes)),
+				mcp.Min(1),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -427,6 +629,14 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			runID := int64(runIDInt)
+			returnContent, err := OptionalParam[bool](request, "return_content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tailLines, maxBytes, err := optionalLogLimitParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -440,6 +650,32 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			if returnContent {
+				data, err := downloadArtifactArchive(ctx, url.String(), defaultMaxArtifactBytes, NewProgressReporter(ctx, request))
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%v, use get_job_logs to get the logs of a single job", err)), nil
+				}
+				logs, stats, err := readRunLogArchive(data, maxBytes, tailLines)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				result := map[string]any{
+					"run_id":        runID,
+					"message":       fmt.Sprintf("Retrieved logs of %d jobs", len(logs)),
+					"logs":          logs,
+					"skipped_files": stats.skippedFiles,
+					"skipped_bytes": stats.skippedBytes,
+				}
+
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
 			// Create response with the logs URL and information
 			result := map[string]any{
 				"logs_url":         url.String(),
@@ -458,6 +694,188 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 		}
 }
 
+// runLogArchiveStats counts what readRunLogArchive left out of the logs because of the limits.
+type runLogArchiveStats struct {
+	// skippedFiles is the number of step logs left out entirely
+	skippedFiles int
+	// skippedBytes is the number of bytes left out, including those of the truncated step logs
+	skippedBytes int64
+}
+
+// readRunLogArchive reads the ZIP archive of the logs of a workflow run, which has a folder per job
+// holding a file per step named after the step number, such as "build/1_Set up job.txt". It returns
+// the log of each job keyed by the name of its folder, made of its steps in order. The top level files
+// of the archive repeat the content of the folders and are ignored.
+// Like the job logs, each log keeps its last maxBytes bytes, then its last tailLines lines unless it
+// is 0. The steps are read from the last one, so that the earlier ones are not inflated when the later
+// ones already fill maxBytes.
+func readRunLogArchive(data []byte, maxBytes, tailLines int) (map[string]string, runLogArchiveStats, error) {
+	var stats runLogArchiveStats
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to read workflow run logs archive: %w", err)
+	}
+
+	steps := map[string][]*zip.File{}
+	for _, f := range archive.File {
+		if !safeArchivePath(f.Name) {
+			return nil, stats, fmt.Errorf("workflow run logs archive contains an unsafe path: %s", f.Name)
+		}
+		job, _, found := strings.Cut(f.Name, "/")
+		if !found || f.FileInfo().IsDir() {
+			continue
+		}
+		steps[job] = append(steps[job], f)
+	}
+
+	logs := make(map[string]string, len(steps))
+	for job, files := range steps {
+		sort.SliceStable(files, func(i, j int) bool {
+			return stepLogNumber(files[i].Name) < stepLogNumber(files[j].Name)
+		})
+
+		remaining := maxBytes
+		parts := make([]string, len(files))
+		for i := len(files) - 1; i >= 0; i-- {
+			if remaining <= 0 {
+				stats.skippedFiles++
+				stats.skippedBytes += int64(files[i].UncompressedSize64)
+				continue
+			}
+			content, skipped, err := readStepLog(files[i], remaining)
+			if err != nil {
+				return nil, stats, err
+			}
+			stats.skippedBytes += skipped
+			if content == "" && skipped > 0 {
+				// Not even the last line of the step fitted
+				stats.skippedFiles++
+			}
+			remaining -= len(content)
+			parts[i] = content
+		}
+
+		content := strings.TrimSpace(strings.Join(parts, ""))
+		content, _ = tailLogLines(content, tailLines)
+		logs[job] = content
+	}
+	return logs, stats, nil
+}
+
+// readStepLog reads the whole lines among the last maxBytes bytes of a step log, and returns the
+// number of bytes skipped.
+func readStepLog(file *zip.File, maxBytes int) (string, int64, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open %s in workflow run logs archive: %w", file.Name, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	tail := &tailBuffer{limit: maxBytes}
+	n, err := io.Copy(tail, rc)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s in workflow run logs archive: %w", file.Name, err)
+	}
+	content, _ := tail.Lines()
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		// Keep the next step on a line of its own
+		content = append(content, '\n')
+	}
+	return string(content), max(n-int64(len(content)), 0), nil
+}
+
+// stepLogNumber returns the step number a step log file name starts with, such as 12 for
+// "build/12_Run tests.txt", or 0 if there is none.
+func stepLogNumber(name string) int {
+	prefix, _, _ := strings.Cut(path.Base(name), "_")
+	n, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// GetWorkflowRunAttempt creates a tool to get details of a specific attempt of a workflow run
+func GetWorkflowRunAttempt(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_run_attempt",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_ATTEMPT_DESCRIPTION", "Get details of a specific attempt of a re-run workflow run, to compare it with other attempts. The previous_attempt_url of an attempt links to the attempt before it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_RUN_ATTEMPT_USER_TITLE", "Get workflow run attempt"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithNumber("attempt_number",
+				mcp.Required(),
+				mcp.Description("The attempt number of the workflow run, starting at 1"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			attemptNumber, err := requiredAttemptNumber(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			workflowRun, resp, err := client.Actions.GetWorkflowRunAttempt(ctx, owner, repo, runID, attemptNumber, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get attempt %d of workflow run: %w", attemptNumber, err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(workflowRun)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// requiredAttemptNumber returns the attempt_number parameter. RequiredInt treats 0 as missing, so
+// the parameter is read as optional to report attempt numbers below 1 as invalid instead.
+func requiredAttemptNumber(request mcp.CallToolRequest) (int, error) {
+	if _, ok := request.GetArguments()["attempt_number"]; !ok {
+		return 0, fmt.Errorf("missing required parameter: attempt_number")
+	}
+	attemptNumber, err := OptionalIntParam(request, "attempt_number")
+	if err != nil {
+		return 0, err
+	}
+	if attemptNumber < 1 {
+		return 0, fmt.Errorf("attempt_number must be at least 1")
+	}
+	return attemptNumber, nil
+}
+
 // ListWorkflowJobs creates a tool to list jobs for a specific workflow run
 func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_jobs",
@@ -479,9 +897,13 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("The unique identifier of the workflow run"),
 			),
 			mcp.WithString("filter",
-				mcp.Description("Filters jobs by their completed_at timestamp"),
+				mcp.Description("Filters jobs by their completed_at timestamp. Ignored when attempt_number is set"),
 				mcp.Enum("latest", "all"),
 			),
+			mcp.WithNumber("attempt_number",
+				mcp.Description("List the jobs of this attempt of the workflow run instead of the latest one, starting at 1"),
+				mcp.Min(1),
+			),
 			mcp.WithNumber("per_page",
 				mcp.Description("The number of results per page (max 100)"),
 			),
@@ -510,12 +932,17 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Get optional pagination parameters
-			perPage, err := OptionalIntParam(request, "per_page")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			var attemptNumber int
+			_, hasAttempt := request.GetArguments()["attempt_number"]
+			if hasAttempt {
+				attemptNumber, err = requiredAttemptNumber(request)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
 			}
-			page, err := OptionalIntParam(request, "page")
+
+			// Get optional pagination parameters
+			pagination, err := OptionalNamedPaginationParams(request, "page", "per_page")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -525,18 +952,27 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Set up list options
-			opts := &github.ListWorkflowJobsOptions{
-				Filter: filter,
-				ListOptions: github.ListOptions{
-					PerPage: perPage,
-					Page:    page,
-				},
+			listOpts := github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
 			}
 
-			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list workflow jobs: %w", err)
+			var jobs *github.Jobs
+			var resp *github.Response
+			if hasAttempt {
+				jobs, resp, err = client.Actions.ListWorkflowJobsAttempt(ctx, owner, repo, runID, int64(attemptNumber), &listOpts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list workflow jobs of attempt %d: %w", attemptNumber, err)
+				}
+			} else {
+				opts := &github.ListWorkflowJobsOptions{
+					Filter:      filter,
+					ListOptions: listOpts,
+				}
+				jobs, resp, err = client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list workflow jobs: %w", err)
+				}
 			}
 			defer func() { _ = resp.Body.Close() }()
 
@@ -558,7 +994,7 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 // GetJobLogs creates a tool to download logs for a specific workflow job or efficiently get all failed job logs for a workflow run
 func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_job_logs",
-			mcp.WithDescription(t("TOOL_GET_JOB_LOGS_DESCRIPTION", "Download logs for a specific workflow job or efficiently get all failed job logs for a workflow run")),
+			mcp.WithDescription(t("TOOL_GET_JOB_LOGS_DESCRIPTION", "Download logs for a specific workflow job, or efficiently get the logs of all failed jobs, or of every job, of a workflow run")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_JOB_LOGS_USER_TITLE", "Get job logs"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -575,14 +1011,39 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("The unique identifier of the workflow job (required for single job logs)"),
 			),
 			mcp.WithNumber("run_id",
-				mcp.Description("Workflow run ID (required when using failed_only)"),
+				mcp.Description("Workflow run ID (required when using failed_only or all_jobs)"),
 			),
 			mcp.WithBoolean("failed_only",
 				mcp.Description("When true, gets logs for all failed jobs in run_id"),
 			),
+			mcp.WithBoolean("all_jobs",
+				mcp.Description("When true, gets logs for every job in run_id, whatever its conclusion"),
+			),
 			mcp.WithBoolean("return_content",
 				mcp.Description("Returns actual log content instead of URLs"),
 			),
+			mcp.WithNumber("tail_lines",
+				mcp.Description(fmt.Sprintf("With return_content, the number of lines to keep from the end of each log, or 0 for the whole log (default %d). Applied after grep", defaultJobLogTailLines)),
+				mcp.Min(0),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("With return_content, the number of bytes to keep from the end of each downloaded log, or of the log of the step with step_name or step_number, earlier bytes are truncated (default %d). Applied before grep and tail_lines", defaultJobLogMaxBytes)),
+				mcp.Min(1),
+			),
+			mcp.WithString("grep",
+				mcp.Description("With return_content, a Go regular expression such as ERROR|FAIL, only the log lines matching it are returned"),
+			),
+			mcp.WithNumber("grep_context",
+				mcp.Description("The number of lines to include before and after each line matching grep"),
+				mcp.Min(0),
+			),
+			mcp.WithString("step_name",
+				mcp.Description("With return_content and job_id, the name of a step of the job such as Run tests, only the log of that step is returned. Applied to the whole log, before max_bytes, grep and tail_lines"),
+			),
+			mcp.WithNumber("step_number",
+				mcp.Description("With return_content and job_id, the number of a step of the job, as an alternative to step_name"),
+				mcp.Min(1),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -607,38 +1068,125 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			returnContent, err := OptionalParam[bool](request, "return_content")
+			allJobs, err := OptionalParam[bool](request, "all_jobs")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			client, err := getClient(ctx)
+			returnContent, err := OptionalParam[bool](request, "return_content")
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			// Validate parameters
-			if failedOnly && runID == 0 {
-				return mcp.NewToolResultError("run_id is required when failed_only is true"), nil
+			logOpts := jobLogOptions{returnContent: returnContent}
+			if logOpts.tailLines, logOpts.maxBytes, err = optionalLogLimitParams(request); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			if !failedOnly && jobID == 0 {
-				return mcp.NewToolResultError("job_id is required when failed_only is false"), nil
+			pattern, err := OptionalParam[string](request, "grep")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			if failedOnly && runID > 0 {
-				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent)
-			} else if jobID > 0 {
-				// Handle single job mode
-				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent)
+			if pattern != "" {
+				if logOpts.grep, err = regexp.Compile(pattern); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid grep pattern: %v", err)), nil
+				}
+			}
+			if logOpts.grepContext, err = OptionalIntParam(request, "grep_context"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if logOpts.grepContext < 0 {
+				return mcp.NewToolResultError("grep_context must not be negative"), nil
+			}
+			if logOpts.stepName, err = OptionalParam[string](request, "step_name"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if logOpts.stepNumber, err = OptionalIntParam(request, "step_number"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if logOpts.stepName != "" || logOpts.stepNumber != 0 {
+				switch {
+				case logOpts.stepName != "" && logOpts.stepNumber != 0:
+					return mcp.NewToolResultError("provide either step_name or step_number, not both"), nil
+				case !returnContent:
+					return mcp.NewToolResultError("step_name and step_number require return_content to be true"), nil
+				case jobID == 0:
+					return mcp.NewToolResultError("step_name and step_number require job_id"), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Validate parameters
+			if allJobs && (failedOnly || jobID != 0) {
+				return mcp.NewToolResultError("all_jobs cannot be combined with failed_only or job_id"), nil
+			}
+			if failedOnly && runID == 0 {
+				return mcp.NewToolResultError("run_id is required when failed_only is true"), nil
+			}
+			if allJobs && runID == 0 {
+				return mcp.NewToolResultError("run_id is required when all_jobs is true"), nil
+			}
+			if !failedOnly && !allJobs && jobID == 0 {
+				return mcp.NewToolResultError("job_id is required when failed_only is false"), nil
+			}
+
+			progress := NewProgressReporter(ctx, request)
+
+			if (failedOnly || allJobs) && runID > 0 {
+				// Handle run mode: get logs for all failed jobs, or all jobs, of the workflow run
+				return handleRunJobLogs(ctx, client, owner, repo, int64(runID), failedOnly, logOpts, progress)
+			} else if jobID > 0 {
+				// Handle single job mode
+				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), logOpts, progress)
 			}
 
 			return mcp.NewToolResultError("Either job_id must be provided for single job logs, or run_id with failed_only=true for failed job logs"), nil
 		}
 }
 
-// handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool) (*mcp.CallToolResult, error) {
+// jobLogOptions are the get_job_logs parameters controlling what is returned of each job log.
+type jobLogOptions struct {
+	// returnContent returns the content of the logs instead of their URLs
+	returnContent bool
+	// maxBytes limits the downloaded content to its last bytes
+	maxBytes int
+	// tailLines limits the content to its last lines, unless it is 0
+	tailLines int
+	// grep, if set, limits the content to the lines matching it and grepContext lines around them
+	grep        *regexp.Regexp
+	grepContext int
+	// stepName or stepNumber, if set, limit the content to the log of a step of a single job. The
+	// steps of the job are set by handleSingleJobLogs to find the step boundaries
+	stepName   string
+	stepNumber int
+	steps      []*github.TaskStep
+}
+
+// optionalLogLimitParams returns the "tail_lines" and "max_bytes" parameters limiting the log content
+// returned, or their default values if not present. An explicit tail_lines of 0 disables the limit.
+func optionalLogLimitParams(request mcp.CallToolRequest) (tailLines int, maxBytes int, err error) {
+	tailLines = defaultJobLogTailLines
+	if _, ok := request.GetArguments()["tail_lines"]; ok {
+		if tailLines, err = OptionalIntParam(request, "tail_lines"); err != nil {
+			return 0, 0, err
+		}
+		if tailLines < 0 {
+			return 0, 0, fmt.Errorf("tail_lines must not be negative")
+		}
+	}
+	if maxBytes, err = OptionalIntParamWithDefault(request, "max_bytes", defaultJobLogMaxBytes); err != nil {
+		return 0, 0, err
+	}
+	if maxBytes < 0 {
+		return 0, 0, fmt.Errorf("max_bytes must be positive")
+	}
+	return tailLines, maxBytes, nil
+}
+
+// handleRunJobLogs gets logs for all jobs in a workflow run, or only the failed ones when failedOnly
+// is true, reporting progress per job
+func handleRunJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, failedOnly bool, opts jobLogOptions, progress *ProgressReporter) (*mcp.CallToolResult, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -648,47 +1196,90 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// Filter for failed jobs
-	var failedJobs []*github.WorkflowJob
+	// Count the jobs by conclusion and select those to get the logs of
+	var selectedJobs []*github.WorkflowJob
+	succeeded, failed := 0, 0
 	for _, job := range jobs.Jobs {
-		if job.GetConclusion() == "failure" {
-			failedJobs = append(failedJobs, job)
+		switch job.GetConclusion() {
+		case "success":
+			succeeded++
+		case "failure":
+			failed++
+		}
+		if !failedOnly || job.GetConclusion() == "failure" {
+			selectedJobs = append(selectedJobs, job)
 		}
 	}
 
-	if len(failedJobs) == 0 {
+	if len(selectedJobs) == 0 {
+		message := "No jobs found in this workflow run"
+		if failedOnly {
+			message = "No failed jobs found in this workflow run"
+		}
 		result := map[string]any{
-			"message":     "No failed jobs found in this workflow run",
-			"run_id":      runID,
-			"total_jobs":  len(jobs.Jobs),
-			"failed_jobs": 0,
+			"message":        message,
+			"run_id":         runID,
+			"total_jobs":     len(jobs.Jobs),
+			"succeeded_jobs": succeeded,
+			"failed_jobs":    0,
 		}
 		r, _ := json.Marshal(result)
 		return mcp.NewToolResultText(string(r)), nil
 	}
 
-	// Collect logs for all failed jobs
-	var logResults []map[string]any
-	for _, job := range failedJobs {
-		jobResult, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent)
-		if err != nil {
-			// Continue with other jobs even if one fails
-			jobResult = map[string]any{
-				"job_id":   job.GetID(),
-				"job_name": job.GetName(),
-				"error":    err.Error(),
+	// Collect logs for the selected jobs, a few at a time, keeping the order of the jobs
+	logResults := make([]map[string]any, len(selectedJobs))
+	sem := make(chan struct{}, jobLogConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for i, job := range selectedJobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var jobResult map[string]any
+			var err error
+			select {
+			case sem <- struct{}{}:
+				jobResult, err = getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), opts, nil)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			if err != nil {
+				// Continue with other jobs even if one fails
+				jobResult = map[string]any{
+					"job_id":   job.GetID(),
+					"job_name": job.GetName(),
+					"error":    err.Error(),
+				}
 			}
-		}
-		logResults = append(logResults, jobResult)
+			if !failedOnly {
+				jobResult["conclusion"] = job.GetConclusion()
+			}
+			logResults[i] = jobResult
+
+			// Progress must increase with every notification
+			mu.Lock()
+			done++
+			progress.Report(float64(done), float64(len(selectedJobs)), fmt.Sprintf("Retrieved logs for job %s", job.GetName()))
+			mu.Unlock()
+		}()
 	}
+	wg.Wait()
 
+	message := fmt.Sprintf("Retrieved logs for %d jobs", len(selectedJobs))
+	if failedOnly {
+		message = fmt.Sprintf("Retrieved logs for %d failed jobs", len(selectedJobs))
+	}
 	result := map[string]any{
-		"message":       fmt.Sprintf("Retrieved logs for %d failed jobs", len(failedJobs)),
-		"run_id":        runID,
-		"total_jobs":    len(jobs.Jobs),
-		"failed_jobs":   len(failedJobs),
-		"logs":          logResults,
-		"return_format": map[string]bool{"content": returnContent, "urls": !returnContent},
+		"message":        message,
+		"run_id":         runID,
+		"total_jobs":     len(jobs.Jobs),
+		"succeeded_jobs": succeeded,
+		"failed_jobs":    failed,
+		"logs":           logResults,
+		"return_format":  map[string]bool{"content": opts.returnContent, "urls": !opts.returnContent},
 	}
 
 	r, err := json.Marshal(result)
@@ -700,8 +1291,28 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // handleSingleJobLogs gets logs for a single job
-func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool) (*mcp.CallToolResult, error) {
-	jobResult, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent)
+func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, opts jobLogOptions, progress *ProgressReporter) (*mcp.CallToolResult, error) {
+	if opts.stepName != "" || opts.stepNumber != 0 {
+		job, resp, err := client.Actions.GetWorkflowJobByID(ctx, owner, repo, jobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow job: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		step := findJobStep(job.Steps, opts.stepName, opts.stepNumber)
+		if step == nil {
+			names := make([]string, 0, len(job.Steps))
+			for _, s := range job.Steps {
+				names = append(names, fmt.Sprintf("%d. %s", s.GetNumber(), s.GetName()))
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("step not found in job %d, available steps: %s", jobID, strings.Join(names, ", "))), nil
+		}
+		opts.stepName = step.GetName()
+		opts.stepNumber = int(step.GetNumber())
+		opts.steps = job.Steps
+	}
+
+	jobResult, err := getJobLogData(ctx, client, owner, repo, jobID, "", opts, progress)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -714,8 +1325,11 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 	return mcp.NewToolResultText(string(r)), nil
 }
 
-// getJobLogData retrieves log data for a single job, either as URL or content
-func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool) (map[string]any, error) {
+// getJobLogData retrieves log data for a single job, either as URL or content. Content is limited to
+// the log of a step when the steps option is set, and to its last maxBytes bytes while downloading,
+// then filtered by the grep option and limited to its last tailLines lines.
+// The progress reporter, which may be nil, receives the number of bytes downloaded.
+func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, opts jobLogOptions, progress *ProgressReporter) (map[string]any, error) {
 	// Get the download URL for the job logs
 	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
 	if err != nil {
@@ -730,13 +1344,41 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 		result["job_name"] = jobName
 	}
 
-	if returnContent {
-		// Download and return the actual log content
-		content, err := downloadLogContent(url.String())
+	if opts.returnContent {
+		// Download and return the actual log content. The step is extracted from the whole log while
+		// downloading, as it may start before the last maxBytes bytes
+		var stepFilter *stepLogFilter
+		var keep func(string) bool
+		if opts.steps != nil {
+			stepFilter = newStepLogFilter(opts.steps, int64(opts.stepNumber))
+			keep = stepFilter.keep
+		}
+		download, err := downloadLogContent(ctx, url.String(), opts.maxBytes, keep, progress)
 		if err != nil {
 			return nil, fmt.Errorf("failed to download log content for job %d: %w", jobID, err)
 		}
+		if stepFilter != nil {
+			if !stepFilter.found {
+				return nil, fmt.Errorf("no lines of step %d (%s) found in the log of job %d, the step may have been skipped or written no output", opts.stepNumber, opts.stepName, jobID)
+			}
+			result["step_name"] = opts.stepName
+			result["step_number"] = opts.stepNumber
+		}
+		content := download.content
+		if opts.grep != nil {
+			var matchedLines int
+			content, matchedLines = grepLogLines(content, opts.grep, opts.grepContext)
+			result["matched_lines"] = matchedLines
+		}
+		untailedLineCount := countLogLines(content)
+		content, returnedLineCount := tailLogLines(content, opts.tailLines)
 		result["logs_content"] = content
+		// The line count of the whole downloaded log, before max_bytes, the step, grep and tail_lines
+		result["original_line_count"] = download.lineCount
+		result["returned_line_count"] = returnedLineCount
+		// Lines left out by max_bytes or tail_lines, not those filtered out by the step or grep
+		result["truncated"] = download.truncated || returnedLineCount < untailedLineCount
+		result["bytes_returned"] = len(content)
 		result["message"] = "Job logs content retrieved successfully"
 	} else {
 		// Return just the URL
@@ -748,26 +1390,279 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 	return result, nil
 }
 
-// downloadLogContent downloads the actual log content from a GitHub logs URL
-func downloadLogContent(logURL string) (string, error) {
-	httpResp, err := http.Get(logURL) //nolint:gosec // URLs are provided by GitHub API and are safe
+// findJobStep returns the step of a job with the number, or else the name compared case
+// insensitively, or nil if there is none.
+func findJobStep(steps []*github.TaskStep, name string, number int) *github.TaskStep {
+	for _, step := range steps {
+		if number != 0 && step.GetNumber() == int64(number) {
+			return step
+		}
+		if name != "" && strings.EqualFold(step.GetName(), name) {
+			return step
+		}
+	}
+	return nil
+}
+
+// stepLogFilter keeps the lines of a job log belonging to a step, given the lines of the log one at a
+// time from its start. Job logs don't delimit steps, so the boundaries are found from the start times
+// of the steps, which only have a precision of a second, and from the first line most steps write:
+// "##[group]Run <command>", or "Post job cleanup." for post steps. A line starts the next step when it is such a marker written at
+// most a second before the step started, or when it was written in a later second than the step
+// started. The "Set up job" and "Complete job" steps write no marker, they start with the first line
+// written in the second they started. Lines without a timestamp belong to the step of the previous line.
+type stepLogFilter struct {
+	// started are the steps having lines, in the order of their numbers
+	started []*github.TaskStep
+	// current is the index in started of the step of the last line
+	current int
+	number  int64
+	// found is set once a line of the step is kept
+	found bool
+}
+
+func newStepLogFilter(steps []*github.TaskStep, number int64) *stepLogFilter {
+	// Steps that were skipped or have not started have no lines
+	var started []*github.TaskStep
+	for _, step := range steps {
+		if step.StartedAt != nil && step.GetConclusion() != "skipped" {
+			started = append(started, step)
+		}
+	}
+	sort.SliceStable(started, func(i, j int) bool {
+		return started[i].GetNumber() < started[j].GetNumber()
+	})
+	return &stepLogFilter{started: started, number: number}
+}
+
+// keep reports whether the line, which follows the lines previously given, belongs to the step.
+func (f *stepLogFilter) keep(line string) bool {
+	if len(f.started) == 0 {
+		return false
+	}
+	if ts, body, ok := parseLogLine(line); ok {
+		for f.current+1 < len(f.started) && startsStep(f.started[f.current+1], ts, body) {
+			f.current++
+		}
+	}
+	if f.started[f.current].GetNumber() != f.number {
+		return false
+	}
+	f.found = true
+	return true
+}
+
+// startsStep reports whether a log line written at ts starts the step, see stepLogFilter.
+func startsStep(step *github.TaskStep, ts time.Time, body string) bool {
+	start := step.GetStartedAt().Time.Truncate(time.Second)
+	second := ts.Truncate(time.Second)
+	switch step.GetName() {
+	case "Set up job", "Complete job":
+		return !second.Before(start)
+	}
+	if strings.HasPrefix(body, "##[group]Run ") || body == "Post job cleanup." {
+		return !second.Before(start.Add(-time.Second))
+	}
+	return second.After(start)
+}
+
+// parseLogLine splits a job log line into its timestamp and the rest of it, ok is false when the line
+// doesn't start with a timestamp.
+func parseLogLine(line string) (ts time.Time, body string, ok bool) {
+	prefix, body, found := strings.Cut(line, " ")
+	if !found {
+		prefix, body = line, ""
+	}
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return ts, body, true
+}
+
+// logDownload is the content kept of a downloaded log.
+type logDownload struct {
+	content string
+	// truncated is set when earlier bytes of the content were left out
+	truncated bool
+	// lineCount is the number of lines of the whole log
+	lineCount int
+}
+
+// downloadLogContent downloads the actual log content from a GitHub logs URL, keeping at most the
+// last maxBytes bytes of it, and reports whether earlier bytes were truncated. The errors of a failed
+// job are usually at the end of its log, which is why the tail is kept rather than the head. When keep
+// is not nil, only the lines it keeps are, and maxBytes applies to them rather than to the whole log.
+func downloadLogContent(ctx context.Context, logURL string, maxBytes int, keep func(string) bool, progress *ProgressReporter) (logDownload, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logURL, nil)
+	if err != nil {
+		return logDownload{}, fmt.Errorf("failed to create logs request: %w", err)
+	}
+	httpResp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to download logs: %w", err)
+		return logDownload{}, fmt.Errorf("failed to download logs: %w", err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
+		return logDownload{}, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
 	}
 
-	content, err := io.ReadAll(httpResp.Body)
+	tail := &tailBuffer{limit: maxBytes}
+	lines := &lineCounter{}
+	body := io.TeeReader(progress.Reader(httpResp.Body, max(httpResp.ContentLength, 0)), lines)
+	if keep == nil {
+		_, err = io.Copy(tail, body)
+	} else {
+		err = copyLogLines(tail, body, keep)
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to read log content: %w", err)
+		return logDownload{}, fmt.Errorf("failed to read log content: %w", err)
 	}
+	content, truncated := tail.Lines()
 
 	// Clean up and format the log content for better readability
-	logContent := strings.TrimSpace(string(content))
-	return logContent, nil
+	return logDownload{
+		content:   strings.TrimSpace(string(content)),
+		truncated: truncated,
+		lineCount: lines.Count(),
+	}, nil
+}
+
+// lineCounter is a writer counting the lines written to it, the last one with or without a line break.
+type lineCounter struct {
+	breaks int
+	last   byte
+}
+
+func (c *lineCounter) Write(p []byte) (int, error) {
+	if len(p) > 0 {
+		c.breaks += bytes.Count(p, []byte{'\n'})
+		c.last = p[len(p)-1]
+	}
+	return len(p), nil
+}
+
+// Count returns the number of lines written.
+func (c *lineCounter) Count() int {
+	if c.last == 0 || c.last == '\n' {
+		return c.breaks
+	}
+	return c.breaks + 1
+}
+
+// copyLogLines copies the lines of r that keep keeps to w, with their line breaks.
+func copyLogLines(w io.Writer, r io.Reader, keep func(string) bool) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" && keep(strings.TrimSuffix(line, "\n")) {
+			if _, werr := io.WriteString(w, line); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// tailBuffer is a writer keeping the last limit bytes written to it. It holds at most twice the
+// limit, plus the size of a single write, whatever the amount of data written.
+type tailBuffer struct {
+	limit     int
+	buf       []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > 2*b.limit {
+		b.discardHead()
+	}
+	return len(p), nil
+}
+
+// discardHead drops everything but the last limit bytes.
+func (b *tailBuffer) discardHead() {
+	if len(b.buf) <= b.limit {
+		return
+	}
+	n := copy(b.buf, b.buf[len(b.buf)-b.limit:])
+	b.buf = b.buf[:n]
+	b.truncated = true
+}
+
+// Bytes returns the last limit bytes written, and whether earlier bytes were discarded.
+func (b *tailBuffer) Bytes() ([]byte, bool) {
+	b.discardHead()
+	return b.buf, b.truncated
+}
+
+// Lines is like Bytes, but drops the partial line the cut was made in when bytes were discarded.
+func (b *tailBuffer) Lines() ([]byte, bool) {
+	content, truncated := b.Bytes()
+	if truncated {
+		if i := bytes.IndexByte(content, '\n'); i >= 0 {
+			content = content[i+1:]
+		}
+	}
+	return content, truncated
+}
+
+// countLogLines returns the number of lines of a log.
+func countLogLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	return strings.Count(content, "\n") + 1
+}
+
+// tailLogLines keeps the last n lines of a log, or all of them when n is 0, and returns the number of
+// lines of the returned content.
+func tailLogLines(content string, n int) (string, int) {
+	lineCount := countLogLines(content)
+	if n == 0 || lineCount <= n {
+		return content, lineCount
+	}
+
+	// Find the start of the n-th line from the end
+	start := len(content)
+	for i := 0; i < n; i++ {
+		start = strings.LastIndexByte(content[:start], '\n')
+	}
+	return content[start+1:], n
+}
+
+// grepLogLines keeps the lines of a log matching re, with up to contextLines lines before and after
+// each of them, and returns the number of matching lines.
+func grepLogLines(content string, re *regexp.Regexp, contextLines int) (string, int) {
+	if content == "" {
+		return content, 0
+	}
+	lines := strings.Split(content, "\n")
+	keep := make([]bool, len(lines))
+	matched := 0
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		matched++
+		for j := max(i-contextLines, 0); j <= min(i+contextLines, len(lines)-1); j++ {
+			keep[j] = true
+		}
+	}
+
+	kept := make([]string, 0, matched)
+	for i, line := range lines {
+		if keep[i] {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n"), matched
 }
 
 // RerunWorkflowRun creates a tool to re-run an entire workflow run
@@ -959,13 +1854,13 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 		}
 }
 
-// ListWorkflowRunArtifacts creates a tool to list artifacts for a workflow run
-func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_workflow_run_artifacts",
-			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_DESCRIPTION", "List artifacts for a workflow run")),
+// ApproveWorkflowRun creates a tool to approve a workflow run of a fork pull request
+func ApproveWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("approve_workflow_run",
+			mcp.WithDescription(t("TOOL_APPROVE_WORKFLOW_RUN_DESCRIPTION", "Approve a workflow run of a pull request from a fork, which waits with the action_required status until a maintainer approves it")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
-				ReadOnlyHint: ToBoolPtr(true),
+				Title:        t("TOOL_APPROVE_WORKFLOW_RUN_USER_TITLE", "Approve workflow run"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -979,12 +1874,6 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
-			mcp.WithNumber("per_page",
-				mcp.Description("The number of results per page (max 100)"),
-			),
-			mcp.WithNumber("page",
-				mcp.Description("The page number of the results to fetch"),
-			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1001,34 +1890,41 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 			}
 			runID := int64(runIDInt)
 
-			// Get optional pagination parameters
-			perPage, err := OptionalIntParam(request, "per_page")
+			client, err := getClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			page, err := OptionalIntParam(request, "page")
+
+			// go-github has no method for this endpoint yet
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/actions/runs/%d/approve", owner, repo, runID), nil)
 			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return nil, fmt.Errorf("failed to create request: %w", err)
 			}
-
-			client, err := getClient(ctx)
+			resp, err := client.Do(ctx, req, nil)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				if resp != nil && resp.StatusCode == http.StatusForbidden {
+					return mcp.NewToolResultError(fmt.Sprintf("not allowed to approve workflow run %d, the token needs write access to the repository and the run must be waiting for approval: %s", runID, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to approve workflow run: %w", err)
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			// Set up list options
-			opts := &github.ListOptions{
-				PerPage: perPage,
-				Page:    page,
+			workflowRun, runResp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow run: %w", err)
 			}
+			defer func() { _ = runResp.Body.Close() }()
 
-			artifacts, resp, err := client.Actions.ListWorkflowRunArtifacts(ctx, owner, repo, runID, opts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list workflow run artifacts: %w", err)
+			result := map[string]any{
+				"message":     "Workflow run has been approved",
+				"run_id":      runID,
+				"run_status":  workflowRun.GetStatus(),
+				"html_url":    workflowRun.GetHTMLURL(),
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(artifacts)
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1037,12 +1933,12 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 		}
 }
 
-// DownloadWorkflowRunArtifact creates a tool to download a workflow run artifact
-func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("download_workflow_run_artifact",
-			mcp.WithDescription(t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_DESCRIPTION", "Get download URL for a workflow run artifact")),
+// GetPendingDeployments creates a tool to get the deployments of a workflow run waiting for approval
+func GetPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pending_deployments",
+			mcp.WithDescription(t("TOOL_GET_PENDING_DEPLOYMENTS_DESCRIPTION", "Get the deployments of a workflow run that are waiting for an environment protection rule, with the environments the current user can approve")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_USER_TITLE", "Download workflow artifact"),
+				Title:        t("TOOL_GET_PENDING_DEPLOYMENTS_USER_TITLE", "Get pending deployments"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -1053,9 +1949,9 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				mcp.Required(),
 				mcp.Description(DescriptionRepositoryName),
 			),
-			mcp.WithNumber("artifact_id",
+			mcp.WithNumber("run_id",
 				mcp.Required(),
-				mcp.Description("The unique identifier of the artifact"),
+				mcp.Description("The unique identifier of the workflow run"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1067,33 +1963,24 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			artifactIDInt, err := RequiredInt(request, "artifact_id")
+			runIDInt, err := RequiredInt(request, "run_id")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			artifactID := int64(artifactIDInt)
+			runID := int64(runIDInt)
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the download URL for the artifact
-			url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
+			deployments, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get artifact download URL: %w", err)
+				return nil, fmt.Errorf("failed to get pending deployments: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Create response with the download URL and information
-			result := map[string]any{
-				"download_url": url.String(),
-				"message":      "Artifact is available for download",
-				"note":         "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time.",
-				"artifact_id":  artifactID,
-			}
-
-			r, err := json.Marshal(result)
+			r, err := json.Marshal(deployments)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1102,14 +1989,13 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 		}
 }
 
-// DeleteWorkflowRunLogs creates a tool to delete logs for a workflow run
-func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("delete_workflow_run_logs",
-			mcp.WithDescription(t("TOOL_DELETE_WORKFLOW_RUN_LOGS_DESCRIPTION", "Delete logs for a workflow run")),
+// ReviewPendingDeployments creates a tool to approve or reject the pending deployments of a workflow run
+func ReviewPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployments",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENTS_DESCRIPTION", "Approve or reject the deployments of a workflow run waiting for an environment protection rule. Use get_pending_deployments first to get the environment IDs")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:           t("TOOL_DELETE_WORKFLOW_RUN_LOGS_USER_TITLE", "Delete workflow logs"),
-				ReadOnlyHint:    ToBoolPtr(false),
-				DestructiveHint: ToBoolPtr(true),
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENTS_USER_TITLE", "Review pending deployments"),
+				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -1123,6 +2009,25 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithArray("environment_ids",
+				mcp.Required(),
+				mcp.Description("The IDs of the environments to approve or reject"),
+				mcp.MinItems(1),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("A comment explaining the review"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1138,26 +2043,46 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			runID := int64(runIDInt)
+			environmentIDs, err := OptionalIntArrayParam(request, "environment_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(environmentIDs) == 0 {
+				return mcp.NewToolResultError("environment_ids must contain at least one environment ID"), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "approved" && state != "rejected" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s, must be approved or rejected", state)), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			resp, err := client.Actions.DeleteWorkflowRunLogs(ctx, owner, repo, runID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to delete workflow run logs: %w", err)
+			review := &github.PendingDeploymentsRequest{
+				EnvironmentIDs: make([]int64, len(environmentIDs)),
+				State:          state,
+				Comment:        comment,
+			}
+			for i, id := range environmentIDs {
+				review.EnvironmentIDs[i] = int64(id)
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			result := map[string]any{
-				"message":     "Workflow run logs have been deleted",
-				"run_id":      runID,
-				"status":      resp.Status,
-				"status_code": resp.StatusCode,
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, runID, review)
+			if err != nil {
+				return nil, fmt.Errorf("failed to review pending deployments: %w", err)
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			r, err := json.Marshal(result)
+			r, err := json.Marshal(deployments)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -1166,12 +2091,12 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 		}
 }
 
-// GetWorkflowRunUsage creates a tool to get usage metrics for a workflow run
-func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_workflow_run_usage",
-			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_USAGE_DESCRIPTION", "Get usage metrics for a workflow run")),
+// ListWorkflowRunArtifacts creates a tool to list artifacts for a workflow run
+func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_workflow_run_artifacts",
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_DESCRIPTION", "List artifacts for a workflow run")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_WORKFLOW_RUN_USAGE_USER_TITLE", "Get workflow usage"),
+				Title:        t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -1186,6 +2111,12 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("The unique identifier of the workflow run"),
 			),
+			mcp.WithNumber("per_page",
+				mcp.Description("The number of results per page (max 100)"),
+			),
+			mcp.WithNumber("page",
+				mcp.Description("The page number of the results to fetch"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1202,7 +2133,584 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			}
 			runID := int64(runIDInt)
 
-			client, err := getClient(ctx)
+			// Get optional pagination parameters
+			pagination, err := OptionalNamedPaginationParams(request, "page", "per_page")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Set up list options
+			opts := &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			}
+
+			artifacts, resp, err := client.Actions.ListWorkflowRunArtifacts(ctx, owner, repo, runID, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow run artifacts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(artifacts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DownloadWorkflowRunArtifact creates a tool to download a workflow run artifact
+func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_workflow_run_artifact",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_DESCRIPTION", "Get download URL for a workflow run artifact")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_USER_TITLE", "Download workflow artifact"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("artifact_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the artifact"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactIDInt, err := RequiredInt(request, "artifact_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactID := int64(artifactIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the download URL for the artifact
+			url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get artifact download URL: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create response with the download URL and information
+			result := map[string]any{
+				"download_url": url.String(),
+				"message":      "Artifact is available for download",
+				"note":         "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time.",
+				"artifact_id":  artifactID,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// defaultArtifactSearchDepth is the number of completed runs get_latest_artifact_by_name looks for
+// the artifact in unless search_depth is given
+const defaultArtifactSearchDepth = 10
+
+// GetLatestArtifactByName creates a tool to find the artifact with a name of the latest successful run of a workflow
+func GetLatestArtifactByName(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_latest_artifact_by_name",
+			mcp.WithDescription(t("TOOL_GET_LATEST_ARTIFACT_BY_NAME_DESCRIPTION", "Find the artifact with a name, such as coverage-report, of the most recent successful run of a workflow, and get its download URL. Use get_artifact_content with its ID to read its files")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LATEST_ARTIFACT_BY_NAME_USER_TITLE", "Get latest artifact by name"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+			mcp.WithString("artifact_name",
+				mcp.Required(),
+				mcp.Description("The name of the artifact"),
+			),
+			mcp.WithNumber("search_depth",
+				mcp.Description(fmt.Sprintf("The number of most recent completed runs of the workflow to look for the artifact in (default %d)", defaultArtifactSearchDepth)),
+				mcp.Min(1),
+				mcp.Max(maxPerPage),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactName, err := RequiredParam[string](request, "artifact_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			searchDepth, err := OptionalIntParamWithDefault(request, "search_depth", defaultArtifactSearchDepth)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if searchDepth < 0 {
+				return mcp.NewToolResultError("search_depth must be positive"), nil
+			}
+			searchDepth = min(searchDepth, maxPerPage)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			runOpts := &github.ListWorkflowRunsOptions{
+				Status:      "completed",
+				ListOptions: github.ListOptions{PerPage: searchDepth},
+			}
+			var workflowRuns *github.WorkflowRuns
+			resp, _, err := resolveWorkflow(workflowID,
+				func(id int64) (*github.Response, error) {
+					var resp *github.Response
+					workflowRuns, resp, err = client.Actions.ListWorkflowRunsByID(ctx, owner, repo, id, runOpts)
+					return resp, err
+				},
+				func(fileName string) (*github.Response, error) {
+					var resp *github.Response
+					workflowRuns, resp, err = client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, fileName, runOpts)
+					return resp, err
+				},
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			searchedRuns := 0
+			available := map[string]bool{}
+			for _, run := range workflowRuns.WorkflowRuns {
+				if run.GetConclusion() != "success" {
+					continue
+				}
+				searchedRuns++
+
+				artifacts, resp, err := client.Actions.ListWorkflowRunArtifacts(ctx, owner, repo, run.GetID(), &github.ListOptions{PerPage: maxPerPage})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list artifacts of workflow run %d: %w", run.GetID(), err)
+				}
+				_ = resp.Body.Close()
+
+				for _, artifact := range artifacts.Artifacts {
+					if artifact.GetExpired() {
+						continue
+					}
+					if artifact.GetName() != artifactName {
+						available[artifact.GetName()] = true
+						continue
+					}
+
+					url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifact.GetID(), 1)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get artifact download URL: %w", err)
+					}
+					_ = resp.Body.Close()
+
+					result := map[string]any{
+						"found":        true,
+						"artifact":     artifact,
+						"download_url": url.String(),
+						"run_id":       run.GetID(),
+						"run_number":   run.GetRunNumber(),
+						"head_branch":  run.GetHeadBranch(),
+						"head_sha":     run.GetHeadSHA(),
+						"note":         "The download_url is temporary and expires after a short time.",
+					}
+
+					r, err := json.Marshal(result)
+					if err != nil {
+						return nil, fmt.Errorf("failed to marshal response: %w", err)
+					}
+
+					return mcp.NewToolResultText(string(r)), nil
+				}
+			}
+
+			names := make([]string, 0, len(available))
+			for name := range available {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			result := map[string]any{
+				"found":               false,
+				"message":             fmt.Sprintf("No artifact named %s in the last %d successful runs of the workflow", artifactName, searchedRuns),
+				"artifact_name":       artifactName,
+				"searched_runs":       searchedRuns,
+				"available_artifacts": names,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// defaultMaxArtifactBytes is the size above which get_artifact_content refuses to download an
+// artifact or return a file from it, unless max_size is given
+const defaultMaxArtifactBytes = 10 * 1024 * 1024
+
+// artifactFile is an entry of the file listing of an artifact.
+type artifactFile struct {
+	Path           string `json:"path"`
+	Size           uint64 `json:"size"`
+	CompressedSize uint64 `json:"compressed_size"`
+}
+
+// GetArtifactContent creates a tool to list the files of a workflow run artifact or read one of them
+func GetArtifactContent(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_artifact_content",
+			mcp.WithDescription(t("TOOL_GET_ARTIFACT_CONTENT_DESCRIPTION", "Download a workflow run artifact and list the files it contains, or get the content of one of them when path is given. Text files are returned as text, other files base64 encoded")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ARTIFACT_CONTENT_USER_TITLE", "Get artifact content"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("artifact_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the artifact"),
+			),
+			mcp.WithString("path",
+				mcp.Description("The path of a file inside the artifact to return, the files are listed when omitted"),
+			),
+			mcp.WithNumber("max_size",
+				mcp.Description(fmt.Sprintf("The maximum size in bytes of the artifact archive and of the returned file (default %d)", defaultMaxArtifactBytes)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactIDInt, err := RequiredInt(request, "artifact_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactID := int64(artifactIDInt)
+			filePath, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxSize, err := OptionalIntParam(request, "max_size")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// An explicit 0 is rejected like negative sizes, rather than selecting the default
+			if _, ok := request.GetArguments()["max_size"]; ok && maxSize <= 0 {
+				return mcp.NewToolResultError("max_size must be positive"), nil
+			}
+			if maxSize == 0 {
+				maxSize = defaultMaxArtifactBytes
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			url, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, 1)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get artifact download URL: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			data, err := downloadArtifactArchive(ctx, url.String(), int64(maxSize), NewProgressReporter(ctx, request))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to read artifact archive: %v", err)), nil
+			}
+			for _, f := range archive.File {
+				if !safeArchivePath(f.Name) {
+					return mcp.NewToolResultError(fmt.Sprintf("artifact archive contains an unsafe path: %s", f.Name)), nil
+				}
+			}
+
+			if filePath == "" {
+				files := make([]artifactFile, 0, len(archive.File))
+				for _, f := range archive.File {
+					if f.FileInfo().IsDir() {
+						continue
+					}
+					files = append(files, artifactFile{
+						Path:           f.Name,
+						Size:           f.UncompressedSize64,
+						CompressedSize: f.CompressedSize64,
+					})
+				}
+				result := map[string]any{
+					"artifact_id": artifactID,
+					"total_files": len(files),
+					"files":       files,
+				}
+
+				r, err := json.Marshal(result)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+			var file *zip.File
+			for _, f := range archive.File {
+				if f.Name == filePath && !f.FileInfo().IsDir() {
+					file = f
+					break
+				}
+			}
+			if file == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("file %s not found in artifact %d, omit path to list its files", filePath, artifactID)), nil
+			}
+			if file.UncompressedSize64 > uint64(maxSize) {
+				return mcp.NewToolResultError(fmt.Sprintf("file %s is %d bytes, larger than the maximum of %d bytes", filePath, file.UncompressedSize64, maxSize)), nil
+			}
+			content, err := readArchiveFile(file, int64(maxSize))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			resourceURI := fmt.Sprintf("repo://%s/%s/actions/artifacts/%d/%s", owner, repo, artifactID, filePath)
+			contentResult := &raw.RawContentResult{
+				Content:     content,
+				ContentType: raw.ResolveMIMEType(filePath, ""),
+			}
+			if contentResult.IsText() {
+				return mcp.NewToolResultResource("successfully extracted text file", mcp.TextResourceContents{
+					URI:      resourceURI,
+					Text:     string(content),
+					MIMEType: contentResult.ContentType,
+				}), nil
+			}
+			return mcp.NewToolResultResource("successfully extracted binary file", mcp.BlobResourceContents{
+				URI:      resourceURI,
+				Blob:     base64.StdEncoding.EncodeToString(content),
+				MIMEType: contentResult.ContentType,
+			}), nil
+		}
+}
+
+// downloadArtifactArchive downloads the ZIP archive of an artifact, failing if it is larger than maxBytes.
+func downloadArtifactArchive(ctx context.Context, archiveURL string, maxBytes int64, progress *ProgressReporter) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifact request: %w", err)
+	}
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download artifact: HTTP %d", httpResp.StatusCode)
+	}
+	if httpResp.ContentLength > maxBytes {
+		return nil, fmt.Errorf("artifact archive is %d bytes, larger than the maximum of %d bytes", httpResp.ContentLength, maxBytes)
+	}
+
+	// Read one byte past the limit to detect archives of unknown length that exceed it
+	data, err := io.ReadAll(io.LimitReader(progress.Reader(httpResp.Body, max(httpResp.ContentLength, 0)), maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("artifact archive is larger than the maximum of %d bytes", maxBytes)
+	}
+	return data, nil
+}
+
+// readArchiveFile reads a file of an archive, failing if it inflates to more than maxBytes whatever
+// size its header declares.
+func readArchiveFile(file *zip.File, maxBytes int64) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s in artifact archive: %w", file.Name, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s in artifact archive: %w", file.Name, err)
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, fmt.Errorf("file %s is larger than the maximum of %d bytes", file.Name, maxBytes)
+	}
+	return content, nil
+}
+
+// safeArchivePath reports whether an archive entry name stays inside the archive: it must be relative,
+// use forward slashes and have no ".." element.
+func safeArchivePath(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return false
+	}
+	for _, element := range strings.Split(name, "/") {
+		if element == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteWorkflowRunLogs creates a tool to delete logs for a workflow run
+func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_workflow_run_logs",
+			mcp.WithDescription(t("TOOL_DELETE_WORKFLOW_RUN_LOGS_DESCRIPTION", "Delete logs for a workflow run")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_WORKFLOW_RUN_LOGS_USER_TITLE", "Delete workflow logs"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.DeleteWorkflowRunLogs(ctx, owner, repo, runID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete workflow run logs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":     "Workflow run logs have been deleted",
+				"run_id":      runID,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetWorkflowRunUsage creates a tool to get usage metrics for a workflow run
+func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_run_usage",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_USAGE_DESCRIPTION", "Get usage metrics for a workflow run")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_RUN_USAGE_USER_TITLE", "Get workflow usage"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
@@ -1221,3 +2729,74 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// GetWorkflowUsage creates a tool to get the billable time of a workflow in the current billing cycle
+func GetWorkflowUsage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_usage",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_USAGE_DESCRIPTION", "Get the billable time of an Actions workflow in the current billing cycle, by runner operating system, to find the most expensive workflows")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_USAGE_USER_TITLE", "Get workflow usage"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var usage *github.WorkflowUsage
+			resp, workflowType, err := resolveWorkflow(workflowID,
+				func(id int64) (resp *github.Response, err error) {
+					usage, resp, err = client.Actions.GetWorkflowUsageByID(ctx, owner, repo, id)
+					return resp, err
+				},
+				func(fileName string) (resp *github.Response, err error) {
+					usage, resp, err = client.Actions.GetWorkflowUsageByFileName(ctx, owner, repo, fileName)
+					return resp, err
+				},
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow usage: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"workflow_type": workflowType,
+				"workflow_id":   workflowID,
+				"billable":      usage.GetBillable(),
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
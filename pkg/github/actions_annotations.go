@@ -0,0 +1,196 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultMaxWorkflowRunAnnotations is the number of annotations get_workflow_run_annotations returns
+// unless max_annotations is given
+const defaultMaxWorkflowRunAnnotations = 100
+
+// checkRunAnnotations are the annotations of a failed check run, that is of a job of a workflow run.
+type checkRunAnnotations struct {
+	CheckName   string               `json:"check_name"`
+	CheckRunID  int64                `json:"check_run_id"`
+	Conclusion  string               `json:"conclusion"`
+	Annotations []workflowAnnotation `json:"annotations"`
+}
+
+// workflowAnnotation is the compact form of a check run annotation.
+type workflowAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line,omitempty"`
+	Level     string `json:"level"`
+	Title     string `json:"title,omitempty"`
+	Message   string `json:"message"`
+}
+
+// GetWorkflowRunAnnotations creates a tool to get the annotations of the failed jobs of a workflow run
+func GetWorkflowRunAnnotations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_run_annotations",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_RUN_ANNOTATIONS_DESCRIPTION", "Get the annotations of the failed jobs of a workflow run, such as compiler errors and test failures with their file and line. Much cheaper than logs, use this first to understand why a run failed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_RUN_ANNOTATIONS_USER_TITLE", "Get workflow run annotations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithNumber("max_annotations",
+				mcp.Description(fmt.Sprintf("The maximum number of annotations to return across all jobs (default %d)", defaultMaxWorkflowRunAnnotations)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			maxAnnotations, err := OptionalIntParamWithDefault(request, "max_annotations", defaultMaxWorkflowRunAnnotations)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxAnnotations < 0 {
+				return mcp.NewToolResultError("max_annotations must be positive"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			workflowRun, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow run: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			failedCheckRuns, err := listFailedCheckRuns(ctx, client, owner, repo, workflowRun)
+			if err != nil {
+				return nil, err
+			}
+
+			groups := make([]checkRunAnnotations, 0, len(failedCheckRuns))
+			total := 0
+			truncated := false
+			for _, checkRun := range failedCheckRuns {
+				group := checkRunAnnotations{
+					CheckName:   checkRun.GetName(),
+					CheckRunID:  checkRun.GetID(),
+					Conclusion:  checkRun.GetConclusion(),
+					Annotations: []workflowAnnotation{},
+				}
+				if total >= maxAnnotations {
+					// Keep listing the failed jobs, only their annotations are left out
+					truncated = truncated || checkRun.GetOutput().GetAnnotationsCount() > 0
+					groups = append(groups, group)
+					continue
+				}
+
+				opts := &github.ListOptions{PerPage: min(maxPerPage, maxAnnotations-total)}
+				for {
+					annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRun.GetID(), opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list annotations of check run %d: %w", checkRun.GetID(), err)
+					}
+					_ = resp.Body.Close()
+
+					for _, a := range annotations {
+						if total >= maxAnnotations {
+							truncated = true
+							break
+						}
+						group.Annotations = append(group.Annotations, workflowAnnotation{
+							Path:      a.GetPath(),
+							StartLine: a.GetStartLine(),
+							Level:     a.GetAnnotationLevel(),
+							Title:     a.GetTitle(),
+							Message:   a.GetMessage(),
+						})
+						total++
+					}
+					if resp.NextPage == 0 {
+						break
+					}
+					if total >= maxAnnotations {
+						truncated = true
+						break
+					}
+					opts.Page = resp.NextPage
+				}
+				groups = append(groups, group)
+			}
+
+			result := map[string]any{
+				"run_id":            runID,
+				"head_sha":          workflowRun.GetHeadSHA(),
+				"failed_jobs":       len(failedCheckRuns),
+				"total_annotations": total,
+				"truncated":         truncated,
+				"jobs":              groups,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listFailedCheckRuns lists the check runs of the head commit of a workflow run that belong to its
+// check suite, one per job, and that failed or timed out.
+func listFailedCheckRuns(ctx context.Context, client *github.Client, owner, repo string, workflowRun *github.WorkflowRun) ([]*github.CheckRun, error) {
+	var failed []*github.CheckRun
+	opts := &github.ListCheckRunsOptions{
+		Filter:      github.Ptr("latest"),
+		ListOptions: github.ListOptions{PerPage: maxPerPage},
+	}
+	for {
+		checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, workflowRun.GetHeadSHA(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		for _, checkRun := range checkRuns.CheckRuns {
+			if checkRun.GetCheckSuite().GetID() != workflowRun.GetCheckSuiteID() {
+				continue
+			}
+			switch checkRun.GetConclusion() {
+			case "failure", "timed_out":
+				failed = append(failed, checkRun)
+			}
+		}
+		if resp.NextPage == 0 {
+			return failed, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
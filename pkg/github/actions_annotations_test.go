@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetWorkflowRunAnnotations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowRunAnnotations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_run_annotations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	getRun := mock.WithRequestMatchHandler(
+		mock.GetReposActionsRunsByOwnerByRepoByRunId,
+		mockResponse(t, http.StatusOK, &github.WorkflowRun{
+			ID:           github.Ptr(int64(12345)),
+			HeadSHA:      github.Ptr("abc123"),
+			CheckSuiteID: github.Ptr(int64(99)),
+		}),
+	)
+	listCheckRuns := mock.WithRequestMatchHandler(
+		mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/commits/abc123/check-runs", r.URL.Path)
+			mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+				Total: github.Ptr(4),
+				CheckRuns: []*github.CheckRun{
+					{
+						ID:         github.Ptr(int64(1)),
+						Name:       github.Ptr("build"),
+						Conclusion: github.Ptr("failure"),
+						CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(99))},
+						Output:     &github.CheckRunOutput{AnnotationsCount: github.Ptr(2)},
+					},
+					{
+						ID:         github.Ptr(int64(2)),
+						Name:       github.Ptr("lint"),
+						Conclusion: github.Ptr("success"),
+						CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(99))},
+					},
+					{
+						ID:         github.Ptr(int64(3)),
+						Name:       github.Ptr("test"),
+						Conclusion: github.Ptr("timed_out"),
+						CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(99))},
+						Output:     &github.CheckRunOutput{AnnotationsCount: github.Ptr(1)},
+					},
+					{
+						// A check run of another workflow on the same commit
+						ID:         github.Ptr(int64(4)),
+						Name:       github.Ptr("codeql"),
+						Conclusion: github.Ptr("failure"),
+						CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(100))},
+					},
+				},
+			})(w, r)
+		}),
+	)
+	listAnnotations := mock.WithRequestMatchHandler(
+		mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			annotations := map[string][]*github.CheckRunAnnotation{
+				"/repos/owner/repo/check-runs/1/annotations": {
+					{
+						Path:            github.Ptr("pkg/foo/foo.go"),
+						StartLine:       github.Ptr(42),
+						AnnotationLevel: github.Ptr("failure"),
+						Message:         github.Ptr("undefined: Foo"),
+					},
+					{
+						Path:            github.Ptr(".github"),
+						AnnotationLevel: github.Ptr("failure"),
+						Message:         github.Ptr("Process completed with exit code 1."),
+					},
+				},
+				"/repos/owner/repo/check-runs/3/annotations": {
+					{
+						Path:            github.Ptr(".github"),
+						AnnotationLevel: github.Ptr("failure"),
+						Message:         github.Ptr("The job running on runner has exceeded the maximum execution time"),
+					},
+				},
+			}
+			found, ok := annotations[r.URL.Path]
+			require.True(t, ok, "unexpected annotations request %s", r.URL.Path)
+			mockResponse(t, http.StatusOK, found)(w, r)
+		}),
+	)
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedTotal     int
+		expectedTruncated bool
+		expectedJobs      []checkRunAnnotations
+	}{
+		{
+			name:          "annotations of the failed jobs",
+			mockedClient:  mock.NewMockedHTTPClient(getRun, listCheckRuns, listAnnotations),
+			requestArgs:   map[string]any{},
+			expectedTotal: 3,
+			expectedJobs: []checkRunAnnotations{
+				{
+					CheckName:  "build",
+					CheckRunID: 1,
+					Conclusion: "failure",
+					Annotations: []workflowAnnotation{
+						{Path: "pkg/foo/foo.go", StartLine: 42, Level: "failure", Message: "undefined: Foo"},
+						{Path: ".github", Level: "failure", Message: "Process completed with exit code 1."},
+					},
+				},
+				{
+					CheckName:  "test",
+					CheckRunID: 3,
+					Conclusion: "timed_out",
+					Annotations: []workflowAnnotation{
+						{Path: ".github", Level: "failure", Message: "The job running on runner has exceeded the maximum execution time"},
+					},
+				},
+			},
+		},
+		{
+			name:         "max annotations",
+			mockedClient: mock.NewMockedHTTPClient(getRun, listCheckRuns, listAnnotations),
+			requestArgs: map[string]any{
+				"max_annotations": float64(1),
+			},
+			expectedTotal:     1,
+			expectedTruncated: true,
+			expectedJobs: []checkRunAnnotations{
+				{
+					CheckName:  "build",
+					CheckRunID: 1,
+					Conclusion: "failure",
+					Annotations: []workflowAnnotation{
+						{Path: "pkg/foo/foo.go", StartLine: 42, Level: "failure", Message: "undefined: Foo"},
+					},
+				},
+				{
+					CheckName:   "test",
+					CheckRunID:  3,
+					Conclusion:  "timed_out",
+					Annotations: []workflowAnnotation{},
+				},
+			},
+		},
+		{
+			name: "no failed jobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRun,
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					&github.ListCheckRunsResults{
+						Total: github.Ptr(1),
+						CheckRuns: []*github.CheckRun{
+							{
+								ID:         github.Ptr(int64(2)),
+								Name:       github.Ptr("lint"),
+								Conclusion: github.Ptr("success"),
+								CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(99))},
+							},
+						},
+					},
+				),
+			),
+			requestArgs:  map[string]any{},
+			expectedJobs: []checkRunAnnotations{},
+		},
+		{
+			name: "check runs listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRun,
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow run",
+		},
+		{
+			name:         "negative max annotations",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"max_annotations": float64(-1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "max_annotations must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowRunAnnotations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				HeadSHA          string                `json:"head_sha"`
+				FailedJobs       int                   `json:"failed_jobs"`
+				TotalAnnotations int                   `json:"total_annotations"`
+				Truncated        bool                  `json:"truncated"`
+				Jobs             []checkRunAnnotations `json:"jobs"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "abc123", response.HeadSHA)
+			assert.Equal(t, len(tc.expectedJobs), response.FailedJobs)
+			assert.Equal(t, tc.expectedTotal, response.TotalAnnotations)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+			assert.Equal(t, tc.expectedJobs, response.Jobs)
+		})
+	}
+}
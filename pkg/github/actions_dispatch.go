@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// workflowDispatchInput is an input of the workflow_dispatch trigger of a workflow, as declared in
+// its on.workflow_dispatch.inputs block.
+type workflowDispatchInput struct {
+	Name        string `yaml:"-" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Required    bool   `yaml:"required" json:"required"`
+	// Type is one of string, boolean, choice, number or environment, string when not declared
+	Type    string `yaml:"type" json:"type"`
+	Default any    `yaml:"default" json:"default,omitempty"`
+	// Options are the allowed values of a choice input
+	Options []string `yaml:"options" json:"options,omitempty"`
+}
+
+// GetWorkflowDispatchInputs creates a tool to get the inputs a workflow accepts when it is run manually
+func GetWorkflowDispatchInputs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workflow_dispatch_inputs",
+			mcp.WithDescription(t("TOOL_GET_WORKFLOW_DISPATCH_INPUTS_DESCRIPTION", "Get the inputs a workflow accepts when it is triggered with run_workflow, with their description, type, default value and allowed options, read from the workflow_dispatch trigger of the workflow file. Call this before run_workflow to pass valid inputs")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKFLOW_DISPATCH_INPUTS_USER_TITLE", "Get workflow dispatch inputs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("workflow_id",
+				mcp.Required(),
+				mcp.Description("The workflow ID (numeric) or workflow file name (e.g., main.yml, ci.yaml)"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("The git reference to read the workflow file at, defaults to the default branch of the repository"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			workflowID, err := RequiredParam[string](request, "workflow_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var workflow *github.Workflow
+			resp, _, err := resolveWorkflow(workflowID,
+				func(id int64) (resp *github.Response, err error) {
+					workflow, resp, err = client.Actions.GetWorkflowByID(ctx, owner, repo, id)
+					return resp, err
+				},
+				func(fileName string) (resp *github.Response, err error) {
+					workflow, resp, err = client.Actions.GetWorkflowByFileName(ctx, owner, repo, fileName)
+					return resp, err
+				},
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflow.GetPath(), &github.RepositoryContentGetOptions{Ref: ref})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get workflow file %s: %w", workflow.GetPath(), err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if fileContent == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("workflow path %s is not a file", workflow.GetPath())), nil
+			}
+			content, err := fileContent.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode workflow file %s: %w", workflow.GetPath(), err)
+			}
+
+			hasDispatch, inputs, err := parseWorkflowDispatchInputs([]byte(content))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse workflow file %s: %v", workflow.GetPath(), err)), nil
+			}
+
+			result := map[string]any{
+				"workflow_id":           workflow.GetID(),
+				"workflow_name":         workflow.GetName(),
+				"path":                  workflow.GetPath(),
+				"has_workflow_dispatch": hasDispatch,
+				"inputs":                inputs,
+			}
+			if !hasDispatch {
+				result["message"] = "The workflow has no workflow_dispatch trigger, so it can't be run with run_workflow"
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// parseWorkflowDispatchInputs reports whether a workflow file has a workflow_dispatch trigger, and
+// returns the inputs of the trigger in the order they are declared. The on key of a workflow may be
+// a single event name, a list of event names or a mapping of event names to their configuration.
+func parseWorkflowDispatchInputs(content []byte) (bool, []workflowDispatchInput, error) {
+	var workflowFile struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(content, &workflowFile); err != nil {
+		return false, nil, err
+	}
+
+	inputs := []workflowDispatchInput{}
+	on := workflowFile.On
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return on.Value == "workflow_dispatch", inputs, nil
+	case yaml.SequenceNode:
+		for _, event := range on.Content {
+			if event.Value == "workflow_dispatch" {
+				return true, inputs, nil
+			}
+		}
+		return false, inputs, nil
+	case yaml.MappingNode:
+		dispatch := mappingValue(&on, "workflow_dispatch")
+		if dispatch == nil {
+			return false, inputs, nil
+		}
+		inputsNode := mappingValue(dispatch, "inputs")
+		if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+			return true, inputs, nil
+		}
+		for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+			input := workflowDispatchInput{}
+			if err := inputsNode.Content[i+1].Decode(&input); err != nil {
+				return false, nil, fmt.Errorf("invalid input %s: %w", inputsNode.Content[i].Value, err)
+			}
+			input.Name = inputsNode.Content[i].Value
+			if input.Type == "" {
+				input.Type = "string"
+			}
+			inputs = append(inputs, input)
+		}
+		return true, inputs, nil
+	default:
+		return false, inputs, nil
+	}
+}
+
+// mappingValue returns the value of a key of a YAML mapping node, or nil if the key is absent.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
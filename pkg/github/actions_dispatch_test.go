@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dispatchWorkflowYAML = `name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Environment to deploy to
+        required: true
+        type: choice
+        options:
+          - staging
+          - production
+      dry_run:
+        description: Only print the deployment plan
+        type: boolean
+        default: false
+      version:
+        description: Version to deploy
+        default: latest
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo deploying
+`
+
+func Test_GetWorkflowDispatchInputs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowDispatchInputs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_dispatch_inputs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	workflowHandler := func(path string) http.HandlerFunc {
+		return mockResponse(t, http.StatusOK, &github.Workflow{
+			ID:   github.Ptr(int64(161335)),
+			Name: github.Ptr("Deploy"),
+			Path: github.Ptr(path),
+		})
+	}
+	contentHandler := func(content string) http.HandlerFunc {
+		return mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(content))),
+		})
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]any
+		expectError         bool
+		expectedErrMsg      string
+		expectedHasDispatch bool
+		expectedInputs      []workflowDispatchInput
+	}{
+		{
+			name: "workflow with dispatch inputs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					workflowHandler(".github/workflows/deploy.yml"),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{
+						"ref": "main",
+					}).andThen(
+						contentHandler(dispatchWorkflowYAML),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "deploy.yml",
+				"ref":         "main",
+			},
+			expectedHasDispatch: true,
+			expectedInputs: []workflowDispatchInput{
+				{
+					Name:        "environment",
+					Description: "Environment to deploy to",
+					Required:    true,
+					Type:        "choice",
+					Options:     []string{"staging", "production"},
+				},
+				{
+					Name:        "dry_run",
+					Description: "Only print the deployment plan",
+					Type:        "boolean",
+					Default:     false,
+				},
+				{
+					Name:        "version",
+					Description: "Version to deploy",
+					Type:        "string",
+					Default:     "latest",
+				},
+			},
+		},
+		{
+			name: "workflow without dispatch trigger",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					workflowHandler(".github/workflows/ci.yml"),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					contentHandler("name: CI\non: [push, pull_request]\njobs: {}\n"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "161335",
+			},
+			expectedHasDispatch: false,
+			expectedInputs:      []workflowDispatchInput{},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepoByWorkflowId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "missing.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowDispatchInputs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				HasWorkflowDispatch bool                    `json:"has_workflow_dispatch"`
+				Inputs              []workflowDispatchInput `json:"inputs"`
+				Message             string                  `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedHasDispatch, response.HasWorkflowDispatch)
+			assert.Equal(t, tc.expectedInputs, response.Inputs)
+			if !tc.expectedHasDispatch {
+				assert.Contains(t, response.Message, "no workflow_dispatch trigger")
+			}
+		})
+	}
+}
+
+func Test_parseWorkflowDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name                string
+		content             string
+		expectedHasDispatch bool
+		expectedInputNames  []string
+		expectError         bool
+	}{
+		{
+			name:                "single event",
+			content:             "on: workflow_dispatch\n",
+			expectedHasDispatch: true,
+		},
+		{
+			name:                "event list",
+			content:             "on: [push, workflow_dispatch]\n",
+			expectedHasDispatch: true,
+		},
+		{
+			name:                "dispatch without inputs",
+			content:             "on:\n  workflow_dispatch:\n",
+			expectedHasDispatch: true,
+		},
+		{
+			name:                "inputs keep their order",
+			content:             "on:\n  workflow_dispatch:\n    inputs:\n      b:\n        type: string\n      a:\n        type: number\n",
+			expectedHasDispatch: true,
+			expectedInputNames:  []string{"b", "a"},
+		},
+		{
+			name:                "other events only",
+			content:             "on:\n  push:\n    branches: [main]\n",
+			expectedHasDispatch: false,
+		},
+		{
+			name:        "invalid yaml",
+			content:     "on: [push\n",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hasDispatch, inputs, err := parseWorkflowDispatchInputs([]byte(tc.content))
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHasDispatch, hasDispatch)
+			names := []string{}
+			for _, input := range inputs {
+				names = append(names, input.Name)
+			}
+			assert.Equal(t, append([]string{}, tc.expectedInputNames...), names)
+		})
+	}
+}
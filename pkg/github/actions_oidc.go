@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// oidcSubClaim is the customization of the sub claim of the OIDC tokens issued to the workflows of a repository
+type oidcSubClaim struct {
+	UseDefault       bool     `json:"use_default"`
+	IncludeClaimKeys []string `json:"include_claim_keys"`
+}
+
+// GetOIDCSubClaim creates a tool to get the OIDC subject claim customization of a repository
+func GetOIDCSubClaim(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_oidc_sub_claim",
+			mcp.WithDescription(t("TOOL_GET_OIDC_SUB_CLAIM_DESCRIPTION", "Get the customization of the sub claim of the OIDC tokens issued to the workflows of a repository. When use_default is false, the sub claim is built from the include_claim_keys in that order. Useful to debug cloud provider trust policies that don't match the tokens of workflows")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_OIDC_SUB_CLAIM_USER_TITLE", "Get OIDC subject claim customization"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subClaim, err := getOIDCSubClaim(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(subClaim)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetOIDCSubClaim creates a tool to change the OIDC subject claim customization of a repository
+func SetOIDCSubClaim(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_oidc_sub_claim",
+			mcp.WithDescription(t("TOOL_SET_OIDC_SUB_CLAIM_DESCRIPTION", "Change the customization of the sub claim of the OIDC tokens issued to the workflows of a repository, and return the resulting customization. Either use the default sub claim of the organization, or build the sub claim from include_claim_keys. Changing it can break the trust policies that match the current sub claim")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_OIDC_SUB_CLAIM_USER_TITLE", "Set OIDC subject claim customization"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithBoolean("use_default",
+				mcp.Required(),
+				mcp.Description("Whether to use the default sub claim. When false, include_claim_keys is required"),
+			),
+			mcp.WithArray("include_claim_keys",
+				mcp.Description("The claim keys the sub claim is built from, in order, like repo, context or job_workflow_ref"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// RequiredParam rejects false, which is a valid value here
+			useDefault, ok, err := OptionalParamOK[bool](request, "use_default")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: use_default"), nil
+			}
+			claimKeys, err := OptionalStringArrayParam(request, "include_claim_keys")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !useDefault && len(claimKeys) == 0 {
+				return mcp.NewToolResultError("include_claim_keys must not be empty when use_default is false"), nil
+			}
+			if useDefault && len(claimKeys) > 0 {
+				return mcp.NewToolResultError("include_claim_keys cannot be set when use_default is true"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			template := &github.OIDCSubjectClaimCustomTemplate{
+				UseDefault:       github.Ptr(useDefault),
+				IncludeClaimKeys: claimKeys,
+			}
+			resp, err := client.Actions.SetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo, template)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set OIDC subject claim customization: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// Read the customization back, the endpoint doesn't return it
+			subClaim, err := getOIDCSubClaim(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(subClaim)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getOIDCSubClaim reads the OIDC subject claim customization of a repository.
+func getOIDCSubClaim(ctx context.Context, client *github.Client, owner, repo string) (*oidcSubClaim, error) {
+	template, resp, err := client.Actions.GetRepoOIDCSubjectClaimCustomTemplate(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OIDC subject claim customization: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	claimKeys := template.IncludeClaimKeys
+	if claimKeys == nil {
+		claimKeys = []string{}
+	}
+	return &oidcSubClaim{
+		UseDefault:       template.GetUseDefault(),
+		IncludeClaimKeys: claimKeys,
+	}, nil
+}
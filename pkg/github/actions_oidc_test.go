@@ -0,0 +1,274 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockOIDCSubClaimRead(t *testing.T, template *github.OIDCSubjectClaimCustomTemplate) mock.MockBackendOption {
+	return mock.WithRequestMatchHandler(
+		mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+		mockResponse(t, http.StatusOK, template),
+	)
+}
+
+func Test_GetOIDCSubClaim(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOIDCSubClaim(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_oidc_sub_claim", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        oidcSubClaim
+	}{
+		{
+			name: "default sub claim",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockOIDCSubClaimRead(t, &github.OIDCSubjectClaimCustomTemplate{
+					UseDefault: github.Ptr(true),
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expected: oidcSubClaim{UseDefault: true, IncludeClaimKeys: []string{}},
+		},
+		{
+			name: "customized sub claim",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockOIDCSubClaimRead(t, &github.OIDCSubjectClaimCustomTemplate{
+					UseDefault:       github.Ptr(false),
+					IncludeClaimKeys: []string{"repo", "context", "job_workflow_ref"},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expected: oidcSubClaim{UseDefault: false, IncludeClaimKeys: []string{"repo", "context", "job_workflow_ref"}},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsOidcCustomizationSubByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get OIDC subject claim customization",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOIDCSubClaim(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response oidcSubClaim
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func Test_SetOIDCSubClaim(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOIDCSubClaim(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_oidc_sub_claim", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "use_default"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        oidcSubClaim
+	}{
+		{
+			name: "customize sub claim",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"use_default":        false,
+						"include_claim_keys": []any{"repo", "environment"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+				mockOIDCSubClaimRead(t, &github.OIDCSubjectClaimCustomTemplate{
+					UseDefault:       github.Ptr(false),
+					IncludeClaimKeys: []string{"repo", "environment"},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"use_default":        false,
+				"include_claim_keys": []any{"repo", "environment"},
+			},
+			expected: oidcSubClaim{UseDefault: false, IncludeClaimKeys: []string{"repo", "environment"}},
+		},
+		{
+			name: "reset to the default sub claim",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"use_default": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+				mockOIDCSubClaimRead(t, &github.OIDCSubjectClaimCustomTemplate{
+					UseDefault: github.Ptr(true),
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"use_default": true,
+			},
+			expected: oidcSubClaim{UseDefault: true, IncludeClaimKeys: []string{}},
+		},
+		{
+			name: "customization rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsOidcCustomizationSubByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid claim key"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"use_default":        false,
+				"include_claim_keys": []any{"unknown"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set OIDC subject claim customization",
+		},
+		{
+			name:         "custom sub claim without claim keys",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"use_default": false,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "include_claim_keys must not be empty when use_default is false",
+		},
+		{
+			name:         "default sub claim with claim keys",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":              "owner",
+				"repo":               "repo",
+				"use_default":        true,
+				"include_claim_keys": []any{"repo"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "include_claim_keys cannot be set when use_default is true",
+		},
+		{
+			name:         "missing required parameter use_default",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: use_default",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetOIDCSubClaim(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response oidcSubClaim
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultOrgRunsMaxRepos is the number of repositories list_org_workflow_runs checks unless
+	// max_repos is given
+	defaultOrgRunsMaxRepos = 30
+	// defaultOrgRunsConcurrency is the number of repositories checked at the same time unless
+	// concurrency is given
+	defaultOrgRunsConcurrency = 4
+	// maxOrgRunsConcurrency caps concurrency, to stay clear of the secondary rate limits of the API
+	maxOrgRunsConcurrency = 10
+)
+
+// repoRunsSummary counts the active workflow runs of a repository.
+type repoRunsSummary struct {
+	Repo         string     `json:"repo"`
+	Queued       int        `json:"queued"`
+	InProgress   int        `json:"in_progress"`
+	OldestQueued *time.Time `json:"oldest_queued_at,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// ListOrgWorkflowRuns creates a tool to summarize the queued and in progress workflow runs across the repositories of an organization
+func ListOrgWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_workflow_runs",
+			mcp.WithDescription(t("TOOL_LIST_ORG_WORKFLOW_RUNS_DESCRIPTION", "Summarize what is running across an organization: the number of queued and in progress workflow runs of each of its most recently pushed repositories, and when the oldest queued run was created. EXPENSIVE: makes 2 API calls per repository, up to max_repos. Only repositories with active runs are listed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_WORKFLOW_RUNS_USER_TITLE", "List organization workflow runs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithNumber("max_repos",
+				mcp.Description(fmt.Sprintf("The number of most recently pushed repositories to check (default %d, at most %d)", defaultOrgRunsMaxRepos, maxPerPage)),
+				mcp.Min(1),
+				mcp.Max(maxPerPage),
+			),
+			mcp.WithNumber("concurrency",
+				mcp.Description(fmt.Sprintf("The number of repositories checked at the same time (default %d, at most %d)", defaultOrgRunsConcurrency, maxOrgRunsConcurrency)),
+				mcp.Min(1),
+				mcp.Max(maxOrgRunsConcurrency),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRepos, err := OptionalIntParamWithDefault(request, "max_repos", defaultOrgRunsMaxRepos)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			concurrency, err := OptionalIntParamWithDefault(request, "concurrency", defaultOrgRunsConcurrency)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxRepos < 0 || concurrency < 0 {
+				return mcp.NewToolResultError("max_repos and concurrency must be positive"), nil
+			}
+			maxRepos = min(maxRepos, maxPerPage)
+			concurrency = min(concurrency, maxOrgRunsConcurrency)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repos, resp, err := client.Repositories.ListByOrg(ctx, org, &github.RepositoryListByOrgOptions{
+				Sort:        "pushed",
+				ListOptions: github.ListOptions{PerPage: maxRepos},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repositories: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// Archived repositories can't run workflows
+			var active []*github.Repository
+			for _, repo := range repos {
+				if !repo.GetArchived() {
+					active = append(active, repo)
+				}
+			}
+
+			summaries := make([]repoRunsSummary, len(active))
+			sem := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+			for i, repo := range active {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+					summaries[i] = summarizeRepoRuns(ctx, client, org, repo.GetName())
+				}()
+			}
+			wg.Wait()
+
+			busy := []repoRunsSummary{}
+			totalQueued, totalInProgress := 0, 0
+			for _, summary := range summaries {
+				totalQueued += summary.Queued
+				totalInProgress += summary.InProgress
+				if summary.Queued > 0 || summary.InProgress > 0 || summary.Error != "" {
+					busy = append(busy, summary)
+				}
+			}
+
+			result := map[string]any{
+				"org":               org,
+				"repos_checked":     len(active),
+				"total_queued":      totalQueued,
+				"total_in_progress": totalInProgress,
+				"repos":             busy,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// summarizeRepoRuns counts the queued and in progress workflow runs of a repository. A failure is
+// reported in the summary rather than failing the whole organization overview.
+func summarizeRepoRuns(ctx context.Context, client *github.Client, owner, repo string) repoRunsSummary {
+	summary := repoRunsSummary{Repo: repo}
+	for _, status := range []string{"queued", "in_progress"} {
+		runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, &github.ListWorkflowRunsOptions{
+			Status:      status,
+			ListOptions: github.ListOptions{PerPage: maxPerPage},
+		})
+		if err != nil {
+			summary.Error = fmt.Sprintf("failed to list %s workflow runs: %v", status, err)
+			return summary
+		}
+		_ = resp.Body.Close()
+
+		if status == "in_progress" {
+			summary.InProgress = runs.GetTotalCount()
+			continue
+		}
+		summary.Queued = runs.GetTotalCount()
+		// Runs are listed newest first, with more queued runs than a page holds this is the oldest of the page
+		for _, run := range runs.WorkflowRuns {
+			created := run.GetCreatedAt().Time
+			if summary.OldestQueued == nil || created.Before(*summary.OldestQueued) {
+				summary.OldestQueued = &created
+			}
+		}
+	}
+	return summary
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	oldest := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	listRepos := mock.WithRequestMatchHandler(
+		mock.GetOrgsReposByOrg,
+		expectQueryParams(t, map[string]string{
+			"sort":     "pushed",
+			"per_page": "30",
+		}).andThen(
+			mockResponse(t, http.StatusOK, []*github.Repository{
+				{Name: github.Ptr("api")},
+				{Name: github.Ptr("web")},
+				{Name: github.Ptr("docs")},
+				{Name: github.Ptr("legacy"), Archived: github.Ptr(true)},
+			}),
+		),
+	)
+	// runs maps the path and status of each request to the runs of the repository
+	runs := map[string]*github.WorkflowRuns{
+		"/repos/org/api/actions/runs?queued": {
+			TotalCount: github.Ptr(2),
+			WorkflowRuns: []*github.WorkflowRun{
+				{ID: github.Ptr(int64(2)), CreatedAt: &github.Timestamp{Time: oldest.Add(time.Hour)}},
+				{ID: github.Ptr(int64(1)), CreatedAt: &github.Timestamp{Time: oldest}},
+			},
+		},
+		"/repos/org/api/actions/runs?in_progress": {
+			TotalCount:   github.Ptr(1),
+			WorkflowRuns: []*github.WorkflowRun{{ID: github.Ptr(int64(3))}},
+		},
+		"/repos/org/web/actions/runs?queued":       {TotalCount: github.Ptr(0)},
+		"/repos/org/web/actions/runs?in_progress":  {TotalCount: github.Ptr(0)},
+		"/repos/org/docs/actions/runs?queued":      {TotalCount: github.Ptr(0)},
+		"/repos/org/docs/actions/runs?in_progress": {TotalCount: github.Ptr(3)},
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	listRuns := mock.WithRequestMatchHandler(
+		mock.GetReposActionsRunsByOwnerByRepo,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			// Give the other workers the time to start their requests
+			time.Sleep(10 * time.Millisecond)
+
+			found, ok := runs[r.URL.Path+"?"+r.URL.Query().Get("status")]
+			if !ok {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, found)(w, r)
+		}),
+	)
+
+	t.Run("summary of the active repositories", func(t *testing.T) {
+		maxInFlight.Store(0)
+		client := github.NewClient(mock.NewMockedHTTPClient(listRepos, listRuns))
+		_, handler := ListOrgWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org":         "org",
+			"concurrency": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response struct {
+			ReposChecked    int               `json:"repos_checked"`
+			TotalQueued     int               `json:"total_queued"`
+			TotalInProgress int               `json:"total_in_progress"`
+			Repos           []repoRunsSummary `json:"repos"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, 3, response.ReposChecked)
+		assert.Equal(t, 2, response.TotalQueued)
+		assert.Equal(t, 4, response.TotalInProgress)
+		assert.Equal(t, []repoRunsSummary{
+			{Repo: "api", Queued: 2, InProgress: 1, OldestQueued: &oldest},
+			{Repo: "docs", InProgress: 3},
+		}, response.Repos)
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(2))
+	})
+
+	t.Run("failing repository is reported", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetOrgsReposByOrg,
+				[]*github.Repository{{Name: github.Ptr("secret")}},
+			),
+			listRuns,
+		))
+		_, handler := ListOrgWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org": "org",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response struct {
+			Repos []repoRunsSummary `json:"repos"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		require.Len(t, response.Repos, 1)
+		assert.Equal(t, "secret", response.Repos[0].Repo)
+		assert.True(t, strings.HasPrefix(response.Repos[0].Error, "failed to list queued workflow runs"))
+	})
+
+	t.Run("organization not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsReposByOrg,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}),
+			),
+		))
+		_, handler := ListOrgWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		_, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"org": "org",
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list repositories")
+	})
+
+	t.Run("missing required parameter org", func(t *testing.T) {
+		_, handler := ListOrgWorkflowRuns(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: org")
+	})
+}
@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	allowedActionsPolicies     = []string{"all", "local_only", "selected"}
+	defaultWorkflowPermissions = []string{"read", "write"}
+)
+
+// actionsPermissions is the Actions policy of a repository, combining whether Actions are enabled
+// and which actions may run with the default permissions of the GITHUB_TOKEN of workflows.
+type actionsPermissions struct {
+	Enabled                      bool   `json:"enabled"`
+	AllowedActions               string `json:"allowed_actions,omitempty"`
+	SelectedActionsURL           string `json:"selected_actions_url,omitempty"`
+	DefaultWorkflowPermissions   string `json:"default_workflow_permissions,omitempty"`
+	CanApprovePullRequestReviews bool   `json:"can_approve_pull_request_reviews"`
+}
+
+// GetActionsPermissions creates a tool to get the Actions permissions of a repository
+func GetActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_actions_permissions",
+			mcp.WithDescription(t("TOOL_GET_ACTIONS_PERMISSIONS_DESCRIPTION", "Get the Actions permissions of a repository: whether Actions are enabled, which actions are allowed to run, and the default permissions of the GITHUB_TOKEN of workflows. Useful when workflows can't run because Actions are disabled")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ACTIONS_PERMISSIONS_USER_TITLE", "Get repository Actions permissions"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			permissions, err := getActionsPermissions(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetActionsPermissions creates a tool to change the Actions permissions of a repository
+func SetActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_actions_permissions",
+			mcp.WithDescription(t("TOOL_SET_ACTIONS_PERMISSIONS_DESCRIPTION", "Change the Actions permissions of a repository. Only the given settings are changed, and the resulting permissions are returned")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ACTIONS_PERMISSIONS_USER_TITLE", "Set repository Actions permissions"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithBoolean("enabled",
+				mcp.Description("Whether Actions are enabled for the repository, required when allowed_actions is set"),
+			),
+			mcp.WithString("allowed_actions",
+				mcp.Description("The actions allowed to run: all actions, only actions of the repository owner (local_only), or selected actions"),
+				mcp.Enum(allowedActionsPolicies...),
+			),
+			mcp.WithString("default_workflow_permissions",
+				mcp.Description("The default permissions of the GITHUB_TOKEN of workflows"),
+				mcp.Enum(defaultWorkflowPermissions...),
+			),
+			mcp.WithBoolean("can_approve_pull_request_reviews",
+				mcp.Description("Whether workflows can approve pull request reviews"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabled, hasEnabled, err := OptionalParamOK[bool](request, "enabled")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowedActions, err := OptionalParam[string](request, "allowed_actions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if allowedActions != "" && !slices.Contains(allowedActionsPolicies, allowedActions) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid allowed_actions: %s, must be one of all, local_only or selected", allowedActions)), nil
+			}
+			if allowedActions != "" && !hasEnabled {
+				return mcp.NewToolResultError("enabled is required when allowed_actions is set"), nil
+			}
+			workflowPermissions, err := OptionalParam[string](request, "default_workflow_permissions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if workflowPermissions != "" && !slices.Contains(defaultWorkflowPermissions, workflowPermissions) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid default_workflow_permissions: %s, must be read or write", workflowPermissions)), nil
+			}
+			canApprove, hasCanApprove, err := OptionalParamOK[bool](request, "can_approve_pull_request_reviews")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !hasEnabled && workflowPermissions == "" && !hasCanApprove {
+				return mcp.NewToolResultError("at least one of enabled, allowed_actions, default_workflow_permissions or can_approve_pull_request_reviews must be set"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if hasEnabled {
+				edit := github.ActionsPermissionsRepository{Enabled: github.Ptr(enabled)}
+				if allowedActions != "" {
+					edit.AllowedActions = github.Ptr(allowedActions)
+				}
+				_, resp, err := client.Repositories.EditActionsPermissions(ctx, owner, repo, edit)
+				if err != nil {
+					return nil, fmt.Errorf("failed to set Actions permissions: %w", err)
+				}
+				_ = resp.Body.Close()
+			}
+
+			if workflowPermissions != "" || hasCanApprove {
+				edit := github.DefaultWorkflowPermissionRepository{}
+				if workflowPermissions != "" {
+					edit.DefaultWorkflowPermissions = github.Ptr(workflowPermissions)
+				}
+				if hasCanApprove {
+					edit.CanApprovePullRequestReviews = github.Ptr(canApprove)
+				}
+				_, resp, err := client.Repositories.EditDefaultWorkflowPermissions(ctx, owner, repo, edit)
+				if err != nil {
+					return nil, fmt.Errorf("failed to set default workflow permissions: %w", err)
+				}
+				_ = resp.Body.Close()
+			}
+
+			// Read the permissions back, the edit endpoints don't return them
+			permissions, err := getActionsPermissions(ctx, client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := json.Marshal(permissions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getActionsPermissions reads both parts of the Actions permissions of a repository.
+func getActionsPermissions(ctx context.Context, client *github.Client, owner, repo string) (*actionsPermissions, error) {
+	permissions, resp, err := client.Repositories.GetActionsPermissions(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Actions permissions: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	defaults, resp, err := client.Repositories.GetDefaultWorkflowPermissions(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default workflow permissions: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	return &actionsPermissions{
+		Enabled:                      permissions.GetEnabled(),
+		AllowedActions:               permissions.GetAllowedActions(),
+		SelectedActionsURL:           permissions.GetSelectedActionsURL(),
+		DefaultWorkflowPermissions:   defaults.GetDefaultWorkflowPermissions(),
+		CanApprovePullRequestReviews: defaults.GetCanApprovePullRequestReviews(),
+	}, nil
+}
@@ -0,0 +1,274 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockActionsPermissionsReads(t *testing.T) []mock.MockBackendOption {
+	return []mock.MockBackendOption{
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsPermissionsByOwnerByRepo,
+			mockResponse(t, http.StatusOK, &github.ActionsPermissionsRepository{
+				Enabled:            github.Ptr(true),
+				AllowedActions:     github.Ptr("selected"),
+				SelectedActionsURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/permissions/selected-actions"),
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsPermissionsWorkflowByOwnerByRepo,
+			mockResponse(t, http.StatusOK, &github.DefaultWorkflowPermissionRepository{
+				DefaultWorkflowPermissions:   github.Ptr("read"),
+				CanApprovePullRequestReviews: github.Ptr(false),
+			}),
+		),
+	}
+}
+
+func Test_GetActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name:         "successful permissions retrieval",
+			mockedClient: mock.NewMockedHTTPClient(mockActionsPermissionsReads(t)...),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsPermissionsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get Actions permissions",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response actionsPermissions
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.True(t, response.Enabled)
+			assert.Equal(t, "selected", response.AllowedActions)
+			assert.NotEmpty(t, response.SelectedActionsURL)
+			assert.Equal(t, "read", response.DefaultWorkflowPermissions)
+			assert.False(t, response.CanApprovePullRequestReviews)
+		})
+	}
+}
+
+func Test_SetActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "enable Actions for selected actions",
+			mockedClient: mock.NewMockedHTTPClient(append(mockActionsPermissionsReads(t),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"enabled":         true,
+						"allowed_actions": "selected",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			)...),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"enabled":         true,
+				"allowed_actions": "selected",
+			},
+		},
+		{
+			name: "set default workflow permissions",
+			mockedClient: mock.NewMockedHTTPClient(append(mockActionsPermissionsReads(t),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsWorkflowByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"default_workflow_permissions":     "read",
+						"can_approve_pull_request_reviews": false,
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			)...),
+			requestArgs: map[string]any{
+				"owner":                            "owner",
+				"repo":                             "repo",
+				"default_workflow_permissions":     "read",
+				"can_approve_pull_request_reviews": false,
+			},
+		},
+		{
+			name: "edit rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsPermissionsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Actions are disabled by the organization"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"enabled": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set Actions permissions",
+		},
+		{
+			name:         "invalid allowed_actions",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"enabled":         true,
+				"allowed_actions": "everything",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid allowed_actions: everything",
+		},
+		{
+			name:         "allowed_actions without enabled",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"allowed_actions": "all",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "enabled is required when allowed_actions is set",
+		},
+		{
+			name:         "invalid default_workflow_permissions",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":                        "owner",
+				"repo":                         "repo",
+				"default_workflow_permissions": "admin",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid default_workflow_permissions: admin",
+		},
+		{
+			name:         "nothing to set",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at least one of",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response actionsPermissions
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.True(t, response.Enabled)
+			assert.Equal(t, "read", response.DefaultWorkflowPermissions)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// actionsSecret is the metadata of an Actions secret returned by the tools. Secret values are never
+// readable through the API, and the type deliberately has no field that could hold one.
+type actionsSecret struct {
+	Name       string           `json:"name"`
+	CreatedAt  github.Timestamp `json:"created_at"`
+	UpdatedAt  github.Timestamp `json:"updated_at"`
+	Visibility string           `json:"visibility,omitempty"`
+	// SelectedRepositories are the full names of the repositories that can use an organization
+	// secret whose visibility is "selected"
+	SelectedRepositories []string `json:"selected_repositories,omitempty"`
+}
+
+type actionsSecretList struct {
+	TotalCount int              `json:"total_count"`
+	Secrets    []*actionsSecret `json:"secrets"`
+}
+
+func newActionsSecret(secret *github.Secret) *actionsSecret {
+	return &actionsSecret{
+		Name:       secret.Name,
+		CreatedAt:  secret.CreatedAt,
+		UpdatedAt:  secret.UpdatedAt,
+		Visibility: secret.Visibility,
+	}
+}
+
+// ListActionsSecrets creates a tool to list the names of the Actions secrets of a repository
+func ListActionsSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_actions_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ACTIONS_SECRETS_DESCRIPTION", "List the names of the Actions secrets of a repository, with when they were created and updated. Secret values are never returned")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ACTIONS_SECRETS_USER_TITLE", "List repository Actions secrets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			}
+			secrets, resp, err := client.Actions.ListRepoSecrets(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository secrets: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := actionsSecretList{
+				TotalCount: secrets.TotalCount,
+				Secrets:    make([]*actionsSecret, 0, len(secrets.Secrets)),
+			}
+			for _, secret := range secrets.Secrets {
+				result.Secrets = append(result.Secrets, newActionsSecret(secret))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListOrgActionsSecrets creates a tool to list the names of the Actions secrets of an organization
+func ListOrgActionsSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_actions_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ORG_ACTIONS_SECRETS_DESCRIPTION", "List the names of the Actions secrets of an organization, with their visibility and, for secrets shared with selected repositories, the repositories that can use them. Secret values are never returned")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_ACTIONS_SECRETS_USER_TITLE", "List organization Actions secrets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			}
+			secrets, resp, err := client.Actions.ListOrgSecrets(ctx, org, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list organization secrets: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := actionsSecretList{
+				TotalCount: secrets.TotalCount,
+				Secrets:    make([]*actionsSecret, 0, len(secrets.Secrets)),
+			}
+			for _, secret := range secrets.Secrets {
+				entry := newActionsSecret(secret)
+				if secret.Visibility == "selected" {
+					entry.SelectedRepositories, err = listSelectedReposForOrgSecret(ctx, client, org, secret.Name)
+					if err != nil {
+						return nil, err
+					}
+				}
+				result.Secrets = append(result.Secrets, entry)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// listSelectedReposForOrgSecret returns the full names of the repositories that can use an
+// organization secret, following every page of the results.
+func listSelectedReposForOrgSecret(ctx context.Context, client *github.Client, org, name string) ([]string, error) {
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := client.Actions.ListSelectedReposForOrgSecret(ctx, org, name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list selected repositories of secret %s: %w", name, err)
+		}
+		_ = resp.Body.Close()
+
+		for _, repo := range repos.Repositories {
+			names = append(names, repo.GetFullName())
+		}
+		if resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateOrUpdateActionsSecret creates a tool to set the value of an Actions secret of a repository
+func CreateOrUpdateActionsSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_actions_secret",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_ACTIONS_SECRET_DESCRIPTION", "Create an Actions secret in a repository, or replace the value of an existing one. The value is encrypted with the public key of the repository before it is sent")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_ACTIONS_SECRET_USER_TITLE", "Create or update repository Actions secret"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("secret_name",
+				mcp.Required(),
+				mcp.Description("The name of the secret"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("The plaintext value of the secret"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			secretName, err := RequiredParam[string](request, "secret_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := RequiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			publicKey, resp, err := client.Actions.GetRepoPublicKey(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository public key: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			encryptedValue, err := sealSecret(publicKey.GetKey(), value)
+			if err != nil {
+				return nil, err
+			}
+
+			secret := &github.EncryptedSecret{
+				Name:           secretName,
+				KeyID:          publicKey.GetKeyID(),
+				EncryptedValue: encryptedValue,
+			}
+			resp, err = client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repo, secret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create or update secret: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			message := "Secret has been updated"
+			if resp.StatusCode == http.StatusCreated {
+				message = "Secret has been created"
+			}
+			result := map[string]any{
+				"message":     message,
+				"secret_name": secretName,
+				"key_id":      publicKey.GetKeyID(),
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// sealSecret encrypts a secret value for GitHub with a libsodium sealed box, using the base64
+// encoded public key of the repository, and returns the base64 encoded ciphertext.
+func sealSecret(publicKey, value string) (string, error) {
+	decodedKey, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode repository public key: %w", err)
+	}
+	if len(decodedKey) != 32 {
+		return "", fmt.Errorf("invalid repository public key: expected 32 bytes, got %d", len(decodedKey))
+	}
+	var key [32]byte
+	copy(key[:], decodedKey)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &key, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
@@ -0,0 +1,464 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// assertNoSecretValues fails the test if any secret in the output has a field other than its metadata.
+func assertNoSecretValues(t *testing.T, text string) {
+	t.Helper()
+	var response struct {
+		Secrets []map[string]any `json:"secrets"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &response))
+	for _, secret := range response.Secrets {
+		for key := range secret {
+			assert.Contains(t, []string{"name", "created_at", "updated_at", "visibility", "selected_repositories"}, key)
+		}
+	}
+	assert.NotContains(t, text, "value")
+}
+
+func Test_ListActionsSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListActionsSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_actions_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedNames   []string
+	}{
+		{
+			name: "successful secrets listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsSecretsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusOK)
+							// An unexpected value field must not be passed through
+							_, _ = w.Write([]byte(`{"total_count": 2, "secrets": [
+								{"name": "DEPLOY_KEY", "created_at": "2025-01-10T10:00:00Z", "updated_at": "2025-02-10T10:00:00Z", "value": "hunter2"},
+								{"name": "NPM_TOKEN", "created_at": "2025-01-11T10:00:00Z", "updated_at": "2025-01-11T10:00:00Z"}
+							]}`))
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedNames: []string{"DEPLOY_KEY", "NPM_TOKEN"},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsSecretsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository secrets",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListActionsSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			text := getTextResult(t, result).Text
+			assertNoSecretValues(t, text)
+
+			var response actionsSecretList
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			assert.Equal(t, 2, response.TotalCount)
+			names := make([]string, 0, len(response.Secrets))
+			for _, secret := range response.Secrets {
+				names = append(names, secret.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+			assert.False(t, response.Secrets[0].CreatedAt.IsZero())
+			assert.True(t, response.Secrets[0].UpdatedAt.After(response.Secrets[0].CreatedAt.Time))
+		})
+	}
+}
+
+func Test_ListOrgActionsSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgActionsSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_actions_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	secretsResponse := `{"total_count": 2, "secrets": [
+		{"name": "SHARED_TOKEN", "created_at": "2025-01-10T10:00:00Z", "updated_at": "2025-01-10T10:00:00Z", "visibility": "all", "value": "hunter2"},
+		{"name": "DEPLOY_KEY", "created_at": "2025-01-11T10:00:00Z", "updated_at": "2025-01-11T10:00:00Z", "visibility": "selected",
+		 "selected_repositories_url": "https://api.github.com/orgs/octo-org/actions/secrets/DEPLOY_KEY/repositories"}
+	]}`
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful secrets listing with selected repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(secretsResponse))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsSecretsRepositoriesByOrgBySecretName,
+					github.SelectedReposList{
+						TotalCount: github.Ptr(2),
+						Repositories: []*github.Repository{
+							{FullName: github.Ptr("octo-org/api")},
+							{FullName: github.Ptr("octo-org/web")},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+		},
+		{
+			name: "selected repositories lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(secretsResponse))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsRepositoriesByOrgBySecretName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list selected repositories of secret DEPLOY_KEY",
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization secrets",
+		},
+		{
+			name:            "missing required parameter org",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgActionsSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			text := getTextResult(t, result).Text
+			assertNoSecretValues(t, text)
+
+			var response actionsSecretList
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			require.Len(t, response.Secrets, 2)
+			assert.Equal(t, "SHARED_TOKEN", response.Secrets[0].Name)
+			assert.Equal(t, "all", response.Secrets[0].Visibility)
+			assert.Empty(t, response.Secrets[0].SelectedRepositories)
+			assert.Equal(t, "DEPLOY_KEY", response.Secrets[1].Name)
+			assert.Equal(t, "selected", response.Secrets[1].Visibility)
+			assert.Equal(t, []string{"octo-org/api", "octo-org/web"}, response.Secrets[1].SelectedRepositories)
+		})
+	}
+}
+
+func Test_CreateOrUpdateActionsSecret(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateActionsSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_actions_secret", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "secret_name", "value"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	repoPublicKey := &github.PublicKey{
+		KeyID: github.Ptr("568250167242549743"),
+		Key:   github.Ptr(base64.StdEncoding.EncodeToString(publicKey[:])),
+	}
+	const plaintext = "hunter2-super-secret"
+
+	// putSecret checks that the request carries the sealed value and never the plaintext
+	putSecret := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.NotContains(t, string(body), plaintext)
+
+			var secret struct {
+				KeyID          string `json:"key_id"`
+				EncryptedValue string `json:"encrypted_value"`
+			}
+			require.NoError(t, json.Unmarshal(body, &secret))
+			assert.Equal(t, "568250167242549743", secret.KeyID)
+			require.NotEmpty(t, secret.EncryptedValue)
+
+			sealed, err := base64.StdEncoding.DecodeString(secret.EncryptedValue)
+			require.NoError(t, err)
+			opened, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+			require.True(t, ok, "encrypted value must open with the repository key pair")
+			assert.Equal(t, plaintext, string(opened))
+
+			w.WriteHeader(status)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedMessage string
+	}{
+		{
+			name: "secret created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsSecretsPublicKeyByOwnerByRepo,
+					repoPublicKey,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsSecretsByOwnerByRepoBySecretName,
+					putSecret(http.StatusCreated),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"secret_name": "DEPLOY_KEY",
+				"value":       plaintext,
+			},
+			expectedMessage: "Secret has been created",
+		},
+		{
+			name: "secret updated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsSecretsPublicKeyByOwnerByRepo,
+					repoPublicKey,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposActionsSecretsByOwnerByRepoBySecretName,
+					putSecret(http.StatusNoContent),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"secret_name": "DEPLOY_KEY",
+				"value":       plaintext,
+			},
+			expectedMessage: "Secret has been updated",
+		},
+		{
+			name: "invalid public key",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsSecretsPublicKeyByOwnerByRepo,
+					&github.PublicKey{
+						KeyID: github.Ptr("568250167242549743"),
+						Key:   github.Ptr(base64.StdEncoding.EncodeToString([]byte("short"))),
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"secret_name": "DEPLOY_KEY",
+				"value":       plaintext,
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid repository public key",
+		},
+		{
+			name: "public key not accessible",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsSecretsPublicKeyByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"secret_name": "DEPLOY_KEY",
+				"value":       plaintext,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository public key",
+		},
+		{
+			name:         "missing required parameter value",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"secret_name": "DEPLOY_KEY",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: value",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateActionsSecret(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			text := getTextResult(t, result).Text
+			assert.NotContains(t, text, plaintext)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(text), &response))
+			assert.Equal(t, tc.expectedMessage, response["message"])
+			assert.Equal(t, "DEPLOY_KEY", response["secret_name"])
+			assert.Equal(t, "568250167242549743", response["key_id"])
+		})
+	}
+}
@@ -1,14 +1,26 @@
 package github
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,6 +92,41 @@ func Test_ListWorkflows(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "pagination given as strings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Workflows{
+							TotalCount: github.Ptr(1),
+							Workflows:  []*github.Workflow{{ID: github.Ptr(int64(123)), Name: github.Ptr("CI")}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"page":     "2",
+				"per_page": "500",
+			},
+			expectError: false,
+		},
+		{
+			name:         "negative page",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  float64(-1),
+			},
+			expectError:    true,
+			expectedErrMsg: "parameter page must be at least 1, is -1",
+		},
 		{
 			name:         "missing required parameter owner",
 			mockedClient: mock.NewMockedHTTPClient(),
@@ -125,6 +172,124 @@ func Test_ListWorkflows(t *testing.T) {
 	}
 }
 
+func Test_ListWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "created")
+	assert.Contains(t, tool.InputSchema.Properties, "head_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude_pull_requests")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{ID: github.Ptr(int64(12345)), HeadSHA: github.Ptr("abc123"), Status: github.Ptr("completed")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "filters are sent as query parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					expectQueryParams(t, map[string]string{
+						"branch":                "main",
+						"status":                "completed",
+						"created":               ">=2024-01-01",
+						"head_sha":              "abc123",
+						"exclude_pull_requests": "true",
+						"page":                  "1",
+						"per_page":              "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, runs),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"workflow_id":           "ci.yml",
+				"branch":                "main",
+				"status":                "completed",
+				"created":               ">=2024-01-01",
+				"head_sha":              "abc123",
+				"exclude_pull_requests": true,
+			},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "missing.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow runs",
+		},
+		{
+			name:         "invalid exclude_pull_requests",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":                 "owner",
+				"repo":                  "repo",
+				"workflow_id":           "ci.yml",
+				"exclude_pull_requests": "yes",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "parameter exclude_pull_requests is not of type bool",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.WorkflowRuns
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.WorkflowRuns, 1)
+			assert.Equal(t, "abc123", response.WorkflowRuns[0].GetHeadSHA())
+		})
+	}
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -210,6 +375,125 @@ func Test_RunWorkflow(t *testing.T) {
 	}
 }
 
+func Test_EnableDisableWorkflow(t *testing.T) {
+	tools := []struct {
+		name     string
+		action   string
+		create   func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		endpoint mock.EndpointPattern
+		message  string
+	}{
+		{
+			name:     "enable_workflow",
+			action:   "enable",
+			create:   EnableWorkflow,
+			endpoint: mock.PutReposActionsWorkflowsEnableByOwnerByRepoByWorkflowId,
+			message:  "Workflow has been enabled",
+		},
+		{
+			name:     "disable_workflow",
+			action:   "disable",
+			create:   DisableWorkflow,
+			endpoint: mock.PutReposActionsWorkflowsDisableByOwnerByRepoByWorkflowId,
+			message:  "Workflow has been disabled",
+		},
+	}
+
+	for _, tt := range tools {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verify tool definition once
+			tool, _ := tt.create(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+			require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+			assert.Equal(t, tt.name, tool.Name)
+			assert.NotEmpty(t, tool.Description)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+			assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+			tests := []struct {
+				name                 string
+				workflowID           string
+				status               int
+				expectedPath         string
+				expectedWorkflowType string
+				expectError          bool
+				expectedErrMsg       string
+			}{
+				{
+					name:                 "by workflow ID",
+					workflowID:           "12345",
+					status:               http.StatusNoContent,
+					expectedPath:         "/repos/owner/repo/actions/workflows/12345/" + tt.action,
+					expectedWorkflowType: "workflow_id",
+				},
+				{
+					name:                 "by workflow file name",
+					workflowID:           "nightly.yml",
+					status:               http.StatusNoContent,
+					expectedPath:         "/repos/owner/repo/actions/workflows/nightly.yml/" + tt.action,
+					expectedWorkflowType: "workflow_file",
+				},
+				{
+					name:           "workflow not found",
+					workflowID:     "missing.yml",
+					status:         http.StatusNotFound,
+					expectError:    true,
+					expectedErrMsg: "failed to " + tt.action + " workflow",
+				},
+			}
+
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					var requestedPath string
+					mockedClient := mock.NewMockedHTTPClient(
+						mock.WithRequestMatchHandler(
+							tt.endpoint,
+							http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+								requestedPath = r.URL.Path
+								w.WriteHeader(tc.status)
+								if tc.status >= 400 {
+									_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+								}
+							}),
+						),
+					)
+					_, handler := tt.create(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+					result, err := handler(context.Background(), createMCPRequest(map[string]any{
+						"owner":       "owner",
+						"repo":        "repo",
+						"workflow_id": tc.workflowID,
+					}))
+					if tc.expectError {
+						require.Error(t, err)
+						assert.Contains(t, err.Error(), tc.expectedErrMsg)
+						return
+					}
+					require.NoError(t, err)
+					require.False(t, result.IsError)
+					assert.Equal(t, tc.expectedPath, requestedPath)
+
+					var response map[string]any
+					require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+					assert.Equal(t, tt.message, response["message"])
+					assert.Equal(t, tt.action, response["action"])
+					assert.Equal(t, tc.workflowID, response["workflow_id"])
+					assert.Equal(t, tc.expectedWorkflowType, response["workflow_type"])
+					assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+				})
+			}
+
+			t.Run("missing required parameter workflow_id", func(t *testing.T) {
+				_, handler := tt.create(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), translations.NullTranslationHelper)
+				result, err := handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo"}))
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				assert.Equal(t, "missing required parameter: workflow_id", getErrorResult(t, result).Text)
+			})
+		})
+	}
+}
+
 func Test_RunWorkflow_WithFilename(t *testing.T) {
 	// Test the unified RunWorkflow function with filenames
 	tests := []struct {
@@ -383,87 +667,87 @@ func Test_CancelWorkflowRun(t *testing.T) {
 	}
 }
 
-func Test_ListWorkflowRunArtifacts(t *testing.T) {
+func Test_ApproveWorkflowRun(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListWorkflowRunArtifacts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ApproveWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "list_workflow_run_artifacts", tool.Name)
+	assert.Equal(t, "approve_workflow_run", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "run_id")
-	assert.Contains(t, tool.InputSchema.Properties, "per_page")
-	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]any
-		expectError    bool
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful artifacts listing",
+			name: "successful approval",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						artifacts := &github.ArtifactList{
-							TotalCount: github.Ptr(int64(2)),
-							Artifacts: []*github.Artifact{
-								{
-									ID:                 github.Ptr(int64(1)),
-									NodeID:             github.Ptr("A_1"),
-									Name:               github.Ptr("build-artifacts"),
-									SizeInBytes:        github.Ptr(int64(1024)),
-									URL:                github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/1"),
-									ArchiveDownloadURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/1/zip"),
-									Expired:            github.Ptr(false),
-									CreatedAt:          &github.Timestamp{},
-									UpdatedAt:          &github.Timestamp{},
-									ExpiresAt:          &github.Timestamp{},
-									WorkflowRun: &github.ArtifactWorkflowRun{
-										ID:               github.Ptr(int64(12345)),
-										RepositoryID:     github.Ptr(int64(1)),
-										HeadRepositoryID: github.Ptr(int64(1)),
-										HeadBranch:       github.Ptr("main"),
-										HeadSHA:          github.Ptr("abc123"),
-									},
-								},
-								{
-									ID:                 github.Ptr(int64(2)),
-									NodeID:             github.Ptr("A_2"),
-									Name:               github.Ptr("test-results"),
-									SizeInBytes:        github.Ptr(int64(512)),
-									URL:                github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/2"),
-									ArchiveDownloadURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/2/zip"),
-									Expired:            github.Ptr(false),
-									CreatedAt:          &github.Timestamp{},
-									UpdatedAt:          &github.Timestamp{},
-									ExpiresAt:          &github.Timestamp{},
-									WorkflowRun: &github.ArtifactWorkflowRun{
-										ID:               github.Ptr(int64(12345)),
-										RepositoryID:     github.Ptr(int64(1)),
-										HeadRepositoryID: github.Ptr(int64(1)),
-										HeadBranch:       github.Ptr("main"),
-										HeadSHA:          github.Ptr("abc123"),
-									},
-								},
-							},
-						}
-						w.WriteHeader(http.StatusOK)
-						_ = json.NewEncoder(w).Encode(artifacts)
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte(`{}`))
 					}),
 				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					&github.WorkflowRun{
+						ID:      github.Ptr(int64(12345)),
+						Status:  github.Ptr("queued"),
+						HTMLURL: github.Ptr("https://github.com/owner/repo/actions/runs/12345"),
+					},
+				),
 			),
 			requestArgs: map[string]any{
 				"owner":  "owner",
 				"repo":   "repo",
 				"run_id": float64(12345),
 			},
-			expectError: false,
+		},
+		{
+			name: "token lacks permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "not allowed to approve workflow run 12345",
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsApproveByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to approve workflow run",
 		},
 		{
 			name:         "missing required parameter run_id",
@@ -472,260 +756,305 @@ func Test_ListWorkflowRunArtifacts(t *testing.T) {
 				"owner": "owner",
 				"repo":  "repo",
 			},
-			expectError:    true,
-			expectedErrMsg: "missing required parameter: run_id",
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: run_id",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListWorkflowRunArtifacts(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
+			_, handler := ApproveWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
 
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
 			require.NoError(t, err)
-			require.Equal(t, tc.expectError, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
 
-			if tc.expectedErrMsg != "" {
-				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			// Unmarshal and verify the result
-			var response github.ArtifactList
-			err = json.Unmarshal([]byte(textContent.Text), &response)
-			require.NoError(t, err)
-			assert.NotNil(t, response.TotalCount)
-			assert.Greater(t, *response.TotalCount, int64(0))
-			assert.NotEmpty(t, response.Artifacts)
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Workflow run has been approved", response["message"])
+			assert.Equal(t, "queued", response["run_status"])
+			assert.Equal(t, float64(12345), response["run_id"])
+			assert.Equal(t, float64(http.StatusCreated), response["status_code"])
 		})
 	}
 }
 
-func Test_DownloadWorkflowRunArtifact(t *testing.T) {
+func Test_GetWorkflowRunAttempt(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := DownloadWorkflowRunArtifact(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetWorkflowRunAttempt(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "download_workflow_run_artifact", tool.Name)
+	assert.Equal(t, "get_workflow_run_attempt", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "artifact_id")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "artifact_id"})
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "attempt_number"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]any
-		expectError    bool
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful artifact download URL",
+			name: "successful attempt retrieval",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/repos/owner/repo/actions/artifacts/123/zip",
-						Method:  "GET",
-					},
+					mock.GetReposActionsRunsAttemptsByOwnerByRepoByRunIdByAttemptNumber,
 					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-						// GitHub returns a 302 redirect to the download URL
-						w.Header().Set("Location", "https://api.github.com/repos/owner/repo/actions/artifacts/123/download")
-						w.WriteHeader(http.StatusFound)
+						assert.Equal(t, "/repos/owner/repo/actions/runs/12345/attempts/2", r.URL.Path)
+						mockResponse(t, http.StatusOK, &github.WorkflowRun{
+							ID:                 github.Ptr(int64(12345)),
+							RunAttempt:         github.Ptr(2),
+							RunStartedAt:       &github.Timestamp{Time: startedAt},
+							PreviousAttemptURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/runs/12345/attempts/1"),
+							Conclusion:         github.Ptr("success"),
+						})(w, r)
 					}),
 				),
 			),
 			requestArgs: map[string]any{
-				"owner":       "owner",
-				"repo":        "repo",
-				"artifact_id": float64(123),
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(12345),
+				"attempt_number": float64(2),
 			},
-			expectError: false,
 		},
 		{
-			name:         "missing required parameter artifact_id",
-			mockedClient: mock.NewMockedHTTPClient(),
+			name: "attempt not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsAttemptsByOwnerByRepoByRunIdByAttemptNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
 			requestArgs: map[string]any{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(12345),
+				"attempt_number": float64(7),
 			},
 			expectError:    true,
-			expectedErrMsg: "missing required parameter: artifact_id",
+			expectedErrMsg: "failed to get attempt 7 of workflow run",
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
-			client := github.NewClient(tc.mockedClient)
-			_, handler := DownloadWorkflowRunArtifact(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
+		{
+			name:         "attempt number zero",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(12345),
+				"attempt_number": float64(0),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "attempt_number must be at least 1",
+		},
+		{
+			name:         "missing required parameter attempt_number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: attempt_number",
+		},
+	}
 
-			require.NoError(t, err)
-			require.Equal(t, tc.expectError, result.IsError)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowRunAttempt(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
 
-			if tc.expectedErrMsg != "" {
-				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			// Unmarshal and verify the result
+			require.False(t, result.IsError)
 			var response map[string]any
-			err = json.Unmarshal([]byte(textContent.Text), &response)
-			require.NoError(t, err)
-			assert.Contains(t, response, "download_url")
-			assert.Contains(t, response, "message")
-			assert.Equal(t, "Artifact is available for download", response["message"])
-			assert.Equal(t, float64(123), response["artifact_id"])
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(2), response["run_attempt"])
+			assert.Equal(t, "2025-06-01T12:00:00Z", response["run_started_at"])
+			assert.Equal(t, "https://api.github.com/repos/owner/repo/actions/runs/12345/attempts/1", response["previous_attempt_url"])
 		})
 	}
 }
 
-func Test_DeleteWorkflowRunLogs(t *testing.T) {
-	// Verify tool definition once
-	mockClient := github.NewClient(nil)
-	tool, _ := DeleteWorkflowRunLogs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
-
-	assert.Equal(t, "delete_workflow_run_logs", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "run_id")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
-
+func Test_ListWorkflowJobs_Attempt(t *testing.T) {
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]any
-		expectError    bool
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful logs deletion",
+			name: "jobs of an attempt",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.DeleteReposActionsRunsLogsByOwnerByRepoByRunId,
+					mock.GetReposActionsRunsAttemptsJobsByOwnerByRepoByRunIdByAttemptNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/actions/runs/12345/attempts/1/jobs", r.URL.Path)
+						mockResponse(t, http.StatusOK, &github.Jobs{
+							TotalCount: github.Ptr(1),
+							Jobs: []*github.WorkflowJob{
+								{ID: github.Ptr(int64(1)), RunAttempt: github.Ptr(int64(1)), Conclusion: github.Ptr("failure")},
+							},
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(12345),
+				"attempt_number": float64(1),
+			},
+		},
+		{
+			name: "attempt not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsAttemptsJobsByOwnerByRepoByRunIdByAttemptNumber,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNoContent)
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]any{
-				"owner":  "owner",
-				"repo":   "repo",
-				"run_id": float64(12345),
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(12345),
+				"attempt_number": float64(7),
 			},
-			expectError: false,
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow jobs of attempt 7",
 		},
 		{
-			name:         "missing required parameter run_id",
+			name:         "attempt number zero",
 			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]any{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(12345),
+				"attempt_number": float64(0),
 			},
-			expectError:    true,
-			expectedErrMsg: "missing required parameter: run_id",
+			expectToolError: true,
+			expectedErrMsg:  "attempt_number must be at least 1",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := DeleteWorkflowRunLogs(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
+			_, handler := ListWorkflowJobs(stubGetClientFn(client), translations.NullTranslationHelper)
 
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
 			require.NoError(t, err)
-			require.Equal(t, tc.expectError, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
 
-			if tc.expectedErrMsg != "" {
-				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			// Unmarshal and verify the result
-			var response map[string]any
-			err = json.Unmarshal([]byte(textContent.Text), &response)
-			require.NoError(t, err)
-			assert.Equal(t, "Workflow run logs have been deleted", response["message"])
-			assert.Equal(t, float64(12345), response["run_id"])
+			require.False(t, result.IsError)
+			var response struct {
+				Jobs github.Jobs `json:"jobs"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Jobs.Jobs, 1)
+			assert.Equal(t, int64(1), response.Jobs.Jobs[0].GetRunAttempt())
 		})
 	}
 }
 
-func Test_GetWorkflowRunUsage(t *testing.T) {
+func Test_GetPendingDeployments(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetWorkflowRunUsage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "get_workflow_run_usage", tool.Name)
+	assert.Equal(t, "get_pending_deployments", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "run_id")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]any
-		expectError    bool
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful workflow run usage",
+			name: "successful pending deployments retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					[]*github.PendingDeployment{
+						{
+							Environment: &github.PendingDeploymentEnvironment{
+								ID:   github.Ptr(int64(161088068)),
+								Name: github.Ptr("production"),
+							},
+							CurrentUserCanApprove: github.Ptr(true),
+						},
+					},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+		},
+		{
+			name: "run not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposActionsRunsTimingByOwnerByRepoByRunId,
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						usage := &github.WorkflowRunUsage{
-							Billable: &github.WorkflowRunBillMap{
-								"UBUNTU": &github.WorkflowRunBill{
-									TotalMS: github.Ptr(int64(120000)),
-									Jobs:    github.Ptr(2),
-									JobRuns: []*github.WorkflowRunJobRun{
-										{
-											JobID:      github.Ptr(1),
-											DurationMS: github.Ptr(int64(60000)),
-										},
-										{
-											JobID:      github.Ptr(2),
-											DurationMS: github.Ptr(int64(60000)),
-										},
-									},
-								},
-							},
-							RunDurationMS: github.Ptr(int64(120000)),
-						}
-						w.WriteHeader(http.StatusOK)
-						_ = json.NewEncoder(w).Encode(usage)
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
@@ -734,7 +1063,8 @@ func Test_GetWorkflowRunUsage(t *testing.T) {
 				"repo":   "repo",
 				"run_id": float64(12345),
 			},
-			expectError: false,
+			expectError:    true,
+			expectedErrMsg: "failed to get pending deployments",
 		},
 		{
 			name:         "missing required parameter run_id",
@@ -743,269 +1073,347 @@ func Test_GetWorkflowRunUsage(t *testing.T) {
 				"owner": "owner",
 				"repo":  "repo",
 			},
-			expectError:    true,
-			expectedErrMsg: "missing required parameter: run_id",
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: run_id",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetWorkflowRunUsage(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
+			_, handler := GetPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
 
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
 			require.NoError(t, err)
-			require.Equal(t, tc.expectError, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
 
-			if tc.expectedErrMsg != "" {
-				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
-			// Unmarshal and verify the result
-			var response github.WorkflowRunUsage
-			err = json.Unmarshal([]byte(textContent.Text), &response)
-			require.NoError(t, err)
-			assert.NotNil(t, response.RunDurationMS)
-			assert.NotNil(t, response.Billable)
+			require.False(t, result.IsError)
+			var response []*github.PendingDeployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response, 1)
+			assert.Equal(t, int64(161088068), response[0].GetEnvironment().GetID())
+			assert.Equal(t, "production", response[0].GetEnvironment().GetName())
+			assert.True(t, response[0].GetCurrentUserCanApprove())
 		})
 	}
 }
 
-func Test_GetJobLogs(t *testing.T) {
+func Test_ReviewPendingDeployments(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetJobLogs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ReviewPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "get_job_logs", tool.Name)
+	assert.Equal(t, "review_pending_deployments", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "job_id")
-	assert.Contains(t, tool.InputSchema.Properties, "run_id")
-	assert.Contains(t, tool.InputSchema.Properties, "failed_only")
-	assert.Contains(t, tool.InputSchema.Properties, "return_content")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "environment_ids", "state", "comment"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]any
-		expectError    bool
-		expectedErrMsg string
-		checkResponse  func(t *testing.T, response map[string]any)
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful single job logs with URL",
+			name: "successful approval",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.Header().Set("Location", "https://github.com/logs/job/123")
-						w.WriteHeader(http.StatusFound)
-					}),
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(161088068), float64(161088069)},
+						"state":           "approved",
+						"comment":         "Ship it",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Deployment{
+							{
+								ID:          github.Ptr(int64(42)),
+								Environment: github.Ptr("production"),
+							},
+						}),
+					),
 				),
 			),
 			requestArgs: map[string]any{
-				"owner":  "owner",
-				"repo":   "repo",
-				"job_id": float64(123),
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{float64(161088068), float64(161088069)},
+				"state":           "approved",
+				"comment":         "Ship it",
 			},
-			expectError: false,
-			checkResponse: func(t *testing.T, response map[string]any) {
-				assert.Equal(t, float64(123), response["job_id"])
-				assert.Contains(t, response, "logs_url")
-				assert.Equal(t, "Job logs are available for download", response["message"])
-				assert.Contains(t, response, "note")
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{float64(161088068)},
+				"state":           "pending",
+				"comment":         "Ship it",
 			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid state: pending, must be approved or rejected",
 		},
 		{
-			name: "successful failed jobs logs",
+			name:         "empty environment_ids",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{},
+				"state":           "rejected",
+				"comment":         "Not now",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "environment_ids must contain at least one environment ID",
+		},
+		{
+			name: "review fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						jobs := &github.Jobs{
-							TotalCount: github.Ptr(3),
-							Jobs: []*github.WorkflowJob{
-								{
-									ID:         github.Ptr(int64(1)),
-									Name:       github.Ptr("test-job-1"),
-									Conclusion: github.Ptr("success"),
-								},
-								{
-									ID:         github.Ptr(int64(2)),
-									Name:       github.Ptr("test-job-2"),
-									Conclusion: github.Ptr("failure"),
-								},
-								{
-									ID:         github.Ptr(int64(3)),
-									Name:       github.Ptr("test-job-3"),
-									Conclusion: github.Ptr("failure"),
-								},
-							},
-						}
-						w.WriteHeader(http.StatusOK)
-						_ = json.NewEncoder(w).Encode(jobs)
-					}),
-				),
-				mock.WithRequestMatchHandler(
-					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
-					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-						w.Header().Set("Location", "https://github.com/logs/job/"+r.URL.Path[len(r.URL.Path)-1:])
-						w.WriteHeader(http.StatusFound)
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]any{
-				"owner":       "owner",
-				"repo":        "repo",
-				"run_id":      float64(456),
-				"failed_only": true,
-			},
-			expectError: false,
-			checkResponse: func(t *testing.T, response map[string]any) {
-				assert.Equal(t, float64(456), response["run_id"])
-				assert.Equal(t, float64(3), response["total_jobs"])
-				assert.Equal(t, float64(2), response["failed_jobs"])
-				assert.Contains(t, response, "logs")
-				assert.Equal(t, "Retrieved logs for 2 failed jobs", response["message"])
-
-				logs, ok := response["logs"].([]interface{})
-				assert.True(t, ok)
-				assert.Len(t, logs, 2)
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{float64(161088068)},
+				"state":           "rejected",
+				"comment":         "Not now",
 			},
+			expectError:    true,
+			expectedErrMsg: "failed to review pending deployments",
 		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response []*github.Deployment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response, 1)
+			assert.Equal(t, int64(42), response[0].GetID())
+			assert.Equal(t, "production", response[0].GetEnvironment())
+		})
+	}
+}
+
+func Test_ListWorkflowRunArtifacts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRunArtifacts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_workflow_run_artifacts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "per_page")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
 		{
-			name: "no failed jobs found",
+			name: "successful artifacts listing",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						jobs := &github.Jobs{
-							TotalCount: github.Ptr(2),
-							Jobs: []*github.WorkflowJob{
+						artifacts := &github.ArtifactList{
+							TotalCount: github.Ptr(int64(2)),
+							Artifacts: []*github.Artifact{
 								{
-									ID:         github.Ptr(int64(1)),
-									Name:       github.Ptr("test-job-1"),
-									Conclusion: github.Ptr("success"),
+									ID:                 github.Ptr(int64(1)),
+									NodeID:             github.Ptr("A_1"),
+									Name:               github.Ptr("build-artifacts"),
+									SizeInBytes:        github.Ptr(int64(1024)),
+									URL:                github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/1"),
+									ArchiveDownloadURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/1/zip"),
+									Expired:            github.Ptr(false),
+									CreatedAt:          &github.Timestamp{},
+									UpdatedAt:          &github.Timestamp{},
+									ExpiresAt:          &github.Timestamp{},
+									WorkflowRun: &github.ArtifactWorkflowRun{
+										ID:               github.Ptr(int64(12345)),
+										RepositoryID:     github.Ptr(int64(1)),
+										HeadRepositoryID: github.Ptr(int64(1)),
+										HeadBranch:       github.Ptr("main"),
+										HeadSHA:          github.Ptr("abc123"),
+									},
 								},
 								{
-									ID:         github.Ptr(int64(2)),
-									Name:       github.Ptr("test-job-2"),
-									Conclusion: github.Ptr("success"),
+									ID:                 github.Ptr(int64(2)),
+									NodeID:             github.Ptr("A_2"),
+									Name:               github.Ptr("test-results"),
+									SizeInBytes:        github.Ptr(int64(512)),
+									URL:                github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/2"),
+									ArchiveDownloadURL: github.Ptr("https://api.github.com/repos/owner/repo/actions/artifacts/2/zip"),
+									Expired:            github.Ptr(false),
+									CreatedAt:          &github.Timestamp{},
+									UpdatedAt:          &github.Timestamp{},
+									ExpiresAt:          &github.Timestamp{},
+									WorkflowRun: &github.ArtifactWorkflowRun{
+										ID:               github.Ptr(int64(12345)),
+										RepositoryID:     github.Ptr(int64(1)),
+										HeadRepositoryID: github.Ptr(int64(1)),
+										HeadBranch:       github.Ptr("main"),
+										HeadSHA:          github.Ptr("abc123"),
+									},
 								},
 							},
 						}
 						w.WriteHeader(http.StatusOK)
-						_ = json.NewEncoder(w).Encode(jobs)
+						_ = json.NewEncoder(w).Encode(artifacts)
 					}),
 				),
 			),
 			requestArgs: map[string]any{
-				"owner":       "owner",
-				"repo":        "repo",
-				"run_id":      float64(456),
-				"failed_only": true,
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
 			},
 			expectError: false,
-			checkResponse: func(t *testing.T, response map[string]any) {
-				assert.Equal(t, "No failed jobs found in this workflow run", response["message"])
-				assert.Equal(t, float64(456), response["run_id"])
-				assert.Equal(t, float64(2), response["total_jobs"])
-				assert.Equal(t, float64(0), response["failed_jobs"])
-			},
 		},
 		{
-			name:         "missing job_id when not using failed_only",
+			name:         "missing required parameter run_id",
 			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]any{
 				"owner": "owner",
 				"repo":  "repo",
 			},
 			expectError:    true,
-			expectedErrMsg: "job_id is required when failed_only is false",
-		},
-		{
-			name:         "missing run_id when using failed_only",
-			mockedClient: mock.NewMockedHTTPClient(),
-			requestArgs: map[string]any{
-				"owner":       "owner",
-				"repo":        "repo",
-				"failed_only": true,
-			},
-			expectError:    true,
-			expectedErrMsg: "run_id is required when failed_only is true",
-		},
-		{
-			name:         "missing required parameter owner",
-			mockedClient: mock.NewMockedHTTPClient(),
-			requestArgs: map[string]any{
-				"repo":   "repo",
-				"job_id": float64(123),
-			},
-			expectError:    true,
-			expectedErrMsg: "missing required parameter: owner",
-		},
-		{
-			name:         "missing required parameter repo",
-			mockedClient: mock.NewMockedHTTPClient(),
-			requestArgs: map[string]any{
-				"owner":  "owner",
-				"job_id": float64(123),
-			},
-			expectError:    true,
-			expectedErrMsg: "missing required parameter: repo",
+			expectedErrMsg: "missing required parameter: run_id",
 		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRunArtifacts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response github.ArtifactList
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.NotNil(t, response.TotalCount)
+			assert.Greater(t, *response.TotalCount, int64(0))
+			assert.NotEmpty(t, response.Artifacts)
+		})
+	}
+}
+
+func Test_DownloadWorkflowRunArtifact(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadWorkflowRunArtifact(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "download_workflow_run_artifact", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "artifact_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
 		{
-			name: "API error when getting single job logs",
+			name: "successful artifact download URL",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_ = json.NewEncoder(w).Encode(map[string]string{
-							"message": "Not Found",
-						})
+					mock.EndpointPattern{
+						Pattern: "/repos/owner/repo/actions/artifacts/123/zip",
+						Method:  "GET",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						// GitHub returns a 302 redirect to the download URL
+						w.Header().Set("Location", "https://api.github.com/repos/owner/repo/actions/artifacts/123/download")
+						w.WriteHeader(http.StatusFound)
 					}),
 				),
 			),
 			requestArgs: map[string]any{
-				"owner":  "owner",
-				"repo":   "repo",
-				"job_id": float64(999),
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(123),
 			},
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name: "API error when listing workflow jobs for failed_only",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_ = json.NewEncoder(w).Encode(map[string]string{
-							"message": "Not Found",
-						})
-					}),
-				),
-			),
+			name:         "missing required parameter artifact_id",
+			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]any{
-				"owner":       "owner",
-				"repo":        "repo",
-				"run_id":      float64(999),
-				"failed_only": true,
+				"owner": "owner",
+				"repo":  "repo",
 			},
-			expectError: true,
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: artifact_id",
 		},
 	}
 
@@ -1013,7 +1421,7 @@ func Test_GetJobLogs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := DownloadWorkflowRunArtifact(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1024,7 +1432,7 @@ func Test_GetJobLogs(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, tc.expectError, result.IsError)
 
-			// Parse the result and get the text content
+			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
 			if tc.expectedErrMsg != "" {
@@ -1032,38 +1440,472 @@ func Test_GetJobLogs(t *testing.T) {
 				return
 			}
 
-			if tc.expectError {
-				// For API errors, just verify we got an error
-				assert.True(t, result.IsError)
+			// Unmarshal and verify the result
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Contains(t, response, "download_url")
+			assert.Contains(t, response, "message")
+			assert.Equal(t, "Artifact is available for download", response["message"])
+			assert.Equal(t, float64(123), response["artifact_id"])
+		})
+	}
+}
+
+// newZipArchive returns a ZIP archive of the files, written in the order of names
+func newZipArchive(t *testing.T, names []string, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(files[name])
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_GetLatestArtifactByName(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLatestArtifactByName(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_latest_artifact_by_name", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id", "artifact_name"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	// The most recent run failed, the next one has no coverage report and the one after has it
+	listRuns := mock.WithRequestMatchHandler(
+		mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+		expectQueryParams(t, map[string]string{
+			"status":   "completed",
+			"per_page": "10",
+		}).andThen(
+			mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+				TotalCount: github.Ptr(3),
+				WorkflowRuns: []*github.WorkflowRun{
+					{ID: github.Ptr(int64(300)), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(200)), Conclusion: github.Ptr("success")},
+					{ID: github.Ptr(int64(100)), Conclusion: github.Ptr("success"), RunNumber: github.Ptr(7), HeadBranch: github.Ptr("main"), HeadSHA: github.Ptr("abc123")},
+				},
+			}),
+		),
+	)
+	listArtifacts := mock.WithRequestMatchHandler(
+		mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			artifacts := map[string][]*github.Artifact{
+				"/repos/owner/repo/actions/runs/200/artifacts": {
+					{ID: github.Ptr(int64(21)), Name: github.Ptr("binaries")},
+					{ID: github.Ptr(int64(22)), Name: github.Ptr("coverage-report"), Expired: github.Ptr(true)},
+				},
+				"/repos/owner/repo/actions/runs/100/artifacts": {
+					{ID: github.Ptr(int64(11)), Name: github.Ptr("binaries")},
+					{ID: github.Ptr(int64(12)), Name: github.Ptr("coverage-report"), SizeInBytes: github.Ptr(int64(2048))},
+				},
+			}
+			found, ok := artifacts[r.URL.Path]
+			require.True(t, ok, "unexpected artifacts request %s", r.URL.Path)
+			mockResponse(t, http.StatusOK, &github.ArtifactList{
+				TotalCount: github.Ptr(int64(len(found))),
+				Artifacts:  found,
+			})(w, r)
+		}),
+	)
+	downloadArtifact := mock.WithRequestMatchHandler(
+		mock.EndpointPattern{
+			Pattern: "/repos/owner/repo/actions/artifacts/12/zip",
+			Method:  "GET",
+		},
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", "https://github.com/artifacts/12/download")
+			w.WriteHeader(http.StatusFound)
+		}),
+	)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectFound     bool
+		expectedNames   []any
+	}{
+		{
+			name:         "artifact found in the second successful run",
+			mockedClient: mock.NewMockedHTTPClient(listRuns, listArtifacts, downloadArtifact),
+			requestArgs: map[string]any{
+				"workflow_id":   "ci.yml",
+				"artifact_name": "coverage-report",
+			},
+			expectFound: true,
+		},
+		{
+			name:         "workflow given by ID",
+			mockedClient: mock.NewMockedHTTPClient(listRuns, listArtifacts, downloadArtifact),
+			requestArgs: map[string]any{
+				"workflow_id":   "1234",
+				"artifact_name": "coverage-report",
+			},
+			expectFound: true,
+		},
+		{
+			name:         "artifact not found",
+			mockedClient: mock.NewMockedHTTPClient(listRuns, listArtifacts),
+			requestArgs: map[string]any{
+				"workflow_id":   "ci.yml",
+				"artifact_name": "docs",
+			},
+			expectedNames: []any{"binaries", "coverage-report"},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"workflow_id":   "missing.yml",
+				"artifact_name": "coverage-report",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow runs",
+		},
+		{
+			name:         "missing required parameter artifact_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"workflow_id": "ci.yml",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: artifact_name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLatestArtifactByName(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
 				return
 			}
+			require.NoError(t, err)
 
-			// Unmarshal and verify the result
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
 			var response map[string]any
-			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectFound, response["found"])
+			if !tc.expectFound {
+				assert.Equal(t, float64(2), response["searched_runs"])
+				assert.Equal(t, tc.expectedNames, response["available_artifacts"])
+				return
+			}
+			artifact := response["artifact"].(map[string]any)
+			assert.Equal(t, float64(12), artifact["id"])
+			assert.Equal(t, float64(2048), artifact["size_in_bytes"])
+			assert.Equal(t, "https://github.com/artifacts/12/download", response["download_url"])
+			assert.Equal(t, float64(100), response["run_id"])
+			assert.Equal(t, float64(7), response["run_number"])
+			assert.Equal(t, "main", response["head_branch"])
+		})
+	}
+}
+
+func Test_GetArtifactContent(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetArtifactContent(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_artifact_content", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "artifact_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	report := []byte("<testsuite tests=\"2\" failures=\"1\"></testsuite>")
+	screenshot := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00}
+	archive := newZipArchive(t, []string{"reports/junit.xml", "screenshot.png"}, map[string][]byte{
+		"reports/junit.xml": report,
+		"screenshot.png":    screenshot,
+	})
+	traversalArchive := newZipArchive(t, []string{"ok.txt", "../../etc/passwd"}, map[string][]byte{
+		"ok.txt":           []byte("ok"),
+		"../../etc/passwd": []byte("root"),
+	})
+
+	// serveArchive serves the archive from a test server, the artifact API redirects to it
+	serveArchive := func(t *testing.T, data []byte) *http.Client {
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/zip")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(data)
+		}))
+		t.Cleanup(testServer.Close)
+
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.EndpointPattern{
+					Pattern: "/repos/owner/repo/actions/artifacts/123/zip",
+					Method:  "GET",
+				},
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Location", testServer.URL)
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		archive         []byte
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+		expectedFiles   []artifactFile
+		expectedText    *mcp.TextResourceContents
+		expectedBlob    *mcp.BlobResourceContents
+	}{
+		{
+			name:    "list files",
+			archive: archive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+			},
+			expectedFiles: []artifactFile{
+				{Path: "reports/junit.xml", Size: uint64(len(report))},
+				{Path: "screenshot.png", Size: uint64(len(screenshot))},
+			},
+		},
+		{
+			name:    "text file",
+			archive: archive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+				"path":        "reports/junit.xml",
+			},
+			expectedText: &mcp.TextResourceContents{
+				URI:      "repo://owner/repo/actions/artifacts/123/reports/junit.xml",
+				MIMEType: raw.ResolveMIMEType("junit.xml", ""),
+				Text:     string(report),
+			},
+		},
+		{
+			name:    "binary file",
+			archive: archive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+				"path":        "/screenshot.png",
+			},
+			expectedBlob: &mcp.BlobResourceContents{
+				URI:      "repo://owner/repo/actions/artifacts/123/screenshot.png",
+				MIMEType: raw.ResolveMIMEType("screenshot.png", ""),
+				Blob:     base64.StdEncoding.EncodeToString(screenshot),
+			},
+		},
+		{
+			name:    "file not found",
+			archive: archive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+				"path":        "missing.txt",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "file missing.txt not found in artifact 123",
+		},
+		{
+			name:    "archive larger than max_size",
+			archive: archive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+				"max_size":    float64(64),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "larger than the maximum of 64 bytes",
+		},
+		{
+			name:    "zero max_size",
+			archive: archive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+				"max_size":    float64(0),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "max_size must be positive",
+		},
+		{
+			name:    "path traversal entry",
+			archive: traversalArchive,
+			requestArgs: map[string]any{
+				"artifact_id": float64(123),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "artifact archive contains an unsafe path: ../../etc/passwd",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(serveArchive(t, tc.archive))
+			_, handler := GetArtifactContent(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
 			require.NoError(t, err)
 
-			if tc.checkResponse != nil {
-				tc.checkResponse(t, response)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			switch {
+			case tc.expectedText != nil:
+				assert.Equal(t, *tc.expectedText, getTextResourceResult(t, result))
+			case tc.expectedBlob != nil:
+				assert.Equal(t, *tc.expectedBlob, getBlobResourceResult(t, result))
+			default:
+				var response struct {
+					TotalFiles int            `json:"total_files"`
+					Files      []artifactFile `json:"files"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+				assert.Equal(t, len(tc.expectedFiles), response.TotalFiles)
+				require.Len(t, response.Files, len(tc.expectedFiles))
+				for i, expected := range tc.expectedFiles {
+					assert.Equal(t, expected.Path, response.Files[i].Path)
+					assert.Equal(t, expected.Size, response.Files[i].Size)
+				}
 			}
 		})
 	}
 }
 
-func Test_GetJobLogs_WithContentReturn(t *testing.T) {
-	// Test the return_content functionality with a mock HTTP server
-	logContent := "2023-01-01T10:00:00.000Z Starting job...\n2023-01-01T10:00:01.000Z Running tests...\n2023-01-01T10:00:02.000Z Job completed successfully"
+func Test_safeArchivePath(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"report.xml":        true,
+		"reports/junit.xml": true,
+		"reports/":          true,
+		"a/..b/c":           true,
+		"":                  false,
+		"/etc/passwd":       false,
+		"../secret":         false,
+		"reports/../../x":   false,
+		"reports\\..\\x":    false,
+	} {
+		assert.Equal(t, expected, safeArchivePath(name), name)
+	}
+}
 
-	// Create a test server to serve log content
+func Test_downloadArtifactArchive_Cancelled(t *testing.T) {
+	started := make(chan struct{})
 	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(logContent))
+		_, _ = w.Write([]byte("PK"))
+		w.(http.Flusher).Flush()
+		close(started)
+		// Stall the rest of the archive until the client goes away
+		<-r.Context().Done()
+	}))
+	defer testServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := downloadArtifactArchive(ctx, testServer.URL, defaultMaxArtifactBytes, nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("download did not stop when the context was cancelled")
+	}
+}
+
+func Test_GetWorkflowRunLogs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowRunLogs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_run_logs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	// The steps are stored out of order, and 10_ must sort after 2_
+	archive := newZipArchive(t, []string{
+		"0_build.txt",
+		"build/",
+		"build/10_Complete job.txt",
+		"build/1_Set up job.txt",
+		"build/2_Run tests.txt",
+		"lint/1_Set up job.txt",
+	}, map[string][]byte{
+		"0_build.txt":               []byte("the whole build log\n"),
+		"build/10_Complete job.txt": []byte("cleaning up\n"),
+		"build/1_Set up job.txt":    []byte("setting up\n"),
+		"build/2_Run tests.txt":     []byte("test 1 ok\ntest 2 FAILED\n"),
+		"lint/1_Set up job.txt":     []byte("lint ok\n"),
+	})
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(archive)
 	}))
 	defer testServer.Close()
 
 	mockedClient := mock.NewMockedHTTPClient(
 		mock.WithRequestMatchHandler(
-			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			mock.EndpointPattern{
+				Pattern: "/repos/owner/repo/actions/runs/12345/logs",
+				Method:  "GET",
+			},
 			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 				w.Header().Set("Location", testServer.URL)
 				w.WriteHeader(http.StatusFound)
@@ -1071,27 +1913,1803 @@ func Test_GetJobLogs_WithContentReturn(t *testing.T) {
 		),
 	)
 
-	client := github.NewClient(mockedClient)
-	_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+	tests := []struct {
+		name                 string
+		requestArgs          map[string]any
+		expectToolError      bool
+		expectedErrMsg       string
+		expectedLogs         map[string]any
+		expectedSkippedFiles float64
+		expectedSkippedBytes float64
+	}{
+		{
+			name:        "URL only by default",
+			requestArgs: map[string]any{},
+		},
+		{
+			name: "content of each job",
+			requestArgs: map[string]any{
+				"return_content": true,
+			},
+			expectedLogs: map[string]any{
+				"build": "setting up\ntest 1 ok\ntest 2 FAILED\ncleaning up",
+				"lint":  "lint ok",
+			},
+		},
+		{
+			name: "tail lines",
+			requestArgs: map[string]any{
+				"return_content": true,
+				"tail_lines":     float64(2),
+			},
+			expectedLogs: map[string]any{
+				"build": "test 2 FAILED\ncleaning up",
+				"lint":  "lint ok",
+			},
+		},
+		{
+			name: "max bytes skips the earlier steps",
+			requestArgs: map[string]any{
+				"return_content": true,
+				"max_bytes":      float64(30),
+			},
+			expectedLogs: map[string]any{
+				"build": "test 2 FAILED\ncleaning up",
+				"lint":  "lint ok",
+			},
+			// "test 1 ok\n" is cut from the tests step and the set up step is skipped
+			expectedSkippedFiles: 1,
+			expectedSkippedBytes: 21,
+		},
+		{
+			name: "negative tail lines",
+			requestArgs: map[string]any{
+				"return_content": true,
+				"tail_lines":     float64(-1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "tail_lines must not be negative",
+		},
+	}
 
-	request := createMCPRequest(map[string]any{
-		"owner":          "owner",
-		"repo":           "repo",
-		"job_id":         float64(123),
-		"return_content": true,
-	})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedClient)
+			_, handler := GetWorkflowRunLogs(stubGetClientFn(client), translations.NullTranslationHelper)
 
-	result, err := handler(context.Background(), request)
-	require.NoError(t, err)
-	require.False(t, result.IsError)
+			args := map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
 
-	textContent := getTextResult(t, result)
-	var response map[string]any
-	err = json.Unmarshal([]byte(textContent.Text), &response)
-	require.NoError(t, err)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
 
-	assert.Equal(t, float64(123), response["job_id"])
-	assert.Equal(t, logContent, response["logs_content"])
-	assert.Equal(t, "Job logs content retrieved successfully", response["message"])
-	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			if tc.expectedLogs == nil {
+				assert.Equal(t, testServer.URL, response["logs_url"])
+				assert.NotContains(t, response, "logs")
+				return
+			}
+			assert.Equal(t, tc.expectedLogs, response["logs"])
+			assert.Equal(t, tc.expectedSkippedFiles, response["skipped_files"])
+			assert.Equal(t, tc.expectedSkippedBytes, response["skipped_bytes"])
+		})
+	}
+}
+
+func Test_DeleteWorkflowRunLogs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteWorkflowRunLogs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_workflow_run_logs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful logs deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsRunsLogsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError: false,
+		},
+		{
+			name:         "missing required parameter run_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: run_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteWorkflowRunLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "Workflow run logs have been deleted", response["message"])
+			assert.Equal(t, float64(12345), response["run_id"])
+		})
+	}
+}
+
+func Test_GetWorkflowRunUsage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowRunUsage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_run_usage", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful workflow run usage",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsTimingByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						usage := &github.WorkflowRunUsage{
+							Billable: &github.WorkflowRunBillMap{
+								"UBUNTU": &github.WorkflowRunBill{
+									TotalMS: github.Ptr(int64(120000)),
+									Jobs:    github.Ptr(2),
+									JobRuns: []*github.WorkflowRunJobRun{
+										{
+											JobID:      github.Ptr(1),
+											DurationMS: github.Ptr(int64(60000)),
+										},
+										{
+											JobID:      github.Ptr(2),
+											DurationMS: github.Ptr(int64(60000)),
+										},
+									},
+								},
+							},
+							RunDurationMS: github.Ptr(int64(120000)),
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(usage)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError: false,
+		},
+		{
+			name:         "missing required parameter run_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: run_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowRunUsage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response github.WorkflowRunUsage
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.NotNil(t, response.RunDurationMS)
+			assert.NotNil(t, response.Billable)
+		})
+	}
+}
+
+func Test_GetWorkflowUsage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowUsage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_usage", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "workflow_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	usage := &github.WorkflowUsage{
+		Billable: &github.WorkflowBillMap{
+			"UBUNTU":  &github.WorkflowBill{TotalMS: github.Ptr(int64(180000))},
+			"WINDOWS": &github.WorkflowBill{TotalMS: github.Ptr(int64(60000))},
+		},
+	}
+	// expectPath serves the usage when the request is for the expected path
+	expectPath := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, path, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(usage)
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		mockedClient         *http.Client
+		requestArgs          map[string]any
+		expectError          bool
+		expectToolError      bool
+		expectedErrMsg       string
+		expectedWorkflowType string
+	}{
+		{
+			name: "usage by workflow ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsTimingByOwnerByRepoByWorkflowId,
+					expectPath("/repos/owner/repo/actions/workflows/12345/timing"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "12345",
+			},
+			expectedWorkflowType: "workflow_id",
+		},
+		{
+			name: "usage by workflow file name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsTimingByOwnerByRepoByWorkflowId,
+					expectPath("/repos/owner/repo/actions/workflows/ci.yml/timing"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+			},
+			expectedWorkflowType: "workflow_file",
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsTimingByOwnerByRepoByWorkflowId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "missing.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow usage",
+		},
+		{
+			name:         "missing required parameter workflow_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: workflow_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetWorkflowUsage(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				WorkflowType string                 `json:"workflow_type"`
+				WorkflowID   string                 `json:"workflow_id"`
+				Billable     github.WorkflowBillMap `json:"billable"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedWorkflowType, response.WorkflowType)
+			assert.Equal(t, tc.requestArgs["workflow_id"], response.WorkflowID)
+			require.Contains(t, response.Billable, "UBUNTU")
+			assert.Equal(t, int64(180000), response.Billable["UBUNTU"].GetTotalMS())
+			assert.Equal(t, int64(60000), response.Billable["WINDOWS"].GetTotalMS())
+		})
+	}
+}
+
+func Test_GetJobLogs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetJobLogs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_job_logs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "job_id")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "failed_only")
+	assert.Contains(t, tool.InputSchema.Properties, "return_content")
+	assert.Contains(t, tool.InputSchema.Properties, "all_jobs")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		checkResponse  func(t *testing.T, response map[string]any)
+	}{
+		{
+			name: "successful single job logs with URL",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", "https://github.com/logs/job/123")
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(123),
+			},
+			expectError: false,
+			checkResponse: func(t *testing.T, response map[string]any) {
+				assert.Equal(t, float64(123), response["job_id"])
+				assert.Contains(t, response, "logs_url")
+				assert.Equal(t, "Job logs are available for download", response["message"])
+				assert.Contains(t, response, "note")
+			},
+		},
+		{
+			name: "successful failed jobs logs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						jobs := &github.Jobs{
+							TotalCount: github.Ptr(3),
+							Jobs: []*github.WorkflowJob{
+								{
+									ID:         github.Ptr(int64(1)),
+									Name:       github.Ptr("test-job-1"),
+									Conclusion: github.Ptr("success"),
+								},
+								{
+									ID:         github.Ptr(int64(2)),
+									Name:       github.Ptr("test-job-2"),
+									Conclusion: github.Ptr("failure"),
+								},
+								{
+									ID:         github.Ptr(int64(3)),
+									Name:       github.Ptr("test-job-3"),
+									Conclusion: github.Ptr("failure"),
+								},
+							},
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(jobs)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Location", "https://github.com/logs/job/"+r.URL.Path[len(r.URL.Path)-1:])
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"run_id":      float64(456),
+				"failed_only": true,
+			},
+			expectError: false,
+			checkResponse: func(t *testing.T, response map[string]any) {
+				assert.Equal(t, float64(456), response["run_id"])
+				assert.Equal(t, float64(3), response["total_jobs"])
+				assert.Equal(t, float64(2), response["failed_jobs"])
+				assert.Contains(t, response, "logs")
+				assert.Equal(t, "Retrieved logs for 2 failed jobs", response["message"])
+
+				logs, ok := response["logs"].([]interface{})
+				assert.True(t, ok)
+				assert.Len(t, logs, 2)
+			},
+		},
+		{
+			name: "no failed jobs found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						jobs := &github.Jobs{
+							TotalCount: github.Ptr(2),
+							Jobs: []*github.WorkflowJob{
+								{
+									ID:         github.Ptr(int64(1)),
+									Name:       github.Ptr("test-job-1"),
+									Conclusion: github.Ptr("success"),
+								},
+								{
+									ID:         github.Ptr(int64(2)),
+									Name:       github.Ptr("test-job-2"),
+									Conclusion: github.Ptr("success"),
+								},
+							},
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(jobs)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"run_id":      float64(456),
+				"failed_only": true,
+			},
+			expectError: false,
+			checkResponse: func(t *testing.T, response map[string]any) {
+				assert.Equal(t, "No failed jobs found in this workflow run", response["message"])
+				assert.Equal(t, float64(456), response["run_id"])
+				assert.Equal(t, float64(2), response["total_jobs"])
+				assert.Equal(t, float64(0), response["failed_jobs"])
+			},
+		},
+		{
+			name:         "missing job_id when not using failed_only",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "job_id is required when failed_only is false",
+		},
+		{
+			name:         "missing run_id when using failed_only",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"failed_only": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "run_id is required when failed_only is true",
+		},
+		{
+			name: "successful all jobs logs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						jobs := &github.Jobs{
+							TotalCount: github.Ptr(3),
+							Jobs: []*github.WorkflowJob{
+								{
+									ID:         github.Ptr(int64(1)),
+									Name:       github.Ptr("build"),
+									Conclusion: github.Ptr("success"),
+								},
+								{
+									ID:         github.Ptr(int64(2)),
+									Name:       github.Ptr("deploy"),
+									Conclusion: github.Ptr("success"),
+								},
+								{
+									ID:         github.Ptr(int64(3)),
+									Name:       github.Ptr("notify"),
+									Conclusion: github.Ptr("skipped"),
+								},
+							},
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(jobs)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Location", "https://github.com/logs/job/"+r.URL.Path[len(r.URL.Path)-1:])
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"run_id":   float64(456),
+				"all_jobs": true,
+			},
+			expectError: false,
+			checkResponse: func(t *testing.T, response map[string]any) {
+				assert.Equal(t, "Retrieved logs for 3 jobs", response["message"])
+				assert.Equal(t, float64(3), response["total_jobs"])
+				assert.Equal(t, float64(2), response["succeeded_jobs"])
+				assert.Equal(t, float64(0), response["failed_jobs"])
+
+				logs, ok := response["logs"].([]interface{})
+				require.True(t, ok)
+				require.Len(t, logs, 3)
+				for i, conclusion := range []string{"success", "success", "skipped"} {
+					jobLog := logs[i].(map[string]any)
+					assert.Equal(t, conclusion, jobLog["conclusion"])
+					assert.Contains(t, jobLog, "logs_url")
+				}
+			},
+		},
+		{
+			name:         "all_jobs with failed_only",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"run_id":      float64(456),
+				"all_jobs":    true,
+				"failed_only": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "all_jobs cannot be combined with failed_only or job_id",
+		},
+		{
+			name:         "all_jobs with job_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"job_id":   float64(123),
+				"all_jobs": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "all_jobs cannot be combined with failed_only or job_id",
+		},
+		{
+			name:         "missing run_id when using all_jobs",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"all_jobs": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "run_id is required when all_jobs is true",
+		},
+		{
+			name:         "missing required parameter owner",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"repo":   "repo",
+				"job_id": float64(123),
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: owner",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"job_id": float64(123),
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repo",
+		},
+		{
+			name: "API error when getting single job logs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_ = json.NewEncoder(w).Encode(map[string]string{
+							"message": "Not Found",
+						})
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"job_id": float64(999),
+			},
+			expectError: true,
+		},
+		{
+			name: "API error when listing workflow jobs for failed_only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_ = json.NewEncoder(w).Encode(map[string]string{
+							"message": "Not Found",
+						})
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"run_id":      float64(999),
+				"failed_only": true,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			if tc.expectError {
+				// For API errors, just verify we got an error
+				assert.True(t, result.IsError)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+
+			if tc.checkResponse != nil {
+				tc.checkResponse(t, response)
+			}
+		})
+	}
+}
+
+func Test_GetJobLogs_WithContentReturn(t *testing.T) {
+	// Test the return_content functionality with a mock HTTP server
+	logContent := "2023-01-01T10:00:00.000Z Starting job...\n2023-01-01T10:00:01.000Z Running tests...\n2023-01-01T10:00:02.000Z Job completed successfully"
+
+	// Create a test server to serve log content
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", testServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	request := createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"job_id":         float64(123),
+		"return_content": true,
+	})
+
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response map[string]any
+	err = json.Unmarshal([]byte(textContent.Text), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(123), response["job_id"])
+	assert.Equal(t, logContent, response["logs_content"])
+	assert.Equal(t, "Job logs content retrieved successfully", response["message"])
+	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
+}
+
+func Test_GetJobLogs_FailedJobsConcurrency(t *testing.T) {
+	const jobCount = 12
+	jobs := make([]*github.WorkflowJob, jobCount)
+	for i := range jobs {
+		jobs[i] = &github.WorkflowJob{
+			ID:         github.Ptr(int64(i + 1)),
+			Name:       github.Ptr(fmt.Sprintf("matrix-%d", i+1)),
+			Conclusion: github.Ptr("failure"),
+		}
+	}
+	listJobs := mock.WithRequestMatchHandler(
+		mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+		mockResponse(t, http.StatusOK, &github.Jobs{TotalCount: github.Ptr(jobCount), Jobs: jobs}),
+	)
+
+	t.Run("concurrency is bounded and the order kept", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			listJobs,
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					n := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						current := maxInFlight.Load()
+						if n <= current || maxInFlight.CompareAndSwap(current, n) {
+							break
+						}
+					}
+					// Give the other workers the time to start their requests
+					time.Sleep(20 * time.Millisecond)
+
+					jobID := strings.Split(r.URL.Path, "/")[6]
+					if jobID == "3" {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Server Error"}`))
+						return
+					}
+					w.Header().Set("Location", "https://github.com/logs/job/"+jobID)
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		))
+		_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"run_id":      float64(456),
+			"failed_only": true,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		logs, ok := response["logs"].([]any)
+		require.True(t, ok)
+		require.Len(t, logs, jobCount)
+		for i, l := range logs {
+			jobLog := l.(map[string]any)
+			assert.Equal(t, float64(i+1), jobLog["job_id"])
+			if i+1 == 3 {
+				// A failing job doesn't fail the others
+				assert.Contains(t, jobLog["error"], "failed to get job logs for job 3")
+				continue
+			}
+			assert.Equal(t, fmt.Sprintf("https://github.com/logs/job/%d", i+1), jobLog["logs_url"])
+		}
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(jobLogConcurrency))
+		assert.Greater(t, maxInFlight.Load(), int32(1))
+	})
+
+	t.Run("cancellation aborts the downloads", func(t *testing.T) {
+		started := make(chan struct{}, jobCount)
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			// Never answer, the download must be aborted by the cancellation
+			<-r.Context().Done()
+		}))
+		defer testServer.Close()
+
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			listJobs,
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Location", testServer.URL)
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		))
+		_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-started
+			cancel()
+		}()
+
+		done := make(chan struct{})
+		var result *mcp.CallToolResult
+		go func() {
+			defer close(done)
+			result, _ = handler(ctx, createMCPRequest(map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"run_id":         float64(456),
+				"failed_only":    true,
+				"return_content": true,
+			}))
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the downloads were not aborted by the cancellation")
+		}
+		require.NotNil(t, result)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		for _, l := range response["logs"].([]any) {
+			assert.Contains(t, l.(map[string]any), "error")
+		}
+	})
+}
+
+func Test_GetJobLogs_TailLines(t *testing.T) {
+	// A log of 600 numbered lines, longer than the default tail
+	lines := make([]string, 600)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	logContent := strings.Join(lines, "\n")
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	newClient := func() *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+				&github.Jobs{
+					TotalCount: github.Ptr(2),
+					Jobs: []*github.WorkflowJob{
+						{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("success")},
+						{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+					},
+				},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Location", testServer.URL)
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		))
+	}
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]any
+		expectedFirstLine string
+		expectedReturned  float64
+		expectToolError   bool
+		expectedErrMsg    string
+		failedOnly        bool
+	}{
+		{
+			name: "default tail",
+			requestArgs: map[string]any{
+				"job_id": float64(2),
+			},
+			expectedFirstLine: "line 101",
+			expectedReturned:  500,
+		},
+		{
+			name: "explicit tail",
+			requestArgs: map[string]any{
+				"job_id":     float64(2),
+				"tail_lines": float64(10),
+			},
+			expectedFirstLine: "line 591",
+			expectedReturned:  10,
+		},
+		{
+			name: "zero means no limit",
+			requestArgs: map[string]any{
+				"job_id":     float64(2),
+				"tail_lines": float64(0),
+			},
+			expectedFirstLine: "line 1",
+			expectedReturned:  600,
+		},
+		{
+			name: "limit above log length",
+			requestArgs: map[string]any{
+				"job_id":     float64(2),
+				"tail_lines": float64(1000),
+			},
+			expectedFirstLine: "line 1",
+			expectedReturned:  600,
+		},
+		{
+			name: "failed only mode",
+			requestArgs: map[string]any{
+				"run_id":      float64(42),
+				"failed_only": true,
+				"tail_lines":  float64(3),
+			},
+			expectedFirstLine: "line 598",
+			expectedReturned:  3,
+			failedOnly:        true,
+		},
+		{
+			name: "negative tail",
+			requestArgs: map[string]any{
+				"job_id":     float64(2),
+				"tail_lines": float64(-1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "tail_lines must not be negative",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetJobLogs(stubGetClientFn(newClient()), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"return_content": true,
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var jobLog map[string]any
+			if tc.failedOnly {
+				var response struct {
+					Logs []map[string]any `json:"logs"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+				require.Len(t, response.Logs, 1)
+				jobLog = response.Logs[0]
+			} else {
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &jobLog))
+			}
+
+			content, ok := jobLog["logs_content"].(string)
+			require.True(t, ok)
+			returnedLines := strings.Split(content, "\n")
+			assert.Equal(t, tc.expectedFirstLine, returnedLines[0])
+			assert.Equal(t, "line 600", returnedLines[len(returnedLines)-1])
+			assert.Len(t, returnedLines, int(tc.expectedReturned))
+			assert.Equal(t, float64(600), jobLog["original_line_count"])
+			assert.Equal(t, tc.expectedReturned, jobLog["returned_line_count"])
+			assert.Equal(t, tc.expectedReturned < 600, jobLog["truncated"])
+		})
+	}
+}
+
+func Test_GetJobLogs_MaxBytes(t *testing.T) {
+	// A log of 40000 numbered lines, well above the default byte limit, with the error at the end
+	lines := make([]string, 40000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %05d", i+1)
+	}
+	lines[len(lines)-1] = "Error: process completed with exit code 1"
+	logContent := strings.Join(lines, "\n")
+	require.Greater(t, len(logContent), defaultJobLogMaxBytes)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]any
+		expectedTruncated bool
+		expectedMaxBytes  int
+		expectedFirstLine string
+		expectToolError   bool
+		expectedErrMsg    string
+	}{
+		{
+			name:              "default limit",
+			requestArgs:       map[string]any{},
+			expectedTruncated: true,
+			expectedMaxBytes:  defaultJobLogMaxBytes,
+		},
+		{
+			name: "explicit limit cutting inside a line",
+			requestArgs: map[string]any{
+				"max_bytes": float64(100),
+			},
+			expectedTruncated: true,
+			expectedMaxBytes:  100,
+			// The last 100 bytes start in the middle of line 39994, which is dropped
+			expectedFirstLine: "line 39995",
+		},
+		{
+			name: "limit above log size",
+			requestArgs: map[string]any{
+				"max_bytes": float64(len(logContent) + 1),
+			},
+			expectedTruncated: false,
+			expectedMaxBytes:  len(logContent),
+			expectedFirstLine: "line 00001",
+		},
+		{
+			name: "negative limit",
+			requestArgs: map[string]any{
+				"max_bytes": float64(-1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "max_bytes must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", testServer.URL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			))
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"job_id":         float64(2),
+				"return_content": true,
+				"tail_lines":     float64(0),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			content, ok := response["logs_content"].(string)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedTruncated, response["truncated"])
+			assert.Equal(t, float64(len(lines)), response["original_line_count"])
+			assert.Equal(t, float64(len(content)), response["bytes_returned"])
+			assert.LessOrEqual(t, len(content), tc.expectedMaxBytes)
+			assert.True(t, strings.HasSuffix(content, "Error: process completed with exit code 1"))
+			if tc.expectedFirstLine != "" {
+				assert.Equal(t, tc.expectedFirstLine, strings.SplitN(content, "\n", 2)[0])
+			}
+		})
+	}
+}
+
+func Test_tailBuffer(t *testing.T) {
+	tail := &tailBuffer{limit: 5}
+	for _, chunk := range []string{"abc", "defgh", "ijklmnopq", "r"} {
+		n, err := tail.Write([]byte(chunk))
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+		assert.LessOrEqual(t, len(tail.buf), 2*tail.limit+len(chunk))
+	}
+	content, truncated := tail.Bytes()
+	assert.Equal(t, "nopqr", string(content))
+	assert.True(t, truncated)
+
+	tail = &tailBuffer{limit: 5}
+	_, _ = tail.Write([]byte("abcde"))
+	content, truncated = tail.Bytes()
+	assert.Equal(t, "abcde", string(content))
+	assert.False(t, truncated)
+}
+
+func Test_tailLogLines(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		n                int
+		expected         string
+		expectedReturned int
+	}{
+		{name: "empty log", content: "", n: 5, expected: "", expectedReturned: 0},
+		{name: "shorter than limit", content: "a\nb", n: 5, expected: "a\nb", expectedReturned: 2},
+		{name: "exactly the limit", content: "a\nb\nc", n: 3, expected: "a\nb\nc", expectedReturned: 3},
+		{name: "longer than limit", content: "a\nb\nc\nd", n: 2, expected: "c\nd", expectedReturned: 2},
+		{name: "single line kept", content: "a\nb\nc", n: 1, expected: "c", expectedReturned: 1},
+		{name: "no limit", content: "a\nb\nc", n: 0, expected: "a\nb\nc", expectedReturned: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content, returned := tailLogLines(tc.content, tc.n)
+			assert.Equal(t, tc.expected, content)
+			assert.Equal(t, tc.expectedReturned, returned)
+		})
+	}
+}
+
+func Test_grepLogLines(t *testing.T) {
+	log := strings.Join([]string{
+		"setup",
+		"compile",
+		"ERROR: missing symbol",
+		"retry",
+		"link",
+		"wait",
+		"FAIL: TestFoo",
+		"cleanup",
+	}, "\n")
+
+	tests := []struct {
+		name            string
+		content         string
+		pattern         string
+		contextLines    int
+		expected        []string
+		expectedMatched int
+	}{
+		{
+			name:            "matching lines only",
+			content:         log,
+			pattern:         "ERROR|FAIL",
+			expected:        []string{"ERROR: missing symbol", "FAIL: TestFoo"},
+			expectedMatched: 2,
+		},
+		{
+			name:            "with context",
+			content:         log,
+			pattern:         "ERROR",
+			contextLines:    1,
+			expected:        []string{"compile", "ERROR: missing symbol", "retry"},
+			expectedMatched: 1,
+		},
+		{
+			name:            "overlapping context at the edges",
+			content:         log,
+			pattern:         "^(setup|retry|cleanup)$",
+			contextLines:    2,
+			expected:        []string{"setup", "compile", "ERROR: missing symbol", "retry", "link", "wait", "FAIL: TestFoo", "cleanup"},
+			expectedMatched: 3,
+		},
+		{
+			name:            "no match",
+			content:         log,
+			pattern:         "panic",
+			expected:        []string{""},
+			expectedMatched: 0,
+		},
+		{
+			name:            "empty log",
+			content:         "",
+			pattern:         ".",
+			expected:        []string{""},
+			expectedMatched: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content, matched := grepLogLines(tc.content, regexp.MustCompile(tc.pattern), tc.contextLines)
+			assert.Equal(t, strings.Join(tc.expected, "\n"), content)
+			assert.Equal(t, tc.expectedMatched, matched)
+		})
+	}
+}
+
+func Test_GetJobLogs_Grep(t *testing.T) {
+	lines := make([]string, 100)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("step %d ok", i+1)
+		if (i+1)%10 == 0 {
+			lines[i] = fmt.Sprintf("step %d ERROR", i+1)
+		}
+	}
+	logContent := strings.Join(lines, "\n")
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+		expectedContent []string
+		expectedMatched float64
+	}{
+		{
+			name: "filter matching lines",
+			requestArgs: map[string]any{
+				"grep": "ERROR",
+			},
+			expectedContent: []string{
+				"step 10 ERROR", "step 20 ERROR", "step 30 ERROR", "step 40 ERROR", "step 50 ERROR",
+				"step 60 ERROR", "step 70 ERROR", "step 80 ERROR", "step 90 ERROR", "step 100 ERROR",
+			},
+			expectedMatched: 10,
+		},
+		{
+			name: "filter then tail",
+			requestArgs: map[string]any{
+				"grep":         "ERROR",
+				"grep_context": float64(1),
+				"tail_lines":   float64(3),
+			},
+			expectedContent: []string{"step 91 ok", "step 99 ok", "step 100 ERROR"},
+			expectedMatched: 10,
+		},
+		{
+			name: "invalid pattern",
+			requestArgs: map[string]any{
+				"grep": "ERROR(",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid grep pattern",
+		},
+		{
+			name: "negative context",
+			requestArgs: map[string]any{
+				"grep":         "ERROR",
+				"grep_context": float64(-2),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "grep_context must not be negative",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", testServer.URL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			))
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"job_id":         float64(7),
+				"return_content": true,
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, strings.Join(tc.expectedContent, "\n"), response["logs_content"])
+			assert.Equal(t, tc.expectedMatched, response["matched_lines"])
+			assert.Equal(t, float64(100), response["original_line_count"])
+			assert.Equal(t, float64(len(tc.expectedContent)), response["returned_line_count"])
+		})
+	}
+}
+
+// stepLogFixture is the log of a job with a set up, a checkout, a test, a skipped lint, a post and a
+// complete step, as GitHub writes it.
+const stepLogFixture = `2024-05-01T10:00:00.1234567Z Current runner version: '2.316.0'
+2024-05-01T10:00:00.2000000Z ##[group]Operating System
+2024-05-01T10:00:00.2000000Z Ubuntu
+2024-05-01T10:00:00.2100000Z 22.04.4
+2024-05-01T10:00:00.2200000Z ##[endgroup]
+2024-05-01T10:00:01.4000000Z Complete job name: test
+2024-05-01T10:00:01.5000000Z ##[group]Run actions/checkout@v4
+2024-05-01T10:00:01.5000000Z with:
+2024-05-01T10:00:01.5000000Z   repository: owner/repo
+2024-05-01T10:00:01.5100000Z ##[endgroup]
+2024-05-01T10:00:02.1000000Z Syncing repository: owner/repo
+2024-05-01T10:00:03.2000000Z Checked out 1a2b3c4
+2024-05-01T10:00:03.9000000Z ##[group]Run go test ./...
+2024-05-01T10:00:03.9000000Z go test ./...
+2024-05-01T10:00:03.9000000Z shell: /usr/bin/bash -e {0}
+2024-05-01T10:00:03.9100000Z ##[endgroup]
+2024-05-01T10:00:10.0000000Z ok  	example.com/pkg	0.5s
+2024-05-01T10:00:12.0000000Z --- FAIL: TestThing (0.00s)
+    thing_test.go:12: unexpected value
+2024-05-01T10:00:12.1000000Z ##[error]Process completed with exit code 1.
+2024-05-01T10:00:12.5000000Z Post job cleanup.
+2024-05-01T10:00:12.6000000Z [command]/usr/bin/git version
+2024-05-01T10:00:13.4000000Z Cleaning up orphan processes`
+
+// stepLogFixtureSteps are the steps of the job stepLogFixture is the log of.
+func stepLogFixtureSteps() []*github.TaskStep {
+	step := func(number int64, name, conclusion string, started, completed int) *github.TaskStep {
+		s := &github.TaskStep{
+			Number:     github.Ptr(number),
+			Name:       github.Ptr(name),
+			Status:     github.Ptr("completed"),
+			Conclusion: github.Ptr(conclusion),
+		}
+		if started >= 0 {
+			s.StartedAt = &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, started, 0, time.UTC)}
+			s.CompletedAt = &github.Timestamp{Time: time.Date(2024, 5, 1, 10, 0, completed, 0, time.UTC)}
+		}
+		return s
+	}
+	return []*github.TaskStep{
+		step(1, "Set up job", "success", 0, 1),
+		step(2, "Checkout", "success", 1, 3),
+		step(3, "Run tests", "failure", 3, 12),
+		step(4, "Lint", "skipped", -1, -1),
+		step(5, "Post Checkout", "success", 12, 12),
+		step(6, "Complete job", "success", 13, 13),
+	}
+}
+
+func Test_stepLogFilter(t *testing.T) {
+	lines := strings.Split(stepLogFixture, "\n")
+	extractStepLog := func(number int64) string {
+		filter := newStepLogFilter(stepLogFixtureSteps(), number)
+		var kept []string
+		for _, line := range lines {
+			if filter.keep(line) {
+				kept = append(kept, line)
+			}
+		}
+		return strings.Join(kept, "\n")
+	}
+
+	tests := []struct {
+		name     string
+		number   int64
+		expected []string
+	}{
+		{
+			name:     "set up job",
+			number:   1,
+			expected: lines[0:6],
+		},
+		{
+			name:     "checkout starts at its marker, in the second set up ends",
+			number:   2,
+			expected: lines[6:12],
+		},
+		{
+			name:     "test step keeps lines without timestamp",
+			number:   3,
+			expected: lines[12:20],
+		},
+		{
+			name:     "skipped step",
+			number:   4,
+			expected: nil,
+		},
+		{
+			name:     "post step",
+			number:   5,
+			expected: lines[20:22],
+		},
+		{
+			name:     "complete job",
+			number:   6,
+			expected: lines[22:],
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, strings.Join(tc.expected, "\n"), extractStepLog(tc.number))
+		})
+	}
+}
+
+func Test_GetJobLogs_Step(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(stepLogFixture))
+	}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+		expectedContent string
+		expectedStep    string
+	}{
+		{
+			name: "step by name",
+			requestArgs: map[string]any{
+				"step_name": "run tests",
+				"grep":      "FAIL",
+			},
+			expectedContent: "2024-05-01T10:00:12.0000000Z --- FAIL: TestThing (0.00s)",
+			expectedStep:    "Run tests",
+		},
+		{
+			name: "step by number",
+			requestArgs: map[string]any{
+				"step_number": float64(5),
+			},
+			expectedContent: "2024-05-01T10:00:12.5000000Z Post job cleanup.\n2024-05-01T10:00:12.6000000Z [command]/usr/bin/git version",
+			expectedStep:    "Post Checkout",
+		},
+		{
+			name: "unknown step",
+			requestArgs: map[string]any{
+				"step_name": "Deploy",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "step not found in job 7, available steps: 1. Set up job, 2. Checkout, 3. Run tests, 4. Lint, 5. Post Checkout, 6. Complete job",
+		},
+		{
+			name: "step without return_content",
+			requestArgs: map[string]any{
+				"step_name":      "Run tests",
+				"return_content": false,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "step_name and step_number require return_content to be true",
+		},
+		{
+			name: "step with failed_only",
+			requestArgs: map[string]any{
+				"step_name":   "Run tests",
+				"job_id":      float64(0),
+				"run_id":      float64(456),
+				"failed_only": true,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "step_name and step_number require job_id",
+		},
+		{
+			name: "both step name and number",
+			requestArgs: map[string]any{
+				"step_name":   "Run tests",
+				"step_number": float64(3),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "provide either step_name or step_number, not both",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					&github.WorkflowJob{
+						ID:    github.Ptr(int64(7)),
+						Name:  github.Ptr("test"),
+						Steps: stepLogFixtureSteps(),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", testServer.URL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			))
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"job_id":         float64(7),
+				"return_content": true,
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedContent, response["logs_content"])
+			assert.Equal(t, tc.expectedStep, response["step_name"])
+		})
+	}
+}
+
+func Test_GetJobLogs_StepOfLargeLog(t *testing.T) {
+	// The fixture with the test step writing enough output for the log to be well above the default
+	// byte limit, so the earlier steps are outside the last max_bytes bytes of the log
+	fixtureLines := strings.Split(stepLogFixture, "\n")
+	lines := append([]string{}, fixtureLines[:16]...)
+	for i := range 10000 {
+		lines = append(lines, fmt.Sprintf("2024-05-01T10:00:05.0000000Z test output %05d", i+1))
+	}
+	lines = append(lines, fixtureLines[16:]...)
+	logContent := strings.Join(lines, "\n")
+	require.Greater(t, len(logContent), defaultJobLogMaxBytes)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer testServer.Close()
+
+	tests := []struct {
+		name              string
+		requestArgs       map[string]any
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedContent   string
+		expectedTruncated bool
+	}{
+		{
+			name: "step at the start of the log",
+			requestArgs: map[string]any{
+				"step_name": "Checkout",
+			},
+			expectedContent: strings.Join(fixtureLines[6:12], "\n"),
+		},
+		{
+			name: "step larger than max_bytes",
+			requestArgs: map[string]any{
+				"step_name":  "Run tests",
+				"max_bytes":  float64(200),
+				"tail_lines": float64(0),
+			},
+			expectedContent:   strings.Join(fixtureLines[17:20], "\n"),
+			expectedTruncated: true,
+		},
+		{
+			name: "tail of a step",
+			requestArgs: map[string]any{
+				"step_number": float64(3),
+				"max_bytes":   float64(len(logContent)),
+				"tail_lines":  float64(2),
+			},
+			expectedContent:   strings.Join(fixtureLines[18:20], "\n"),
+			expectedTruncated: true,
+		},
+		{
+			name: "skipped step",
+			requestArgs: map[string]any{
+				"step_name": "Lint",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "no lines of step 4 (Lint) found in the log of job 7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsJobsByOwnerByRepoByJobId,
+					&github.WorkflowJob{
+						ID:    github.Ptr(int64(7)),
+						Name:  github.Ptr("test"),
+						Steps: stepLogFixtureSteps(),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", testServer.URL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			))
+			_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"job_id":         float64(7),
+				"return_content": true,
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedContent, response["logs_content"])
+			assert.Equal(t, tc.expectedTruncated, response["truncated"])
+			assert.Equal(t, float64(len(lines)), response["original_line_count"])
+		})
+	}
+}
+
+func Test_renderWorkflowRunListMarkdown(t *testing.T) {
+	created := &github.Timestamp{Time: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)}
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(25),
+		WorkflowRuns: []*github.WorkflowRun{
+			{
+				ID:           github.Ptr(int64(12345)),
+				RunNumber:    github.Ptr(42),
+				DisplayTitle: github.Ptr("Fix flaky test"),
+				Status:       github.Ptr("completed"),
+				Conclusion:   github.Ptr("failure"),
+				HeadBranch:   github.Ptr("main"),
+				Event:        github.Ptr("push"),
+				CreatedAt:    created,
+			},
+			{
+				ID:         github.Ptr(int64(12346)),
+				RunNumber:  github.Ptr(43),
+				Status:     github.Ptr("in_progress"),
+				HeadBranch: github.Ptr("feature"),
+				Event:      github.Ptr("pull_request"),
+				CreatedAt:  created,
+			},
+		},
+	}
+
+	expected := "Showing 2 of 25 workflow runs.\n" +
+		"\n" +
+		"| ID | Run | Title | Status | Conclusion | Branch | Event | Created |\n" +
+		"| --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+		"| 12345 | #42 | Fix flaky test | completed | failure | main | push | 2024-03-01 09:30 |\n" +
+		"| 12346 | #43 | - | in_progress | - | feature | pull_request | 2024-03-01 09:30 |\n"
+	assert.Equal(t, expected, renderWorkflowRunListMarkdown(runs))
+	assert.Equal(t, "_No workflow runs found._\n", renderWorkflowRunListMarkdown(&github.WorkflowRuns{}))
 }
@@ -0,0 +1,251 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListActionsVariables creates a tool to list the Actions variables of a repository
+func ListActionsVariables(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_actions_variables",
+			mcp.WithDescription(t("TOOL_LIST_ACTIONS_VARIABLES_DESCRIPTION", "List the Actions variables of a repository with their values")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ACTIONS_VARIABLES_USER_TITLE", "List repository Actions variables"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			}
+			variables, resp, err := client.Actions.ListRepoVariables(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository variables: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(variables)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateActionsVariable creates a tool to create an Actions variable in a repository
+func CreateActionsVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_actions_variable",
+			mcp.WithDescription(t("TOOL_CREATE_ACTIONS_VARIABLE_DESCRIPTION", "Create an Actions variable in a repository. Use create_or_update_actions_secret for sensitive values")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_ACTIONS_VARIABLE_USER_TITLE", "Create repository Actions variable"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the variable"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("The value of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return writeActionsVariable(ctx, getClient, request, true)
+		}
+}
+
+// UpdateActionsVariable creates a tool to update an Actions variable of a repository
+func UpdateActionsVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_actions_variable",
+			mcp.WithDescription(t("TOOL_UPDATE_ACTIONS_VARIABLE_DESCRIPTION", "Update the value of an existing Actions variable of a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ACTIONS_VARIABLE_USER_TITLE", "Update repository Actions variable"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the variable"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("The new value of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return writeActionsVariable(ctx, getClient, request, false)
+		}
+}
+
+// writeActionsVariable creates or updates the variable described by the request.
+func writeActionsVariable(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, create bool) (*mcp.CallToolResult, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, err := RequiredParam[string](request, "name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	value, err := RequiredParam[string](request, "value")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	variable := &github.ActionsVariable{
+		Name:  name,
+		Value: value,
+	}
+	var resp *github.Response
+	var message string
+	if create {
+		resp, err = client.Actions.CreateRepoVariable(ctx, owner, repo, variable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create variable: %w", err)
+		}
+		message = "Variable has been created"
+	} else {
+		resp, err = client.Actions.UpdateRepoVariable(ctx, owner, repo, variable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update variable: %w", err)
+		}
+		message = "Variable has been updated"
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result := map[string]any{
+		"message":     message,
+		"name":        name,
+		"status":      resp.Status,
+		"status_code": resp.StatusCode,
+	}
+
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// DeleteActionsVariable creates a tool to delete an Actions variable of a repository
+func DeleteActionsVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_actions_variable",
+			mcp.WithDescription(t("TOOL_DELETE_ACTIONS_VARIABLE_DESCRIPTION", "Delete an Actions variable of a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_ACTIONS_VARIABLE_USER_TITLE", "Delete repository Actions variable"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.DeleteRepoVariable(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete variable: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":     "Variable has been deleted",
+				"name":        name,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
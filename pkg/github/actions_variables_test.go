@@ -0,0 +1,357 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListActionsVariables(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListActionsVariables(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_actions_variables", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful variables listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.ActionsVariables{
+							TotalCount: 2,
+							Variables: []*github.ActionsVariable{
+								{Name: "DEPLOY_ENV", Value: "staging"},
+								{Name: "NODE_VERSION", Value: "20"},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"perPage": float64(50),
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository variables",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListActionsVariables(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.ActionsVariables
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 2, response.TotalCount)
+			require.Len(t, response.Variables, 2)
+			assert.Equal(t, "DEPLOY_ENV", response.Variables[0].Name)
+			assert.Equal(t, "staging", response.Variables[0].Value)
+		})
+	}
+}
+
+func Test_WriteActionsVariable(t *testing.T) {
+	type toolFn func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+
+	tests := []struct {
+		name            string
+		tool            toolFn
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedMessage string
+		expectedStatus  int
+	}{
+		{
+			name: "variable created",
+			tool: CreateActionsVariable,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsVariablesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":  "DEPLOY_ENV",
+						"value": "staging",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "DEPLOY_ENV",
+				"value": "staging",
+			},
+			expectedMessage: "Variable has been created",
+			expectedStatus:  http.StatusCreated,
+		},
+		{
+			name: "variable updated",
+			tool: UpdateActionsVariable,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposActionsVariablesByOwnerByRepoByName,
+					expectRequestBody(t, map[string]any{
+						"name":  "DEPLOY_ENV",
+						"value": "production",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "DEPLOY_ENV",
+				"value": "production",
+			},
+			expectedMessage: "Variable has been updated",
+			expectedStatus:  http.StatusNoContent,
+		},
+		{
+			name: "variable already exists",
+			tool: CreateActionsVariable,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsVariablesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Already exists"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "DEPLOY_ENV",
+				"value": "staging",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create variable",
+		},
+		{
+			name:         "create missing required parameter name",
+			tool:         CreateActionsVariable,
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"value": "staging",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: name",
+		},
+		{
+			name:         "update missing required parameter name",
+			tool:         UpdateActionsVariable,
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"value": "production",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := tc.tool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedMessage, response["message"])
+			assert.Equal(t, "DEPLOY_ENV", response["name"])
+			assert.Equal(t, float64(tc.expectedStatus), response["status_code"])
+		})
+	}
+
+	for _, fn := range []toolFn{CreateActionsVariable, UpdateActionsVariable} {
+		tool, _ := fn(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+		require.NoError(t, toolsnaps.Test(tool.Name, tool))
+		assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "value"})
+		assert.False(t, *tool.Annotations.ReadOnlyHint)
+	}
+}
+
+func Test_DeleteActionsVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteActionsVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_actions_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "variable deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsVariablesByOwnerByRepoByName,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "DEPLOY_ENV",
+			},
+		},
+		{
+			name: "variable not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsVariablesByOwnerByRepoByName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "DEPLOY_ENV",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete variable",
+		},
+		{
+			name:         "missing required parameter name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteActionsVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Variable has been deleted", response["message"])
+			assert.Equal(t, "DEPLOY_ENV", response["name"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultWorkflowRunWaitSeconds is how long wait_for_workflow_run waits unless timeout_seconds is given
+	defaultWorkflowRunWaitSeconds = 120
+	// maxWorkflowRunWaitSeconds caps timeout_seconds, so a single tool call can't block for long
+	maxWorkflowRunWaitSeconds = 300
+	// defaultWorkflowRunPollSeconds is the time between two polls unless poll_interval_seconds is given
+	defaultWorkflowRunPollSeconds = 10
+	// minWorkflowRunPollSeconds is the floor of poll_interval_seconds, to avoid hammering the API
+	minWorkflowRunPollSeconds = 5
+)
+
+// workflowRunWaiter holds the clock of wait_for_workflow_run, which is replaced in tests.
+type workflowRunWaiter struct {
+	// wait blocks for the duration or until the context is done
+	wait func(ctx context.Context, d time.Duration) error
+	now  func() time.Time
+}
+
+// WaitForWorkflowRun creates a tool that polls a workflow run until it is completed
+func WaitForWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return waitForWorkflowRun(getClient, t, workflowRunWaiter{wait: waitContext, now: time.Now})
+}
+
+func waitForWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc, waiter workflowRunWaiter) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("wait_for_workflow_run",
+			mcp.WithDescription(t("TOOL_WAIT_FOR_WORKFLOW_RUN_DESCRIPTION", "Wait for a workflow run to complete, polling its status, and return its final status and conclusion. Use this after run_workflow or rerun_workflow_run instead of calling get_workflow_run repeatedly. If the run is still going when the timeout is reached, timed_out is true and the tool can be called again")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_WAIT_FOR_WORKFLOW_RUN_USER_TITLE", "Wait for workflow run"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithNumber("timeout_seconds",
+				mcp.Description(fmt.Sprintf("How long to wait for the run to complete (default %d, at most %d)", defaultWorkflowRunWaitSeconds, maxWorkflowRunWaitSeconds)),
+				mcp.Min(1),
+				mcp.Max(maxWorkflowRunWaitSeconds),
+			),
+			mcp.WithNumber("poll_interval_seconds",
+				mcp.Description(fmt.Sprintf("The time between two checks of the run status (default %d, at least %d)", defaultWorkflowRunPollSeconds, minWorkflowRunPollSeconds)),
+				mcp.Min(minWorkflowRunPollSeconds),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			timeoutSeconds, err := OptionalIntParam(request, "timeout_seconds")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// An explicit 0 is rejected like negative timeouts, rather than selecting the default
+			if _, ok := request.GetArguments()["timeout_seconds"]; ok && timeoutSeconds <= 0 {
+				return mcp.NewToolResultError("timeout_seconds must be positive"), nil
+			}
+			if timeoutSeconds == 0 {
+				timeoutSeconds = defaultWorkflowRunWaitSeconds
+			}
+			pollSeconds, err := OptionalIntParamWithDefault(request, "poll_interval_seconds", defaultWorkflowRunPollSeconds)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Out of range values are clamped rather than rejected, waiting is best effort anyway
+			timeout := time.Duration(min(timeoutSeconds, maxWorkflowRunWaitSeconds)) * time.Second
+			pollInterval := time.Duration(max(pollSeconds, minWorkflowRunPollSeconds)) * time.Second
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			progress := NewProgressReporter(ctx, request)
+			start := waiter.now()
+			deadline := start.Add(timeout)
+			polls := 0
+			for {
+				workflowRun, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get workflow run: %w", err)
+				}
+				_ = resp.Body.Close()
+				polls++
+
+				if workflowRun.GetStatus() == "completed" {
+					return workflowRunWaitResult(workflowRun, waiter.now().Sub(start), polls, false)
+				}
+
+				elapsed := waiter.now().Sub(start)
+				progress.Report(elapsed.Seconds(), timeout.Seconds(), fmt.Sprintf("Workflow run is %s", workflowRun.GetStatus()))
+
+				remaining := deadline.Sub(waiter.now())
+				if remaining <= 0 {
+					return workflowRunWaitResult(workflowRun, waiter.now().Sub(start), polls, true)
+				}
+				if err := waiter.wait(ctx, min(pollInterval, remaining)); err != nil {
+					// The deadline of the request ends the wait like the timeout does, a cancelled
+					// request ends it with an error
+					if !errors.Is(err, context.DeadlineExceeded) {
+						return nil, fmt.Errorf("stopped waiting for workflow run: %w", err)
+					}
+					return workflowRunWaitResult(workflowRun, waiter.now().Sub(start), polls, true)
+				}
+			}
+		}
+}
+
+func workflowRunWaitResult(workflowRun *github.WorkflowRun, elapsed time.Duration, polls int, timedOut bool) (*mcp.CallToolResult, error) {
+	result := map[string]any{
+		"run_id":          workflowRun.GetID(),
+		"status":          workflowRun.GetStatus(),
+		"conclusion":      workflowRun.GetConclusion(),
+		"html_url":        workflowRun.GetHTMLURL(),
+		"elapsed_seconds": int(elapsed.Round(time.Second).Seconds()),
+		"polls":           polls,
+		"timed_out":       timedOut,
+	}
+
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
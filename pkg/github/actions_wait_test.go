@@ -0,0 +1,256 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWorkflowRunWaiter is a clock that advances by the duration of every wait instead of sleeping.
+type fakeWorkflowRunWaiter struct {
+	now   time.Time
+	waits []time.Duration
+	err   error
+}
+
+func (f *fakeWorkflowRunWaiter) waiter() workflowRunWaiter {
+	return workflowRunWaiter{
+		wait: func(_ context.Context, d time.Duration) error {
+			f.waits = append(f.waits, d)
+			if f.err != nil {
+				return f.err
+			}
+			f.now = f.now.Add(d)
+			return nil
+		},
+		now: func() time.Time { return f.now },
+	}
+}
+
+// mockWorkflowRunStatus serves a workflow run that is in progress for the first inProgressPolls
+// polls and completed afterwards, or always in progress when inProgressPolls is negative.
+func mockWorkflowRunStatus(t *testing.T, inProgressPolls int32) *http.Client {
+	var polls atomic.Int32
+	return mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				run := &github.WorkflowRun{
+					ID:      github.Ptr(int64(12345)),
+					Status:  github.Ptr("in_progress"),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/actions/runs/12345"),
+				}
+				if n := polls.Add(1); inProgressPolls >= 0 && n > inProgressPolls {
+					run.Status = github.Ptr("completed")
+					run.Conclusion = github.Ptr("success")
+				}
+				mockResponse(t, http.StatusOK, run)(w, r)
+			}),
+		),
+	)
+}
+
+func Test_WaitForWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := WaitForWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "wait_for_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		waitErr           error
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedStatus    string
+		expectedTimedOut  bool
+		expectedPolls     float64
+		expectedElapsed   float64
+		expectedWaitEvery time.Duration
+	}{
+		{
+			name:              "run completes after a few polls",
+			mockedClient:      mockWorkflowRunStatus(t, 2),
+			requestArgs:       map[string]any{},
+			expectedStatus:    "completed",
+			expectedPolls:     3,
+			expectedElapsed:   20,
+			expectedWaitEvery: 10 * time.Second,
+		},
+		{
+			name:           "run already completed",
+			mockedClient:   mockWorkflowRunStatus(t, 0),
+			requestArgs:    map[string]any{},
+			expectedStatus: "completed",
+			expectedPolls:  1,
+		},
+		{
+			name:         "timeout reached",
+			mockedClient: mockWorkflowRunStatus(t, -1),
+			requestArgs: map[string]any{
+				"timeout_seconds": float64(30),
+			},
+			expectedStatus:    "in_progress",
+			expectedTimedOut:  true,
+			expectedPolls:     4,
+			expectedElapsed:   30,
+			expectedWaitEvery: 10 * time.Second,
+		},
+		{
+			name:         "poll interval has a floor",
+			mockedClient: mockWorkflowRunStatus(t, 2),
+			requestArgs: map[string]any{
+				"poll_interval_seconds": float64(1),
+			},
+			expectedStatus:    "completed",
+			expectedPolls:     3,
+			expectedElapsed:   10,
+			expectedWaitEvery: minWorkflowRunPollSeconds * time.Second,
+		},
+		{
+			name:         "timeout is capped",
+			mockedClient: mockWorkflowRunStatus(t, -1),
+			requestArgs: map[string]any{
+				"timeout_seconds":       float64(1000),
+				"poll_interval_seconds": float64(100),
+			},
+			expectedStatus:    "in_progress",
+			expectedTimedOut:  true,
+			expectedPolls:     4,
+			expectedElapsed:   maxWorkflowRunWaitSeconds,
+			expectedWaitEvery: 100 * time.Second,
+		},
+		{
+			name:             "request deadline reached",
+			mockedClient:     mockWorkflowRunStatus(t, -1),
+			requestArgs:      map[string]any{},
+			waitErr:          context.DeadlineExceeded,
+			expectedStatus:   "in_progress",
+			expectedTimedOut: true,
+			expectedPolls:    1,
+		},
+		{
+			name:           "request cancelled",
+			mockedClient:   mockWorkflowRunStatus(t, -1),
+			requestArgs:    map[string]any{},
+			waitErr:        context.Canceled,
+			expectError:    true,
+			expectedErrMsg: "stopped waiting for workflow run",
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "failed to get workflow run",
+		},
+		{
+			name:         "negative timeout",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"timeout_seconds": float64(-5),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "timeout_seconds must be positive",
+		},
+		{
+			name:         "zero timeout",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"timeout_seconds": float64(0),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "timeout_seconds must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := &fakeWorkflowRunWaiter{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), err: tc.waitErr}
+			client := github.NewClient(tc.mockedClient)
+			_, handler := waitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper, clock.waiter())
+
+			args := map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedStatus, response["status"])
+			assert.Equal(t, tc.expectedTimedOut, response["timed_out"])
+			assert.Equal(t, tc.expectedPolls, response["polls"])
+			assert.Equal(t, tc.expectedElapsed, response["elapsed_seconds"])
+			if tc.expectedStatus == "completed" {
+				assert.Equal(t, "success", response["conclusion"])
+			}
+			if tc.expectedWaitEvery != 0 {
+				for _, d := range clock.waits {
+					assert.Equal(t, tc.expectedWaitEvery, d)
+				}
+			}
+		})
+	}
+}
+
+func Test_WaitForWorkflowRun_StopsOnCancel(t *testing.T) {
+	client := github.NewClient(mockWorkflowRunStatus(t, -1))
+	_, handler := WaitForWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := handler(ctx, createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"run_id": float64(12345),
+	}))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	// The poll interval is at least 5 seconds, the cancellation must not wait for it
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
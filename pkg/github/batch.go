@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// BatchReadToolName is the name of the batch_read tool, before the tool prefix is applied.
+	BatchReadToolName = "batch_read"
+	// maxBatchCalls is the maximum number of tool calls in a batch.
+	maxBatchCalls = 10
+	// maxBatchConcurrency is the maximum number of tool calls of a batch executed at the same time.
+	maxBatchConcurrency = 4
+	// maxBatchResponseBytes caps the size of the results of a batch. Results past the cap are replaced by an error.
+	maxBatchResponseBytes = 1024 * 1024
+)
+
+// unbatchableTools are the read-only tools that cannot be called in a batch, before the tool prefix
+// is applied. wait_for_workflow_run blocks for up to minutes, holding a worker of the batch meanwhile.
+var unbatchableTools = []string{BatchReadToolName, "wait_for_workflow_run"}
+
+// batchCall is a tool call requested in a batch.
+type batchCall struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// batchCallResult is the outcome of a tool call of a batch. Result is set when the tool returned a
+// result, which may itself be a tool error, and Error when the call failed before producing one.
+type batchCallResult struct {
+	Tool   string              `json:"tool"`
+	Result *mcp.CallToolResult `json:"result,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// BatchRead creates a tool that executes several read-only tools of the toolset group in one request.
+func BatchRead(tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(BatchReadToolName,
+			mcp.WithDescription(t("TOOL_BATCH_READ_DESCRIPTION", "Execute up to 10 independent read-only GitHub tool calls in one request, for example to get an issue, a pull request and its status together. Results are returned in the order of the calls, a failing call does not fail the others. Write tools and "+tsg.ToolPrefix()+"wait_for_workflow_run cannot be batched.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_BATCH_READ_USER_TITLE", "Batch read-only tool calls"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithArray("calls",
+				mcp.Required(),
+				mcp.Description("The tool calls to execute"),
+				mcp.MinItems(1),
+				mcp.MaxItems(maxBatchCalls),
+				mcp.Items(
+					map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"tool": map[string]any{
+								"type":        "string",
+								"description": "The name of a read-only tool",
+							},
+							"arguments": map[string]any{
+								"type":        "object",
+								"description": "The arguments of the tool call",
+							},
+						},
+						"required": []string{"tool"},
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			calls, err := batchCallsParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Validate the whole batch before executing anything, so that a write tool never runs
+			tools := make([]server.ServerTool, len(calls))
+			for i, call := range calls {
+				if slices.Contains(unbatchableTools, strings.TrimPrefix(call.Tool, tsg.ToolPrefix())) {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d: %s cannot be batched", i, call.Tool)), nil
+				}
+				tool, ok := tsg.LookupTool(call.Tool)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d: tool %s does not exist or is not enabled", i, call.Tool)), nil
+				}
+				if !toolsets.IsReadOnlyTool(tool) {
+					return mcp.NewToolResultError(fmt.Sprintf("call %d: tool %s is not read-only and cannot be batched", i, call.Tool)), nil
+				}
+				tools[i] = tool
+			}
+
+			results := make([]batchCallResult, len(calls))
+			sem := make(chan struct{}, maxBatchConcurrency)
+			var wg sync.WaitGroup
+			for i, call := range calls {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+					results[i] = executeBatchCall(ctx, tools[i], call)
+				}()
+			}
+			wg.Wait()
+
+			limitBatchResults(results, maxBatchResponseBytes)
+
+			r, err := json.Marshal(results)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal batch results: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// batchCallsParam returns the tool calls of a batch_read request.
+func batchCallsParam(request mcp.CallToolRequest) ([]batchCall, error) {
+	raw, ok := request.GetArguments()["calls"]
+	if !ok {
+		return nil, fmt.Errorf("missing required parameter: calls")
+	}
+	// Round trip through JSON to turn the decoded arguments into typed calls
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal calls: %w", err)
+	}
+	var calls []batchCall
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("parameter calls must be an array of {tool, arguments} objects: %w", err)
+	}
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("missing required parameter: calls")
+	}
+	if len(calls) > maxBatchCalls {
+		return nil, fmt.Errorf("a batch accepts at most %d calls, got %d", maxBatchCalls, len(calls))
+	}
+	for i, call := range calls {
+		if call.Tool == "" {
+			return nil, fmt.Errorf("call %d: missing required parameter: tool", i)
+		}
+	}
+	return calls, nil
+}
+
+// executeBatchCall calls the tool handler as the server would for a tools/call request.
+func executeBatchCall(ctx context.Context, tool server.ServerTool, call batchCall) batchCallResult {
+	var request mcp.CallToolRequest
+	request.Method = string(mcp.MethodToolsCall)
+	request.Params.Name = call.Tool
+	request.Params.Arguments = call.Arguments
+	if request.Params.Arguments == nil {
+		request.Params.Arguments = map[string]any{}
+	}
+
+	result, err := tool.Handler(ctx, request)
+	switch {
+	case err != nil:
+		return batchCallResult{Tool: call.Tool, Error: err.Error()}
+	case result == nil:
+		return batchCallResult{Tool: call.Tool, Error: "tool returned no result"}
+	default:
+		return batchCallResult{Tool: call.Tool, Result: result}
+	}
+}
+
+// limitBatchResults replaces the results that would take the batch past maxBytes by an error, in
+// order, so that the earlier calls are the ones returned.
+func limitBatchResults(results []batchCallResult, maxBytes int) {
+	size := 0
+	for i, result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			results[i] = batchCallResult{Tool: result.Tool, Error: fmt.Sprintf("failed to marshal result: %s", err)}
+			continue
+		}
+		if size+len(data) > maxBytes {
+			results[i] = batchCallResult{
+				Tool:  result.Tool,
+				Error: fmt.Sprintf("result omitted, the batch response would exceed %d bytes", maxBytes),
+			}
+			continue
+		}
+		size += len(data)
+	}
+}
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTestTool(name string, readOnly bool, handler server.ToolHandlerFunc) server.ServerTool {
+	return toolsets.NewServerTool(
+		mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(readOnly)})),
+		handler,
+	)
+}
+
+// newBatchTestToolsetGroup returns a toolset group with the batch tool and a few fake tools.
+// writeCalls counts the calls of the write tool.
+func newBatchTestToolsetGroup(writeCalls *atomic.Int32) *toolsets.ToolsetGroup {
+	tsg := toolsets.NewToolsetGroup(false)
+	repos := toolsets.NewToolset("repos", "Repository tools").
+		AddReadTools(
+			newBatchTestTool("echo", true, func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				// Finish in reverse order of the delays to check that results keep the call order
+				delay, _ := OptionalIntParam(request, "delay_ms")
+				time.Sleep(time.Duration(delay) * time.Millisecond)
+				value, err := RequiredParam[string](request, "value")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				return mcp.NewToolResultText(value), nil
+			}),
+			newBatchTestTool("broken", true, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return nil, errors.New("connection reset")
+			}),
+		).
+		AddWriteTools(
+			newBatchTestTool("delete_file", false, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				writeCalls.Add(1)
+				return mcp.NewToolResultText("deleted"), nil
+			}),
+		)
+	batch := toolsets.NewToolset("batch", "Batch tools").
+		AddReadTools(toolsets.NewServerTool(BatchRead(tsg, translations.NullTranslationHelper)))
+	tsg.AddToolset(repos)
+	tsg.AddToolset(batch)
+	_ = tsg.EnableToolsets([]string{"all"})
+	return tsg
+}
+
+func Test_BatchRead(t *testing.T) {
+	// Verify tool definition once
+	tool, _ := BatchRead(toolsets.NewToolsetGroup(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "batch_read", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "calls")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"calls"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	prefixed, _ := BatchRead(toolsets.NewToolsetGroup(false, toolsets.WithToolPrefix("github_")), translations.NullTranslationHelper)
+	assert.Contains(t, prefixed.Description, "Write tools and github_wait_for_workflow_run cannot be batched")
+
+	tooManyCalls := make([]any, maxBatchCalls+1)
+	for i := range tooManyCalls {
+		tooManyCalls[i] = map[string]any{"tool": "echo", "arguments": map[string]any{"value": "x"}}
+	}
+
+	tests := []struct {
+		name           string
+		calls          any
+		expectedErrMsg string
+		expected       []batchCallResult
+	}{
+		{
+			name: "results keep the order of the calls",
+			calls: []any{
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "first", "delay_ms": float64(30)}},
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "second", "delay_ms": float64(15)}},
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "third"}},
+			},
+			expected: []batchCallResult{
+				{Tool: "echo", Result: mcp.NewToolResultText("first")},
+				{Tool: "echo", Result: mcp.NewToolResultText("second")},
+				{Tool: "echo", Result: mcp.NewToolResultText("third")},
+			},
+		},
+		{
+			name: "failing calls do not fail the batch",
+			calls: []any{
+				map[string]any{"tool": "broken"},
+				map[string]any{"tool": "echo"},
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "ok"}},
+			},
+			expected: []batchCallResult{
+				{Tool: "broken", Error: "connection reset"},
+				{Tool: "echo", Result: mcp.NewToolResultError("missing required parameter: value")},
+				{Tool: "echo", Result: mcp.NewToolResultText("ok")},
+			},
+		},
+		{
+			name: "write tool is rejected",
+			calls: []any{
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "x"}},
+				map[string]any{"tool": "delete_file", "arguments": map[string]any{"path": "README.md"}},
+			},
+			expectedErrMsg: "call 1: tool delete_file is not read-only and cannot be batched",
+		},
+		{
+			name:           "unknown tool is rejected",
+			calls:          []any{map[string]any{"tool": "unknown"}},
+			expectedErrMsg: "call 0: tool unknown does not exist or is not enabled",
+		},
+		{
+			name:           "batch_read cannot batch itself",
+			calls:          []any{map[string]any{"tool": "batch_read"}},
+			expectedErrMsg: "call 0: batch_read cannot be batched",
+		},
+		{
+			name: "wait_for_workflow_run cannot be batched",
+			calls: []any{
+				map[string]any{"tool": "echo", "arguments": map[string]any{"value": "x"}},
+				map[string]any{"tool": "wait_for_workflow_run", "arguments": map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(1)}},
+			},
+			expectedErrMsg: "call 1: wait_for_workflow_run cannot be batched",
+		},
+		{
+			name:           "missing tool name",
+			calls:          []any{map[string]any{"arguments": map[string]any{}}},
+			expectedErrMsg: "call 0: missing required parameter: tool",
+		},
+		{
+			name:           "too many calls",
+			calls:          tooManyCalls,
+			expectedErrMsg: fmt.Sprintf("a batch accepts at most %d calls, got %d", maxBatchCalls, maxBatchCalls+1),
+		},
+		{
+			name:           "empty batch",
+			calls:          []any{},
+			expectedErrMsg: "missing required parameter: calls",
+		},
+		{
+			name:           "calls of the wrong type",
+			calls:          "echo",
+			expectedErrMsg: "parameter calls must be an array of {tool, arguments} objects",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var writeCalls atomic.Int32
+			tsg := newBatchTestToolsetGroup(&writeCalls)
+			batchTool, ok := tsg.LookupTool("batch_read")
+			require.True(t, ok)
+
+			result, err := batchTool.Handler(context.Background(), createMCPRequest(map[string]any{"calls": tc.calls}))
+			require.NoError(t, err)
+			assert.Zero(t, writeCalls.Load())
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			expectedJSON, err := json.Marshal(tc.expected)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expectedJSON), textContent.Text)
+		})
+	}
+}
+
+func Test_limitBatchResults(t *testing.T) {
+	small := batchCallResult{Tool: "echo", Result: mcp.NewToolResultText("a")}
+	smallJSON, err := json.Marshal(small)
+	require.NoError(t, err)
+
+	results := []batchCallResult{
+		small,
+		{Tool: "echo", Result: mcp.NewToolResultText(string(make([]byte, 100)))},
+		small,
+	}
+	// The second result does not fit, the third still does
+	limitBatchResults(results, 2*len(smallJSON)+10)
+
+	assert.Equal(t, small, results[0])
+	assert.Equal(t, batchCallResult{
+		Tool:  "echo",
+		Error: fmt.Sprintf("result omitted, the batch response would exceed %d bytes", 2*len(smallJSON)+10),
+	}, results[1])
+	assert.Equal(t, small, results[2])
+}
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	checkRunStatuses = []string{"queued", "in_progress", "completed"}
+	checkRunFilters  = []string{"latest", "all"}
+)
+
+// checkRun is the compact form of a check run
+type checkRun struct {
+	ID               int64      `json:"id"`
+	Name             string     `json:"name"`
+	Status           string     `json:"status"`
+	Conclusion       string     `json:"conclusion,omitempty"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	DetailsURL       string     `json:"details_url,omitempty"`
+	AnnotationsCount int        `json:"annotations_count"`
+}
+
+func newCheckRun(run *github.CheckRun) checkRun {
+	result := checkRun{
+		ID:               run.GetID(),
+		Name:             run.GetName(),
+		Status:           run.GetStatus(),
+		Conclusion:       run.GetConclusion(),
+		DetailsURL:       run.GetDetailsURL(),
+		AnnotationsCount: run.GetOutput().GetAnnotationsCount(),
+	}
+	if run.StartedAt != nil {
+		result.StartedAt = &run.StartedAt.Time
+	}
+	if run.CompletedAt != nil {
+		result.CompletedAt = &run.CompletedAt.Time
+	}
+	return result
+}
+
+// ListCheckRunsForRef creates a tool to list the check runs of a commit
+func ListCheckRunsForRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_runs_for_ref",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_RUNS_FOR_REF_DESCRIPTION", "List the check runs of a commit, such as the jobs of workflow runs and the checks of GitHub Apps, with their status and conclusion. Use get_check_run_annotations to see why a check run failed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_RUNS_FOR_REF_USER_TITLE", "List check runs for a ref"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The SHA of the commit, or a branch or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only list the check runs with this name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Only list the check runs with this status"),
+				mcp.Enum(checkRunStatuses...),
+			),
+			mcp.WithString("filter",
+				mcp.Description("List only the most recent check run of each name, or all of them including the runs that were re-run (default latest)"),
+				mcp.Enum(checkRunFilters...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if status != "" && !slices.Contains(checkRunStatuses, status) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid status: %s, must be one of queued, in_progress or completed", status)), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if filter != "" && !slices.Contains(checkRunFilters, filter) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid filter: %s, must be latest or all", filter)), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckRunsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if checkName != "" {
+				opts.CheckName = github.Ptr(checkName)
+			}
+			if status != "" {
+				opts.Status = github.Ptr(status)
+			}
+			if filter != "" {
+				opts.Filter = github.Ptr(filter)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			results, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list check runs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			checkRuns := make([]checkRun, 0, len(results.CheckRuns))
+			for _, run := range results.CheckRuns {
+				checkRuns = append(checkRuns, newCheckRun(run))
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"total_count": results.GetTotal(),
+				"check_runs":  checkRuns,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetCheckRunAnnotations creates a tool to get the annotations of a check run
+func GetCheckRunAnnotations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_check_run_annotations",
+			mcp.WithDescription(t("TOOL_GET_CHECK_RUN_ANNOTATIONS_DESCRIPTION", "Get the annotations of a check run, such as lint errors and test failures with their file and line")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CHECK_RUN_ANNOTATIONS_USER_TITLE", "Get check run annotations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("The ID of the check run, as returned by list_check_runs_for_ref"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, int64(checkRunID), &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list check run annotations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]workflowAnnotation, 0, len(annotations))
+			for _, a := range annotations {
+				result = append(result, workflowAnnotation{
+					Path:      a.GetPath(),
+					StartLine: a.GetStartLine(),
+					Level:     a.GetAnnotationLevel(),
+					Title:     a.GetTitle(),
+					Message:   a.GetMessage(),
+				})
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"check_run_id": checkRunID,
+				"annotations":  result,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCheckRunsForRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckRunsForRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_runs_for_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "check_name")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	startedAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(2 * time.Minute)
+	lint := &github.CheckRun{
+		ID:          github.Ptr(int64(11)),
+		Name:        github.Ptr("lint"),
+		Status:      github.Ptr("completed"),
+		Conclusion:  github.Ptr("failure"),
+		StartedAt:   &github.Timestamp{Time: startedAt},
+		CompletedAt: &github.Timestamp{Time: completedAt},
+		DetailsURL:  github.Ptr("https://github.com/owner/repo/runs/11"),
+		HeadSHA:     github.Ptr("abc123"),
+		Output: &github.CheckRunOutput{
+			Title:            github.Ptr("2 errors"),
+			AnnotationsCount: github.Ptr(2),
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedTotal     int
+		expectedCheckRuns []checkRun
+	}{
+		{
+			name: "check runs filtered by name and status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"check_name": "lint",
+						"status":     "completed",
+						"filter":     "all",
+						"page":       "1",
+						"per_page":   "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+							Total:     github.Ptr(1),
+							CheckRuns: []*github.CheckRun{lint},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"check_name": "lint",
+				"status":     "completed",
+				"filter":     "all",
+			},
+			expectedTotal: 1,
+			expectedCheckRuns: []checkRun{{
+				ID:               11,
+				Name:             "lint",
+				Status:           "completed",
+				Conclusion:       "failure",
+				StartedAt:        &startedAt,
+				CompletedAt:      &completedAt,
+				DetailsURL:       "https://github.com/owner/repo/runs/11",
+				AnnotationsCount: 2,
+			}},
+		},
+		{
+			name: "ref without check runs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					&github.ListCheckRunsResults{Total: github.Ptr(0)},
+				),
+			),
+			expectedTotal:     0,
+			expectedCheckRuns: []checkRun{},
+		},
+		{
+			name:         "invalid status",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"status": "failed",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid status: failed, must be one of queued, in_progress or completed",
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCheckRunsForRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "abc123",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				TotalCount int        `json:"total_count"`
+				CheckRuns  []checkRun `json:"check_runs"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedTotal, response.TotalCount)
+			assert.Equal(t, tc.expectedCheckRuns, response.CheckRuns)
+		})
+	}
+}
+
+func Test_GetCheckRunAnnotations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCheckRunAnnotations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_check_run_annotations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		expectError         bool
+		expectedErrMsg      string
+		expectedAnnotations []workflowAnnotation
+	}{
+		{
+			name: "annotations of a failed check run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					expectPath(t, "/repos/owner/repo/check-runs/11/annotations").andThen(
+						mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{
+							{
+								Path:            github.Ptr("pkg/server.go"),
+								StartLine:       github.Ptr(42),
+								EndLine:         github.Ptr(42),
+								AnnotationLevel: github.Ptr("failure"),
+								Title:           github.Ptr("errcheck"),
+								Message:         github.Ptr("Error return value is not checked"),
+							},
+							{
+								Path:            github.Ptr(".github"),
+								AnnotationLevel: github.Ptr("warning"),
+								Message:         github.Ptr("Node.js 16 actions are deprecated"),
+							},
+						}),
+					),
+				),
+			),
+			expectedAnnotations: []workflowAnnotation{
+				{
+					Path:      "pkg/server.go",
+					StartLine: 42,
+					Level:     "failure",
+					Title:     "errcheck",
+					Message:   "Error return value is not checked",
+				},
+				{
+					Path:    ".github",
+					Level:   "warning",
+					Message: "Node.js 16 actions are deprecated",
+				},
+			},
+		},
+		{
+			name: "check run without annotations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					[]*github.CheckRunAnnotation{},
+				),
+			),
+			expectedAnnotations: []workflowAnnotation{},
+		},
+		{
+			name: "check run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list check run annotations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCheckRunAnnotations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(11),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				CheckRunID  int64                `json:"check_run_id"`
+				Annotations []workflowAnnotation `json:"annotations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(11), response.CheckRunID)
+			assert.Equal(t, tc.expectedAnnotations, response.Annotations)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package github
+
+import "context"
+
+// tokenContextKey is the context key for a GitHub token that overrides the server token.
+type tokenContextKey struct{}
+
+// apiHostContextKey is the context key for a GitHub API host that overrides the server host.
+type apiHostContextKey struct{}
+
+// ContextWithToken returns a copy of ctx carrying a GitHub token. Servers that host several users in one
+// process populate it from the per-session auth, for example in an http.Server's middleware or with
+// server.WithHTTPContextFunc, so that the clients returned to tool handlers act as that user.
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// TokenFromContext returns the GitHub token set with ContextWithToken, if any.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenContextKey{}).(string)
+	return token, ok && token != ""
+}
+
+// ContextWithAPIHost returns a copy of ctx carrying the GitHub host to target, in the same format as the
+// --gh-host flag, for example https://github.example.com. It must be set together with ContextWithToken: the
+// server's own token is never sent to a host taken from the context, and such requests fail.
+func ContextWithAPIHost(ctx context.Context, host string) context.Context {
+	return context.WithValue(ctx, apiHostContextKey{}, host)
+}
+
+// APIHostFromContext returns the GitHub host set with ContextWithAPIHost, if any.
+func APIHostFromContext(ctx context.Context) (string, bool) {
+	host, ok := ctx.Value(apiHostContextKey{}).(string)
+	return host, ok && host != ""
+}
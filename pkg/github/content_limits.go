@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMaxResourceBlobBytes is the default size above which binary repository resources are described
+// rather than returned.
+const DefaultMaxResourceBlobBytes = 5 * 1024 * 1024
+
+// ContentLimits bounds the size of the content that tools and resources return, so that hosted
+// deployments can keep memory use and responses small. Zero values select the defaults.
+type ContentLimits struct {
+	// MaxResourceBlobBytes is the size above which a binary file read through a repository resource is
+	// replaced by a text description of the file, instead of being returned base64 encoded.
+	MaxResourceBlobBytes int64
+
+	// MaxResultBytes, if positive, is the byte budget of JSON tool results, see ResultOptions.
+	MaxResultBytes int
+
+	// IndentResults pretty-prints JSON tool results.
+	IndentResults bool
+}
+
+// maxResourceBlobBytes returns the configured MaxResourceBlobBytes or its default.
+func (l ContentLimits) maxResourceBlobBytes() int64 {
+	if l.MaxResourceBlobBytes > 0 {
+		return l.MaxResourceBlobBytes
+	}
+	return DefaultMaxResourceBlobBytes
+}
+
+// resultOptions returns the default options of MarshalledTextResult. Options given after them override them.
+func (l ContentLimits) resultOptions() []ResultOption {
+	return []ResultOption{WithIndent(l.IndentResults), WithMaxBytes(l.MaxResultBytes)}
+}
+
+// limitJSONResult wraps a tool so that its JSON text results are rendered with the indentation and
+// byte budget of the limits, as MarshalledTextResult does. Error results, results of several contents
+// and text that is not JSON, such as Markdown, are returned unchanged.
+func (l ContentLimits) limitJSONResult(tool server.ServerTool) server.ServerTool {
+	if !l.IndentResults && l.MaxResultBytes <= 0 {
+		return tool
+	}
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError || len(result.Content) != 1 {
+			return result, err
+		}
+		text, ok := result.Content[0].(mcp.TextContent)
+		if !ok || !json.Valid([]byte(text.Text)) {
+			return result, nil
+		}
+		return MarshalledTextResult(json.RawMessage(text.Text), l.resultOptions()...), nil
+	}
+	return tool
+}
@@ -2,14 +2,19 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 // GetMe creates a tool to get details of the authenticated user.
-func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+func GetMe(getClient GetClientFn, limits ContentLimits, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	tool := mcp.NewTool("get_me",
 		mcp.WithDescription(t("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated GitHub user. Use this when a request includes \"me\", \"my\". The output will not change unless the user changes their profile, so only call this once.")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
@@ -33,8 +38,187 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 			return mcp.NewToolResultErrorFromErr("failed to get user", err), nil
 		}
 
-		return MarshalledTextResult(user), nil
+		return MarshalledTextResult(user, limits.resultOptions()...), nil
 	})
 
 	return tool, handler
 }
+
+const (
+	// ActivityScopePerformed lists the events performed by the authenticated user.
+	ActivityScopePerformed = "performed"
+	// ActivityScopeReceived lists the events of the repositories and users the authenticated user watches or follows.
+	ActivityScopeReceived = "received"
+	// maxActivityPages is the maximum number of event pages read for list_my_recent_activity.
+	maxActivityPages = 3
+)
+
+// activityEvent is the normalized form of an event of the events feed.
+type activityEvent struct {
+	Type      string    `json:"type"`
+	Repo      string    `json:"repo,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Summary   string    `json:"summary"`
+}
+
+// ListMyRecentActivity creates a tool to list the recent events performed or received by the authenticated user.
+func ListMyRecentActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_my_recent_activity",
+			mcp.WithDescription(t("TOOL_LIST_MY_RECENT_ACTIVITY_DESCRIPTION", "List recent activity of the authenticated user, newest first. Use the received scope to catch up on what happened in the repositories and users they watch or follow, and the performed scope for what they did themselves.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MY_RECENT_ACTIVITY_USER_TITLE", "List my recent activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("scope",
+				mcp.Description("Events received by the user from watched repositories and followed users (default), or events performed by the user"),
+				mcp.Enum(ActivityScopeReceived, ActivityScopePerformed),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only list events created after this time (ISO 8601 timestamp: YYYY-MM-DDTHH:MM:SSZ or YYYY-MM-DD)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, err := OptionalParam[string](request, "scope")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch scope {
+			case "":
+				scope = ActivityScopeReceived
+			case ActivityScopeReceived, ActivityScopePerformed:
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid scope: %s, must be %s or %s", scope, ActivityScopeReceived, ActivityScopePerformed)), nil
+			}
+
+			sinceParam, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var since time.Time
+			if sinceParam != "" {
+				since, err = parseISOTimestamp(sinceParam)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list activity: %s", err.Error())), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get GitHub client", err), nil
+			}
+
+			user, _, err := client.Users.Get(ctx, "")
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("failed to get user", err), nil
+			}
+
+			activity := []activityEvent{}
+			opts := &github.ListOptions{PerPage: maxPerPage}
+		pages:
+			for page := 0; page < maxActivityPages; page++ {
+				var events []*github.Event
+				var resp *github.Response
+				if scope == ActivityScopePerformed {
+					events, resp, err = client.Activity.ListEventsPerformedByUser(ctx, user.GetLogin(), false, opts)
+				} else {
+					events, resp, err = client.Activity.ListEventsReceivedByUser(ctx, user.GetLogin(), false, opts)
+				}
+				if err != nil {
+					return mcp.NewToolResultErrorFromErr("failed to list events", err), nil
+				}
+				_ = resp.Body.Close()
+
+				for _, event := range events {
+					// Events are listed newest first, so the rest of the feed is older
+					if !since.IsZero() && !event.GetCreatedAt().After(since) {
+						break pages
+					}
+					activity = append(activity, normalizeEvent(event))
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			r, err := json.Marshal(activity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal activity: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// normalizeEvent turns an event of the events feed into an activityEvent.
+func normalizeEvent(event *github.Event) activityEvent {
+	return activityEvent{
+		Type:      event.GetType(),
+		Repo:      event.GetRepo().GetName(),
+		Actor:     event.GetActor().GetLogin(),
+		CreatedAt: event.GetCreatedAt().Time,
+		Summary:   summarizeEvent(event),
+	}
+}
+
+// summarizeEvent describes the payload of an event in a sentence. Events of unknown types, or whose
+// payload cannot be parsed, are described by their type.
+func summarizeEvent(event *github.Event) string {
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return event.GetType()
+	}
+
+	switch p := payload.(type) {
+	case *github.PushEvent:
+		commits := p.GetSize()
+		if p.Size == nil {
+			commits = len(p.Commits)
+		}
+		return fmt.Sprintf("pushed %d commit(s) to %s", commits, strings.TrimPrefix(p.GetRef(), "refs/heads/"))
+	case *github.IssuesEvent:
+		return fmt.Sprintf("%s issue #%d: %s", p.GetAction(), p.GetIssue().GetNumber(), p.GetIssue().GetTitle())
+	case *github.IssueCommentEvent:
+		kind := "issue"
+		if p.GetIssue().IsPullRequest() {
+			kind = "pull request"
+		}
+		return fmt.Sprintf("commented on %s #%d: %s", kind, p.GetIssue().GetNumber(), p.GetIssue().GetTitle())
+	case *github.PullRequestEvent:
+		action := p.GetAction()
+		if action == "closed" && p.GetPullRequest().GetMerged() {
+			action = "merged"
+		}
+		return fmt.Sprintf("%s pull request #%d: %s", action, p.GetPullRequest().GetNumber(), p.GetPullRequest().GetTitle())
+	case *github.PullRequestReviewEvent:
+		return fmt.Sprintf("reviewed pull request #%d (%s): %s", p.GetPullRequest().GetNumber(), strings.ToLower(p.GetReview().GetState()), p.GetPullRequest().GetTitle())
+	case *github.PullRequestReviewCommentEvent:
+		return fmt.Sprintf("commented on the review of pull request #%d: %s", p.GetPullRequest().GetNumber(), p.GetPullRequest().GetTitle())
+	case *github.CreateEvent:
+		if p.GetRefType() == "repository" {
+			return "created the repository"
+		}
+		return fmt.Sprintf("created %s %s", p.GetRefType(), p.GetRef())
+	case *github.DeleteEvent:
+		return fmt.Sprintf("deleted %s %s", p.GetRefType(), p.GetRef())
+	case *github.ReleaseEvent:
+		return fmt.Sprintf("%s release %s", p.GetAction(), p.GetRelease().GetTagName())
+	case *github.ForkEvent:
+		return fmt.Sprintf("forked the repository to %s", p.GetForkee().GetFullName())
+	case *github.WatchEvent:
+		return "starred the repository"
+	case *github.CommitCommentEvent:
+		sha := p.GetComment().GetCommitID()
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		return fmt.Sprintf("commented on commit %s", sha)
+	case *github.MemberEvent:
+		return fmt.Sprintf("%s collaborator %s", p.GetAction(), p.GetMember().GetLogin())
+	case *github.PublicEvent:
+		return "made the repository public"
+	default:
+		return event.GetType()
+	}
+}
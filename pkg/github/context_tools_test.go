@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -17,7 +19,7 @@ import (
 func Test_GetMe(t *testing.T) {
 	t.Parallel()
 
-	tool, _ := GetMe(nil, translations.NullTranslationHelper)
+	tool, _ := GetMe(nil, ContentLimits{}, translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	// Verify some basic very important properties
@@ -103,7 +105,7 @@ func Test_GetMe(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			_, handler := GetMe(tc.stubbedGetClientFn, translations.NullTranslationHelper)
+			_, handler := GetMe(tc.stubbedGetClientFn, ContentLimits{}, translations.NullTranslationHelper)
 
 			request := createMCPRequest(tc.requestArgs)
 			result, err := handler(context.Background(), request)
@@ -131,3 +133,297 @@ func Test_GetMe(t *testing.T) {
 		})
 	}
 }
+
+// newTestEvent returns an event of the given type with the payload marshalled as the API returns it.
+func newTestEvent(t *testing.T, eventType string, createdAt time.Time, payload any) *github.Event {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	rawPayload := json.RawMessage(raw)
+	return &github.Event{
+		Type:       github.Ptr(eventType),
+		Repo:       &github.Repository{Name: github.Ptr("octocat/hello-world")},
+		Actor:      &github.User{Login: github.Ptr("monalisa")},
+		CreatedAt:  &github.Timestamp{Time: createdAt},
+		RawPayload: &rawPayload,
+	}
+}
+
+func Test_summarizeEvent(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		eventType string
+		payload   any
+		expected  string
+	}{
+		{
+			name:      "push",
+			eventType: "PushEvent",
+			payload: &github.PushEvent{
+				Ref:  github.Ptr("refs/heads/main"),
+				Size: github.Ptr(3),
+			},
+			expected: "pushed 3 commit(s) to main",
+		},
+		{
+			name:      "push without size counts the commits",
+			eventType: "PushEvent",
+			payload: &github.PushEvent{
+				Ref:     github.Ptr("refs/heads/feature"),
+				Commits: []*github.HeadCommit{{ID: github.Ptr("a")}, {ID: github.Ptr("b")}},
+			},
+			expected: "pushed 2 commit(s) to feature",
+		},
+		{
+			name:      "issue",
+			eventType: "IssuesEvent",
+			payload: &github.IssuesEvent{
+				Action: github.Ptr("opened"),
+				Issue:  &github.Issue{Number: github.Ptr(42), Title: github.Ptr("Crash on start")},
+			},
+			expected: "opened issue #42: Crash on start",
+		},
+		{
+			name:      "comment on a pull request",
+			eventType: "IssueCommentEvent",
+			payload: &github.IssueCommentEvent{
+				Action: github.Ptr("created"),
+				Issue: &github.Issue{
+					Number:           github.Ptr(7),
+					Title:            github.Ptr("Add feature"),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/octocat/hello-world/pulls/7")},
+				},
+			},
+			expected: "commented on pull request #7: Add feature",
+		},
+		{
+			name:      "opened pull request",
+			eventType: "PullRequestEvent",
+			payload: &github.PullRequestEvent{
+				Action:      github.Ptr("opened"),
+				PullRequest: &github.PullRequest{Number: github.Ptr(7), Title: github.Ptr("Add feature")},
+			},
+			expected: "opened pull request #7: Add feature",
+		},
+		{
+			name:      "merged pull request",
+			eventType: "PullRequestEvent",
+			payload: &github.PullRequestEvent{
+				Action:      github.Ptr("closed"),
+				PullRequest: &github.PullRequest{Number: github.Ptr(7), Title: github.Ptr("Add feature"), Merged: github.Ptr(true)},
+			},
+			expected: "merged pull request #7: Add feature",
+		},
+		{
+			name:      "pull request review",
+			eventType: "PullRequestReviewEvent",
+			payload: &github.PullRequestReviewEvent{
+				Review:      &github.PullRequestReview{State: github.Ptr("APPROVED")},
+				PullRequest: &github.PullRequest{Number: github.Ptr(7), Title: github.Ptr("Add feature")},
+			},
+			expected: "reviewed pull request #7 (approved): Add feature",
+		},
+		{
+			name:      "branch created",
+			eventType: "CreateEvent",
+			payload:   &github.CreateEvent{RefType: github.Ptr("branch"), Ref: github.Ptr("feature")},
+			expected:  "created branch feature",
+		},
+		{
+			name:      "tag deleted",
+			eventType: "DeleteEvent",
+			payload:   &github.DeleteEvent{RefType: github.Ptr("tag"), Ref: github.Ptr("v1.0.0")},
+			expected:  "deleted tag v1.0.0",
+		},
+		{
+			name:      "release",
+			eventType: "ReleaseEvent",
+			payload: &github.ReleaseEvent{
+				Action:  github.Ptr("published"),
+				Release: &github.RepositoryRelease{TagName: github.Ptr("v1.1.0")},
+			},
+			expected: "published release v1.1.0",
+		},
+		{
+			name:      "fork",
+			eventType: "ForkEvent",
+			payload:   &github.ForkEvent{Forkee: &github.Repository{FullName: github.Ptr("hubot/hello-world")}},
+			expected:  "forked the repository to hubot/hello-world",
+		},
+		{
+			name:      "star",
+			eventType: "WatchEvent",
+			payload:   &github.WatchEvent{Action: github.Ptr("started")},
+			expected:  "starred the repository",
+		},
+		{
+			name:      "commit comment",
+			eventType: "CommitCommentEvent",
+			payload: &github.CommitCommentEvent{
+				Comment: &github.RepositoryComment{CommitID: github.Ptr("6dcb09b5b57875f334f61aebed695e2e4193db5e")},
+			},
+			expected: "commented on commit 6dcb09b",
+		},
+		{
+			name:      "collaborator added",
+			eventType: "MemberEvent",
+			payload: &github.MemberEvent{
+				Action: github.Ptr("added"),
+				Member: &github.User{Login: github.Ptr("hubot")},
+			},
+			expected: "added collaborator hubot",
+		},
+		{
+			name:      "repository made public",
+			eventType: "PublicEvent",
+			payload:   &github.PublicEvent{},
+			expected:  "made the repository public",
+		},
+		{
+			name:      "unknown event type",
+			eventType: "GollumEvent",
+			payload:   map[string]any{"pages": []any{}},
+			expected:  "GollumEvent",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, summarizeEvent(newTestEvent(t, tc.eventType, now, tc.payload)))
+		})
+	}
+}
+
+func Test_ListMyRecentActivity(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListMyRecentActivity(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_my_recent_activity", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "scope")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Empty(t, tool.InputSchema.Required)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	user := &github.User{Login: github.Ptr("monalisa")}
+	events := []*github.Event{
+		newTestEvent(t, "PushEvent", now, &github.PushEvent{Ref: github.Ptr("refs/heads/main"), Size: github.Ptr(1)}),
+		newTestEvent(t, "WatchEvent", now.Add(-48*time.Hour), &github.WatchEvent{Action: github.Ptr("started")}),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       []activityEvent
+	}{
+		{
+			name: "received events by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, user),
+				mock.WithRequestMatch(mock.GetUsersReceivedEventsByUsername, events),
+			),
+			requestArgs: map[string]any{},
+			expected: []activityEvent{
+				{Type: "PushEvent", Repo: "octocat/hello-world", Actor: "monalisa", CreatedAt: now, Summary: "pushed 1 commit(s) to main"},
+				{Type: "WatchEvent", Repo: "octocat/hello-world", Actor: "monalisa", CreatedAt: now.Add(-48 * time.Hour), Summary: "starred the repository"},
+			},
+		},
+		{
+			name: "performed events since a date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, user),
+				mock.WithRequestMatch(mock.GetUsersEventsByUsername, events),
+			),
+			requestArgs: map[string]any{
+				"scope": "performed",
+				"since": "2025-06-01",
+			},
+			expected: []activityEvent{
+				{Type: "PushEvent", Repo: "octocat/hello-world", Actor: "monalisa", CreatedAt: now, Summary: "pushed 1 commit(s) to main"},
+			},
+		},
+		{
+			name:           "invalid scope",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"scope": "all"},
+			expectError:    true,
+			expectedErrMsg: "invalid scope: all, must be received or performed",
+		},
+		{
+			name:           "invalid since",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"since": "yesterday"},
+			expectError:    true,
+			expectedErrMsg: "invalid ISO 8601 timestamp: yesterday",
+		},
+		{
+			name: "listing events fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUser, user),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersReceivedEventsByUsername,
+					badRequestHandler("expected test failure"),
+				),
+			),
+			requestArgs:    map[string]any{},
+			expectError:    true,
+			expectedErrMsg: "failed to list events",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListMyRecentActivity(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned []activityEvent
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ListMyRecentActivity_PageCap(t *testing.T) {
+	var requests int
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetUser, &github.User{Login: github.Ptr("monalisa")}),
+		mock.WithRequestMatchHandler(
+			mock.GetUsersReceivedEventsByUsername,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				// Every page links to a next one
+				w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next"`, r.URL.Path, requests+1))
+				_ = json.NewEncoder(w).Encode([]*github.Event{
+					newTestEvent(t, "WatchEvent", time.Now(), &github.WatchEvent{}),
+				})
+			}),
+		),
+	)
+	_, handler := ListMyRecentActivity(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []activityEvent
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, maxActivityPages, requests)
+	assert.Len(t, returned, maxActivityPages)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -20,8 +23,9 @@ func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
 }
 
 func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	prefix := toolsetGroup.ToolPrefix()
 	return mcp.NewTool("enable_toolset",
-			mcp.WithDescription(t("TOOL_ENABLE_TOOLSET_DESCRIPTION", "Enable one of the sets of tools the GitHub MCP server provides, use get_toolset_tools and list_available_toolsets first to see what this will enable")),
+			mcp.WithDescription(t("TOOL_ENABLE_TOOLSET_DESCRIPTION", "Enable one of the sets of tools the GitHub MCP server provides, use "+prefix+"get_toolset_tools and "+prefix+"list_available_toolsets first to see what this will enable")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title: t("TOOL_ENABLE_TOOLSET_USER_TITLE", "Enable a toolset"),
 				// Not modifying GitHub data so no need to show a warning
@@ -60,8 +64,9 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 }
 
 func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	prefix := toolsetGroup.ToolPrefix()
 	return mcp.NewTool("list_available_toolsets",
-			mcp.WithDescription(t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call")),
+			mcp.WithDescription(t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call "+prefix+"get_toolset_tools with these toolset names to discover specific tools you can call")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_AVAILABLE_TOOLSETS_USER_TITLE", "List available toolsets"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -136,3 +141,226 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+const (
+	// maxToolSuggestions is the maximum number of close matches describe_tool lists for an unknown tool.
+	maxToolSuggestions = 5
+	// maxFoundTools is the maximum number of tools returned by find_tools.
+	maxFoundTools = 10
+)
+
+// findToolsStopWords are ignored in find_tools queries, they match almost every description.
+var findToolsStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "can": true, "do": true, "for": true, "how": true, "i": true,
+	"in": true, "is": true, "it": true, "me": true, "my": true, "of": true, "on": true, "or": true,
+	"the": true, "to": true, "tool": true, "what": true, "which": true, "with": true,
+}
+
+func DescribeTool(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("describe_tool",
+			mcp.WithDescription(t("TOOL_DESCRIBE_TOOL_DESCRIPTION", "Get the full input schema, annotations and owning toolset of a tool, whether its toolset is enabled or not. Use this to check the exact parameters of a tool before enabling its toolset")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DESCRIBE_TOOL_USER_TITLE", "Describe a tool"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("tool",
+				mcp.Required(),
+				mcp.Description("The name of the tool to describe"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			toolName, err := RequiredParam[string](request, "tool")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolset, st, ok := toolsetGroup.FindTool(toolName)
+			if !ok {
+				msg := fmt.Sprintf("Tool %s not found", toolName)
+				if suggestions := similarToolNames(toolName, toolsetGroup.AvailableToolNames()); len(suggestions) > 0 {
+					msg += fmt.Sprintf(", did you mean: %s", strings.Join(suggestions, ", "))
+				}
+				return mcp.NewToolResultError(msg), nil
+			}
+
+			payload := map[string]any{
+				"name":            st.Tool.Name,
+				"description":     st.Tool.Description,
+				"toolset":         toolset.Name,
+				"toolset_enabled": toolset.Enabled,
+				"input_schema":    st.Tool.InputSchema,
+				"annotations":     st.Tool.Annotations,
+			}
+
+			r, err := json.Marshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal tool: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// similarToolNames returns the names closest to name, either containing it or within a small edit
+// distance of it, closest first.
+func similarToolNames(name string, names []string) []string {
+	name = strings.ToLower(name)
+	maxDistance := max(2, len(name)/3)
+
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	for _, candidate := range names {
+		lower := strings.ToLower(candidate)
+		distance := editDistance(name, lower)
+		if distance > maxDistance && !strings.Contains(lower, name) {
+			continue
+		}
+		matches = append(matches, match{name: candidate, distance: distance})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	suggestions := make([]string, 0, min(len(matches), maxToolSuggestions))
+	for i := 0; i < len(matches) && i < maxToolSuggestions; i++ {
+		suggestions = append(suggestions, matches[i].name)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func FindTools(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	prefix := toolsetGroup.ToolPrefix()
+	return mcp.NewTool("find_tools",
+			mcp.WithDescription(t("TOOL_FIND_TOOLS_DESCRIPTION", "Search the tools of all toolsets, enabled or not, by keywords, for example \"dismiss code scanning alert\". Returns the best matching tools with their toolset, use "+prefix+"enable_toolset to enable the toolset of a tool that is not enabled yet")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FIND_TOOLS_USER_TITLE", "Find tools"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Keywords describing what the tool should do"),
+			),
+		),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := RequiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			terms := searchTerms(query)
+			if len(terms) == 0 {
+				return mcp.NewToolResultError("query must contain at least one keyword"), nil
+			}
+
+			type match struct {
+				tool  toolsets.ToolsetTool
+				score int
+			}
+			var matches []match
+			for _, tool := range toolsetGroup.AvailableTools() {
+				if score := scoreTool(terms, tool.Tool.Tool); score > 0 {
+					matches = append(matches, match{tool: tool, score: score})
+				}
+			}
+			// Tools are sorted by name, a stable sort keeps that order between equal scores
+			sort.SliceStable(matches, func(i, j int) bool {
+				return matches[i].score > matches[j].score
+			})
+
+			payload := []map[string]string{}
+			for i := 0; i < len(matches) && i < maxFoundTools; i++ {
+				tool := matches[i].tool
+				found := map[string]string{
+					"name":              tool.Tool.Tool.Name,
+					"description":       tool.Tool.Tool.Description,
+					"toolset":           tool.Toolset.Name,
+					"currently_enabled": fmt.Sprintf("%t", tool.Toolset.Enabled),
+				}
+				if !tool.Toolset.Enabled {
+					found["hint"] = fmt.Sprintf("Call %senable_toolset with toolset %s to use this tool", prefix, tool.Toolset.Name)
+				}
+				payload = append(payload, found)
+			}
+
+			r, err := json.Marshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal features: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// searchTerms splits a query into lowercase keywords, without stop words and plural suffixes.
+func searchTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if findToolsStopWords[word] {
+			continue
+		}
+		terms = append(terms, singular(word))
+	}
+	return terms
+}
+
+// singular strips the plural suffix of a word, so that "alerts" matches "alert" and "branches" matches "branch".
+func singular(word string) string {
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// scoreTool scores how well the tool matches the search terms. A term matching a word of the tool
+// name weighs more than a term found in the name, which weighs more than a term of the description.
+func scoreTool(terms []string, tool mcp.Tool) int {
+	nameWords := make(map[string]bool)
+	for _, word := range strings.Split(strings.ToLower(tool.Name), "_") {
+		nameWords[singular(word)] = true
+	}
+	name := strings.ToLower(tool.Name)
+	description := strings.ToLower(tool.Description)
+
+	score := 0
+	for _, term := range terms {
+		switch {
+		case nameWords[term]:
+			score += 4
+		case strings.Contains(name, term):
+			score += 2
+		case strings.Contains(description, term):
+			score++
+		}
+	}
+	return score
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DescribeTool(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), nil, nil, translations.NullTranslationHelper, ContentLimits{})
+	require.NoError(t, tsg.EnableToolsets([]string{"repos"}))
+
+	tool, handler := DescribeTool(tsg, translations.NullTranslationHelper)
+	assert.Equal(t, "describe_tool", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"tool"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		tool            string
+		expectedToolset string
+		expectedEnabled bool
+		expectedErrMsg  string
+	}{
+		{
+			name:            "tool of an enabled toolset",
+			tool:            "get_file_contents",
+			expectedToolset: "repos",
+			expectedEnabled: true,
+		},
+		{
+			name:            "tool of a disabled toolset",
+			tool:            "get_issue",
+			expectedToolset: "issues",
+			expectedEnabled: false,
+		},
+		{
+			name:           "unknown tool suggests close matches",
+			tool:           "get_isue",
+			expectedErrMsg: "Tool get_isue not found, did you mean: get_issue",
+		},
+		{
+			name:           "unknown tool without close matches",
+			tool:           "xyz",
+			expectedErrMsg: "Tool xyz not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{"tool": tc.tool}))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var described struct {
+				Name           string              `json:"name"`
+				Toolset        string              `json:"toolset"`
+				ToolsetEnabled bool                `json:"toolset_enabled"`
+				InputSchema    mcp.ToolInputSchema `json:"input_schema"`
+				Annotations    mcp.ToolAnnotation  `json:"annotations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &described))
+
+			_, expected, ok := tsg.FindTool(tc.tool)
+			require.True(t, ok)
+			assert.Equal(t, tc.tool, described.Name)
+			assert.Equal(t, tc.expectedToolset, described.Toolset)
+			assert.Equal(t, tc.expectedEnabled, described.ToolsetEnabled)
+			assert.ElementsMatch(t, expected.Tool.InputSchema.Required, described.InputSchema.Required)
+			assert.Equal(t, len(expected.Tool.InputSchema.Properties), len(described.InputSchema.Properties))
+			require.NotNil(t, described.Annotations.ReadOnlyHint)
+			assert.True(t, *described.Annotations.ReadOnlyHint)
+		})
+	}
+}
+
+func Test_similarToolNames(t *testing.T) {
+	names := []string{"get_issue", "get_issue_comments", "list_issues", "get_me", "search_code"}
+
+	assert.Equal(t, []string{"get_issue", "get_issue_comments"}, similarToolNames("get_issue", names))
+	assert.Equal(t, []string{"get_me"}, similarToolNames("GET_ME", names))
+	assert.Empty(t, similarToolNames("create_branch", names))
+}
+
+func Test_FindTools(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), nil, nil, translations.NullTranslationHelper, ContentLimits{})
+	require.NoError(t, tsg.EnableToolsets([]string{"repos"}))
+
+	tool, handler := FindTools(tsg, translations.NullTranslationHelper)
+	assert.Equal(t, "find_tools", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	find := func(t *testing.T, query string) []map[string]string {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"query": query}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var found []map[string]string
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &found))
+		return found
+	}
+
+	t.Run("matches tools of disabled toolsets", func(t *testing.T) {
+		found := find(t, "Which tool lists code scanning alerts?")
+		require.NotEmpty(t, found)
+		assert.Equal(t, "list_code_scanning_alerts", found[0]["name"])
+		assert.Equal(t, "code_security", found[0]["toolset"])
+		assert.Equal(t, "false", found[0]["currently_enabled"])
+		assert.Equal(t, "Call enable_toolset with toolset code_security to use this tool", found[0]["hint"])
+	})
+
+	t.Run("enabled toolsets need no hint", func(t *testing.T) {
+		found := find(t, "create branch")
+		require.NotEmpty(t, found)
+		assert.Equal(t, "create_branch", found[0]["name"])
+		assert.Equal(t, "true", found[0]["currently_enabled"])
+		assert.NotContains(t, found[0], "hint")
+	})
+
+	t.Run("results are capped", func(t *testing.T) {
+		assert.Len(t, find(t, "get list"), maxFoundTools)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Empty(t, find(t, "xyzzy"))
+	})
+
+	t.Run("query of stop words only", func(t *testing.T) {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"query": "which tool can I"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Equal(t, "query must contain at least one keyword", getErrorResult(t, result).Text)
+	})
+}
+
+func Test_FindTools_RanksNamesFirst(t *testing.T) {
+	newTool := func(name, description string) server.ServerTool {
+		return toolsets.NewServerTool(
+			mcp.NewTool(name, mcp.WithDescription(description), mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: ToBoolPtr(true)})),
+			nil,
+		)
+	}
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(toolsets.NewToolset("repos", "Repository tools").AddReadTools(
+		newTool("get_file_contents", "Get the contents of a file or directory on a branch"),
+		newTool("list_branches", "List branches in a repository"),
+		newTool("get_subbranchy_thing", "Get something"),
+	))
+
+	_, handler := FindTools(tsg, translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"query": "branch"}))
+	require.NoError(t, err)
+	var found []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &found))
+
+	names := make([]string, len(found))
+	for i, tool := range found {
+		names[i] = tool["name"]
+	}
+	// A name word first, then a name substring, then a description match
+	assert.Equal(t, []string{"list_branches", "get_subbranchy_thing", "get_file_contents"}, names)
+}
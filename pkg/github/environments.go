@@ -0,0 +1,629 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxEnvironmentWaitTimer is the longest wait timer of an environment, 30 days in minutes
+const maxEnvironmentWaitTimer = 43200
+
+// ListEnvironments creates a tool to list the deployment environments of a repository
+func ListEnvironments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environments",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENTS_DESCRIPTION", "List the deployment environments of a repository with their protection rules, such as required reviewers, wait timers and deployment branch policies")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENTS_USER_TITLE", "List environments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{
+					PerPage: pagination.perPage,
+					Page:    pagination.page,
+				},
+			}
+			environments, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list environments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(environments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetEnvironment creates a tool to get a deployment environment of a repository
+func GetEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_environment",
+			mcp.WithDescription(t("TOOL_GET_ENVIRONMENT_DESCRIPTION", "Get a deployment environment of a repository with its protection rules, such as required reviewers, wait timer and deployment branch policy")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ENVIRONMENT_USER_TITLE", "Get environment"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			environment, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get environment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(environment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateOrUpdateEnvironment creates a tool to create a deployment environment or replace its protection rules
+func CreateOrUpdateEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_environment",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_DESCRIPTION", "Create a deployment environment in a repository, or replace the protection rules of an existing one. Protection rules that are not given are removed, so use get_environment first to keep the current ones")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_USER_TITLE", "Create or update environment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+			mcp.WithNumber("wait_timer",
+				mcp.Description("The number of minutes to wait before a job referencing the environment starts, at most 43200 (30 days)"),
+				mcp.Min(0),
+				mcp.Max(maxEnvironmentWaitTimer),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"type", "id"},
+						"properties": map[string]interface{}{
+							"type": map[string]interface{}{
+								"type":        "string",
+								"description": "Whether the reviewer is a user or a team",
+								"enum":        []string{"User", "Team"},
+							},
+							"id": map[string]interface{}{
+								"type":        "number",
+								"description": "The ID of the user or team",
+							},
+						},
+					}),
+				mcp.Description("Up to 6 users or teams that must approve jobs referencing the environment, each object with type (User or Team) and id (number)"),
+			),
+			mcp.WithBoolean("prevent_self_review",
+				mcp.Description("Whether the user who triggered a deployment is prevented from approving it"),
+			),
+			mcp.WithBoolean("can_admins_bypass",
+				mcp.Description("Whether repository administrators can bypass the protection rules (default true)"),
+			),
+			mcp.WithBoolean("protected_branches",
+				mcp.Description("Only branches with branch protection rules can deploy to the environment"),
+			),
+			mcp.WithBoolean("custom_branch_policies",
+				mcp.Description("Only branches matching the custom deployment branch policies of the environment can deploy to it"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			environment := &github.CreateUpdateEnvironment{}
+			waitTimer, err := OptionalIntParam(request, "wait_timer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if waitTimer < 0 || waitTimer > maxEnvironmentWaitTimer {
+				return mcp.NewToolResultError(fmt.Sprintf("wait_timer must be between 0 and %d minutes", maxEnvironmentWaitTimer)), nil
+			}
+			environment.WaitTimer = github.Ptr(waitTimer)
+			if environment.Reviewers, err = environmentReviewersParam(request); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			preventSelfReview, ok, err := OptionalParamOK[bool](request, "prevent_self_review")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				environment.PreventSelfReview = github.Ptr(preventSelfReview)
+			}
+			canAdminsBypass, ok, err := OptionalParamOK[bool](request, "can_admins_bypass")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				environment.CanAdminsBypass = github.Ptr(canAdminsBypass)
+			}
+			protectedBranches, err := OptionalParam[bool](request, "protected_branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			customBranchPolicies, err := OptionalParam[bool](request, "custom_branch_policies")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if protectedBranches && customBranchPolicies {
+				return mcp.NewToolResultError("only one of protected_branches and custom_branch_policies can be true"), nil
+			}
+			// Without a policy, any branch can deploy to the environment
+			if protectedBranches || customBranchPolicies {
+				environment.DeploymentBranchPolicy = &github.BranchPolicy{
+					ProtectedBranches:    github.Ptr(protectedBranches),
+					CustomBranchPolicies: github.Ptr(customBranchPolicies),
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, name, environment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create or update environment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(created)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// environmentReviewersParam returns the reviewers parameter of create_or_update_environment.
+func environmentReviewersParam(request mcp.CallToolRequest) ([]*github.EnvReviewers, error) {
+	raw, ok := request.GetArguments()["reviewers"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("reviewers parameter must be an array of objects with type and id")
+	}
+
+	reviewers := make([]*github.EnvReviewers, 0, len(items))
+	for _, item := range items {
+		reviewer, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("reviewers parameter must be an array of objects with type and id")
+		}
+		reviewerType, _ := reviewer["type"].(string)
+		if reviewerType != "User" && reviewerType != "Team" {
+			return nil, fmt.Errorf("invalid reviewer type: %v, must be User or Team", reviewer["type"])
+		}
+		id, err := toInt("id", reviewer["id"])
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid reviewer id: %v", reviewer["id"])
+		}
+		reviewers = append(reviewers, &github.EnvReviewers{
+			Type: github.Ptr(reviewerType),
+			ID:   github.Ptr(int64(id)),
+		})
+	}
+	return reviewers, nil
+}
+
+// DeleteEnvironment creates a tool to delete a deployment environment of a repository
+func DeleteEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_environment",
+			mcp.WithDescription(t("TOOL_DELETE_ENVIRONMENT_DESCRIPTION", "Delete a deployment environment of a repository, with its protection rules and secrets")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_ENVIRONMENT_USER_TITLE", "Delete environment"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteEnvironment(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete environment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":          "Environment has been deleted",
+				"environment_name": name,
+				"status":           resp.Status,
+				"status_code":      resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListDeploymentBranchPolicies creates a tool to list the deployment branch policies of an environment
+func ListDeploymentBranchPolicies(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deployment_branch_policies",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOYMENT_BRANCH_POLICIES_DESCRIPTION", "List the name patterns of the branches and tags that can deploy to an environment using custom deployment branch policies")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOYMENT_BRANCH_POLICIES_USER_TITLE", "List deployment branch policies"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environment, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			policies, resp, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, environment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list deployment branch policies: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(policies)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateDeploymentBranchPolicy creates a tool to allow branches or tags matching a pattern to deploy to an environment
+func CreateDeploymentBranchPolicy(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deployment_branch_policy",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOYMENT_BRANCH_POLICY_DESCRIPTION", "Allow the branches or tags matching a name pattern to deploy to an environment. The environment must use custom deployment branch policies, see create_or_update_environment")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOYMENT_BRANCH_POLICY_USER_TITLE", "Create deployment branch policy"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("The name pattern branches or tags must match to deploy, such as main or release/*"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Whether the pattern matches branches or tags (default branch)"),
+				mcp.Enum("branch", "tag"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environmentName, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			policyType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if policyType == "" {
+				policyType = "branch"
+			}
+			if policyType != "branch" && policyType != "tag" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid type: %s, must be branch or tag", policyType)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			environment, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environmentName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get environment: %w", err)
+			}
+			_ = resp.Body.Close()
+			if !environment.GetDeploymentBranchPolicy().GetCustomBranchPolicies() {
+				return mcp.NewToolResultError(fmt.Sprintf("environment %s does not use custom deployment branch policies, enable custom_branch_policies with create_or_update_environment first", environmentName)), nil
+			}
+
+			policy, resp, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repo, environmentName, &github.DeploymentBranchPolicyRequest{
+				Name: github.Ptr(name),
+				Type: github.Ptr(policyType),
+			})
+			if err != nil {
+				var errResp *github.ErrorResponse
+				if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("GitHub rejected the %s policy %s: %s", policyType, name, validationErrorMessage(errResp))), nil
+				}
+				return nil, fmt.Errorf("failed to create deployment branch policy: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(policy)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// validationErrorMessage describes a 422 response, with the message of each error if there are any,
+// such as "name already exists".
+func validationErrorMessage(errResp *github.ErrorResponse) string {
+	messages := []string{}
+	for _, e := range errResp.Errors {
+		switch {
+		case e.Message != "":
+			messages = append(messages, e.Message)
+		case e.Field != "" && e.Code != "":
+			messages = append(messages, fmt.Sprintf("%s %s", e.Field, e.Code))
+		}
+	}
+	if len(messages) == 0 {
+		return errResp.Message
+	}
+	return fmt.Sprintf("%s (%s)", errResp.Message, strings.Join(messages, ", "))
+}
+
+// DeleteDeploymentBranchPolicy creates a tool to delete a deployment branch policy of an environment
+func DeleteDeploymentBranchPolicy(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_deployment_branch_policy",
+			mcp.WithDescription(t("TOOL_DELETE_DEPLOYMENT_BRANCH_POLICY_DESCRIPTION", "Delete a deployment branch policy of an environment, given its ID or its name pattern")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_DEPLOYMENT_BRANCH_POLICY_USER_TITLE", "Delete deployment branch policy"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("environment_name",
+				mcp.Required(),
+				mcp.Description("The name of the environment"),
+			),
+			mcp.WithNumber("branch_policy_id",
+				mcp.Description("The ID of the policy, required unless name is given"),
+			),
+			mcp.WithString("name",
+				mcp.Description("The name pattern of the policy, such as release/*, required unless branch_policy_id is given"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			environmentName, err := RequiredParam[string](request, "environment_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			policyID, err := OptionalIntParam(request, "branch_policy_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if policyID == 0 && name == "" {
+				return mcp.NewToolResultError("either branch_policy_id or name must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			id := int64(policyID)
+			if id == 0 {
+				policies, resp, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, environmentName)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list deployment branch policies: %w", err)
+				}
+				_ = resp.Body.Close()
+				for _, policy := range policies.BranchPolicies {
+					if policy.GetName() == name {
+						id = policy.GetID()
+						break
+					}
+				}
+				if id == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("no deployment branch policy named %s in environment %s", name, environmentName)), nil
+				}
+			}
+
+			resp, err := client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repo, environmentName, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete deployment branch policy: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":          "Deployment branch policy has been deleted",
+				"environment_name": environmentName,
+				"branch_policy_id": id,
+				"status":           resp.Status,
+				"status_code":      resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
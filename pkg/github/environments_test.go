@@ -0,0 +1,925 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListEnvironments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_environments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful environments listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.EnvResponse{
+							TotalCount: github.Ptr(2),
+							Environments: []*github.Environment{
+								{Name: github.Ptr("staging")},
+								{
+									Name: github.Ptr("production"),
+									ProtectionRules: []*github.ProtectionRule{
+										{Type: github.Ptr("wait_timer"), WaitTimer: github.Ptr(30)},
+									},
+								},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list environments",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnvironments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.EnvResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 2, response.GetTotalCount())
+			require.Len(t, response.Environments, 2)
+			assert.Equal(t, "production", response.Environments[1].GetName())
+			require.Len(t, response.Environments[1].ProtectionRules, 1)
+			assert.Equal(t, 30, response.Environments[1].ProtectionRules[0].GetWaitTimer())
+		})
+	}
+}
+
+func Test_GetEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful environment retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/environments/production", r.URL.Path)
+						mockResponse(t, http.StatusOK, &github.Environment{
+							Name: github.Ptr("production"),
+							DeploymentBranchPolicy: &github.BranchPolicy{
+								ProtectedBranches:    github.Ptr(true),
+								CustomBranchPolicies: github.Ptr(false),
+							},
+						})(w, r)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "qa",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get environment",
+		},
+		{
+			name:         "missing required parameter environment_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: environment_name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.Environment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "production", response.GetName())
+			assert.True(t, response.GetDeploymentBranchPolicy().GetProtectedBranches())
+		})
+	}
+}
+
+func Test_CreateOrUpdateEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "environment with protection rules",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer": float64(30),
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(1)},
+							map[string]any{"type": "Team", "id": float64(42)},
+						},
+						"prevent_self_review": true,
+						"can_admins_bypass":   false,
+						"deployment_branch_policy": map[string]any{
+							"protected_branches":     true,
+							"custom_branch_policies": false,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Environment{Name: github.Ptr("production")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"wait_timer":       float64(30),
+				"reviewers": []any{
+					map[string]any{"type": "User", "id": float64(1)},
+					map[string]any{"type": "Team", "id": float64(42)},
+				},
+				"prevent_self_review": true,
+				"can_admins_bypass":   false,
+				"protected_branches":  true,
+			},
+		},
+		{
+			name: "environment without protection rules",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer":               float64(0),
+						"reviewers":                nil,
+						"can_admins_bypass":        true,
+						"deployment_branch_policy": nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Environment{Name: github.Ptr("production")}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+		},
+		{
+			name: "creation rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create or update environment",
+		},
+		{
+			name:         "invalid reviewer type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"reviewers": []any{
+					map[string]any{"type": "Bot", "id": float64(1)},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid reviewer type: Bot",
+		},
+		{
+			name:         "invalid reviewer id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"reviewers": []any{
+					map[string]any{"type": "User"},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid reviewer id",
+		},
+		{
+			name:         "wait timer too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"wait_timer":       float64(50000),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "wait_timer must be between 0 and 43200 minutes",
+		},
+		{
+			name:         "conflicting branch policies",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"environment_name":       "production",
+				"protected_branches":     true,
+				"custom_branch_policies": true,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "only one of protected_branches and custom_branch_policies can be true",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.Environment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "production", response.GetName())
+		})
+	}
+}
+
+func Test_DeleteEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "environment deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "staging",
+			},
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "staging",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete environment",
+		},
+		{
+			name:         "missing required parameter environment_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: environment_name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteEnvironment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Environment has been deleted", response["message"])
+			assert.Equal(t, "staging", response["environment_name"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
+
+func Test_ListDeploymentBranchPolicies(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDeploymentBranchPolicies(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployment_branch_policies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful policies listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusOK, &github.DeploymentBranchPolicyResponse{
+						TotalCount: github.Ptr(2),
+						BranchPolicies: []*github.DeploymentBranchPolicy{
+							{ID: github.Ptr(int64(1)), Name: github.Ptr("main"), Type: github.Ptr("branch")},
+							{ID: github.Ptr(int64(2)), Name: github.Ptr("release/*"), Type: github.Ptr("branch")},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list deployment branch policies",
+		},
+		{
+			name:         "missing required parameter environment_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: environment_name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListDeploymentBranchPolicies(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.DeploymentBranchPolicyResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 2, response.GetTotalCount())
+			require.Len(t, response.BranchPolicies, 2)
+			assert.Equal(t, "release/*", response.BranchPolicies[1].GetName())
+		})
+	}
+}
+
+// mockEnvironmentBranchPolicy serves an environment that uses custom deployment branch policies or not.
+func mockEnvironmentBranchPolicy(t *testing.T, customBranchPolicies bool) mock.MockBackendOption {
+	return mock.WithRequestMatchHandler(
+		mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+		mockResponse(t, http.StatusOK, &github.Environment{
+			Name: github.Ptr("production"),
+			DeploymentBranchPolicy: &github.BranchPolicy{
+				ProtectedBranches:    github.Ptr(!customBranchPolicies),
+				CustomBranchPolicies: github.Ptr(customBranchPolicies),
+			},
+		}),
+	)
+}
+
+func Test_CreateDeploymentBranchPolicy(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateDeploymentBranchPolicy(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_deployment_branch_policy", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name", "name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedType    string
+	}{
+		{
+			name: "wildcard branch pattern",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockEnvironmentBranchPolicy(t, true),
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"name": "release/*",
+						"type": "branch",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.DeploymentBranchPolicy{
+							ID:   github.Ptr(int64(42)),
+							Name: github.Ptr("release/*"),
+							Type: github.Ptr("branch"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "release/*",
+			},
+			expectedType: "branch",
+		},
+		{
+			name: "tag pattern",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockEnvironmentBranchPolicy(t, true),
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"name": "v*",
+						"type": "tag",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.DeploymentBranchPolicy{
+							ID:   github.Ptr(int64(42)),
+							Name: github.Ptr("v*"),
+							Type: github.Ptr("tag"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "v*",
+				"type":             "tag",
+			},
+			expectedType: "tag",
+		},
+		{
+			name: "custom branch policies disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockEnvironmentBranchPolicy(t, false),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "release/*",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "environment production does not use custom deployment branch policies",
+		},
+		{
+			name: "policy rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockEnvironmentBranchPolicy(t, true),
+				mock.WithRequestMatchHandler(
+					mock.PostReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "DeploymentBranchPolicy", "code": "custom", "message": "Name already exists"}]}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "release/*",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "GitHub rejected the branch policy release/*: Validation Failed (Name already exists)",
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "release/*",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get environment",
+		},
+		{
+			name:         "invalid type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "release/*",
+				"type":             "commit",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid type: commit",
+		},
+		{
+			name:         "missing required parameter name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateDeploymentBranchPolicy(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.DeploymentBranchPolicy
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(42), response.GetID())
+			assert.Equal(t, tc.requestArgs["name"], response.GetName())
+			assert.Equal(t, tc.expectedType, response.GetType())
+		})
+	}
+}
+
+func Test_DeleteDeploymentBranchPolicy(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteDeploymentBranchPolicy(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_deployment_branch_policy", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "environment_name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	listPolicies := mock.WithRequestMatchHandler(
+		mock.GetReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentName,
+		mockResponse(t, http.StatusOK, &github.DeploymentBranchPolicyResponse{
+			TotalCount: github.Ptr(2),
+			BranchPolicies: []*github.DeploymentBranchPolicy{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("main")},
+				{ID: github.Ptr(int64(42)), Name: github.Ptr("release/*")},
+			},
+		}),
+	)
+	deletePolicy := mock.WithRequestMatchHandler(
+		mock.DeleteReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentNameByBranchPolicyId,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/environments/production/deployment-branch-policies/42", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name:         "delete by id",
+			mockedClient: mock.NewMockedHTTPClient(deletePolicy),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"branch_policy_id": float64(42),
+			},
+		},
+		{
+			name:         "delete by wildcard name",
+			mockedClient: mock.NewMockedHTTPClient(listPolicies, deletePolicy),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "release/*",
+			},
+		},
+		{
+			name:         "name not found",
+			mockedClient: mock.NewMockedHTTPClient(listPolicies),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"name":             "hotfix/*",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "no deployment branch policy named hotfix/* in environment production",
+		},
+		{
+			name: "policy not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposEnvironmentsDeploymentBranchPoliciesByOwnerByRepoByEnvironmentNameByBranchPolicyId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+				"branch_policy_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete deployment branch policy",
+		},
+		{
+			name:         "neither id nor name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"environment_name": "production",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "either branch_policy_id or name must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteDeploymentBranchPolicy(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Deployment branch policy has been deleted", response["message"])
+			assert.Equal(t, float64(42), response["branch_policy_id"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
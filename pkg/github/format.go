@@ -0,0 +1,87 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// OutputFormatJSON returns the GitHub API response as JSON, it is the default.
+	OutputFormatJSON = "json"
+	// OutputFormatMarkdown returns a compact markdown summary meant to be shown to users.
+	OutputFormatMarkdown = "markdown"
+)
+
+// WithOutputFormat returns a ToolOption that adds the "format" parameter to tools that can render
+// their result as markdown.
+func WithOutputFormat() mcp.ToolOption {
+	return mcp.WithString("format",
+		mcp.Description("Output format: json (default) for the full API response, or markdown for a compact summary to show to users"),
+		mcp.Enum(OutputFormatJSON, OutputFormatMarkdown),
+	)
+}
+
+// OptionalOutputFormat returns the "format" parameter of the request, OutputFormatJSON if it is not set.
+func OptionalOutputFormat(r mcp.CallToolRequest) (string, error) {
+	format, err := OptionalParam[string](r, "format")
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case "", OutputFormatJSON:
+		return OutputFormatJSON, nil
+	case OutputFormatMarkdown:
+		return OutputFormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("invalid format: %s, must be %s or %s", format, OutputFormatJSON, OutputFormatMarkdown)
+	}
+}
+
+// markdownTable renders a markdown table. Cells are escaped, so they can hold any text.
+func markdownTable(headers []string, rows [][]string) string {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			fmt.Fprintf(&b, " %s |", escapeMarkdownCell(cell))
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(headers)
+	b.WriteString("|")
+	for range headers {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+// escapeMarkdownCell keeps a value on one line and stops its pipes from ending the table cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// formatListTime formats a timestamp for markdown lists, to the minute.
+func formatListTime(t github.Timestamp) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04")
+}
+
+// orDash returns s, or "-" for an empty value so that table cells are never blank.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
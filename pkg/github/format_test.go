@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OptionalOutputFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]any
+		expected    string
+		expectError string
+	}{
+		{name: "default", params: map[string]any{}, expected: OutputFormatJSON},
+		{name: "empty", params: map[string]any{"format": ""}, expected: OutputFormatJSON},
+		{name: "json", params: map[string]any{"format": "json"}, expected: OutputFormatJSON},
+		{name: "markdown", params: map[string]any{"format": "markdown"}, expected: OutputFormatMarkdown},
+		{name: "unknown", params: map[string]any{"format": "html"}, expectError: "invalid format: html, must be json or markdown"},
+		{name: "wrong type", params: map[string]any{"format": float64(1)}, expectError: "parameter format is not of type string"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := OptionalOutputFormat(createMCPRequest(tc.params))
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, format)
+		})
+	}
+}
+
+func Test_markdownTable(t *testing.T) {
+	expected := "| Name | Value |\n" +
+		"| --- | --- |\n" +
+		"| a\\|b | line one line two |\n"
+	assert.Equal(t, expected, markdownTable([]string{"Name", "Value"}, [][]string{{"a|b", "line one\r\nline two"}}))
+}
+
+func Test_ListIssues_MarkdownFormat(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepo,
+			[]*github.Issue{
+				{
+					Number: github.Ptr(1),
+					Title:  github.Ptr("First issue"),
+					State:  github.Ptr("open"),
+					User:   &github.User{Login: github.Ptr("octocat")},
+				},
+			},
+		),
+	)
+	_, handler := ListIssues(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"format": "markdown",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	expected := "| # | Title | State | Author | Labels | Updated |\n" +
+		"| --- | --- | --- | --- | --- | --- |\n" +
+		"| #1 | First issue | open | @octocat | - | - |\n"
+	assert.Equal(t, expected, getTextResult(t, result).Text)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":  "owner",
+		"repo":   "repo",
+		"format": "xml",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "invalid format: xml")
+}
@@ -0,0 +1,259 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// GQLErrorKind classifies the errors returned by the GitHub GraphQL API.
+type GQLErrorKind string
+
+const (
+	GQLErrorNotFound    GQLErrorKind = "NOT_FOUND"
+	GQLErrorForbidden   GQLErrorKind = "FORBIDDEN"
+	GQLErrorRateLimited GQLErrorKind = "RATE_LIMITED"
+	GQLErrorUnknown     GQLErrorKind = "UNKNOWN"
+)
+
+const (
+	// defaultGQLRetryAfter is the wait before retrying a secondary rate limit when no Retry-After is sent
+	defaultGQLRetryAfter = 5 * time.Second
+	// maxGQLRetryAfter is the longest wait honoured before retrying, longer waits are returned to the caller
+	maxGQLRetryAfter = 30 * time.Second
+)
+
+// GQLRateLimit is the GraphQL rate limit status reported alongside a query.
+type GQLRateLimit struct {
+	Remaining int
+	ResetAt   time.Time
+}
+
+// GQLError is a classified GitHub GraphQL API error.
+type GQLError struct {
+	Kind GQLErrorKind
+	// RetryAfter is the wait requested by the API, if the error is a rate limit
+	RetryAfter time.Duration
+	// RateLimit is set when the query asked for the rate limit status
+	RateLimit *GQLRateLimit
+	Err       error
+}
+
+func (e *GQLError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Kind, e.Err)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	if e.RateLimit != nil {
+		msg += fmt.Sprintf(" (rate limit remaining: %d, resets at %s)", e.RateLimit.Remaining, e.RateLimit.ResetAt.UTC().Format(time.RFC3339))
+	}
+	return msg
+}
+
+func (e *GQLError) Unwrap() error {
+	return e.Err
+}
+
+// GQLClient wraps the GraphQL client returned by a GetGQLClientFn. Errors are classified as
+// GQLError, and a request that hits a secondary rate limit is retried once.
+type GQLClient struct {
+	getClient GetGQLClientFn
+	// wait blocks for the duration or until the context is done, it is replaced in tests
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+// NewGQLClient creates a GQLClient around getClient.
+func NewGQLClient(getClient GetGQLClientFn) *GQLClient {
+	return &GQLClient{
+		getClient: getClient,
+		wait:      waitContext,
+	}
+}
+
+// Query executes the GraphQL query q with the variables.
+func (c *GQLClient) Query(ctx context.Context, q any, variables map[string]any) error {
+	return c.do(ctx, func(ctx context.Context, client *githubv4.Client) error {
+		return client.Query(ctx, q, variables)
+	}, nil)
+}
+
+// QueryWithRateLimit executes the GraphQL query q with the variables, also selecting the
+// rateLimit { remaining resetAt } field. The rate limit is returned, and attached to any GQLError,
+// so that handlers can report it.
+func (c *GQLClient) QueryWithRateLimit(ctx context.Context, q any, variables map[string]any) (*GQLRateLimit, error) {
+	target := reflect.ValueOf(q)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query must be a pointer to a struct, got %T", q)
+	}
+
+	// Select the query's fields through an inline fragment, next to the rate limit field,
+	// and copy the result back once the query has completed.
+	wrapped := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Query", Type: target.Elem().Type(), Tag: `graphql:"... on Query"`},
+		{Name: "RateLimit", Type: reflect.TypeOf(gqlRateLimitField{}), Tag: `graphql:"rateLimit"`},
+	}))
+
+	var rateLimit *GQLRateLimit
+	err := c.do(ctx, func(ctx context.Context, client *githubv4.Client) error {
+		err := client.Query(ctx, wrapped.Interface(), variables)
+		rateLimit = wrapped.Elem().Field(1).Interface().(gqlRateLimitField).toRateLimit()
+		return err
+	}, func() *GQLRateLimit { return rateLimit })
+	target.Elem().Set(wrapped.Elem().Field(0))
+
+	return rateLimit, err
+}
+
+// Mutate executes the GraphQL mutation m with the input and variables.
+func (c *GQLClient) Mutate(ctx context.Context, m any, input githubv4.Input, variables map[string]any) error {
+	return c.do(ctx, func(ctx context.Context, client *githubv4.Client) error {
+		return client.Mutate(ctx, m, input, variables)
+	}, nil)
+}
+
+func (c *GQLClient) do(ctx context.Context, op func(context.Context, *githubv4.Client) error, rateLimit func() *GQLRateLimit) error {
+	client, err := c.getClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		recorder := &gqlResponseRecorder{}
+		err := op(context.WithValue(ctx, gqlResponseRecorderKey{}, recorder), client)
+		if err == nil {
+			return nil
+		}
+
+		gqlErr := classifyGQLError(err, recorder)
+		if rateLimit != nil {
+			gqlErr.RateLimit = rateLimit()
+		}
+
+		if attempt > 0 || !recorder.secondaryRateLimit(err) || gqlErr.RetryAfter > maxGQLRetryAfter {
+			return gqlErr
+		}
+
+		wait := gqlErr.RetryAfter
+		if wait == 0 {
+			wait = defaultGQLRetryAfter
+		}
+		if err := c.wait(ctx, wait); err != nil {
+			return gqlErr
+		}
+	}
+}
+
+// classifyGQLError maps the error of a GraphQL request to a GQLError. The githubv4 client does not
+// expose the type of GraphQL errors, so they are classified by their status code and message.
+func classifyGQLError(err error, recorder *gqlResponseRecorder) *GQLError {
+	gqlErr := &GQLError{Kind: GQLErrorUnknown, Err: err}
+	status, header := recorder.response(err)
+	msg := strings.ToLower(err.Error())
+
+	switch {
+	case status == http.StatusTooManyRequests,
+		strings.Contains(msg, "rate limit"),
+		status == http.StatusForbidden && header.Get("X-RateLimit-Remaining") == "0":
+		gqlErr.Kind = GQLErrorRateLimited
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+			gqlErr.RetryAfter = time.Duration(seconds) * time.Second
+		}
+	case status == http.StatusNotFound, strings.Contains(msg, "could not resolve to"):
+		gqlErr.Kind = GQLErrorNotFound
+	case status == http.StatusForbidden, status == http.StatusUnauthorized,
+		strings.Contains(msg, "resource not accessible"),
+		strings.Contains(msg, "does not have permission"):
+		gqlErr.Kind = GQLErrorForbidden
+	}
+
+	return gqlErr
+}
+
+type gqlRateLimitField struct {
+	Remaining githubv4.Int
+	ResetAt   githubv4.DateTime
+}
+
+func (f gqlRateLimitField) toRateLimit() *GQLRateLimit {
+	if f.ResetAt.IsZero() {
+		return nil
+	}
+	return &GQLRateLimit{
+		Remaining: int(f.Remaining),
+		ResetAt:   f.ResetAt.Time,
+	}
+}
+
+type gqlResponseRecorderKey struct{}
+
+// gqlResponseRecorder keeps the status and headers of the last GraphQL response for a request,
+// because the githubv4 client only exposes the response body in its errors.
+type gqlResponseRecorder struct {
+	mu     sync.Mutex
+	status int
+	header http.Header
+}
+
+// response returns the recorded status and headers. When the transport did not record the response,
+// the status is taken from the error of the githubv4 client, and the headers are empty.
+func (r *gqlResponseRecorder) response(err error) (int, http.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.header != nil {
+		return r.status, r.header
+	}
+
+	var status int
+	if rest, ok := strings.CutPrefix(err.Error(), "non-200 OK status code: "); ok {
+		status, _ = strconv.Atoi(strings.SplitN(rest, " ", 2)[0])
+	}
+	return status, http.Header{}
+}
+
+// secondaryRateLimit reports whether the request was rejected by a secondary rate limit, which is
+// a 403 or 429 that still has primary rate limit remaining.
+func (r *gqlResponseRecorder) secondaryRateLimit(err error) bool {
+	status, header := r.response(err)
+	if status != http.StatusForbidden && status != http.StatusTooManyRequests {
+		return false
+	}
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		return false
+	}
+	return header.Get("Retry-After") != "" || strings.Contains(strings.ToLower(err.Error()), "secondary rate limit")
+}
+
+// GQLResponseTransport records the status and headers of GraphQL responses so that GQLClient can
+// classify errors and honour Retry-After. Wrap the transport of the GraphQL HTTP client with it.
+type GQLResponseTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *GQLResponseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+	if recorder, ok := req.Context().Value(gqlResponseRecorderKey{}).(*gqlResponseRecorder); ok && err == nil {
+		recorder.mu.Lock()
+		recorder.status = resp.StatusCode
+		recorder.header = resp.Header.Clone()
+		recorder.mu.Unlock()
+	}
+	return resp, err
+}
+
+func waitContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,199 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repositoryIDQuery struct {
+	Repository struct {
+		ID githubv4.ID
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+var repositoryIDVariables = map[string]any{
+	"owner": githubv4.String("owner"),
+	"repo":  githubv4.String("repo"),
+}
+
+// sequenceTransport replies to each request with the next response in the sequence.
+type sequenceTransport struct {
+	responses []func() *http.Response
+	requests  int
+}
+
+func (t *sequenceTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	resp := t.responses[t.requests]()
+	t.requests++
+	return resp, nil
+}
+
+func gqlHTTPResponse(status int, header http.Header, body string) func() *http.Response {
+	return func() *http.Response {
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+}
+
+func newSequenceGQLClient(transport *sequenceTransport) *GQLClient {
+	httpClient := &http.Client{Transport: &GQLResponseTransport{Transport: transport}}
+	return NewGQLClient(stubGetGQLClientFn(githubv4.NewClient(httpClient)))
+}
+
+func Test_GQLClient_ClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		httpClient   *http.Client
+		expectedKind GQLErrorKind
+	}{
+		{
+			name: "not found",
+			httpClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(repositoryIDQuery{}, repositoryIDVariables,
+					githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'owner/repo'."),
+				),
+			),
+			expectedKind: GQLErrorNotFound,
+		},
+		{
+			name: "forbidden",
+			httpClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(repositoryIDQuery{}, repositoryIDVariables,
+					githubv4mock.ErrorResponse("Resource not accessible by integration"),
+				),
+			),
+			expectedKind: GQLErrorForbidden,
+		},
+		{
+			name: "rate limited",
+			httpClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(repositoryIDQuery{}, repositoryIDVariables,
+					githubv4mock.ErrorResponse("API rate limit exceeded for user ID 1."),
+				),
+			),
+			expectedKind: GQLErrorRateLimited,
+		},
+		{
+			name: "unknown",
+			httpClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(repositoryIDQuery{}, repositoryIDVariables,
+					githubv4mock.ErrorResponse("something went wrong"),
+				),
+			),
+			expectedKind: GQLErrorUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewGQLClient(stubGetGQLClientFn(githubv4.NewClient(tc.httpClient)))
+
+			var q repositoryIDQuery
+			err := client.Query(context.Background(), &q, repositoryIDVariables)
+
+			var gqlErr *GQLError
+			require.ErrorAs(t, err, &gqlErr)
+			assert.Equal(t, tc.expectedKind, gqlErr.Kind)
+		})
+	}
+}
+
+func Test_GQLClient_QueryWithRateLimit(t *testing.T) {
+	resetAt := time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC)
+	expectedQuery := struct {
+		Query     repositoryIDQuery `graphql:"... on Query"`
+		RateLimit struct {
+			Remaining githubv4.Int
+			ResetAt   githubv4.DateTime
+		} `graphql:"rateLimit"`
+	}{}
+
+	httpClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(expectedQuery, repositoryIDVariables,
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"id": "R_1"},
+				"rateLimit":  map[string]any{"remaining": 4999, "resetAt": resetAt.Format(time.RFC3339)},
+			}),
+		),
+	)
+	client := NewGQLClient(stubGetGQLClientFn(githubv4.NewClient(httpClient)))
+
+	var q repositoryIDQuery
+	rateLimit, err := client.QueryWithRateLimit(context.Background(), &q, repositoryIDVariables)
+	require.NoError(t, err)
+	assert.Equal(t, githubv4.ID("R_1"), q.Repository.ID)
+	require.NotNil(t, rateLimit)
+	assert.Equal(t, 4999, rateLimit.Remaining)
+	assert.True(t, resetAt.Equal(rateLimit.ResetAt))
+
+	_, err = client.QueryWithRateLimit(context.Background(), q, repositoryIDVariables)
+	require.Error(t, err)
+}
+
+func Test_GQLClient_RetriesSecondaryRateLimitOnce(t *testing.T) {
+	secondaryRateLimit := gqlHTTPResponse(http.StatusForbidden, http.Header{"Retry-After": []string{"2"}},
+		`{"message": "You have exceeded a secondary rate limit."}`)
+	success := gqlHTTPResponse(http.StatusOK, nil, `{"data": {"repository": {"id": "R_1"}}}`)
+
+	t.Run("retries after Retry-After", func(t *testing.T) {
+		transport := &sequenceTransport{responses: []func() *http.Response{secondaryRateLimit, success}}
+		client := newSequenceGQLClient(transport)
+		var waits []time.Duration
+		client.wait = func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}
+
+		var q repositoryIDQuery
+		require.NoError(t, client.Query(context.Background(), &q, repositoryIDVariables))
+		assert.Equal(t, githubv4.ID("R_1"), q.Repository.ID)
+		assert.Equal(t, []time.Duration{2 * time.Second}, waits)
+		assert.Equal(t, 2, transport.requests)
+	})
+
+	t.Run("gives up after one retry", func(t *testing.T) {
+		transport := &sequenceTransport{responses: []func() *http.Response{secondaryRateLimit, secondaryRateLimit}}
+		client := newSequenceGQLClient(transport)
+		client.wait = func(context.Context, time.Duration) error { return nil }
+
+		var q repositoryIDQuery
+		err := client.Query(context.Background(), &q, repositoryIDVariables)
+
+		var gqlErr *GQLError
+		require.ErrorAs(t, err, &gqlErr)
+		assert.Equal(t, GQLErrorRateLimited, gqlErr.Kind)
+		assert.Equal(t, 2*time.Second, gqlErr.RetryAfter)
+		assert.Equal(t, 2, transport.requests)
+	})
+
+	t.Run("does not retry a forbidden request", func(t *testing.T) {
+		transport := &sequenceTransport{responses: []func() *http.Response{
+			gqlHTTPResponse(http.StatusForbidden, nil, `{"message": "Must have admin rights to Repository."}`),
+		}}
+		client := newSequenceGQLClient(transport)
+
+		var q repositoryIDQuery
+		err := client.Query(context.Background(), &q, repositoryIDVariables)
+
+		var gqlErr *GQLError
+		require.ErrorAs(t, err, &gqlErr)
+		assert.Equal(t, GQLErrorForbidden, gqlErr.Kind)
+		assert.Equal(t, 1, transport.requests)
+	})
+}
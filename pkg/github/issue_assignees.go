@@ -0,0 +1,230 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// loginPattern matches GitHub user logins: letters, numbers and single hyphens, not starting or ending
+// with a hyphen. The length is checked separately, logins have at most 39 characters.
+var loginPattern = regexp.MustCompile(`^[a-zA-Z0-9](?:-?[a-zA-Z0-9])*$`)
+
+// normalizeLogins strips the leading '@' of logins and deduplicates them case-insensitively, keeping
+// their order, and returns the logins that can't be GitHub users separately.
+func normalizeLogins(logins []string) (normalized []string, invalid []string) {
+	normalized = make([]string, 0, len(logins))
+	for _, original := range logins {
+		login := strings.TrimPrefix(strings.TrimSpace(original), "@")
+		if len(login) > 39 || !loginPattern.MatchString(login) {
+			invalid = append(invalid, fmt.Sprintf("%q", original))
+			continue
+		}
+		if !slices.ContainsFunc(normalized, func(l string) bool { return strings.EqualFold(l, login) }) {
+			normalized = append(normalized, login)
+		}
+	}
+	return normalized, invalid
+}
+
+// assigneeLogins returns the logins of the assignees of an issue, never nil so that it marshals as an
+// empty array
+func assigneeLogins(issue *github.Issue) []string {
+	logins := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		logins = append(logins, assignee.GetLogin())
+	}
+	return logins
+}
+
+// containsLogin reports whether logins contains login, ignoring case like GitHub does
+func containsLogin(logins []string, login string) bool {
+	return slices.ContainsFunc(logins, func(l string) bool { return strings.EqualFold(l, login) })
+}
+
+// AddAssigneesToIssue creates a tool to add assignees to an issue
+func AddAssigneesToIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_assignees_to_issue",
+			mcp.WithDescription(t("TOOL_ADD_ASSIGNEES_TO_ISSUE_DESCRIPTION", "Add assignees to an issue or pull request, keeping the ones it already has. Users who can't be assigned, such as users without access to the repository, are silently ignored by GitHub and reported as ignored")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_ASSIGNEES_TO_ISSUE_USER_TITLE", "Add assignees to issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to add the assignees to"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description("Logins of the users to assign, at most 10 assignees in total"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, invalid := normalizeLogins(assignees)
+			if len(invalid) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid logins: %s", strings.Join(invalid, ", "))), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("assignees must contain at least one login"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.AddAssignees(ctx, owner, repo, issueNumber, assignees)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add assignees to issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The API answers 201 even when some users couldn't be assigned, so they are found by
+			// comparing the requested assignees with the resulting ones
+			result := assigneeLogins(issue)
+			ignored := make([]string, 0)
+			for _, login := range assignees {
+				if !containsLogin(result, login) {
+					ignored = append(ignored, login)
+				}
+			}
+
+			response := map[string]any{
+				"issue_number": issueNumber,
+				"assignees":    result,
+				"ignored":      ignored,
+			}
+			if len(ignored) > 0 {
+				response["message"] = "some users were not assigned, they may not exist, not have access to the repository, or the issue may already have 10 assignees"
+			}
+
+			r, err := json.Marshal(response)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RemoveAssigneesFromIssue creates a tool to remove assignees from an issue
+func RemoveAssigneesFromIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_assignees_from_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_ASSIGNEES_FROM_ISSUE_DESCRIPTION", "Remove assignees from an issue or pull request, keeping its other assignees")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_ASSIGNEES_FROM_ISSUE_USER_TITLE", "Remove assignees from issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to remove the assignees from"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description("Logins of the users to unassign"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, invalid := normalizeLogins(assignees)
+			if len(invalid) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid logins: %s", strings.Join(invalid, ", "))), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("assignees must contain at least one login"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.RemoveAssignees(ctx, owner, repo, issueNumber, assignees)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove assignees from issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := assigneeLogins(issue)
+			notRemoved := make([]string, 0)
+			for _, login := range assignees {
+				if containsLogin(result, login) {
+					notRemoved = append(notRemoved, login)
+				}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number": issueNumber,
+				"assignees":    result,
+				"not_removed":  notRemoved,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
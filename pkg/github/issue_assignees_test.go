@@ -0,0 +1,261 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_normalizeLogins(t *testing.T) {
+	normalized, invalid := normalizeLogins([]string{
+		"octocat",
+		"@monalisa",
+		"Octocat",
+		"hubot-2",
+		"-leading",
+		"trailing-",
+		"double--hyphen",
+		"with space",
+		"abcdefghijabcdefghijabcdefghijabcdefghij",
+	})
+	assert.Equal(t, []string{"octocat", "monalisa", "hubot-2"}, normalized)
+	assert.Equal(t, []string{
+		`"-leading"`,
+		`"trailing-"`,
+		`"double--hyphen"`,
+		`"with space"`,
+		`"abcdefghijabcdefghijabcdefghijabcdefghij"`,
+	}, invalid)
+}
+
+func Test_AddAssigneesToIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddAssigneesToIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_assignees_to_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		assignees         []any
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedAssignees []string
+		expectedIgnored   []string
+	}{
+		{
+			name: "all users assigned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/assignees").andThen(
+						expectRequestBody(t, map[string]any{
+							"assignees": []any{"octocat", "monalisa"},
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.Issue{
+								Number: github.Ptr(42),
+								Assignees: []*github.User{
+									{Login: github.Ptr("hubot")},
+									{Login: github.Ptr("octocat")},
+									{Login: github.Ptr("monalisa")},
+								},
+							}),
+						),
+					),
+				),
+			),
+			assignees:         []any{"octocat", "@monalisa"},
+			expectedAssignees: []string{"hubot", "octocat", "monalisa"},
+			expectedIgnored:   []string{},
+		},
+		{
+			name: "users without access are ignored",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusCreated, &github.Issue{
+						Number:    github.Ptr(42),
+						Assignees: []*github.User{{Login: github.Ptr("octocat")}},
+					}),
+				),
+			),
+			assignees:         []any{"OctoCat", "outsider"},
+			expectedAssignees: []string{"octocat"},
+			expectedIgnored:   []string{"outsider"},
+		},
+		{
+			name:            "invalid login",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			assignees:       []any{"octocat", "not a login"},
+			expectToolError: true,
+			expectedErrMsg:  `invalid logins: "not a login"`,
+		},
+		{
+			name:            "no assignees",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			assignees:       []any{},
+			expectToolError: true,
+			expectedErrMsg:  "assignees must contain at least one login",
+		},
+		{
+			name: "adding fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			assignees:      []any{"octocat"},
+			expectError:    true,
+			expectedErrMsg: "failed to add assignees to issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddAssigneesToIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    tc.assignees,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				Assignees []string `json:"assignees"`
+				Ignored   []string `json:"ignored"`
+				Message   string   `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedAssignees, response.Assignees)
+			assert.Equal(t, tc.expectedIgnored, response.Ignored)
+			if len(tc.expectedIgnored) > 0 {
+				assert.Contains(t, response.Message, "some users were not assigned")
+			} else {
+				assert.Empty(t, response.Message)
+			}
+		})
+	}
+}
+
+func Test_RemoveAssigneesFromIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveAssigneesFromIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_assignees_from_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectError        bool
+		expectedErrMsg     string
+		expectedAssignees  []string
+		expectedNotRemoved []string
+	}{
+		{
+			name: "remove assignees",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"assignees": []any{"octocat"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:    github.Ptr(42),
+							Assignees: []*github.User{{Login: github.Ptr("monalisa")}},
+						}),
+					),
+				),
+			),
+			expectedAssignees:  []string{"monalisa"},
+			expectedNotRemoved: []string{},
+		},
+		{
+			name: "assignee still assigned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, &github.Issue{
+						Number:    github.Ptr(42),
+						Assignees: []*github.User{{Login: github.Ptr("octocat")}},
+					}),
+				),
+			),
+			expectedAssignees:  []string{"octocat"},
+			expectedNotRemoved: []string{"octocat"},
+		},
+		{
+			name: "removing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to remove assignees from issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveAssigneesFromIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []any{"octocat"},
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Assignees  []string `json:"assignees"`
+				NotRemoved []string `json:"not_removed"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedAssignees, response.Assignees)
+			assert.Equal(t, tc.expectedNotRemoved, response.NotRemoved)
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// labelColorPattern matches the label colors GitHub accepts: 6 hexadecimal digits, without a leading '#'
+var labelColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// issueLabel is a label of a repository, without the API URLs and node IDs
+type issueLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+	Default     bool   `json:"default"`
+}
+
+func newIssueLabel(label *github.Label) issueLabel {
+	return issueLabel{
+		Name:        label.GetName(),
+		Color:       label.GetColor(),
+		Description: label.GetDescription(),
+		Default:     label.GetDefault(),
+	}
+}
+
+// labelNames returns the names of labels, never nil so that it marshals as an empty array
+func labelNames(labels []*github.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+// normalizeLabelColor strips the leading '#' of a label color, as it's commonly written in CSS, and reports
+// whether the color is one GitHub accepts.
+func normalizeLabelColor(color string) (string, bool) {
+	color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+	return color, labelColorPattern.MatchString(color)
+}
+
+// ListRepositoryLabels creates a tool to list the labels of a repository
+func ListRepositoryLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_labels",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_LABELS_DESCRIPTION", "List the labels of a GitHub repository, to find the label names that can be added to issues and pull requests")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_LABELS_USER_TITLE", "List repository labels"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			labels, resp, err := client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository labels: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]issueLabel, 0, len(labels))
+			for _, label := range labels {
+				result = append(result, newIssueLabel(label))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddLabelsToIssue creates a tool to add labels to an issue
+func AddLabelsToIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_labels_to_issue",
+			mcp.WithDescription(t("TOOL_ADD_LABELS_TO_ISSUE_DESCRIPTION", "Add labels to an issue or pull request, keeping the labels it already has. Use list_repository_labels to find the existing label names")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_LABELS_TO_ISSUE_USER_TITLE", "Add labels to issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to add the labels to"),
+			),
+			mcp.WithArray("labels",
+				mcp.Required(),
+				mcp.Description("Names of the labels to add"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := OptionalStringArrayParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(labels) == 0 {
+				return mcp.NewToolResultError("labels must contain at least one label"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issueLabels, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issueNumber, labels)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add labels to issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number": issueNumber,
+				"labels":       labelNames(issueLabels),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RemoveLabelFromIssue creates a tool to remove a label from an issue
+func RemoveLabelFromIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_label_from_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_LABEL_FROM_ISSUE_DESCRIPTION", "Remove a label from an issue or pull request, keeping its other labels")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_LABEL_FROM_ISSUE_USER_TITLE", "Remove label from issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to remove the label from"),
+			),
+			mcp.WithString("label",
+				mcp.Required(),
+				mcp.Description("Name of the label to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := RequiredParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label = strings.TrimSpace(label)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, issueNumber, label)
+			if err != nil {
+				// The API answers 404 when the issue doesn't have the label
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("label %q is not on issue #%d in %s/%s", label, issueNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to remove label from issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number": issueNumber,
+				"removed":      label,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateLabel creates a tool to create a label in a repository
+func CreateLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_label",
+			mcp.WithDescription(t("TOOL_CREATE_LABEL_DESCRIPTION", "Create a label in a GitHub repository, which can then be added to its issues and pull requests")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_LABEL_USER_TITLE", "Create label"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the label, emoji can be added with their :codes:"),
+			),
+			mcp.WithString("color",
+				mcp.Required(),
+				mcp.Description("Color of the label as 6 hexadecimal digits without a leading '#', such as 'd73a4a'"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the label, at most 100 characters"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			color, err := RequiredParam[string](request, "color")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			color, ok := normalizeLabelColor(color)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid color: %s, must be 6 hexadecimal digits such as 'd73a4a'", color)), nil
+			}
+
+			label := &github.Label{
+				Name:  github.Ptr(name),
+				Color: github.Ptr(color),
+			}
+			if description != "" {
+				label.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Issues.CreateLabel(ctx, owner, repo, label)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create label %q in %s/%s, a label with the same name may already exist: %s", name, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newIssueLabel(created))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateLabel creates a tool to rename, recolor or re-describe a label of a repository
+func UpdateLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_label",
+			mcp.WithDescription(t("TOOL_UPDATE_LABEL_DESCRIPTION", "Update a label of a GitHub repository. Renaming a label keeps it on the issues and pull requests that have it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_LABEL_USER_TITLE", "Update label"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Current name of the label"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New name of the label"),
+			),
+			mcp.WithString("color",
+				mcp.Description("New color of the label as 6 hexadecimal digits without a leading '#', such as 'd73a4a'"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description of the label, at most 100 characters. An empty string removes the description"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			color, err := OptionalParam[string](request, "color")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasDescription := request.GetArguments()["description"]
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			label := &github.Label{}
+			if newName != "" {
+				label.Name = github.Ptr(newName)
+			}
+			if color != "" {
+				normalized, ok := normalizeLabelColor(color)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid color: %s, must be 6 hexadecimal digits such as 'd73a4a'", normalized)), nil
+				}
+				label.Color = github.Ptr(normalized)
+			}
+			if hasDescription {
+				label.Description = github.Ptr(description)
+			}
+			if label.Name == nil && label.Color == nil && label.Description == nil {
+				return mcp.NewToolResultError("at least one of new_name, color or description must be set"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updated, resp, err := client.Issues.EditLabel(ctx, owner, repo, name, label)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("label %q not found in %s/%s", name, owner, repo)), nil
+				}
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot update label %q in %s/%s, a label named %q may already exist: %s", name, owner, repo, newName, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to update label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newIssueLabel(updated))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteLabel creates a tool to delete a label of a repository
+func DeleteLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_label",
+			mcp.WithDescription(t("TOOL_DELETE_LABEL_DESCRIPTION", "Delete a label of a GitHub repository. The label is also removed from all the issues and pull requests that have it, and this cannot be undone")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_LABEL_USER_TITLE", "Delete label"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the label to delete"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.DeleteLabel(ctx, owner, repo, name)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("label %q not found in %s/%s", name, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to delete label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":     "Label has been deleted",
+				"name":        name,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,685 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedLabels []issueLabel
+	}{
+		{
+			name: "labels of a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{
+							{
+								ID:          github.Ptr(int64(1)),
+								Name:        github.Ptr("bug"),
+								Color:       github.Ptr("d73a4a"),
+								Description: github.Ptr("Something isn't working"),
+								Default:     github.Ptr(true),
+							},
+							{
+								ID:    github.Ptr(int64(2)),
+								Name:  github.Ptr("needs triage"),
+								Color: github.Ptr("ededed"),
+							},
+						}),
+					),
+				),
+			),
+			expectedLabels: []issueLabel{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working", Default: true},
+				{Name: "needs triage", Color: "ededed"},
+			},
+		},
+		{
+			name: "repository without labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLabelsByOwnerByRepo,
+					[]*github.Label{},
+				),
+			),
+			expectedLabels: []issueLabel{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list repository labels",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(50),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var labels []issueLabel
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &labels))
+			assert.Equal(t, tc.expectedLabels, labels)
+		})
+	}
+}
+
+func Test_AddLabelsToIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddLabelsToIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_labels_to_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "labels"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		labels          []any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedLabels  []string
+	}{
+		{
+			name: "add several labels",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/labels").andThen(
+						expectRequestBody(t, []any{"bug", "needs triage"}).andThen(
+							mockResponse(t, http.StatusOK, []*github.Label{
+								{Name: github.Ptr("good first issue")},
+								{Name: github.Ptr("bug")},
+								{Name: github.Ptr("needs triage")},
+							}),
+						),
+					),
+				),
+			),
+			labels:         []any{"bug", "needs triage"},
+			expectedLabels: []string{"good first issue", "bug", "needs triage"},
+		},
+		{
+			name:            "no labels",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			labels:          []any{},
+			expectToolError: true,
+			expectedErrMsg:  "labels must contain at least one label",
+		},
+		{
+			name: "adding fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			labels:         []any{"bug"},
+			expectError:    true,
+			expectedErrMsg: "failed to add labels to issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddLabelsToIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"labels":       tc.labels,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				IssueNumber int      `json:"issue_number"`
+				Labels      []string `json:"labels"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 42, response.IssueNumber)
+			assert.Equal(t, tc.expectedLabels, response.Labels)
+		})
+	}
+}
+
+func Test_RemoveLabelFromIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveLabelFromIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_label_from_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "label"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "remove a label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					expectPath(t, "/repos/owner/repo/issues/42/labels/needs triage").andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("bug")}}),
+					),
+				),
+			),
+		},
+		{
+			name: "label not on issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Label does not exist"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  `label "needs triage" is not on issue #42 in owner/repo`,
+		},
+		{
+			name: "removing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to remove label from issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveLabelFromIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"label":        "needs triage",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(42), response["issue_number"])
+			assert.Equal(t, "needs triage", response["removed"])
+		})
+	}
+}
+
+func Test_CreateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "color"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedLabel   issueLabel
+	}{
+		{
+			name: "create a label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "needs triage",
+						"color":       "ededed",
+						"description": "Not looked at yet",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{
+							ID:          github.Ptr(int64(1)),
+							Name:        github.Ptr("needs triage"),
+							Color:       github.Ptr("ededed"),
+							Description: github.Ptr("Not looked at yet"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"name":        "needs triage",
+				"color":       "ededed",
+				"description": "Not looked at yet",
+			},
+			expectedLabel: issueLabel{Name: "needs triage", Color: "ededed", Description: "Not looked at yet"},
+		},
+		{
+			name: "color with a leading #",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":  "bug",
+						"color": "D73A4A",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{
+							Name:  github.Ptr("bug"),
+							Color: github.Ptr("d73a4a"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"name":  "bug",
+				"color": "#D73A4A",
+			},
+			expectedLabel: issueLabel{Name: "bug", Color: "d73a4a"},
+		},
+		{
+			name:         "color too short",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"name":  "bug",
+				"color": "#d73",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid color: d73",
+		},
+		{
+			name:         "color not hexadecimal",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"name":  "bug",
+				"color": "red",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid color: red",
+		},
+		{
+			name: "label already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]any{
+						"message": "Validation Failed",
+						"errors":  []map[string]string{{"resource": "Label", "code": "already_exists", "field": "name"}},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"name":  "bug",
+				"color": "d73a4a",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `cannot create label "bug" in owner/repo`,
+		},
+		{
+			name: "creating fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"name":  "bug",
+				"color": "d73a4a",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create label",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var label issueLabel
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &label))
+			assert.Equal(t, tc.expectedLabel, label)
+		})
+	}
+}
+
+func Test_UpdateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.Contains(t, tool.InputSchema.Properties, "color")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedLabel   issueLabel
+	}{
+		{
+			name: "rename a label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					expectPath(t, "/repos/owner/repo/labels/bug").andThen(
+						expectRequestBody(t, map[string]any{
+							"name": "type: bug",
+						}).andThen(
+							mockResponse(t, http.StatusOK, &github.Label{
+								Name:  github.Ptr("type: bug"),
+								Color: github.Ptr("d73a4a"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"new_name": "type: bug",
+			},
+			expectedLabel: issueLabel{Name: "type: bug", Color: "d73a4a"},
+		},
+		{
+			name: "recolor and remove the description",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					expectRequestBody(t, map[string]any{
+						"color":       "b60205",
+						"description": "",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Label{
+							Name:  github.Ptr("bug"),
+							Color: github.Ptr("b60205"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"color":       "#b60205",
+				"description": "",
+			},
+			expectedLabel: issueLabel{Name: "bug", Color: "b60205"},
+		},
+		{
+			name:         "invalid color",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"color": "#b6020g",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid color: b6020g",
+		},
+		{
+			name:            "nothing to update",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "at least one of new_name, color or description must be set",
+		},
+		{
+			name: "label not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"new_name": "type: bug",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `label "bug" not found in owner/repo`,
+		},
+		{
+			name: "new name already taken",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"new_name": "defect",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `a label named "defect" may already exist`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "bug",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var label issueLabel
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &label))
+			assert.Equal(t, tc.expectedLabel, label)
+		})
+	}
+}
+
+func Test_DeleteLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_label", tool.Name)
+	assert.Contains(t, tool.Description, "removed from all the issues and pull requests")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "delete a label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposLabelsByOwnerByRepoByName,
+					expectPath(t, "/repos/owner/repo/labels/wontfix").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "label not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposLabelsByOwnerByRepoByName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  `label "wontfix" not found in owner/repo`,
+		},
+		{
+			name: "deleting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposLabelsByOwnerByRepoByName,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete label",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "wontfix",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Label has been deleted", response["message"])
+			assert.Equal(t, "wontfix", response["name"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
@@ -0,0 +1,543 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	milestoneStates     = []string{"open", "closed", "all"}
+	milestoneSorts      = []string{"due_on", "completeness"}
+	milestoneDirections = []string{"asc", "desc"}
+)
+
+// issueMilestone is a milestone of a repository, without the API URLs, node IDs and creator
+type issueMilestone struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description,omitempty"`
+	State        string     `json:"state"`
+	OpenIssues   int        `json:"open_issues"`
+	ClosedIssues int        `json:"closed_issues"`
+	DueOn        *time.Time `json:"due_on,omitempty"`
+	Overdue      bool       `json:"overdue"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	URL          string     `json:"url"`
+}
+
+// newIssueMilestone converts a milestone, where an open milestone whose due date is before now is overdue
+func newIssueMilestone(milestone *github.Milestone, now time.Time) issueMilestone {
+	m := issueMilestone{
+		Number:       milestone.GetNumber(),
+		Title:        milestone.GetTitle(),
+		Description:  milestone.GetDescription(),
+		State:        milestone.GetState(),
+		OpenIssues:   milestone.GetOpenIssues(),
+		ClosedIssues: milestone.GetClosedIssues(),
+		URL:          milestone.GetHTMLURL(),
+	}
+	if milestone.DueOn != nil {
+		m.DueOn = &milestone.DueOn.Time
+		m.Overdue = m.State == "open" && milestone.DueOn.Before(now)
+	}
+	if milestone.ClosedAt != nil {
+		m.ClosedAt = &milestone.ClosedAt.Time
+	}
+	return m
+}
+
+// marshalMilestone marshals a milestone as the text result of a tool
+func marshalMilestone(milestone *github.Milestone) (*mcp.CallToolResult, error) {
+	r, err := json.Marshal(newIssueMilestone(milestone, time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// ListMilestones creates a tool to list the milestones of a repository
+func ListMilestones(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_milestones",
+			mcp.WithDescription(t("TOOL_LIST_MILESTONES_DESCRIPTION", "List the milestones of a GitHub repository with their progress, due date and whether they are overdue")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MILESTONES_USER_TITLE", "List milestones"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state, defaults to open"),
+				mcp.Enum(milestoneStates...),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort by due date or by the share of closed issues, defaults to due_on"),
+				mcp.Enum(milestoneSorts...),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction, defaults to asc"),
+				mcp.Enum(milestoneDirections...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "" && !slices.Contains(milestoneStates, state) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s, must be one of %s", state, strings.Join(milestoneStates, ", "))), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if sort != "" && !slices.Contains(milestoneSorts, sort) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid sort: %s, must be one of %s", sort, strings.Join(milestoneSorts, ", "))), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if direction != "" && !slices.Contains(milestoneDirections, direction) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid direction: %s, must be one of %s", direction, strings.Join(milestoneDirections, ", "))), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, &github.MilestoneListOptions{
+				State:     state,
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list milestones: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			now := time.Now()
+			result := make([]issueMilestone, 0, len(milestones))
+			for _, milestone := range milestones {
+				result = append(result, newIssueMilestone(milestone, now))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateMilestone creates a tool to create a milestone in a repository
+func CreateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_milestone",
+			mcp.WithDescription(t("TOOL_CREATE_MILESTONE_DESCRIPTION", "Create a milestone in a GitHub repository, to group the issues and pull requests planned for a release")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_MILESTONE_USER_TITLE", "Create milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Title of the milestone"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the milestone"),
+			),
+			mcp.WithString("due_on",
+				mcp.Description("Due date of the milestone, in RFC3339 format (2025-06-30T00:00:00Z) or as a date (2025-06-30)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dueOn, err := OptionalParam[string](request, "due_on")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			milestone := &github.Milestone{
+				Title: github.Ptr(title),
+			}
+			if description != "" {
+				milestone.Description = github.Ptr(description)
+			}
+			if dueOn != "" {
+				due, err := parseISOTimestamp(dueOn)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid due_on: %s", err.Error())), nil
+				}
+				milestone.DueOn = &github.Timestamp{Time: due}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create milestone %q in %s/%s, a milestone with the same title may already exist: %s", title, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create milestone: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalMilestone(created)
+		}
+}
+
+// UpdateMilestone creates a tool to update a milestone of a repository
+func UpdateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_milestone",
+			mcp.WithDescription(t("TOOL_UPDATE_MILESTONE_DESCRIPTION", "Update the title, description, due date or state of a milestone of a GitHub repository. Use close_milestone to close a milestone")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_MILESTONE_USER_TITLE", "Update milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Number of the milestone to update"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title of the milestone"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description of the milestone. An empty string removes the description"),
+			),
+			mcp.WithString("due_on",
+				mcp.Description("New due date of the milestone, in RFC3339 format (2025-06-30T00:00:00Z) or as a date (2025-06-30)"),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state of the milestone"),
+				mcp.Enum("open", "closed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := OptionalParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasDescription := request.GetArguments()["description"]
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dueOn, err := OptionalParam[string](request, "due_on")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			milestone := &github.Milestone{}
+			if title != "" {
+				milestone.Title = github.Ptr(title)
+			}
+			if hasDescription {
+				milestone.Description = github.Ptr(description)
+			}
+			if dueOn != "" {
+				due, err := parseISOTimestamp(dueOn)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid due_on: %s", err.Error())), nil
+				}
+				milestone.DueOn = &github.Timestamp{Time: due}
+			}
+			if state != "" {
+				if state != "open" && state != "closed" {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s, must be open or closed", state)), nil
+				}
+				milestone.State = github.Ptr(state)
+			}
+			if milestone.Title == nil && milestone.Description == nil && milestone.DueOn == nil && milestone.State == nil {
+				return mcp.NewToolResultError("at least one of title, description, due_on or state must be set"), nil
+			}
+
+			return editMilestone(ctx, getClient, owner, repo, number, milestone)
+		}
+}
+
+// CloseMilestone creates a tool to close a milestone of a repository
+func CloseMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("close_milestone",
+			mcp.WithDescription(t("TOOL_CLOSE_MILESTONE_DESCRIPTION", "Close a milestone of a GitHub repository, such as once its release has shipped. Its issues and pull requests keep the milestone")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CLOSE_MILESTONE_USER_TITLE", "Close milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Number of the milestone to close"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return editMilestone(ctx, getClient, owner, repo, number, &github.Milestone{State: github.Ptr("closed")})
+		}
+}
+
+// editMilestone edits a milestone for the update_milestone and close_milestone tools
+func editMilestone(ctx context.Context, getClient GetClientFn, owner, repo string, number int, milestone *github.Milestone) (*mcp.CallToolResult, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	edited, resp, err := client.Issues.EditMilestone(ctx, owner, repo, number, milestone)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return mcp.NewToolResultError(fmt.Sprintf("milestone #%d not found in %s/%s", number, owner, repo)), nil
+		}
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return marshalMilestone(edited)
+}
+
+// SetIssueMilestone creates a tool to set or clear the milestone of an issue
+func SetIssueMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_issue_milestone",
+			mcp.WithDescription(t("TOOL_SET_ISSUE_MILESTONE_DESCRIPTION", "Set the milestone of an issue or pull request by the milestone's number or title, replacing its current milestone, or remove its milestone")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ISSUE_MILESTONE_USER_TITLE", "Set issue milestone"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to set the milestone of"),
+			),
+			mcp.WithString("milestone",
+				mcp.Required(),
+				mcp.Description("Number or title of the milestone. Digits are read as a title when a milestone has that title, and as a number otherwise. null, 0 or an empty string removes the milestone"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, ok := request.GetArguments()["milestone"]
+			if !ok {
+				return mcp.NewToolResultError("missing required parameter: milestone"), nil
+			}
+
+			// The milestone is a number, a title, or empty to remove it
+			var number int
+			var title string
+			switch v := value.(type) {
+			case nil:
+			case float64:
+				number, err = toInt("milestone", v)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			case string:
+				// Digits may be the title of a milestone such as "2025", which is looked up before the number
+				title = strings.TrimSpace(v)
+				if n, err := toInt("milestone", title); err == nil {
+					number = n
+					if n == 0 {
+						title = ""
+					}
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("parameter milestone must be a number, a title or null, is %T", value)), nil
+			}
+			if number < 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid milestone number: %d", number)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if title != "" {
+				milestone, err := findMilestoneByTitle(ctx, client, owner, repo, title)
+				if err != nil {
+					return nil, err
+				}
+				switch {
+				case milestone != nil:
+					number = milestone.GetNumber()
+				case number == 0:
+					return mcp.NewToolResultError(fmt.Sprintf("milestone %q not found in %s/%s, use list_milestones to find the existing milestones", title, owner, repo)), nil
+				}
+			}
+
+			var issue *github.Issue
+			var resp *github.Response
+			if number == 0 {
+				issue, resp, err = client.Issues.RemoveMilestone(ctx, owner, repo, issueNumber)
+			} else {
+				issue, resp, err = client.Issues.Edit(ctx, owner, repo, issueNumber, &github.IssueRequest{Milestone: github.Ptr(number)})
+			}
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot set milestone #%d on issue #%d in %s/%s, the milestone may not exist: %s", number, issueNumber, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to set issue milestone: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			var milestone map[string]any
+			if issue.Milestone != nil {
+				milestone = map[string]any{
+					"number": issue.Milestone.GetNumber(),
+					"title":  issue.Milestone.GetTitle(),
+				}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number": issueNumber,
+				"milestone":    milestone,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// findMilestoneByTitle looks for a milestone of a repository, open or closed, by its title ignoring
+// case, and returns nil when there is none.
+func findMilestoneByTitle(ctx context.Context, client *github.Client, owner, repo, title string) (*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{
+		State:       "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list milestones: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		for _, milestone := range milestones {
+			if strings.EqualFold(milestone.GetTitle(), title) {
+				return milestone, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
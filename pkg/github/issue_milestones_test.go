@@ -0,0 +1,770 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newIssueMilestone(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	past := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		milestone       *github.Milestone
+		expectedOverdue bool
+	}{
+		{
+			name: "open and past its due date",
+			milestone: &github.Milestone{
+				State: github.Ptr("open"),
+				DueOn: &github.Timestamp{Time: past},
+			},
+			expectedOverdue: true,
+		},
+		{
+			name: "open and due later",
+			milestone: &github.Milestone{
+				State: github.Ptr("open"),
+				DueOn: &github.Timestamp{Time: future},
+			},
+			expectedOverdue: false,
+		},
+		{
+			name: "closed after its due date",
+			milestone: &github.Milestone{
+				State: github.Ptr("closed"),
+				DueOn: &github.Timestamp{Time: past},
+			},
+			expectedOverdue: false,
+		},
+		{
+			name: "open without a due date",
+			milestone: &github.Milestone{
+				State: github.Ptr("open"),
+			},
+			expectedOverdue: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			milestone := newIssueMilestone(tc.milestone, now)
+			assert.Equal(t, tc.expectedOverdue, milestone.Overdue)
+		})
+	}
+}
+
+func Test_ListMilestones(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListMilestones(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_milestones", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	// Far enough from now for the test not to depend on when it runs
+	past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectToolError    bool
+		expectedErrMsg     string
+		expectedMilestones []issueMilestone
+	}{
+		{
+			name: "milestones sorted by due date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "all",
+						"sort":      "due_on",
+						"direction": "asc",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Milestone{
+							{
+								Number:       github.Ptr(1),
+								Title:        github.Ptr("v1.0"),
+								State:        github.Ptr("open"),
+								OpenIssues:   github.Ptr(3),
+								ClosedIssues: github.Ptr(7),
+								DueOn:        &github.Timestamp{Time: past},
+								HTMLURL:      github.Ptr("https://github.com/owner/repo/milestone/1"),
+							},
+							{
+								Number:      github.Ptr(2),
+								Title:       github.Ptr("v2.0"),
+								Description: github.Ptr("Next major"),
+								State:       github.Ptr("open"),
+								DueOn:       &github.Timestamp{Time: future},
+								HTMLURL:     github.Ptr("https://github.com/owner/repo/milestone/2"),
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state":     "all",
+				"sort":      "due_on",
+				"direction": "asc",
+			},
+			expectedMilestones: []issueMilestone{
+				{
+					Number:       1,
+					Title:        "v1.0",
+					State:        "open",
+					OpenIssues:   3,
+					ClosedIssues: 7,
+					DueOn:        &past,
+					Overdue:      true,
+					URL:          "https://github.com/owner/repo/milestone/1",
+				},
+				{
+					Number:      2,
+					Title:       "v2.0",
+					Description: "Next major",
+					State:       "open",
+					DueOn:       &future,
+					URL:         "https://github.com/owner/repo/milestone/2",
+				},
+			},
+		},
+		{
+			name: "repository without milestones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepo,
+					[]*github.Milestone{},
+				),
+			),
+			expectedMilestones: []issueMilestone{},
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state": "done",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid state: done",
+		},
+		{
+			name:         "invalid sort",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"sort": "title",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid sort: title",
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list milestones",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListMilestones(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var milestones []issueMilestone
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestones))
+			assert.Equal(t, tc.expectedMilestones, milestones)
+		})
+	}
+}
+
+func Test_CreateMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "due_on")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	createdMilestone := func(dueOn time.Time) http.HandlerFunc {
+		return mockResponse(t, http.StatusCreated, &github.Milestone{
+			Number: github.Ptr(3),
+			Title:  github.Ptr("v1.1"),
+			State:  github.Ptr("open"),
+			DueOn:  &github.Timestamp{Time: dueOn},
+		})
+	}
+	dueOn := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "due date in RFC3339 format",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":       "v1.1",
+						"description": "Bug fixes",
+						"due_on":      "2025-06-30T00:00:00Z",
+					}).andThen(createdMilestone(dueOn)),
+				),
+			),
+			requestArgs: map[string]any{
+				"description": "Bug fixes",
+				"due_on":      "2025-06-30T00:00:00Z",
+			},
+		},
+		{
+			name: "due date as a date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":  "v1.1",
+						"due_on": "2025-06-30T00:00:00Z",
+					}).andThen(createdMilestone(dueOn)),
+				),
+			),
+			requestArgs: map[string]any{
+				"due_on": "2025-06-30",
+			},
+		},
+		{
+			name:         "invalid due date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"due_on": "next friday",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid due_on",
+		},
+		{
+			name: "milestone already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  `cannot create milestone "v1.1" in owner/repo`,
+		},
+		{
+			name: "creating fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to create milestone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"title": "v1.1",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var milestone issueMilestone
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+			assert.Equal(t, 3, milestone.Number)
+			assert.Equal(t, "v1.1", milestone.Title)
+			require.NotNil(t, milestone.DueOn)
+			assert.True(t, dueOn.Equal(*milestone.DueOn))
+		})
+	}
+}
+
+func Test_UpdateMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "due_on")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedTitle   string
+	}{
+		{
+			name: "retitle and postpone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					expectPath(t, "/repos/owner/repo/milestones/3").andThen(
+						expectRequestBody(t, map[string]any{
+							"title":       "v1.2",
+							"description": "",
+							"due_on":      "2025-07-31T00:00:00Z",
+						}).andThen(
+							mockResponse(t, http.StatusOK, &github.Milestone{
+								Number: github.Ptr(3),
+								Title:  github.Ptr("v1.2"),
+								State:  github.Ptr("open"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"title":       "v1.2",
+				"description": "",
+				"due_on":      "2025-07-31",
+			},
+			expectedTitle: "v1.2",
+		},
+		{
+			name:         "invalid due date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"due_on": "31/07/2025",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid due_on",
+		},
+		{
+			name:            "nothing to update",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "at least one of title, description, due_on or state must be set",
+		},
+		{
+			name: "milestone not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"title": "v1.2",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "milestone #3 not found in owner/repo",
+		},
+		{
+			name: "updating fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"state": "open",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update milestone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(3),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var milestone issueMilestone
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+			assert.Equal(t, tc.expectedTitle, milestone.Title)
+		})
+	}
+}
+
+func Test_CloseMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CloseMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "close_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	closedAt := time.Date(2025, 6, 30, 18, 0, 0, 0, time.UTC)
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+			expectPath(t, "/repos/owner/repo/milestones/3").andThen(
+				expectRequestBody(t, map[string]any{
+					"state": "closed",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.Milestone{
+						Number: github.Ptr(3),
+						Title:  github.Ptr("v1.1"),
+						State:  github.Ptr("closed"),
+						// Closing a milestone after its due date doesn't make it overdue
+						DueOn:    &github.Timestamp{Time: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+						ClosedAt: &github.Timestamp{Time: closedAt},
+					}),
+				),
+			),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	_, handler := CloseMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":            "owner",
+		"repo":             "repo",
+		"milestone_number": float64(3),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var milestone issueMilestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestone))
+	assert.Equal(t, "closed", milestone.State)
+	assert.False(t, milestone.Overdue)
+	require.NotNil(t, milestone.ClosedAt)
+	assert.True(t, closedAt.Equal(*milestone.ClosedAt))
+}
+
+func Test_SetIssueMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetIssueMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_issue_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "milestone"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	issueWithMilestone := mockResponse(t, http.StatusOK, &github.Issue{
+		Number:    github.Ptr(42),
+		Milestone: &github.Milestone{Number: github.Ptr(3), Title: github.Ptr("v1.1")},
+	})
+	issueWithoutMilestone := mockResponse(t, http.StatusOK, &github.Issue{
+		Number: github.Ptr(42),
+	})
+	removedMilestone := func() mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+			expectRequestBody(t, map[string]any{"milestone": nil}).andThen(issueWithoutMilestone),
+		)
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		milestone         any
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedMilestone map[string]any
+	}{
+		{
+			name: "by number",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42").andThen(
+						expectRequestBody(t, map[string]any{"milestone": float64(3)}).andThen(issueWithMilestone),
+					),
+				),
+			),
+			milestone:         float64(3),
+			expectedMilestone: map[string]any{"number": float64(3), "title": "v1.1"},
+		},
+		{
+			name: "by number as a string",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepo,
+					[]*github.Milestone{
+						{Number: github.Ptr(3), Title: github.Ptr("v1.1")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"milestone": float64(3)}).andThen(issueWithMilestone),
+				),
+			),
+			milestone:         "3",
+			expectedMilestone: map[string]any{"number": float64(3), "title": "v1.1"},
+		},
+		{
+			name: "numeric title is read as a title",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepo,
+					[]*github.Milestone{
+						{Number: github.Ptr(3), Title: github.Ptr("v1.1")},
+						{Number: github.Ptr(7), Title: github.Ptr("2025")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"milestone": float64(7)}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:    github.Ptr(42),
+							Milestone: &github.Milestone{Number: github.Ptr(7), Title: github.Ptr("2025")},
+						}),
+					),
+				),
+			),
+			milestone:         "2025",
+			expectedMilestone: map[string]any{"number": float64(7), "title": "2025"},
+		},
+		{
+			name: "by title on a later page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetReposMilestonesByOwnerByRepo,
+					[]*github.Milestone{
+						{Number: github.Ptr(1), Title: github.Ptr("v1.0")},
+					},
+					[]*github.Milestone{
+						{Number: github.Ptr(2), Title: github.Ptr("v2.0")},
+						{Number: github.Ptr(3), Title: github.Ptr("v1.1")},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"milestone": float64(3)}).andThen(issueWithMilestone),
+				),
+			),
+			milestone:         "V1.1",
+			expectedMilestone: map[string]any{"number": float64(3), "title": "v1.1"},
+		},
+		{
+			name: "title of a closed milestone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":    "all",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Milestone{
+							{Number: github.Ptr(3), Title: github.Ptr("v1.1"), State: github.Ptr("closed")},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"milestone": float64(3)}).andThen(issueWithMilestone),
+				),
+			),
+			milestone:         "v1.1",
+			expectedMilestone: map[string]any{"number": float64(3), "title": "v1.1"},
+		},
+		{
+			name: "title not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepo,
+					[]*github.Milestone{
+						{Number: github.Ptr(1), Title: github.Ptr("v1.0")},
+					},
+				),
+			),
+			milestone:       "v9.9",
+			expectToolError: true,
+			expectedErrMsg:  `milestone "v9.9" not found in owner/repo`,
+		},
+		{
+			name:         "clear with null",
+			mockedClient: mock.NewMockedHTTPClient(removedMilestone()),
+			milestone:    nil,
+		},
+		{
+			name:         "clear with 0",
+			mockedClient: mock.NewMockedHTTPClient(removedMilestone()),
+			milestone:    float64(0),
+		},
+		{
+			name:         "clear with an empty string",
+			mockedClient: mock.NewMockedHTTPClient(removedMilestone()),
+			milestone:    "",
+		},
+		{
+			name:            "invalid milestone number",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			milestone:       float64(1.5),
+			expectToolError: true,
+			expectedErrMsg:  "parameter milestone must be an integer",
+		},
+		{
+			name: "milestone does not exist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			milestone:       float64(99),
+			expectToolError: true,
+			expectedErrMsg:  "cannot set milestone #99 on issue #42 in owner/repo",
+		},
+		{
+			name: "setting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+				),
+			),
+			milestone:      float64(3),
+			expectError:    true,
+			expectedErrMsg: "failed to set issue milestone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetIssueMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"milestone":    tc.milestone,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(42), response["issue_number"])
+			if tc.expectedMilestone == nil {
+				assert.Nil(t, response["milestone"])
+			} else {
+				assert.Equal(t, tc.expectedMilestone, response["milestone"])
+			}
+		})
+	}
+}
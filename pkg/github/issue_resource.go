@@ -0,0 +1,281 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxConversationResourceBytes caps the size of the markdown rendered for issue and pull request resources.
+const maxConversationResourceBytes = 64 * 1024
+
+// GetIssueResourceContent defines the resource template and handler for getting an issue with its comments.
+func GetIssueResourceContent(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/issues/{number}", // Resource template
+			t("RESOURCE_ISSUE_DESCRIPTION", "Issue with comments"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		IssueResourceHandler(getClient)
+}
+
+// GetPullRequestResourceContent defines the resource template and handler for getting a pull request with its comments and review threads.
+func GetPullRequestResourceContent(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"repo://{owner}/{repo}/pulls/{number}", // Resource template
+			t("RESOURCE_PULL_REQUEST_DESCRIPTION", "Pull request with comments and review threads"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestResourceHandler(getClient)
+}
+
+// IssueResourceHandler returns a handler function for issue resource requests.
+func IssueResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := conversationResourceArguments(request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		issue, _, err := client.Issues.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		comments, _, err := client.Issues.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue comments: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/markdown",
+				Text:     truncateMarkdown(renderIssueMarkdown(issue, comments), maxConversationResourceBytes),
+			},
+		}, nil
+	}
+}
+
+// PullRequestResourceHandler returns a handler function for pull request resource requests.
+func PullRequestResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := conversationResourceArguments(request)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request: %w", err)
+		}
+
+		comments, _, err := client.Issues.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request comments: %w", err)
+		}
+
+		reviewComments, _, err := client.PullRequests.ListComments(ctx, owner, repo, number, &github.PullRequestListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request review comments: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: "text/markdown",
+				Text:     truncateMarkdown(renderPullRequestMarkdown(pr, comments, reviewComments), maxConversationResourceBytes),
+			},
+		}, nil
+	}
+}
+
+// conversationResourceArguments extracts the owner, repo and number arguments of an issue or pull request resource.
+func conversationResourceArguments(request mcp.ReadResourceRequest) (string, string, int, error) {
+	// the matcher will give []string with one element
+	// https://github.com/mark3labs/mcp-go/pull/54
+	o, ok := request.Params.Arguments["owner"].([]string)
+	if !ok || len(o) == 0 {
+		return "", "", 0, fmt.Errorf("owner is required")
+	}
+
+	r, ok := request.Params.Arguments["repo"].([]string)
+	if !ok || len(r) == 0 {
+		return "", "", 0, fmt.Errorf("repo is required")
+	}
+
+	n, ok := request.Params.Arguments["number"].([]string)
+	if !ok || len(n) == 0 {
+		return "", "", 0, fmt.Errorf("number is required")
+	}
+	number, err := strconv.Atoi(n[0])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid number: %w", err)
+	}
+
+	return o[0], r[0], number, nil
+}
+
+func renderIssueMarkdown(issue *github.Issue, comments []*github.IssueComment) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s (#%d)\n\n", issue.GetTitle(), issue.GetNumber())
+	metadata := []string{
+		"**State:** " + issue.GetState(),
+		"**Author:** @" + issue.GetUser().GetLogin(),
+		"**Created:** " + formatResourceTime(issue.GetCreatedAt()),
+	}
+	metadata = append(metadata, labelsAndAssignees(issue.Labels, issue.Assignees)...)
+	b.WriteString(strings.Join(metadata, " | "))
+	fmt.Fprintf(&b, "\n%s\n\n", issue.GetHTMLURL())
+
+	writeMarkdownBody(&b, issue.GetBody())
+	writeIssueComments(&b, comments)
+
+	return b.String()
+}
+
+func renderPullRequestMarkdown(pr *github.PullRequest, comments []*github.IssueComment, reviewComments []*github.PullRequestComment) string {
+	var b strings.Builder
+
+	state := pr.GetState()
+	switch {
+	case pr.GetMerged():
+		state = "merged"
+	case pr.GetDraft():
+		state = "draft"
+	}
+
+	fmt.Fprintf(&b, "# %s (#%d)\n\n", pr.GetTitle(), pr.GetNumber())
+	metadata := []string{
+		"**State:** " + state,
+		"**Author:** @" + pr.GetUser().GetLogin(),
+		"**Created:** " + formatResourceTime(pr.GetCreatedAt()),
+		fmt.Sprintf("**Branch:** %s → %s", pr.GetHead().GetLabel(), pr.GetBase().GetRef()),
+	}
+	metadata = append(metadata, labelsAndAssignees(pr.Labels, pr.Assignees)...)
+	b.WriteString(strings.Join(metadata, " | "))
+	fmt.Fprintf(&b, "\n%s\n\n", pr.GetHTMLURL())
+
+	writeMarkdownBody(&b, pr.GetBody())
+	writeIssueComments(&b, comments)
+
+	if len(reviewComments) == 0 {
+		return b.String()
+	}
+
+	// Group review comments into threads, keyed by the comment that started the thread
+	threads := map[int64][]*github.PullRequestComment{}
+	var roots []*github.PullRequestComment
+	for _, c := range reviewComments {
+		if c.GetInReplyTo() == 0 {
+			roots = append(roots, c)
+			continue
+		}
+		threads[c.GetInReplyTo()] = append(threads[c.GetInReplyTo()], c)
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].GetCreatedAt().Before(roots[j].GetCreatedAt().Time)
+	})
+
+	fmt.Fprintf(&b, "\n## Review threads (%d)\n", len(roots))
+	for _, root := range roots {
+		line := root.GetLine()
+		if line == 0 {
+			line = root.GetOriginalLine()
+		}
+		fmt.Fprintf(&b, "\n### %s:%d\n\n", root.GetPath(), line)
+		fmt.Fprintf(&b, "**@%s** (%s):\n\n%s\n", root.GetUser().GetLogin(), formatResourceTime(root.GetCreatedAt()), root.GetBody())
+		for _, reply := range threads[root.GetID()] {
+			fmt.Fprintf(&b, "\n> **@%s** (%s):\n>\n> %s\n", reply.GetUser().GetLogin(), formatResourceTime(reply.GetCreatedAt()), strings.ReplaceAll(reply.GetBody(), "\n", "\n> "))
+		}
+	}
+
+	return b.String()
+}
+
+func labelsAndAssignees(labels []*github.Label, assignees []*github.User) []string {
+	var metadata []string
+	if len(labels) > 0 {
+		names := make([]string, 0, len(labels))
+		for _, l := range labels {
+			names = append(names, l.GetName())
+		}
+		metadata = append(metadata, "**Labels:** "+strings.Join(names, ", "))
+	}
+	if len(assignees) > 0 {
+		logins := make([]string, 0, len(assignees))
+		for _, a := range assignees {
+			logins = append(logins, "@"+a.GetLogin())
+		}
+		metadata = append(metadata, "**Assignees:** "+strings.Join(logins, ", "))
+	}
+	return metadata
+}
+
+func writeMarkdownBody(b *strings.Builder, body string) {
+	if body == "" {
+		b.WriteString("_No description provided._\n")
+		return
+	}
+	b.WriteString(body)
+	b.WriteString("\n")
+}
+
+func writeIssueComments(b *strings.Builder, comments []*github.IssueComment) {
+	if len(comments) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## Comments (%d)\n", len(comments))
+	for _, c := range comments {
+		fmt.Fprintf(b, "\n### @%s (%s)\n\n%s\n", c.GetUser().GetLogin(), formatResourceTime(c.GetCreatedAt()), c.GetBody())
+	}
+}
+
+func formatResourceTime(t github.Timestamp) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// truncateMarkdown cuts the markdown to at most maxBytes, at a line boundary where possible,
+// and appends a note explaining that the content was truncated.
+func truncateMarkdown(md string, maxBytes int) string {
+	if len(md) <= maxBytes {
+		return md
+	}
+
+	truncated := md[:maxBytes]
+	if i := strings.LastIndex(truncated, "\n"); i > 0 {
+		truncated = truncated[:i]
+	}
+	// Avoid cutting a multi-byte character in half
+	truncated = strings.ToValidUTF8(truncated, "")
+
+	return fmt.Sprintf("%s\n\n---\n_Truncated: the content exceeds %d bytes. Use the issue and pull request tools to read the remaining comments._\n", truncated, maxBytes)
+}
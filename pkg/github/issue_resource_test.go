@@ -0,0 +1,196 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueResourceContent(t *testing.T) {
+	tmpl, _ := GetIssueResourceContent(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/issues/{number}", tmpl.URITemplate.Raw())
+	require.Equal(t, "text/markdown", tmpl.MIMEType)
+}
+
+func Test_GetPullRequestResourceContent(t *testing.T) {
+	tmpl, _ := GetPullRequestResourceContent(nil, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/pulls/{number}", tmpl.URITemplate.Raw())
+	require.Equal(t, "text/markdown", tmpl.MIMEType)
+}
+
+func newResourceRequest(uri string, args map[string]any) mcp.ReadResourceRequest {
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	request.Params.Arguments = args
+	return request
+}
+
+func Test_IssueResourceHandler(t *testing.T) {
+	created := github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	mockIssue := &github.Issue{
+		Number:    github.Ptr(42),
+		Title:     github.Ptr("Crash on startup"),
+		Body:      github.Ptr("The server crashes when started without a token."),
+		State:     github.Ptr("open"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:      &github.User{Login: github.Ptr("reporter")},
+		CreatedAt: &created,
+		Labels:    []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+		Assignees: []*github.User{{Login: github.Ptr("maintainer")}},
+	}
+	mockComments := []*github.IssueComment{
+		{Body: github.Ptr("I can reproduce this."), User: &github.User{Login: github.Ptr("maintainer")}, CreatedAt: &created},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+		expectError  string
+		expectedText string
+	}{
+		{
+			name: "renders issue with comments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue),
+				mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, mockComments),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"42"},
+			},
+			expectedText: "# Crash on startup (#42)\n\n" +
+				"**State:** open | **Author:** @reporter | **Created:** 2024-05-01T12:00:00Z | **Labels:** bug, p1 | **Assignees:** @maintainer\n" +
+				"https://github.com/owner/repo/issues/42\n\n" +
+				"The server crashes when started without a token.\n" +
+				"\n## Comments (1)\n" +
+				"\n### @maintainer (2024-05-01T12:00:00Z)\n\nI can reproduce this.\n",
+		},
+		{
+			name:         "missing number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+			},
+			expectError: "number is required",
+		},
+		{
+			name:         "invalid number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"abc"},
+			},
+			expectError: "invalid number",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"number": []string{"999"},
+			},
+			expectError: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			handler := IssueResourceHandler(stubGetClientFn(client))
+
+			resp, err := handler(context.Background(), newResourceRequest("repo://owner/repo/issues/42", tc.requestArgs))
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp, 1)
+			content, ok := resp[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.Equal(t, "repo://owner/repo/issues/42", content.URI)
+			assert.Equal(t, "text/markdown", content.MIMEType)
+			assert.Equal(t, tc.expectedText, content.Text)
+		})
+	}
+}
+
+func Test_PullRequestResourceHandler(t *testing.T) {
+	created := github.Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	replied := github.Timestamp{Time: time.Date(2024, 5, 2, 12, 0, 0, 0, time.UTC)}
+	mockPR := &github.PullRequest{
+		Number:    github.Ptr(7),
+		Title:     github.Ptr("Add feature"),
+		Body:      github.Ptr(""),
+		State:     github.Ptr("open"),
+		Draft:     github.Ptr(true),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/7"),
+		User:      &github.User{Login: github.Ptr("author")},
+		CreatedAt: &created,
+		Head:      &github.PullRequestBranch{Label: github.Ptr("author:feature")},
+		Base:      &github.PullRequestBranch{Ref: github.Ptr("main")},
+	}
+	mockReviewComments := []*github.PullRequestComment{
+		{ID: github.Ptr(int64(2)), InReplyTo: github.Ptr(int64(1)), Body: github.Ptr("Good catch,\nfixed."), User: &github.User{Login: github.Ptr("author")}, CreatedAt: &replied},
+		{ID: github.Ptr(int64(1)), Path: github.Ptr("main.go"), Line: github.Ptr(10), Body: github.Ptr("This can panic."), User: &github.User{Login: github.Ptr("reviewer")}, CreatedAt: &created},
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR),
+		mock.WithRequestMatch(mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber, []*github.IssueComment{}),
+		mock.WithRequestMatch(mock.GetReposPullsCommentsByOwnerByRepoByPullNumber, mockReviewComments),
+	)
+	handler := PullRequestResourceHandler(stubGetClientFn(github.NewClient(mockedClient)))
+
+	resp, err := handler(context.Background(), newResourceRequest("repo://owner/repo/pulls/7", map[string]any{
+		"owner":  []string{"owner"},
+		"repo":   []string{"repo"},
+		"number": []string{"7"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	content, ok := resp[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+
+	expected := "# Add feature (#7)\n\n" +
+		"**State:** draft | **Author:** @author | **Created:** 2024-05-01T12:00:00Z | **Branch:** author:feature → main\n" +
+		"https://github.com/owner/repo/pull/7\n\n" +
+		"_No description provided._\n" +
+		"\n## Review threads (1)\n" +
+		"\n### main.go:10\n\n" +
+		"**@reviewer** (2024-05-01T12:00:00Z):\n\nThis can panic.\n" +
+		"\n> **@author** (2024-05-02T12:00:00Z):\n>\n> Good catch,\n> fixed.\n"
+	assert.Equal(t, expected, content.Text)
+}
+
+func Test_truncateMarkdown(t *testing.T) {
+	short := "# Title\n\nbody\n"
+	assert.Equal(t, short, truncateMarkdown(short, 100))
+
+	long := "# Title\n\n" + strings.Repeat("line of text\n", 20)
+	truncated := truncateMarkdown(long, 50)
+	assert.True(t, strings.HasPrefix(truncated, "# Title\n\nline of text\nline of text\nline of text\n\n---\n"))
+	assert.Contains(t, truncated, "_Truncated: the content exceeds 50 bytes.")
+}
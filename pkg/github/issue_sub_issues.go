@@ -0,0 +1,486 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// subIssuesSummary counts the sub-issues of an issue, and how many of them are closed
+type subIssuesSummary struct {
+	Total            int `json:"total"`
+	Completed        int `json:"completed"`
+	PercentCompleted int `json:"percent_completed"`
+}
+
+// parentIssueResponse is an issue as returned by the API with its sub-issue summary, which
+// go-github has no field for
+type parentIssueResponse struct {
+	github.Issue
+	SubIssuesSummary *subIssuesSummary `json:"sub_issues_summary,omitempty"`
+}
+
+// subIssue is a sub-issue of an issue. Sub-issues can be in another repository than their parent,
+// and are added, removed and reordered by their ID rather than their number
+type subIssue struct {
+	ID         int64  `json:"id"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	Repository string `json:"repository"`
+	URL        string `json:"url"`
+}
+
+func newSubIssue(issue *github.Issue) subIssue {
+	_, repository, _ := strings.Cut(issue.GetRepositoryURL(), "/repos/")
+	return subIssue{
+		ID:         issue.GetID(),
+		Number:     issue.GetNumber(),
+		Title:      issue.GetTitle(),
+		State:      issue.GetState(),
+		Repository: repository,
+		URL:        issue.GetHTMLURL(),
+	}
+}
+
+// subIssueRef identifies the sub-issue a tool acts on, either by its ID or by its number in a repository
+type subIssueRef struct {
+	id     int64
+	owner  string
+	repo   string
+	number int
+}
+
+// withSubIssueParams adds the parameters identifying a sub-issue to a tool
+func withSubIssueParams() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber("sub_issue_id",
+			mcp.Description("ID of the sub-issue, as returned by list_sub_issues or get_issue. This is not the issue number"),
+		)(tool)
+		mcp.WithNumber("sub_issue_number",
+			mcp.Description("Number of the sub-issue, instead of sub_issue_id"),
+		)(tool)
+		mcp.WithString("sub_issue_repo",
+			mcp.Description("Repository of sub_issue_number as owner/repo, defaults to the repository of the parent issue"),
+		)(tool)
+	}
+}
+
+// subIssueRefParam gets the sub-issue a tool acts on from its parameters, where sub_issue_repo
+// defaults to the repository of the parent issue
+func subIssueRefParam(request mcp.CallToolRequest, owner, repo string) (subIssueRef, error) {
+	id, err := OptionalIntParam(request, "sub_issue_id")
+	if err != nil {
+		return subIssueRef{}, err
+	}
+	number, err := OptionalIntParam(request, "sub_issue_number")
+	if err != nil {
+		return subIssueRef{}, err
+	}
+	subRepo, err := OptionalParam[string](request, "sub_issue_repo")
+	if err != nil {
+		return subIssueRef{}, err
+	}
+	if (id == 0) == (number == 0) {
+		return subIssueRef{}, fmt.Errorf("exactly one of sub_issue_id or sub_issue_number must be set")
+	}
+	if id != 0 {
+		if subRepo != "" {
+			return subIssueRef{}, fmt.Errorf("sub_issue_repo can only be used with sub_issue_number")
+		}
+		return subIssueRef{id: int64(id)}, nil
+	}
+
+	ref := subIssueRef{owner: owner, repo: repo, number: number}
+	if subRepo != "" {
+		subOwner, name, ok := strings.Cut(subRepo, "/")
+		if !ok || subOwner == "" || name == "" || strings.Contains(name, "/") {
+			return subIssueRef{}, fmt.Errorf("invalid sub_issue_repo: %s, must be owner/repo", subRepo)
+		}
+		ref.owner, ref.repo = subOwner, name
+	}
+	return ref, nil
+}
+
+// resolveID returns the ID of the sub-issue, getting the issue when it was given by number. A
+// result is returned instead when the issue doesn't exist.
+func (ref subIssueRef) resolveID(ctx context.Context, client *github.Client) (int64, *mcp.CallToolResult, error) {
+	if ref.id != 0 {
+		return ref.id, nil, nil
+	}
+	issue, resp, err := client.Issues.Get(ctx, ref.owner, ref.repo, ref.number)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return 0, mcp.NewToolResultError(fmt.Sprintf("issue #%d not found in %s/%s", ref.number, ref.owner, ref.repo)), nil
+		}
+		return 0, nil, fmt.Errorf("failed to get sub-issue: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return issue.GetID(), nil, nil
+}
+
+func (ref subIssueRef) String() string {
+	if ref.id != 0 {
+		return fmt.Sprintf("issue %d", ref.id)
+	}
+	return fmt.Sprintf("issue %s/%s#%d", ref.owner, ref.repo, ref.number)
+}
+
+// marshalSubIssueChange marshals the parent issue returned when a sub-issue is added, removed or
+// reordered, as the text result of a tool
+func marshalSubIssueChange(parent *parentIssueResponse, subIssueID int64) (*mcp.CallToolResult, error) {
+	summary := parent.SubIssuesSummary
+	if summary == nil {
+		summary = &subIssuesSummary{}
+	}
+	r, err := json.Marshal(map[string]any{
+		"issue_number":       parent.GetNumber(),
+		"sub_issue_id":       subIssueID,
+		"sub_issues_summary": summary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// ListSubIssues creates a tool to list the sub-issues of an issue
+func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sub_issues",
+			mcp.WithDescription(t("TOOL_LIST_SUB_ISSUES_DESCRIPTION", "List the sub-issues of an issue in their order, with how many of them are completed. Sub-issues can be in other repositories than their parent")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SUB_ISSUES_USER_TITLE", "List sub-issues"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no methods for the sub-issue endpoints yet
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues?page=%d&per_page=%d", owner, repo, issueNumber, pagination.page, pagination.perPage), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var issues []*github.Issue
+			resp, err := client.Do(ctx, req, &issues)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("issue #%d not found in %s/%s", issueNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to list sub-issues: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The summary is only returned with the parent issue
+			req, err = client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, issueNumber), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			parent := &parentIssueResponse{}
+			parentResp, err := client.Do(ctx, req, parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+			defer func() { _ = parentResp.Body.Close() }()
+
+			subIssues := make([]subIssue, 0, len(issues))
+			for _, issue := range issues {
+				subIssues = append(subIssues, newSubIssue(issue))
+			}
+			summary := parent.SubIssuesSummary
+			if summary == nil {
+				summary = &subIssuesSummary{}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number":       issueNumber,
+				"sub_issues":         subIssues,
+				"sub_issues_summary": summary,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddSubIssue creates a tool to add a sub-issue to an issue
+func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_sub_issue",
+			mcp.WithDescription(t("TOOL_ADD_SUB_ISSUE_DESCRIPTION", "Add an issue as the last sub-issue of another issue, given by its ID or its number. An issue can only have one parent, set replace_parent to move it from its current parent")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SUB_ISSUE_USER_TITLE", "Add sub-issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			withSubIssueParams(),
+			mcp.WithBoolean("replace_parent",
+				mcp.Description("Move the sub-issue from its current parent, if it has one"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := subIssueRefParam(request, owner, repo)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			replaceParent, err := OptionalParam[bool](request, "replace_parent")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssueID, result, err := ref.resolveID(ctx, client)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			body := map[string]any{"sub_issue_id": subIssueID}
+			if replaceParent {
+				body["replace_parent"] = true
+			}
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues", owner, repo, issueNumber), body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			parent := &parentIssueResponse{}
+			resp, err := client.Do(ctx, req, parent)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot add %s as a sub-issue of #%d in %s/%s, it may already have a parent, be in a repository the parent can't link to, or the parent may have too many sub-issues: %s", ref, issueNumber, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to add sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalSubIssueChange(parent, subIssueID)
+		}
+}
+
+// RemoveSubIssue creates a tool to remove a sub-issue from an issue
+func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_sub_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_SUB_ISSUE_DESCRIPTION", "Remove a sub-issue from an issue, given by its ID or its number. The sub-issue itself is not closed or deleted")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			withSubIssueParams(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := subIssueRefParam(request, owner, repo)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssueID, result, err := ref.resolveID(ctx, client)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			req, err := client.NewRequest(http.MethodDelete, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issue", owner, repo, issueNumber), map[string]any{
+				"sub_issue_id": subIssueID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			parent := &parentIssueResponse{}
+			resp, err := client.Do(ctx, req, parent)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is not a sub-issue of #%d in %s/%s: %s", ref, issueNumber, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to remove sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalSubIssueChange(parent, subIssueID)
+		}
+}
+
+// ReprioritizeSubIssue creates a tool to move a sub-issue of an issue before or after another one
+func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("reprioritize_sub_issue",
+			mcp.WithDescription(t("TOOL_REPRIORITIZE_SUB_ISSUE_DESCRIPTION", "Move a sub-issue of an issue, given by its ID or its number, right after or right before another of its sub-issues")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPRIORITIZE_SUB_ISSUE_USER_TITLE", "Reprioritize sub-issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			withSubIssueParams(),
+			mcp.WithNumber("after_id",
+				mcp.Description("ID of the sub-issue to move the sub-issue after"),
+			),
+			mcp.WithNumber("before_id",
+				mcp.Description("ID of the sub-issue to move the sub-issue before"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := subIssueRefParam(request, owner, repo)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			afterID, err := OptionalIntParam(request, "after_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			beforeID, err := OptionalIntParam(request, "before_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (afterID == 0) == (beforeID == 0) {
+				return mcp.NewToolResultError("exactly one of after_id or before_id must be set"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssueID, result, err := ref.resolveID(ctx, client)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			body := map[string]any{"sub_issue_id": subIssueID}
+			if afterID != 0 {
+				body["after_id"] = afterID
+			} else {
+				body["before_id"] = beforeID
+			}
+			req, err := client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues/priority", owner, repo, issueNumber), body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			parent := &parentIssueResponse{}
+			resp, err := client.Do(ctx, req, parent)
+			if err != nil {
+				if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot reprioritize %s, it and the sub-issue to move it next to must both be sub-issues of #%d in %s/%s: %s", ref, issueNumber, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to reprioritize sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return marshalSubIssueChange(parent, subIssueID)
+		}
+}
@@ -0,0 +1,522 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parentIssue returns a parent issue as returned by the sub-issue endpoints
+func parentIssue(total, completed int) map[string]any {
+	return map[string]any{
+		"id":     1001,
+		"number": 42,
+		"title":  "Epic",
+		"sub_issues_summary": map[string]any{
+			"total":             total,
+			"completed":         completed,
+			"percent_completed": completed * 100 / total,
+		},
+	}
+}
+
+func Test_ListSubIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_sub_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "list sub-issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/sub_issues").andThen(
+						expectQueryParams(t, map[string]string{
+							"page":     "1",
+							"per_page": "30",
+						}).andThen(
+							mockResponse(t, http.StatusOK, []*github.Issue{
+								{
+									ID:            github.Ptr(int64(2001)),
+									Number:        github.Ptr(43),
+									Title:         github.Ptr("Task"),
+									State:         github.Ptr("closed"),
+									RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+									HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/43"),
+								},
+								{
+									ID:            github.Ptr(int64(2002)),
+									Number:        github.Ptr(7),
+									Title:         github.Ptr("Task elsewhere"),
+									State:         github.Ptr("open"),
+									RepositoryURL: github.Ptr("https://api.github.com/repos/owner/other-repo"),
+									HTMLURL:       github.Ptr("https://github.com/owner/other-repo/issues/7"),
+								},
+							}),
+						),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42").andThen(
+						mockResponse(t, http.StatusOK, parentIssue(2, 1)),
+					),
+				),
+			),
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "issue #42 not found in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListSubIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}))
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				IssueNumber      int              `json:"issue_number"`
+				SubIssues        []subIssue       `json:"sub_issues"`
+				SubIssuesSummary subIssuesSummary `json:"sub_issues_summary"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 42, response.IssueNumber)
+			assert.Equal(t, []subIssue{
+				{ID: 2001, Number: 43, Title: "Task", State: "closed", Repository: "owner/repo", URL: "https://github.com/owner/repo/issues/43"},
+				{ID: 2002, Number: 7, Title: "Task elsewhere", State: "open", Repository: "owner/other-repo", URL: "https://github.com/owner/other-repo/issues/7"},
+			}, response.SubIssues)
+			assert.Equal(t, subIssuesSummary{Total: 2, Completed: 1, PercentCompleted: 50}, response.SubIssuesSummary)
+		})
+	}
+}
+
+func Test_AddSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_id")
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_repo")
+	assert.Contains(t, tool.InputSchema.Properties, "replace_parent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "add by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/sub_issues").andThen(
+						expectRequestBody(t, map[string]any{
+							"sub_issue_id": float64(2001),
+						}).andThen(
+							mockResponse(t, http.StatusCreated, parentIssue(4, 1)),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"sub_issue_id": float64(2001),
+			},
+		},
+		{
+			name: "add by number from another repository, replacing the parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/other-repo/issues/7").andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							ID:     github.Ptr(int64(2001)),
+							Number: github.Ptr(7),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id":   float64(2001),
+						"replace_parent": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, parentIssue(4, 1)),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"sub_issue_number": float64(7),
+				"sub_issue_repo":   "owner/other-repo",
+				"replace_parent":   true,
+			},
+		},
+		{
+			name: "issue from another repository without the relationship permissions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, &github.Issue{
+						ID:     github.Ptr(int64(3001)),
+						Number: github.Ptr(9),
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"sub_issue_number": float64(9),
+				"sub_issue_repo":   "other-org/private-repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot add issue other-org/private-repo#9 as a sub-issue of #42 in owner/repo",
+		},
+		{
+			name: "sub-issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"sub_issue_number": float64(99),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "issue #99 not found in owner/repo",
+		},
+		{
+			name:         "both ID and number",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"sub_issue_id":     float64(2001),
+				"sub_issue_number": float64(7),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "exactly one of sub_issue_id or sub_issue_number must be set",
+		},
+		{
+			name:            "neither ID nor number",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "exactly one of sub_issue_id or sub_issue_number must be set",
+		},
+		{
+			name:         "repository with an ID",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"sub_issue_id":   float64(2001),
+				"sub_issue_repo": "owner/other-repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "sub_issue_repo can only be used with sub_issue_number",
+		},
+		{
+			name:         "invalid repository",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"sub_issue_number": float64(7),
+				"sub_issue_repo":   "other-repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid sub_issue_repo: other-repo, must be owner/repo",
+		},
+		{
+			name: "adding fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"sub_issue_id": float64(2001),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add sub-issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				IssueNumber      int              `json:"issue_number"`
+				SubIssueID       int64            `json:"sub_issue_id"`
+				SubIssuesSummary subIssuesSummary `json:"sub_issues_summary"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 42, response.IssueNumber)
+			assert.Equal(t, int64(2001), response.SubIssueID)
+			assert.Equal(t, subIssuesSummary{Total: 4, Completed: 1, PercentCompleted: 25}, response.SubIssuesSummary)
+		})
+	}
+}
+
+func Test_RemoveSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "remove sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/sub_issue").andThen(
+						expectRequestBody(t, map[string]any{
+							"sub_issue_id": float64(2001),
+						}).andThen(
+							mockResponse(t, http.StatusOK, parentIssue(2, 1)),
+						),
+					),
+				),
+			),
+		},
+		{
+			name: "not a sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "issue 2001 is not a sub-issue of #42 in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"sub_issue_id": float64(2001),
+			}))
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				SubIssueID       int64            `json:"sub_issue_id"`
+				SubIssuesSummary subIssuesSummary `json:"sub_issues_summary"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(2001), response.SubIssueID)
+			assert.Equal(t, subIssuesSummary{Total: 2, Completed: 1, PercentCompleted: 50}, response.SubIssuesSummary)
+		})
+	}
+}
+
+func Test_ReprioritizeSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReprioritizeSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "reprioritize_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "after_id")
+	assert.Contains(t, tool.InputSchema.Properties, "before_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "move after another sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/sub_issues/priority").andThen(
+						expectRequestBody(t, map[string]any{
+							"sub_issue_id": float64(2001),
+							"after_id":     float64(2002),
+						}).andThen(
+							mockResponse(t, http.StatusOK, parentIssue(2, 1)),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"after_id": float64(2002),
+			},
+		},
+		{
+			name: "move before another sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id": float64(2001),
+						"before_id":    float64(2002),
+					}).andThen(
+						mockResponse(t, http.StatusOK, parentIssue(2, 1)),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"before_id": float64(2002),
+			},
+		},
+		{
+			name:         "both after and before",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"after_id":  float64(2002),
+				"before_id": float64(2003),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "exactly one of after_id or before_id must be set",
+		},
+		{
+			name: "not a sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"after_id": float64(9999),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot reprioritize issue 2001",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReprioritizeSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"sub_issue_id": float64(2001),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				SubIssueID int64 `json:"sub_issue_id"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(2001), response.SubIssueID)
+		})
+	}
+}
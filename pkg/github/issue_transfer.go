@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// TransferIssue creates a tool to transfer an issue to another repository. The REST API has no
+// endpoint for this, so it uses the transferIssue GraphQL mutation.
+func TransferIssue(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_issue",
+			mcp.WithDescription(t("TOOL_TRANSFER_ISSUE_DESCRIPTION", "Transfer an issue to another repository of the same owner, keeping its comments and assignees who can be assigned there. Issues can't be transferred to a repository of another user or organization, nor from a private to a public repository. Pull requests can't be transferred")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_TRANSFER_ISSUE_USER_TITLE", "Transfer issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue to transfer"),
+			),
+			mcp.WithString("target_owner",
+				mcp.Description("Owner of the repository to transfer the issue to, defaults to owner. Issues can only be transferred between repositories of the same owner"),
+			),
+			mcp.WithString("target_repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository to transfer the issue to"),
+			),
+			mcp.WithBoolean("create_labels_if_missing",
+				mcp.Description("Create the labels of the issue that don't exist in the target repository, instead of dropping them"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetOwner, err := OptionalParam[string](request, "target_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetRepo, err := RequiredParam[string](request, "target_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			createLabels, err := OptionalParam[bool](request, "create_labels_if_missing")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// The API refuses transfers to another owner with a generic error, so they are rejected upfront
+			if targetOwner != "" && !strings.EqualFold(targetOwner, owner) {
+				return mcp.NewToolResultError(fmt.Sprintf("cannot transfer issue #%d from %s/%s to %s/%s: issues can only be transferred between repositories of the same user or organization", issueNumber, owner, repo, targetOwner, targetRepo)), nil
+			}
+			if strings.EqualFold(targetRepo, repo) {
+				return mcp.NewToolResultError(fmt.Sprintf("issue #%d is already in %s/%s", issueNumber, owner, repo)), nil
+			}
+
+			client := NewGQLClient(getGQLClient)
+
+			var issueQuery struct {
+				Repository struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"issue(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $name)"`
+			}
+			if err := client.Query(ctx, &issueQuery, map[string]any{
+				"owner":  githubv4.String(owner),
+				"name":   githubv4.String(repo),
+				"number": githubv4.Int(issueNumber), // #nosec G115 - issue numbers fit in an int32
+			}); err != nil {
+				if isGQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("issue #%d not found in %s/%s: %s", issueNumber, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to get issue: %w", err)
+			}
+
+			var repositoryQuery struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $name)"`
+			}
+			if err := client.Query(ctx, &repositoryQuery, map[string]any{
+				"owner": githubv4.String(owner),
+				"name":  githubv4.String(targetRepo),
+			}); err != nil {
+				if isGQLNotFound(err) {
+					return mcp.NewToolResultError(fmt.Sprintf("target repository %s/%s not found: %s", owner, targetRepo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to get target repository: %w", err)
+			}
+
+			var transferIssueMutation struct {
+				TransferIssue struct {
+					Issue struct {
+						Number githubv4.Int
+						URL    githubv4.String
+					}
+				} `graphql:"transferIssue(input: $input)"`
+			}
+			input := githubv4.TransferIssueInput{
+				IssueID:      issueQuery.Repository.Issue.ID,
+				RepositoryID: repositoryQuery.Repository.ID,
+			}
+			if createLabels {
+				input.CreateLabelsIfMissing = githubv4.NewBoolean(true)
+			}
+			if err := client.Mutate(ctx, &transferIssueMutation, input, nil); err != nil {
+				// Transfers the API refuses, such as from a private to a public repository, are
+				// reported as errors of the mutation
+				return mcp.NewToolResultError(fmt.Sprintf("failed to transfer issue #%d from %s/%s to %s/%s: %s", issueNumber, owner, repo, owner, targetRepo, err.Error())), nil
+			}
+
+			transferred := transferIssueMutation.TransferIssue.Issue
+			r, err := json.Marshal(map[string]any{
+				"repository": fmt.Sprintf("%s/%s", owner, targetRepo),
+				"number":     int(transferred.Number),
+				"url":        string(transferred.URL),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// isGQLNotFound reports whether err is a GraphQL error for something that doesn't exist, or that
+// the user can't see
+func isGQLNotFound(err error) bool {
+	var gqlErr *GQLError
+	return errors.As(err, &gqlErr) && gqlErr.Kind == GQLErrorNotFound
+}
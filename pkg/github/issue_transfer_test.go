@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TransferIssue(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := TransferIssue(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "target_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "create_labels_if_missing")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "target_repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	issueQuery := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"issue(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $name)"`
+			}{},
+			map[string]any{
+				"owner":  githubv4.String("owner"),
+				"name":   githubv4.String("repo"),
+				"number": githubv4.Int(42),
+			},
+			response,
+		)
+	}
+	foundIssue := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"issue": map[string]any{"id": "I_kwDOA"},
+		},
+	})
+	repositoryQuery := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					ID githubv4.ID
+				} `graphql:"repository(owner: $owner, name: $name)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"name":  githubv4.String("other-repo"),
+			},
+			response,
+		)
+	}
+	foundRepository := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"id": "R_kgDOB"},
+	})
+	transferMutation := func(input githubv4.TransferIssueInput, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				TransferIssue struct {
+					Issue struct {
+						Number githubv4.Int
+						URL    githubv4.String
+					}
+				} `graphql:"transferIssue(input: $input)"`
+			}{},
+			input,
+			nil,
+			response,
+		)
+	}
+	transferred := githubv4mock.DataResponse(map[string]any{
+		"transferIssue": map[string]any{
+			"issue": map[string]any{
+				"number": 7,
+				"url":    "https://github.com/owner/other-repo/issues/7",
+			},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "transfer an issue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueQuery(foundIssue),
+				repositoryQuery(foundRepository),
+				transferMutation(githubv4.TransferIssueInput{
+					IssueID:      githubv4.ID("I_kwDOA"),
+					RepositoryID: githubv4.ID("R_kgDOB"),
+				}, transferred),
+			),
+			requestArgs: map[string]any{},
+		},
+		{
+			name: "transfer creating the missing labels",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueQuery(foundIssue),
+				repositoryQuery(foundRepository),
+				transferMutation(githubv4.TransferIssueInput{
+					IssueID:               githubv4.ID("I_kwDOA"),
+					RepositoryID:          githubv4.ID("R_kgDOB"),
+					CreateLabelsIfMissing: githubv4.NewBoolean(true),
+				}, transferred),
+			),
+			requestArgs: map[string]any{
+				"target_owner":             "Owner",
+				"create_labels_if_missing": true,
+			},
+		},
+		{
+			name:         "transfer to another owner",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"target_owner": "other-org",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "issues can only be transferred between repositories of the same user or organization",
+		},
+		{
+			name:         "transfer to the same repository",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"target_repo": "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "issue #42 is already in owner/repo",
+		},
+		{
+			name: "issue not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueQuery(githubv4mock.ErrorResponse("Could not resolve to an Issue with the number of 42.")),
+			),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "issue #42 not found in owner/repo",
+		},
+		{
+			name: "target repository not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueQuery(foundIssue),
+				repositoryQuery(githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'owner/other-repo'.")),
+			),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "target repository owner/other-repo not found",
+		},
+		{
+			name: "transfer refused",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				issueQuery(foundIssue),
+				repositoryQuery(foundRepository),
+				transferMutation(githubv4.TransferIssueInput{
+					IssueID:      githubv4.ID("I_kwDOA"),
+					RepositoryID: githubv4.ID("R_kgDOB"),
+				}, githubv4mock.ErrorResponse("Issues cannot be transferred from a private repository to a public repository.")),
+			),
+			requestArgs:     map[string]any{},
+			expectToolError: true,
+			expectedErrMsg:  "failed to transfer issue #42 from owner/repo to owner/other-repo: UNKNOWN: Issues cannot be transferred from a private repository to a public repository.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := TransferIssue(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"target_repo":  "other-repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "owner/other-repo", response["repository"])
+			assert.Equal(t, float64(7), response["number"])
+			assert.Equal(t, "https://github.com/owner/other-repo/issues/7", response["url"])
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -403,6 +404,7 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Filter by date (ISO 8601 timestamp)"),
 			),
 			WithPagination(),
+			WithOutputFormat(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -450,12 +452,16 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				opts.Since = timestamp
 			}
 
-			if page, ok := request.GetArguments()["page"].(float64); ok {
-				opts.ListOptions.Page = int(page)
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			opts.ListOptions.Page = pagination.page
+			opts.ListOptions.PerPage = pagination.perPage
 
-			if perPage, ok := request.GetArguments()["perPage"].(float64); ok {
-				opts.ListOptions.PerPage = int(perPage)
+			format, err := OptionalOutputFormat(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
@@ -476,6 +482,10 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list issues: %s", string(body))), nil
 			}
 
+			if format == OutputFormatMarkdown {
+				return mcp.NewToolResultText(renderIssueListMarkdown(issues)), nil
+			}
+
 			r, err := json.Marshal(issues)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal issues: %w", err)
@@ -485,10 +495,33 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 		}
 }
 
+// renderIssueListMarkdown renders issues as a markdown table for the markdown format of list_issues.
+func renderIssueListMarkdown(issues []*github.Issue) string {
+	if len(issues) == 0 {
+		return "_No issues found._\n"
+	}
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		labels := make([]string, 0, len(issue.Labels))
+		for _, l := range issue.Labels {
+			labels = append(labels, l.GetName())
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("#%d", issue.GetNumber()),
+			issue.GetTitle(),
+			issue.GetState(),
+			"@" + issue.GetUser().GetLogin(),
+			orDash(strings.Join(labels, ", ")),
+			formatListTime(issue.GetUpdatedAt()),
+		})
+	}
+	return markdownTable([]string{"#", "Title", "State", "Author", "Labels", "Updated"}, rows)
+}
+
 // UpdateIssue creates a tool to update an existing issue in a GitHub repository.
 func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("update_issue",
-			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_DESCRIPTION", "Update an existing issue in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_DESCRIPTION", "Update an existing issue in a GitHub repository. To close an issue, set state_reason to say whether it was completed, not planned or a duplicate.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_ISSUE_USER_TITLE", "Edit issue"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -515,6 +548,13 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("New state"),
 				mcp.Enum("open", "closed"),
 			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for the state change: 'completed', 'not_planned' or 'duplicate' to close the issue, 'reopened' to reopen it. Implies the state when it isn't given"),
+				mcp.Enum(issueStateReasons...),
+			),
+			mcp.WithNumber("duplicate_of",
+				mcp.Description("Number of the issue this one duplicates, to close it as a duplicate with a 'Duplicate of #N' comment"),
+			),
 			mcp.WithArray("labels",
 				mcp.Description("New labels"),
 				mcp.Items(
@@ -573,6 +613,32 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			duplicateOf, err := OptionalIntParam(request, "duplicate_of")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if duplicateOf != 0 {
+				if stateReason == "" {
+					stateReason = "duplicate"
+				}
+				if stateReason != "duplicate" {
+					return mcp.NewToolResultError(fmt.Sprintf("duplicate_of can only be used with state_reason duplicate, not %s", stateReason)), nil
+				}
+				if duplicateOf == issueNumber {
+					return mcp.NewToolResultError("an issue cannot be a duplicate of itself"), nil
+				}
+			}
+			if stateReason != "" {
+				state, err = stateForReason(state, stateReason)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				issueRequest.StateReason = github.Ptr(stateReason)
+			}
 			if state != "" {
 				issueRequest.State = github.Ptr(state)
 			}
@@ -622,7 +688,183 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to update issue: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(updatedIssue)
+			result := struct {
+				*github.Issue
+				DuplicateCommentURL string `json:"duplicate_comment_url,omitempty"`
+			}{Issue: updatedIssue}
+
+			// GitHub links the issues and shows the duplicate in the timeline from this comment
+			if duplicateOf != 0 {
+				comment, commentResp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, &github.IssueComment{
+					Body: github.Ptr(fmt.Sprintf("Duplicate of #%d", duplicateOf)),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("issue was closed as a duplicate, but failed to add the duplicate comment: %w", err)
+				}
+				_ = commentResp.Body.Close()
+				result.DuplicateCommentURL = comment.GetHTMLURL()
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// issueStateReasons are the reasons the API accepts for changing the state of an issue
+var issueStateReasons = []string{"completed", "not_planned", "duplicate", "reopened"}
+
+// stateForReason checks that a state reason is allowed for the state, and returns the state the
+// reason implies when no state is given: reopened is only for opening an issue, the others are
+// only for closing one.
+func stateForReason(state, reason string) (string, error) {
+	if !slices.Contains(issueStateReasons, reason) {
+		return "", fmt.Errorf("invalid state_reason: %s, must be one of %s", reason, strings.Join(issueStateReasons, ", "))
+	}
+	implied := "closed"
+	if reason == "reopened" {
+		implied = "open"
+	}
+	if state != "" && state != implied {
+		return "", fmt.Errorf("state_reason %s can only be used with state %s, not %s", reason, implied, state)
+	}
+	return implied, nil
+}
+
+// issueLockReasons are the reasons the API accepts for locking an issue
+var issueLockReasons = []string{"off-topic", "too heated", "resolved", "spam"}
+
+// LockIssue creates a tool to lock the conversation of an issue.
+func LockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("lock_issue",
+			mcp.WithDescription(t("TOOL_LOCK_ISSUE_DESCRIPTION", "Lock the conversation of an issue, so only collaborators can comment on it. Pull requests share their numbers with issues, so this also locks pull requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LOCK_ISSUE_USER_TITLE", "Lock issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to lock"),
+			),
+			mcp.WithString("lock_reason",
+				mcp.Description("Reason for locking, shown on the issue"),
+				mcp.Enum(issueLockReasons...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lockReason, err := OptionalParam[string](request, "lock_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if lockReason != "" && !slices.Contains(issueLockReasons, lockReason) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid lock_reason: %s, must be one of %s", lockReason, strings.Join(issueLockReasons, ", "))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Lock(ctx, owner, repo, issueNumber, &github.LockIssueOptions{LockReason: lockReason})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("issue #%d not found in %s/%s", issueNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to lock issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"issue_number": issueNumber,
+				"action":       "locked",
+			}
+			if lockReason != "" {
+				result["lock_reason"] = lockReason
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UnlockIssue creates a tool to unlock the conversation of an issue.
+func UnlockIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unlock_issue",
+			mcp.WithDescription(t("TOOL_UNLOCK_ISSUE_DESCRIPTION", "Unlock the conversation of an issue, so everyone can comment on it again. Pull requests share their numbers with issues, so this also unlocks pull requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNLOCK_ISSUE_USER_TITLE", "Unlock issue"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number to unlock"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.Unlock(ctx, owner, repo, issueNumber)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("issue #%d not found in %s/%s", issueNumber, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to unlock issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"issue_number": issueNumber,
+				"action":       "unlocked",
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -671,19 +913,15 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			page, err := OptionalIntParamWithDefault(request, "page", 1)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			perPage, err := OptionalIntParamWithDefault(request, "per_page", 30)
+			pagination, err := OptionalNamedPaginationParams(request, "page", "per_page")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.IssueListCommentsOptions{
 				ListOptions: github.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
 				},
 			}
 
@@ -752,6 +752,8 @@ func Test_UpdateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "title")
 	assert.Contains(t, tool.InputSchema.Properties, "body")
 	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "duplicate_of")
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
@@ -946,6 +948,463 @@ func Test_UpdateIssue(t *testing.T) {
 	}
 }
 
+func Test_UpdateIssue_StateReason(t *testing.T) {
+	issueWithState := func(state, reason string) *github.Issue {
+		return &github.Issue{
+			Number:      github.Ptr(123),
+			State:       github.Ptr(state),
+			StateReason: github.Ptr(reason),
+		}
+	}
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]any
+		expectError         bool
+		expectToolError     bool
+		expectedErrMsg      string
+		expectedState       string
+		expectedStateReason string
+		expectedCommentURL  string
+	}{
+		{
+			name: "close as completed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "completed",
+					}).andThen(
+						mockResponse(t, http.StatusOK, issueWithState("closed", "completed")),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state":        "closed",
+				"state_reason": "completed",
+			},
+			expectedState:       "closed",
+			expectedStateReason: "completed",
+		},
+		{
+			name: "close as not planned without a state",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "not_planned",
+					}).andThen(
+						mockResponse(t, http.StatusOK, issueWithState("closed", "not_planned")),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state_reason": "not_planned",
+			},
+			expectedState:       "closed",
+			expectedStateReason: "not_planned",
+		},
+		{
+			name: "close as duplicate",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "duplicate",
+					}).andThen(
+						mockResponse(t, http.StatusOK, issueWithState("closed", "duplicate")),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state_reason": "duplicate",
+			},
+			expectedState:       "closed",
+			expectedStateReason: "duplicate",
+		},
+		{
+			name: "reopen",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "open",
+						"state_reason": "reopened",
+					}).andThen(
+						mockResponse(t, http.StatusOK, issueWithState("open", "reopened")),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state":        "open",
+				"state_reason": "reopened",
+			},
+			expectedState:       "open",
+			expectedStateReason: "reopened",
+		},
+		{
+			name: "close as duplicate of another issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "duplicate",
+					}).andThen(
+						mockResponse(t, http.StatusOK, issueWithState("closed", "duplicate")),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/123/comments").andThen(
+						expectRequestBody(t, map[string]any{
+							"body": "Duplicate of #45",
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.IssueComment{
+								HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123#issuecomment-1"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"duplicate_of": float64(45),
+			},
+			expectedState:       "closed",
+			expectedStateReason: "duplicate",
+			expectedCommentURL:  "https://github.com/owner/repo/issues/123#issuecomment-1",
+		},
+		{
+			name: "duplicate comment fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, issueWithState("closed", "duplicate")),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"state_reason": "duplicate",
+				"duplicate_of": float64(45),
+			},
+			expectError:    true,
+			expectedErrMsg: "issue was closed as a duplicate, but failed to add the duplicate comment",
+		},
+		{
+			name:         "reopened with state closed",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state":        "closed",
+				"state_reason": "reopened",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "state_reason reopened can only be used with state open, not closed",
+		},
+		{
+			name:         "completed with state open",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state":        "open",
+				"state_reason": "completed",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "state_reason completed can only be used with state closed, not open",
+		},
+		{
+			name:         "invalid state reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state_reason": "wontfix",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid state_reason: wontfix",
+		},
+		{
+			name:         "duplicate_of with another reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state_reason": "not_planned",
+				"duplicate_of": float64(45),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "duplicate_of can only be used with state_reason duplicate, not not_planned",
+		},
+		{
+			name:         "duplicate of itself",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"duplicate_of": float64(123),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "an issue cannot be a duplicate of itself",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response struct {
+				State               string `json:"state"`
+				StateReason         string `json:"state_reason"`
+				DuplicateCommentURL string `json:"duplicate_comment_url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedState, response.State)
+			assert.Equal(t, tc.expectedStateReason, response.StateReason)
+			assert.Equal(t, tc.expectedCommentURL, response.DuplicateCommentURL)
+		})
+	}
+}
+
+func Test_LockIssue(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := LockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "lock_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "lock_reason")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	lockedWithReason := func(reason string) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+				expectPath(t, "/repos/owner/repo/issues/42/lock").andThen(
+					expectRequestBody(t, map[string]any{"lock_reason": reason}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		lockReason      string
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name:         "lock as off-topic",
+			mockedClient: lockedWithReason("off-topic"),
+			lockReason:   "off-topic",
+		},
+		{
+			name:         "lock as too heated",
+			mockedClient: lockedWithReason("too heated"),
+			lockReason:   "too heated",
+		},
+		{
+			name:         "lock as resolved",
+			mockedClient: lockedWithReason("resolved"),
+			lockReason:   "resolved",
+		},
+		{
+			name:         "lock as spam",
+			mockedClient: lockedWithReason("spam"),
+			lockReason:   "spam",
+		},
+		{
+			name: "lock without a reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name:            "invalid reason",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			lockReason:      "boring",
+			expectToolError: true,
+			expectedErrMsg:  "invalid lock_reason: boring",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "issue #42 not found in owner/repo",
+		},
+		{
+			name: "locking fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to lock issue",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := LockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}
+			if tc.lockReason != "" {
+				args["lock_reason"] = tc.lockReason
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(42), response["issue_number"])
+			assert.Equal(t, "locked", response["action"])
+			if tc.lockReason != "" {
+				assert.Equal(t, tc.lockReason, response["lock_reason"])
+			} else {
+				assert.NotContains(t, response, "lock_reason")
+			}
+		})
+	}
+}
+
+func Test_UnlockIssue(t *testing.T) {
+	// Verify tool definition
+	mockClient := github.NewClient(nil)
+	tool, _ := UnlockIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unlock_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "unlock an issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLockByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/lock").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "issue #42 not found in owner/repo",
+		},
+		{
+			name: "unlocking fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLockByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to unlock issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UnlockIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(42), response["issue_number"])
+			assert.Equal(t, "unlocked", response["action"])
+		})
+	}
+}
+
 func Test_ParseISOTimestamp(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1550,3 +2009,30 @@ func TestAssignCopilotToIssue(t *testing.T) {
 		})
 	}
 }
+
+func Test_renderIssueListMarkdown(t *testing.T) {
+	updated := &github.Timestamp{Time: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)}
+	issues := []*github.Issue{
+		{
+			Number:    github.Ptr(42),
+			Title:     github.Ptr("Crash when | is in the title"),
+			State:     github.Ptr("open"),
+			User:      &github.User{Login: github.Ptr("octocat")},
+			Labels:    []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+			UpdatedAt: updated,
+		},
+		{
+			Number: github.Ptr(7),
+			Title:  github.Ptr("Multi\nline title"),
+			State:  github.Ptr("closed"),
+			User:   &github.User{Login: github.Ptr("hubot")},
+		},
+	}
+
+	expected := "| # | Title | State | Author | Labels | Updated |\n" +
+		"| --- | --- | --- | --- | --- | --- |\n" +
+		"| #42 | Crash when \\| is in the title | open | @octocat | bug, p1 | 2024-03-01 09:30 |\n" +
+		"| #7 | Multi line title | closed | @hubot | - | - |\n"
+	assert.Equal(t, expected, renderIssueListMarkdown(issues))
+	assert.Equal(t, "_No issues found._\n", renderIssueListMarkdown(nil))
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -46,6 +47,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are listed."),
 			),
 			WithPagination(),
+			WithOutputFormat(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -82,6 +84,11 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			format, err := OptionalOutputFormat(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// Build options
 			opts := &github.NotificationListOptions{
 				All:           filter == FilterIncludeRead,
@@ -130,6 +137,10 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get notifications: %s", string(body))), nil
 			}
 
+			if format == OutputFormatMarkdown {
+				return mcp.NewToolResultText(renderNotificationListMarkdown(notifications)), nil
+			}
+
 			// Marshal response to JSON
 			r, err := json.Marshal(notifications)
 			if err != nil {
@@ -140,6 +151,31 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 		}
 }
 
+// renderNotificationListMarkdown renders notifications as a bulleted list for the markdown format of
+// list_notifications. The thread ID is kept so that the notification tools can act on an entry.
+func renderNotificationListMarkdown(notifications []*github.Notification) string {
+	if len(notifications) == 0 {
+		return "_No notifications._\n"
+	}
+	var b strings.Builder
+	for _, n := range notifications {
+		marker := ""
+		if n.GetUnread() {
+			marker = "**[unread]** "
+		}
+		fmt.Fprintf(&b, "- %s%s %s: %s (%s, updated %s, thread `%s`)\n",
+			marker,
+			n.GetRepository().GetFullName(),
+			n.GetSubject().GetType(),
+			n.GetSubject().GetTitle(),
+			n.GetReason(),
+			formatListTime(n.GetUpdatedAt()),
+			n.GetID(),
+		)
+	}
+	return b.String()
+}
+
 // DismissNotification creates a tool to mark a notification as read/done.
 func DismissNotification(getclient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("dismiss_notification",
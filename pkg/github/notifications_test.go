@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -754,3 +755,30 @@ func Test_GetNotificationDetails(t *testing.T) {
 		})
 	}
 }
+
+func Test_renderNotificationListMarkdown(t *testing.T) {
+	updated := &github.Timestamp{Time: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)}
+	notifications := []*github.Notification{
+		{
+			ID:         github.Ptr("101"),
+			Unread:     github.Ptr(true),
+			Reason:     github.Ptr("review_requested"),
+			Repository: &github.Repository{FullName: github.Ptr("octo/repo")},
+			Subject:    &github.NotificationSubject{Type: github.Ptr("PullRequest"), Title: github.Ptr("Add markdown output")},
+			UpdatedAt:  updated,
+		},
+		{
+			ID:         github.Ptr("102"),
+			Unread:     github.Ptr(false),
+			Reason:     github.Ptr("mention"),
+			Repository: &github.Repository{FullName: github.Ptr("octo/docs")},
+			Subject:    &github.NotificationSubject{Type: github.Ptr("Issue"), Title: github.Ptr("Typo in README")},
+			UpdatedAt:  updated,
+		},
+	}
+
+	expected := "- **[unread]** octo/repo PullRequest: Add markdown output (review_requested, updated 2024-03-01 09:30, thread `101`)\n" +
+		"- octo/docs Issue: Typo in README (mention, updated 2024-03-01 09:30, thread `102`)\n"
+	assert.Equal(t, expected, renderNotificationListMarkdown(notifications))
+	assert.Equal(t, "_No notifications._\n", renderNotificationListMarkdown(nil))
+}
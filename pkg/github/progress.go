@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"io"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressByteInterval is the number of bytes read between two progress notifications of a download.
+const progressByteInterval = 1024 * 1024
+
+// ProgressReporter emits progress notifications for a tool call. When the client did not send a
+// progress token with the request, or there is no server in the context, reporting is a no-op.
+// A nil *ProgressReporter is also a valid no-op reporter.
+type ProgressReporter struct {
+	ctx    context.Context
+	server *server.MCPServer
+	token  mcp.ProgressToken
+}
+
+// NewProgressReporter creates a progress reporter for the tool call request.
+func NewProgressReporter(ctx context.Context, request mcp.CallToolRequest) *ProgressReporter {
+	var token mcp.ProgressToken
+	if request.Params.Meta != nil {
+		token = request.Params.Meta.ProgressToken
+	}
+	return &ProgressReporter{
+		ctx:    ctx,
+		server: server.ServerFromContext(ctx),
+		token:  token,
+	}
+}
+
+// Enabled reports whether the client asked for progress notifications.
+func (p *ProgressReporter) Enabled() bool {
+	return p != nil && p.token != nil && p.server != nil
+}
+
+// Report sends a progress notification. Progress must increase with every call. A total of zero
+// means the total is unknown, and an empty message is omitted.
+func (p *ProgressReporter) Report(progress float64, total float64, message string) {
+	if !p.Enabled() {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	// Progress is best effort, a client that can't receive notifications shouldn't fail the tool call
+	_ = p.server.SendNotificationToClient(p.ctx, "notifications/progress", params)
+}
+
+// Reader wraps r so that the number of bytes read is reported every progressByteInterval bytes
+// and once more when the reader is exhausted. A total of zero means the size is unknown.
+func (p *ProgressReporter) Reader(r io.Reader, total int64) io.Reader {
+	if !p.Enabled() {
+		return r
+	}
+	return &progressReader{reader: r, progress: p, total: total}
+}
+
+type progressReader struct {
+	reader       io.Reader
+	progress     *ProgressReporter
+	total        int64
+	read         int64
+	lastReported int64
+	done         bool
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.reader.Read(b)
+	r.read += int64(n)
+
+	switch {
+	case err == io.EOF && !r.done:
+		r.done = true
+		if r.read > r.lastReported || r.read == 0 {
+			r.report()
+		}
+	case r.read-r.lastReported >= progressByteInterval:
+		r.report()
+	}
+
+	return n, err
+}
+
+func (r *progressReader) report() {
+	r.lastReported = r.read
+	r.progress.Report(float64(r.read), float64(r.total), "")
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notificationSink is a client session that collects the notifications sent to it.
+type notificationSink struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newNotificationSink() *notificationSink {
+	return &notificationSink{notifications: make(chan mcp.JSONRPCNotification, 100)}
+}
+
+func (s *notificationSink) Initialize()       {}
+func (s *notificationSink) Initialized() bool { return true }
+func (s *notificationSink) SessionID() string { return "progress-test" }
+func (s *notificationSink) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+// progressEvents returns the params of the progress notifications received so far, in order.
+func (s *notificationSink) progressEvents() []map[string]any {
+	var events []map[string]any
+	for {
+		select {
+		case n := <-s.notifications:
+			if n.Method == "notifications/progress" {
+				events = append(events, n.Params.AdditionalFields)
+			}
+		default:
+			return events
+		}
+	}
+}
+
+// callToolWithProgress calls the tool through an MCP server, so that the handler has access to the
+// server and client session, and returns the result with the progress notifications received.
+func callToolWithProgress(t *testing.T, tool mcp.Tool, handler server.ToolHandlerFunc, args map[string]any, progressToken any) (mcp.CallToolResult, []map[string]any) {
+	t.Helper()
+	s := server.NewMCPServer("test", "1.0.0")
+	s.AddTool(tool, handler)
+
+	sink := newNotificationSink()
+	require.NoError(t, s.RegisterSession(context.Background(), sink))
+	ctx := s.WithContext(context.Background(), sink)
+
+	params := map[string]any{"name": tool.Name, "arguments": args}
+	if progressToken != nil {
+		params["_meta"] = map[string]any{"progressToken": progressToken}
+	}
+	message, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  params,
+	})
+	require.NoError(t, err)
+
+	response, ok := s.HandleMessage(ctx, message).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := response.Result.(mcp.CallToolResult)
+	require.True(t, ok)
+
+	return result, sink.progressEvents()
+}
+
+func Test_ProgressReporter_NoopWithoutToken(t *testing.T) {
+	var nilReporter *ProgressReporter
+	assert.False(t, nilReporter.Enabled())
+	nilReporter.Report(1, 2, "ignored")
+
+	reporter := NewProgressReporter(context.Background(), createMCPRequest(nil))
+	assert.False(t, reporter.Enabled())
+
+	r := strings.NewReader("content")
+	assert.Same(t, r, reporter.Reader(r, 7))
+}
+
+func Test_ProgressReporter_Reader(t *testing.T) {
+	tool := mcp.NewTool("download")
+	content := bytes.Repeat([]byte("x"), 2*progressByteInterval+10)
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		progress := NewProgressReporter(ctx, request)
+		data, err := io.ReadAll(progress.Reader(bytes.NewReader(content), int64(len(content))))
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(string(data[:1])), nil
+	}
+
+	result, events := callToolWithProgress(t, tool, handler, nil, "download-token")
+	require.False(t, result.IsError)
+	require.NotEmpty(t, events)
+
+	var last float64
+	for _, event := range events {
+		assert.Equal(t, "download-token", event["progressToken"])
+		assert.Equal(t, float64(len(content)), event["total"])
+		progress, ok := event["progress"].(float64)
+		require.True(t, ok)
+		assert.Greater(t, progress, last)
+		last = progress
+	}
+	assert.Equal(t, float64(len(content)), last)
+
+	_, events = callToolWithProgress(t, tool, handler, nil, nil)
+	assert.Empty(t, events)
+}
+
+func Test_GetJobLogs_FailedOnlyReportsProgress(t *testing.T) {
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("log line"))
+	}))
+	defer logServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+			&github.Jobs{
+				TotalCount: github.Ptr(3),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("lint"), Conclusion: github.Ptr("success")},
+					{ID: github.Ptr(int64(3)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", logServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+	tool, handler := GetJobLogs(stubGetClientFn(github.NewClient(mockedClient)), translations.NullTranslationHelper)
+
+	result, events := callToolWithProgress(t, tool, handler, map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"run_id":         float64(42),
+		"failed_only":    true,
+		"return_content": true,
+	}, float64(7))
+	require.False(t, result.IsError)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, float64(7), events[0]["progressToken"])
+	assert.Equal(t, float64(1), events[0]["progress"])
+	assert.Equal(t, float64(2), events[0]["total"])
+	assert.Equal(t, float64(2), events[1]["progress"])
+	// The logs are retrieved concurrently, in any order
+	assert.ElementsMatch(t, []any{"Retrieved logs for job build", "Retrieved logs for job test"}, []any{events[0]["message"], events[1]["message"]})
+}
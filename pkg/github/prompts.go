@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// The tools each prompt tells the model to call. A prompt is only registered if all of them are
+// available, so that it does not refer to tools removed by the read-only mode or the tool filter.
+var (
+	reviewPullRequestPromptTools = []string{
+		"get_pull_request",
+		"get_pull_request_diff",
+		"get_pull_request_comments",
+		"get_pull_request_reviews",
+		"create_pending_pull_request_review",
+		"add_pull_request_review_comment_to_pending_review",
+		"submit_pending_pull_request_review",
+	}
+	triageFailedWorkflowRunPromptTools = []string{"get_workflow_run", "get_job_logs"}
+	issueTriagePromptTools             = []string{"get_issue", "get_issue_comments", "search_issues", "update_issue", "add_issue_comment"}
+)
+
+// RegisterPrompts registers the prompts for common GitHub workflows with the server. The prompts
+// refer to the tools of the toolset group by their registered names, including the tool prefix.
+func RegisterPrompts(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) {
+	if promptToolsAvailable(tsg, reviewPullRequestPromptTools...) {
+		s.AddPrompt(ReviewPullRequestPrompt(tsg, t))
+	}
+	if promptToolsAvailable(tsg, triageFailedWorkflowRunPromptTools...) {
+		s.AddPrompt(TriageFailedWorkflowRunPrompt(tsg, t))
+	}
+	if promptToolsAvailable(tsg, issueTriagePromptTools...) {
+		s.AddPrompt(IssueTriagePrompt(tsg, t))
+	}
+}
+
+// promptToolsAvailable reports whether the group provides all the named tools, whether their
+// toolsets are enabled or not, since dynamic toolsets can enable them later.
+func promptToolsAvailable(tsg *toolsets.ToolsetGroup, names ...string) bool {
+	available := tsg.AvailableToolNames()
+	for _, name := range names {
+		if !slices.Contains(available, tsg.ToolPrefix()+name) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReviewPullRequestPrompt creates a prompt that guides the model through reviewing a pull request.
+func ReviewPullRequestPrompt(tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	p := tsg.ToolPrefix()
+	return mcp.NewPrompt("review_pull_request",
+			mcp.WithPromptDescription(t("PROMPT_REVIEW_PULL_REQUEST_DESCRIPTION", "Review a pull request and leave a review with inline comments")),
+			mcp.WithArgument("owner",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Repository owner"),
+			),
+			mcp.WithArgument("repo",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Repository name"),
+			),
+			mcp.WithArgument("pull_number",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Pull request number"),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			args, err := requiredPromptArguments(request, "owner", "repo", "pull_number")
+			if err != nil {
+				return nil, err
+			}
+			owner, repo, pullNumber := args[0], args[1], args[2]
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Review pull request #%s in %s/%s", pullNumber, owner, repo),
+				Messages: []mcp.PromptMessage{
+					mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(fmt.Sprintf(
+						"Please review pull request #%[3]s in the repository %[1]s/%[2]s.\n\n"+
+							"1. Use %[4]sget_pull_request to understand the purpose of the change, and %[4]sget_pull_request_diff to read the changes.\n"+
+							"2. Use %[4]sget_pull_request_comments and %[4]sget_pull_request_reviews to read the existing review threads, so you do not repeat feedback that has already been given.\n"+
+							"3. Start a review with %[4]screate_pending_pull_request_review.\n"+
+							"4. Add inline feedback with %[4]sadd_pull_request_review_comment_to_pending_review, one comment per issue, referencing the exact lines.\n"+
+							"5. Finish with %[4]ssubmit_pending_pull_request_review, choosing APPROVE, REQUEST_CHANGES or COMMENT and summarising your findings.\n\n"+
+							"Focus on correctness, security, and maintainability rather than style nits.",
+						owner, repo, pullNumber, p,
+					))),
+				},
+			}, nil
+		}
+}
+
+// TriageFailedWorkflowRunPrompt creates a prompt that guides the model through diagnosing a failed workflow run.
+func TriageFailedWorkflowRunPrompt(tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	p := tsg.ToolPrefix()
+	rerun := "If it looks flaky, suggest rerunning the failed jobs."
+	if promptToolsAvailable(tsg, "rerun_failed_jobs") {
+		rerun = "If it looks flaky, suggest " + p + "rerun_failed_jobs."
+	}
+	return mcp.NewPrompt("triage_failed_workflow_run",
+			mcp.WithPromptDescription(t("PROMPT_TRIAGE_FAILED_WORKFLOW_RUN_DESCRIPTION", "Diagnose why a GitHub Actions workflow run failed and suggest a fix")),
+			mcp.WithArgument("owner",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Repository owner"),
+			),
+			mcp.WithArgument("repo",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Repository name"),
+			),
+			mcp.WithArgument("run_id",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Workflow run ID"),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			args, err := requiredPromptArguments(request, "owner", "repo", "run_id")
+			if err != nil {
+				return nil, err
+			}
+			owner, repo, runID := args[0], args[1], args[2]
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Triage failed workflow run %s in %s/%s", runID, owner, repo),
+				Messages: []mcp.PromptMessage{
+					mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(fmt.Sprintf(
+						"Workflow run %[3]s in the repository %[1]s/%[2]s has failed. Please find out why.\n\n"+
+							"1. Use %[4]sget_workflow_run to see which workflow, commit and event triggered the run.\n"+
+							"2. Use %[4]sget_job_logs with run_id=%[3]s, failed_only=true and return_content=true to read the logs of every failed job in one call. Do not download the full run logs.\n"+
+							"3. Identify the first real error in each failed job, ignoring cascading failures.\n"+
+							"4. Explain the root cause, state whether it looks like a flaky failure or a genuine regression, and suggest a fix. %[5]s",
+						owner, repo, runID, p, rerun,
+					))),
+				},
+			}, nil
+		}
+}
+
+// IssueTriagePrompt creates a prompt that guides the model through triaging an issue.
+func IssueTriagePrompt(tsg *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	p := tsg.ToolPrefix()
+	return mcp.NewPrompt("issue_triage",
+			mcp.WithPromptDescription(t("PROMPT_ISSUE_TRIAGE_DESCRIPTION", "Triage an issue: classify it, apply labels and ask for missing information")),
+			mcp.WithArgument("owner",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Repository owner"),
+			),
+			mcp.WithArgument("repo",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Repository name"),
+			),
+			mcp.WithArgument("issue_number",
+				mcp.RequiredArgument(),
+				mcp.ArgumentDescription("Issue number"),
+			),
+		),
+		func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			args, err := requiredPromptArguments(request, "owner", "repo", "issue_number")
+			if err != nil {
+				return nil, err
+			}
+			owner, repo, issueNumber := args[0], args[1], args[2]
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Triage issue #%s in %s/%s", issueNumber, owner, repo),
+				Messages: []mcp.PromptMessage{
+					mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(fmt.Sprintf(
+						"Please triage issue #%[3]s in the repository %[1]s/%[2]s.\n\n"+
+							"1. Use %[4]sget_issue and %[4]sget_issue_comments to understand the report.\n"+
+							"2. Use %[4]ssearch_issues to look for duplicates in the same repository.\n"+
+							"3. Classify the issue as a bug, feature request, question or documentation problem.\n"+
+							"4. Apply labels with %[4]supdate_issue. Only use labels that already exist in the repository, prefer one type label plus any relevant area labels, and do not remove labels added by maintainers.\n"+
+							"5. If information needed to reproduce or act on the issue is missing, ask for it with %[4]sadd_issue_comment. If it is a duplicate, link the original issue in a comment instead.",
+						owner, repo, issueNumber, p,
+					))),
+				},
+			}, nil
+		}
+}
+
+// requiredPromptArguments returns the values of the named prompt arguments, in order,
+// or an error if any of them is missing or empty.
+func requiredPromptArguments(request mcp.GetPromptRequest, names ...string) ([]string, error) {
+	values := make([]string, len(names))
+	for i, name := range names {
+		value := request.Params.Arguments[name]
+		if value == "" {
+			return nil, fmt.Errorf("missing required argument: %s", name)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
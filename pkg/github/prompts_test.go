@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Prompts(t *testing.T) {
+	tests := []struct {
+		name          string
+		prompt        func(*toolsets.ToolsetGroup, translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc)
+		promptName    string
+		arguments     map[string]string
+		expectedTools []string
+		expectedText  []string
+	}{
+		{
+			name:       "review pull request",
+			prompt:     ReviewPullRequestPrompt,
+			promptName: "review_pull_request",
+			arguments:  map[string]string{"owner": "octo", "repo": "hello", "pull_number": "42"},
+			expectedTools: []string{
+				"get_pull_request_diff",
+				"get_pull_request_comments",
+				"create_pending_pull_request_review",
+				"add_pull_request_review_comment_to_pending_review",
+				"submit_pending_pull_request_review",
+			},
+			expectedText: []string{"#42", "octo/hello"},
+		},
+		{
+			name:          "triage failed workflow run",
+			prompt:        TriageFailedWorkflowRunPrompt,
+			promptName:    "triage_failed_workflow_run",
+			arguments:     map[string]string{"owner": "octo", "repo": "hello", "run_id": "1234"},
+			expectedTools: []string{"get_workflow_run", "get_job_logs", "rerun_failed_jobs"},
+			expectedText:  []string{"run_id=1234", "failed_only=true"},
+		},
+		{
+			name:          "issue triage",
+			prompt:        IssueTriagePrompt,
+			promptName:    "issue_triage",
+			arguments:     map[string]string{"owner": "octo", "repo": "hello", "issue_number": "7"},
+			expectedTools: []string{"get_issue", "search_issues", "update_issue", "add_issue_comment"},
+			expectedText:  []string{"#7", "labels"},
+		},
+	}
+
+	// Collect every tool name so we can check prompts only reference tools that exist
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, ContentLimits{})
+	require.NoError(t, tsg.EnableToolsets([]string{"all"}))
+	toolNames := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			toolNames[tool.Tool.Name] = true
+		}
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt, handler := tc.prompt(tsg, translations.NullTranslationHelper)
+
+			assert.Equal(t, tc.promptName, prompt.Name)
+			assert.NotEmpty(t, prompt.Description)
+			require.Len(t, prompt.Arguments, len(tc.arguments))
+			for _, arg := range prompt.Arguments {
+				assert.Contains(t, tc.arguments, arg.Name)
+				assert.True(t, arg.Required, "argument %s should be required", arg.Name)
+				assert.NotEmpty(t, arg.Description)
+			}
+
+			request := mcp.GetPromptRequest{}
+			request.Params.Name = tc.promptName
+			request.Params.Arguments = tc.arguments
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.Len(t, result.Messages, 1)
+			assert.Equal(t, mcp.RoleUser, result.Messages[0].Role)
+
+			text := result.Messages[0].Content.(mcp.TextContent).Text
+			for _, tool := range tc.expectedTools {
+				assert.True(t, toolNames[tool], "prompt references unknown tool %s", tool)
+				assert.Contains(t, text, tool)
+			}
+			for _, expected := range tc.expectedText {
+				assert.Contains(t, text, expected)
+			}
+
+			// Missing required arguments are rejected
+			request.Params.Arguments = map[string]string{"owner": "octo"}
+			_, err = handler(context.Background(), request)
+			assert.ErrorContains(t, err, "missing required argument")
+		})
+	}
+}
+
+// listPrompts returns the prompts registered with the server, by name.
+func listPrompts(t *testing.T, s *server.MCPServer) map[string]mcp.Prompt {
+	t.Helper()
+	resp, ok := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`)).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := resp.Result.(mcp.ListPromptsResult)
+	require.True(t, ok)
+
+	prompts := make(map[string]mcp.Prompt, len(result.Prompts))
+	for _, prompt := range result.Prompts {
+		prompts[prompt.Name] = prompt
+	}
+	return prompts
+}
+
+// getPromptText renders the prompt registered with the server and returns the text of its message.
+func getPromptText(t *testing.T, s *server.MCPServer, name string, arguments map[string]string) string {
+	t.Helper()
+	params, err := json.Marshal(map[string]any{"name": name, "arguments": arguments})
+	require.NoError(t, err)
+	resp, ok := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/get","params":`+string(params)+`}`)).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	result, ok := resp.Result.(mcp.GetPromptResult)
+	require.True(t, ok)
+	require.Len(t, result.Messages, 1)
+	return result.Messages[0].Content.(mcp.TextContent).Text
+}
+
+func Test_RegisterPrompts(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.1")
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, ContentLimits{})
+	RegisterPrompts(s, tsg, translations.NullTranslationHelper)
+
+	assert.ElementsMatch(t, []string{"review_pull_request", "triage_failed_workflow_run", "issue_triage"}, slices.Collect(maps.Keys(listPrompts(t, s))))
+}
+
+func Test_RegisterPrompts_ToolPrefix(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.1")
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, ContentLimits{}, toolsets.WithToolPrefix("github_"))
+	RegisterPrompts(s, tsg, translations.NullTranslationHelper)
+
+	text := getPromptText(t, s, "triage_failed_workflow_run", map[string]string{"owner": "octo", "repo": "hello", "run_id": "1234"})
+	assert.Contains(t, text, "Use github_get_workflow_run")
+	assert.Contains(t, text, "Use github_get_job_logs")
+	assert.Contains(t, text, "suggest github_rerun_failed_jobs")
+
+	text = getPromptText(t, s, "review_pull_request", map[string]string{"owner": "octo", "repo": "hello", "pull_number": "42"})
+	for _, name := range reviewPullRequestPromptTools {
+		assert.Contains(t, text, "github_"+name)
+	}
+	assert.NotContains(t, text, " get_pull_request")
+
+	text = getPromptText(t, s, "issue_triage", map[string]string{"owner": "octo", "repo": "hello", "issue_number": "7"})
+	for _, name := range issueTriagePromptTools {
+		assert.Contains(t, text, "github_"+name)
+	}
+}
+
+func Test_RegisterPrompts_UnavailableTools(t *testing.T) {
+	s := server.NewMCPServer("test", "0.0.1")
+	tsg := DefaultToolsetGroup(true, nil, nil, nil, translations.NullTranslationHelper, ContentLimits{},
+		toolsets.WithToolFilter(nil, []string{"get_pull_request_diff"}))
+	RegisterPrompts(s, tsg, translations.NullTranslationHelper)
+
+	// The review prompt needs a denied tool, and issue triage a write tool removed by the read-only mode
+	assert.ElementsMatch(t, []string{"triage_failed_workflow_run"}, slices.Collect(maps.Keys(listPrompts(t, s))))
+
+	text := getPromptText(t, s, "triage_failed_workflow_run", map[string]string{"owner": "octo", "repo": "hello", "run_id": "1234"})
+	assert.NotContains(t, text, "rerun_failed_jobs")
+	assert.Contains(t, text, "suggest rerunning the failed jobs")
+}
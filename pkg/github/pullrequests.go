@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v72/github"
@@ -349,6 +350,7 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithOutputFormat(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -383,6 +385,10 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			format, err := OptionalOutputFormat(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			opts := &github.PullRequestListOptions{
 				State:     state,
@@ -414,6 +420,10 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
 			}
 
+			if format == OutputFormatMarkdown {
+				return mcp.NewToolResultText(renderPullRequestListMarkdown(prs)), nil
+			}
+
 			r, err := json.Marshal(prs)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -595,6 +605,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputFormat(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -609,6 +620,10 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			format, err := OptionalOutputFormat(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			// First get the PR to find the head SHA
 			client, err := getClient(ctx)
 			if err != nil {
@@ -643,6 +658,10 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil
 			}
 
+			if format == OutputFormatMarkdown {
+				return mcp.NewToolResultText(renderPullRequestStatusMarkdown(status)), nil
+			}
+
 			r, err := json.Marshal(status)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
@@ -652,6 +671,58 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 		}
 }
 
+// renderPullRequestListMarkdown renders pull requests as a markdown table for the markdown format of list_pull_requests.
+func renderPullRequestListMarkdown(prs []*github.PullRequest) string {
+	if len(prs) == 0 {
+		return "_No pull requests found._\n"
+	}
+	rows := make([][]string, 0, len(prs))
+	for _, pr := range prs {
+		state := pr.GetState()
+		switch {
+		case !pr.GetMergedAt().IsZero():
+			state = "merged"
+		case pr.GetDraft():
+			state = "draft"
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("#%d", pr.GetNumber()),
+			pr.GetTitle(),
+			state,
+			"@" + pr.GetUser().GetLogin(),
+			fmt.Sprintf("%s → %s", pr.GetHead().GetRef(), pr.GetBase().GetRef()),
+			formatListTime(pr.GetUpdatedAt()),
+		})
+	}
+	return markdownTable([]string{"#", "Title", "State", "Author", "Branch", "Updated"}, rows)
+}
+
+// renderPullRequestStatusMarkdown renders the combined status of a pull request as a bulleted summary
+// for the markdown format of get_pull_request_status.
+func renderPullRequestStatusMarkdown(status *github.CombinedStatus) string {
+	var b strings.Builder
+	sha := status.GetSHA()
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	fmt.Fprintf(&b, "**Status:** %s for `%s` (%d checks)\n", status.GetState(), sha, len(status.Statuses))
+	if len(status.Statuses) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n")
+	for _, s := range status.Statuses {
+		fmt.Fprintf(&b, "- **%s**: %s", s.GetContext(), s.GetState())
+		if s.GetDescription() != "" {
+			fmt.Fprintf(&b, " — %s", s.GetDescription())
+		}
+		if s.GetTargetURL() != "" {
+			fmt.Fprintf(&b, " ([details](%s))", s.GetTargetURL())
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 // UpdatePullRequestBranch creates a tool to update a pull request branch with the latest changes from the base branch.
 func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("update_pull_request_branch",
@@ -909,10 +980,7 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 			}
 
 			// Given our owner, repo and PR number, lookup the GQL ID of the PR.
-			client, err := getGQLClient(ctx)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
-			}
+			client := NewGQLClient(getGQLClient)
 
 			var getPullRequestQuery struct {
 				Repository struct {
@@ -1000,10 +1068,7 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			}
 
 			// Given our owner, repo and PR number, lookup the GQL ID of the PR.
-			client, err := getGQLClient(ctx)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
-			}
+			client := NewGQLClient(getGQLClient)
 
 			var getPullRequestQuery struct {
 				Repository struct {
@@ -1122,10 +1187,7 @@ func AddPullRequestReviewCommentToPendingReview(getGQLClient GetGQLClientFn, t t
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			client, err := getGQLClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
-			}
+			client := NewGQLClient(getGQLClient)
 
 			// First we'll get the current user
 			var getViewerQuery struct {
@@ -1253,10 +1315,7 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			client, err := getGQLClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
-			}
+			client := NewGQLClient(getGQLClient)
 
 			// First we'll get the current user
 			var getViewerQuery struct {
@@ -1368,10 +1427,7 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			client, err := getGQLClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
-			}
+			client := NewGQLClient(getGQLClient)
 
 			// First we'll get the current user
 			var getViewerQuery struct {
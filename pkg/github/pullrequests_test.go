@@ -2309,3 +2309,64 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 		),
 	)
 }
+
+func Test_renderPullRequestListMarkdown(t *testing.T) {
+	updated := &github.Timestamp{Time: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)}
+	prs := []*github.PullRequest{
+		{
+			Number:    github.Ptr(12),
+			Title:     github.Ptr("Add markdown output"),
+			State:     github.Ptr("open"),
+			Draft:     github.Ptr(true),
+			User:      &github.User{Login: github.Ptr("octocat")},
+			Head:      &github.PullRequestBranch{Ref: github.Ptr("feature/markdown")},
+			Base:      &github.PullRequestBranch{Ref: github.Ptr("main")},
+			UpdatedAt: updated,
+		},
+		{
+			Number:    github.Ptr(11),
+			Title:     github.Ptr("Fix typo"),
+			State:     github.Ptr("closed"),
+			MergedAt:  updated,
+			User:      &github.User{Login: github.Ptr("hubot")},
+			Head:      &github.PullRequestBranch{Ref: github.Ptr("typo")},
+			Base:      &github.PullRequestBranch{Ref: github.Ptr("main")},
+			UpdatedAt: updated,
+		},
+	}
+
+	expected := "| # | Title | State | Author | Branch | Updated |\n" +
+		"| --- | --- | --- | --- | --- | --- |\n" +
+		"| #12 | Add markdown output | draft | @octocat | feature/markdown → main | 2024-03-01 09:30 |\n" +
+		"| #11 | Fix typo | merged | @hubot | typo → main | 2024-03-01 09:30 |\n"
+	assert.Equal(t, expected, renderPullRequestListMarkdown(prs))
+	assert.Equal(t, "_No pull requests found._\n", renderPullRequestListMarkdown(nil))
+}
+
+func Test_renderPullRequestStatusMarkdown(t *testing.T) {
+	status := &github.CombinedStatus{
+		State: github.Ptr("failure"),
+		SHA:   github.Ptr("abcdef1234567890"),
+		Statuses: []*github.RepoStatus{
+			{
+				Context:     github.Ptr("ci/build"),
+				State:       github.Ptr("success"),
+				Description: github.Ptr("Build passed"),
+				TargetURL:   github.Ptr("https://ci.example.com/builds/1"),
+			},
+			{
+				Context: github.Ptr("ci/lint"),
+				State:   github.Ptr("failure"),
+			},
+		},
+	}
+
+	expected := "**Status:** failure for `abcdef1` (2 checks)\n" +
+		"\n" +
+		"- **ci/build**: success — Build passed ([details](https://ci.example.com/builds/1))\n" +
+		"- **ci/lint**: failure\n"
+	assert.Equal(t, expected, renderPullRequestStatusMarkdown(status))
+
+	pending := &github.CombinedStatus{State: github.Ptr("pending"), SHA: github.Ptr("abc")}
+	assert.Equal(t, "**Status:** pending for `abc` (0 checks)\n", renderPullRequestStatusMarkdown(pending))
+}
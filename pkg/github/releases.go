@@ -0,0 +1,734 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// release is a release of a repository with the metadata of its assets
+type release struct {
+	ID          int64          `json:"id"`
+	TagName     string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	Draft       bool           `json:"draft"`
+	Prerelease  bool           `json:"prerelease"`
+	PublishedAt *time.Time     `json:"published_at,omitempty"`
+	HTMLURL     string         `json:"html_url"`
+	Body        string         `json:"body,omitempty"`
+	Assets      []releaseAsset `json:"assets"`
+}
+
+// releaseAsset is a file attached to a release
+type releaseAsset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	ContentType        string `json:"content_type,omitempty"`
+	Size               int    `json:"size"`
+	DownloadCount      int    `json:"download_count"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+func newRelease(r *github.RepositoryRelease) release {
+	assets := make([]releaseAsset, 0, len(r.Assets))
+	for _, asset := range r.Assets {
+		assets = append(assets, releaseAsset{
+			ID:                 asset.GetID(),
+			Name:               asset.GetName(),
+			ContentType:        asset.GetContentType(),
+			Size:               asset.GetSize(),
+			DownloadCount:      asset.GetDownloadCount(),
+			BrowserDownloadURL: asset.GetBrowserDownloadURL(),
+		})
+	}
+	var publishedAt *time.Time
+	if r.PublishedAt != nil {
+		publishedAt = &r.PublishedAt.Time
+	}
+	return release{
+		ID:          r.GetID(),
+		TagName:     r.GetTagName(),
+		Name:        r.GetName(),
+		Draft:       r.GetDraft(),
+		Prerelease:  r.GetPrerelease(),
+		PublishedAt: publishedAt,
+		HTMLURL:     r.GetHTMLURL(),
+		Body:        r.GetBody(),
+		Assets:      assets,
+	}
+}
+
+// ListReleases creates a tool to list the releases of a repository
+func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_releases",
+			mcp.WithDescription(t("TOOL_LIST_RELEASES_DESCRIPTION", "List the releases of a GitHub repository, newest first, with the metadata of their assets")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+			repositoryReleases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list releases: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			releases := make([]release, 0, len(repositoryReleases))
+			for _, r := range repositoryReleases {
+				releases = append(releases, newRelease(r))
+			}
+
+			r, err := json.Marshal(releases)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRelease creates a tool to get a release of a repository by its ID
+func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_DESCRIPTION", "Get a release of a GitHub repository by its ID, with the metadata of its assets")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_USER_TITLE", "Get release"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The ID of the release"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repositoryRelease, resp, err := client.Repositories.GetRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newRelease(repositoryRelease))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetLatestRelease creates a tool to get the latest release of a repository
+func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_latest_release",
+			mcp.WithDescription(t("TOOL_GET_LATEST_RELEASE_DESCRIPTION", "Get the latest published release of a GitHub repository, which is neither a draft nor a prerelease. found is false when the repository has no such release")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var result map[string]any
+			repositoryRelease, resp, err := client.Repositories.GetLatestRelease(ctx, owner, repo)
+			switch {
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// The repository has no published release, which is not an error
+				_ = resp.Body.Close()
+				result = map[string]any{
+					"found":   false,
+					"message": fmt.Sprintf("%s/%s has no published release", owner, repo),
+				}
+			case err != nil:
+				return nil, fmt.Errorf("failed to get latest release: %w", err)
+			default:
+				_ = resp.Body.Close()
+				result = map[string]any{
+					"found":   true,
+					"release": newRelease(repositoryRelease),
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// defaultMaxReleaseAssetBytes is the default maximum size of the release asset content returned by get_release_by_tag
+const defaultMaxReleaseAssetBytes = 1024 * 1024
+
+// GetReleaseByTag creates a tool to get a release of a repository by its tag, optionally with the content of one of its assets
+func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release_by_tag",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_BY_TAG_DESCRIPTION", "Get a release of a GitHub repository by its tag, with the metadata of its assets. When asset_name is given, the content of that asset is returned instead, as text or as base64 encoded binary content")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get release by tag"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("The name of the tag of the release, like v1.2.0"),
+			),
+			mcp.WithString("asset_name",
+				mcp.Description("The name of an asset of the release to return the content of, like checksums.txt"),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description(fmt.Sprintf("Maximum number of bytes of asset content to return (default %d). Text content is truncated, larger binary assets are not returned", defaultMaxReleaseAssetBytes)),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assetName, err := OptionalParam[string](request, "asset_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxBytes, err := OptionalIntParamWithDefault(request, "max_bytes", defaultMaxReleaseAssetBytes)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxBytes < 1 {
+				return mcp.NewToolResultError("max_bytes must be positive"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repositoryRelease, resp, err := client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("release with tag %s not found in %s/%s", tag, owner, repo)), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get release by tag: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if assetName == "" {
+				r, err := json.Marshal(newRelease(repositoryRelease))
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			var asset *github.ReleaseAsset
+			names := make([]string, 0, len(repositoryRelease.Assets))
+			for _, a := range repositoryRelease.Assets {
+				if a.GetName() == assetName {
+					asset = a
+				}
+				names = append(names, a.GetName())
+			}
+			if asset == nil {
+				if len(names) == 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("asset %s not found, release %s has no assets", assetName, tag)), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("asset %s not found in release %s, its assets are: %s", assetName, tag, strings.Join(names, ", "))), nil
+			}
+
+			content, err := downloadReleaseAsset(ctx, client, owner, repo, asset.GetID(), int64(maxBytes))
+			if err != nil {
+				return nil, err
+			}
+
+			resourceURI, err := url.JoinPath("repo://", owner, repo, "releases", "tags", tag, "assets", assetName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create resource URI: %w", err)
+			}
+			size := max(int64(asset.GetSize()), int64(len(content)))
+			contentResult := &raw.RawContentResult{
+				Content:     content,
+				ContentType: raw.ResolveMIMEType(assetName, asset.GetContentType()),
+			}
+			if contentResult.IsText() {
+				truncatedNote := ""
+				if size > int64(len(content)) {
+					truncatedNote = fmt.Sprintf(" (truncated to %d of %d bytes)", len(content), size)
+				}
+				return mcp.NewToolResultResource("successfully downloaded text asset"+truncatedNote, mcp.TextResourceContents{
+					URI:      resourceURI,
+					Text:     string(content),
+					MIMEType: contentResult.ContentType,
+				}), nil
+			}
+			// A truncated binary is of no use, point to the download URL instead
+			if size > int64(len(content)) {
+				return mcp.NewToolResultError(fmt.Sprintf("binary asset %s is %d bytes, larger than the maximum of %d bytes, download it from %s", assetName, size, maxBytes, asset.GetBrowserDownloadURL())), nil
+			}
+			return mcp.NewToolResultResource("successfully downloaded binary asset", mcp.BlobResourceContents{
+				URI:      resourceURI,
+				Blob:     base64.StdEncoding.EncodeToString(content),
+				MIMEType: contentResult.ContentType,
+			}), nil
+		}
+}
+
+// downloadReleaseAsset downloads at most maxBytes bytes of the content of a release asset, following the
+// redirect of the API to the storage of the asset.
+func downloadReleaseAsset(ctx context.Context, client *github.Client, owner, repo string, assetID, maxBytes int64) ([]byte, error) {
+	rc, _, err := client.Repositories.DownloadReleaseAsset(ctx, owner, repo, assetID, http.DefaultClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download release asset: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release asset: %w", err)
+	}
+	return content, nil
+}
+
+var releaseMakeLatestValues = []string{"true", "false", "legacy"}
+
+// CreateRelease creates a tool to create a release of a repository
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_release",
+			mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release of a GitHub repository. The tag is created from target_commitish when it doesn't exist. Set generate_release_notes to let GitHub write the release notes from the merged pull requests")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("tag_name",
+				mcp.Required(),
+				mcp.Description("The name of the tag of the release"),
+			),
+			mcp.WithString("target_commitish",
+				mcp.Description("The branch or commit SHA the tag is created from when it doesn't exist, the default branch when not given"),
+			),
+			mcp.WithString("name",
+				mcp.Description("The name of the release"),
+			),
+			mcp.WithString("body",
+				mcp.Description("The description of the release. With generate_release_notes, it is prepended to the generated notes"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Create an unpublished draft release"),
+			),
+			mcp.WithBoolean("prerelease",
+				mcp.Description("Mark the release as a prerelease"),
+			),
+			mcp.WithBoolean("generate_release_notes",
+				mcp.Description("Let GitHub generate the name and notes of the release"),
+			),
+			mcp.WithString("make_latest",
+				mcp.Description("Whether the release is set as the latest release of the repository: true, false, or legacy to decide from the creation date and version (default true)"),
+				mcp.Enum(releaseMakeLatestValues...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tagName, err := RequiredParam[string](request, "tag_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.TrimSpace(tagName) == "" {
+				return mcp.NewToolResultError("tag_name cannot be empty"), nil
+			}
+
+			toCreate := &github.RepositoryRelease{TagName: github.Ptr(tagName)}
+			for _, param := range []struct {
+				name  string
+				field **string
+			}{
+				{"target_commitish", &toCreate.TargetCommitish},
+				{"name", &toCreate.Name},
+				{"body", &toCreate.Body},
+				{"make_latest", &toCreate.MakeLatest},
+			} {
+				value, err := OptionalParam[string](request, param.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if value != "" {
+					*param.field = github.Ptr(value)
+				}
+			}
+			for _, param := range []struct {
+				name  string
+				field **bool
+			}{
+				{"draft", &toCreate.Draft},
+				{"prerelease", &toCreate.Prerelease},
+				{"generate_release_notes", &toCreate.GenerateReleaseNotes},
+			} {
+				value, ok, err := OptionalParamOK[bool](request, param.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*param.field = github.Ptr(value)
+				}
+			}
+			if toCreate.MakeLatest != nil && !slices.Contains(releaseMakeLatestValues, toCreate.GetMakeLatest()) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid make_latest: %s, must be one of true, false or legacy", toCreate.GetMakeLatest())), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, toCreate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"id":         created.GetID(),
+				"tag_name":   created.GetTagName(),
+				"name":       created.GetName(),
+				"draft":      created.GetDraft(),
+				"prerelease": created.GetPrerelease(),
+				"html_url":   created.GetHTMLURL(),
+				"upload_url": created.GetUploadURL(),
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRelease creates a tool to edit a release of a repository
+func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_release",
+			mcp.WithDescription(t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Edit a release of a GitHub repository, like publishing a draft release. Only the given fields are changed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The ID of the release"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Description("The name of the tag of the release"),
+			),
+			mcp.WithString("name",
+				mcp.Description("The name of the release"),
+			),
+			mcp.WithString("body",
+				mcp.Description("The description of the release"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Whether the release is an unpublished draft, false publishes it"),
+			),
+			mcp.WithBoolean("prerelease",
+				mcp.Description("Whether the release is a prerelease"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Only the given fields are sent, the others are left as they are
+			edit := &github.RepositoryRelease{}
+			changed := false
+			for _, param := range []struct {
+				name  string
+				field **string
+			}{
+				{"tag_name", &edit.TagName},
+				{"name", &edit.Name},
+				{"body", &edit.Body},
+			} {
+				value, ok, err := OptionalParamOK[string](request, param.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*param.field = github.Ptr(value)
+					changed = true
+				}
+			}
+			for _, param := range []struct {
+				name  string
+				field **bool
+			}{
+				{"draft", &edit.Draft},
+				{"prerelease", &edit.Prerelease},
+			} {
+				value, ok, err := OptionalParamOK[bool](request, param.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*param.field = github.Ptr(value)
+					changed = true
+				}
+			}
+			if edit.TagName != nil && strings.TrimSpace(edit.GetTagName()) == "" {
+				return mcp.NewToolResultError("tag_name cannot be empty"), nil
+			}
+			if !changed {
+				return mcp.NewToolResultError("at least one of tag_name, name, body, draft or prerelease must be set"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, int64(releaseID), edit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newRelease(updated))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRelease creates a tool to delete a release of a repository, and optionally its tag
+func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_release",
+			mcp.WithDescription(t("TOOL_DELETE_RELEASE_DESCRIPTION", "Delete a release of a GitHub repository. The git tag of the release is kept unless delete_tag is true")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_RELEASE_USER_TITLE", "Delete release"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("release_id",
+				mcp.Required(),
+				mcp.Description("The ID of the release"),
+			),
+			mcp.WithBoolean("delete_tag",
+				mcp.Description("Also delete the git tag of the release (default false)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			releaseID, err := RequiredInt(request, "release_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deleteTag, err := OptionalParam[bool](request, "delete_tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The tag name is only known from the release, get it before the release is gone
+			tagName := ""
+			if deleteTag {
+				existing, resp, err := client.Repositories.GetRelease(ctx, owner, repo, int64(releaseID))
+				if err != nil {
+					return nil, fmt.Errorf("failed to get release: %w", err)
+				}
+				_ = resp.Body.Close()
+				tagName = existing.GetTagName()
+			}
+
+			resp, err := client.Repositories.DeleteRelease(ctx, owner, repo, int64(releaseID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete release: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"message":         "Release has been deleted",
+				"release_id":      releaseID,
+				"release_deleted": true,
+				"tag_deleted":     false,
+			}
+			if deleteTag {
+				result["tag_name"] = tagName
+				// The release is already deleted, a failure to delete the tag is reported rather than returned
+				tagResp, err := client.Git.DeleteRef(ctx, owner, repo, "refs/tags/"+tagName)
+				if err != nil {
+					result["message"] = "Release has been deleted, but its tag could not be deleted"
+					result["tag_error"] = err.Error()
+				} else {
+					_ = tagResp.Body.Close()
+					result["message"] = "Release and its tag have been deleted"
+					result["tag_deleted"] = true
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,1021 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockRelease = &github.RepositoryRelease{
+	ID:          github.Ptr(int64(1)),
+	TagName:     github.Ptr("v1.2.0"),
+	Name:        github.Ptr("Version 1.2.0"),
+	Body:        github.Ptr("Bug fixes"),
+	HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.2.0"),
+	PublishedAt: &github.Timestamp{Time: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+	Assets: []*github.ReleaseAsset{
+		{
+			ID:                 github.Ptr(int64(10)),
+			Name:               github.Ptr("app-linux-amd64.tar.gz"),
+			ContentType:        github.Ptr("application/gzip"),
+			Size:               github.Ptr(1048576),
+			DownloadCount:      github.Ptr(42),
+			BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.2.0/app-linux-amd64.tar.gz"),
+		},
+	},
+}
+
+func Test_ListReleases(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListReleases(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_releases", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	prerelease := &github.RepositoryRelease{
+		ID:         github.Ptr(int64(2)),
+		TagName:    github.Ptr("v1.3.0-rc.1"),
+		Prerelease: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedReleases []string
+	}{
+		{
+			name: "releases with pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryRelease{prerelease, mockRelease}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedReleases: []string{"v1.3.0-rc.1", "v1.2.0"},
+		},
+		{
+			name: "repository without releases",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepo,
+					[]*github.RepositoryRelease{},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedReleases: []string{},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list releases",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListReleases(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response []release
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.NotNil(t, response, "an empty list is returned rather than null")
+			tags := make([]string, 0, len(response))
+			for _, r := range response {
+				tags = append(tags, r.TagName)
+			}
+			assert.Equal(t, tc.expectedReleases, tags)
+		})
+	}
+}
+
+func Test_GetRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "release with assets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					expectPath(t, "/repos/owner/repo/releases/1").andThen(
+						mockResponse(t, http.StatusOK, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+			},
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get release",
+		},
+		{
+			name:         "missing required parameter release_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: release_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response release
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, newRelease(mockRelease), response)
+		})
+	}
+}
+
+func Test_GetLatestRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLatestRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_latest_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedFound  bool
+	}{
+		{
+			name: "latest release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					mockRelease,
+				),
+			),
+			expectedFound: true,
+		},
+		{
+			name: "repository without releases",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectedFound: false,
+		},
+		{
+			name: "server error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesLatestByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get latest release",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLatestRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Found   bool     `json:"found"`
+				Message string   `json:"message"`
+				Release *release `json:"release"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedFound, response.Found)
+			if tc.expectedFound {
+				require.NotNil(t, response.Release)
+				assert.Equal(t, "v1.2.0", response.Release.TagName)
+				require.Len(t, response.Release.Assets, 1)
+				assert.Equal(t, 42, response.Release.Assets[0].DownloadCount)
+			} else {
+				assert.Nil(t, response.Release)
+				assert.Equal(t, "owner/repo has no published release", response.Message)
+			}
+		})
+	}
+}
+
+func Test_CreateRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "generate_release_notes")
+	assert.Contains(t, tool.InputSchema.Properties, "make_latest")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	createdRelease := &github.RepositoryRelease{
+		ID:        github.Ptr(int64(5)),
+		TagName:   github.Ptr("v2.0.0"),
+		Name:      github.Ptr("v2.0.0"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/releases/tag/v2.0.0"),
+		UploadURL: github.Ptr("https://uploads.github.com/repos/owner/repo/releases/5/assets{?name,label}"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "release with generated notes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":               "v2.0.0",
+						"target_commitish":       "main",
+						"generate_release_notes": true,
+						"make_latest":            "true",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, createdRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"tag_name":               "v2.0.0",
+				"target_commitish":       "main",
+				"generate_release_notes": true,
+				"make_latest":            "true",
+			},
+		},
+		{
+			name: "draft prerelease with a body",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":   "v2.0.0",
+						"name":       "v2.0.0",
+						"body":       "Release candidate",
+						"draft":      true,
+						"prerelease": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, createdRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag_name":   "v2.0.0",
+				"name":       "v2.0.0",
+				"body":       "Release candidate",
+				"draft":      true,
+				"prerelease": true,
+			},
+		},
+		{
+			name: "tag already released",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "Release", "code": "already_exists", "field": "tag_name"}]}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v2.0.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create release",
+		},
+		{
+			name:         "blank tag_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "  ",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "tag_name cannot be empty",
+		},
+		{
+			name:         "missing tag_name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: tag_name",
+		},
+		{
+			name:         "invalid make_latest",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag_name":    "v2.0.0",
+				"make_latest": "always",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid make_latest: always",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(5), response["id"])
+			assert.Equal(t, createdRelease.GetHTMLURL(), response["html_url"])
+			assert.Equal(t, createdRelease.GetUploadURL(), response["upload_url"])
+		})
+	}
+}
+
+func Test_UpdateRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "publish a draft without touching the body",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectRequestBody(t, map[string]any{
+						"draft": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"draft":      false,
+			},
+		},
+		{
+			name: "rename and clear the body",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectRequestBody(t, map[string]any{
+						"name": "Version 1.2.0",
+						"body": "",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"name":       "Version 1.2.0",
+				"body":       "",
+			},
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(2),
+				"prerelease": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update release",
+		},
+		{
+			name:         "nothing to change",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at least one of tag_name, name, body, draft or prerelease must be set",
+		},
+		{
+			name:         "empty tag name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"tag_name":   " ",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "tag_name cannot be empty",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response release
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, newRelease(mockRelease), response)
+		})
+	}
+}
+
+func Test_DeleteRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "release_id"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	deleted := mock.WithRequestMatchHandler(
+		mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+		expectPath(t, "/repos/owner/repo/releases/1").andThen(
+			mockResponse(t, http.StatusNoContent, nil),
+		),
+	)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectError        bool
+		expectToolError    bool
+		expectedErrMsg     string
+		expectedTagDeleted bool
+		expectedTagError   bool
+	}{
+		{
+			name:         "keep the tag by default",
+			mockedClient: mock.NewMockedHTTPClient(deleted),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+			},
+		},
+		{
+			name: "delete the tag too",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					mockRelease,
+				),
+				deleted,
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/tags/v1.2.0").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"delete_tag": true,
+			},
+			expectedTagDeleted: true,
+		},
+		{
+			name: "tag deletion fails after the release is deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepoByReleaseId,
+					mockRelease,
+				),
+				deleted,
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"delete_tag": true,
+			},
+			expectedTagError: true,
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(2),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete release",
+		},
+		{
+			name:         "missing required parameter release_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: release_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, true, response["release_deleted"])
+			assert.Equal(t, tc.expectedTagDeleted, response["tag_deleted"])
+			if tc.expectedTagError {
+				assert.Equal(t, "v1.2.0", response["tag_name"])
+				assert.Contains(t, response["tag_error"], "Reference does not exist")
+			} else {
+				assert.NotContains(t, response, "tag_error")
+			}
+		})
+	}
+}
+
+func Test_GetReleaseByTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReleaseByTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_release_by_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "asset_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	checksums := "0123abcd  app-linux-amd64.tar.gz\n4567ef89  app-darwin-arm64.tar.gz\n"
+	binary := []byte{0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03}
+
+	// The asset storage the API redirects to
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/checksums.txt":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte(checksums))
+		case "/app-linux-amd64.tar.gz":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(binary)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	taggedRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(1)),
+		TagName: github.Ptr("v1.4.2"),
+		Name:    github.Ptr("Version 1.4.2"),
+		Assets: []*github.ReleaseAsset{
+			{
+				ID:          github.Ptr(int64(10)),
+				Name:        github.Ptr("checksums.txt"),
+				ContentType: github.Ptr("text/plain"),
+				Size:        github.Ptr(len(checksums)),
+			},
+			{
+				ID:                 github.Ptr(int64(11)),
+				Name:               github.Ptr("app-linux-amd64.tar.gz"),
+				ContentType:        github.Ptr("application/gzip"),
+				Size:               github.Ptr(len(binary)),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.4.2/app-linux-amd64.tar.gz"),
+			},
+		},
+	}
+
+	releaseByTag := func(tag string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposReleasesTagsByOwnerByRepoByTag,
+			expectPath(t, "/repos/owner/repo/releases/tags/"+tag).andThen(
+				mockResponse(t, http.StatusOK, taggedRelease),
+			),
+		)
+	}
+	assetRedirect := mock.WithRequestMatchHandler(
+		mock.GetReposReleasesAssetsByOwnerByRepoByAssetId,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := map[string]string{
+				"/repos/owner/repo/releases/assets/10": "checksums.txt",
+				"/repos/owner/repo/releases/assets/11": "app-linux-amd64.tar.gz",
+			}[r.URL.Path]
+			w.Header().Set("Location", testServer.URL+"/"+name)
+			w.WriteHeader(http.StatusFound)
+		}),
+	)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResult  any
+	}{
+		{
+			name:         "release metadata",
+			mockedClient: mock.NewMockedHTTPClient(releaseByTag("v1.4.2")),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.4.2",
+			},
+			expectedResult: newRelease(taggedRelease),
+		},
+		{
+			name:         "text asset content",
+			mockedClient: mock.NewMockedHTTPClient(releaseByTag("v1.4.2"), assetRedirect),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.4.2",
+				"asset_name": "checksums.txt",
+			},
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/releases/tags/v1.4.2/assets/checksums.txt",
+				Text:     checksums,
+				MIMEType: "text/plain",
+			},
+		},
+		{
+			name:         "truncated text asset content",
+			mockedClient: mock.NewMockedHTTPClient(releaseByTag("v1.4.2"), assetRedirect),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.4.2",
+				"asset_name": "checksums.txt",
+				"max_bytes":  float64(10),
+			},
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/releases/tags/v1.4.2/assets/checksums.txt",
+				Text:     checksums[:10],
+				MIMEType: "text/plain",
+			},
+		},
+		{
+			name:         "binary asset content",
+			mockedClient: mock.NewMockedHTTPClient(releaseByTag("v1.4.2"), assetRedirect),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.4.2",
+				"asset_name": "app-linux-amd64.tar.gz",
+			},
+			expectedResult: mcp.BlobResourceContents{
+				URI:      "repo://owner/repo/releases/tags/v1.4.2/assets/app-linux-amd64.tar.gz",
+				Blob:     "H4sIAAAAAAAAAw==",
+				MIMEType: "application/gzip",
+			},
+		},
+		{
+			name:         "binary asset larger than max_bytes",
+			mockedClient: mock.NewMockedHTTPClient(releaseByTag("v1.4.2"), assetRedirect),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.4.2",
+				"asset_name": "app-linux-amd64.tar.gz",
+				"max_bytes":  float64(4),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "binary asset app-linux-amd64.tar.gz is 10 bytes, larger than the maximum of 4 bytes, download it from https://github.com/owner/repo/releases/download/v1.4.2/app-linux-amd64.tar.gz",
+		},
+		{
+			name: "release not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v9.9.9",
+				"asset_name": "checksums.txt",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "release with tag v9.9.9 not found in owner/repo",
+		},
+		{
+			name:         "asset not found",
+			mockedClient: mock.NewMockedHTTPClient(releaseByTag("v1.4.2")),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.4.2",
+				"asset_name": "SHA256SUMS",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "asset SHA256SUMS not found in release v1.4.2, its assets are: checksums.txt, app-linux-amd64.tar.gz",
+		},
+		{
+			name: "asset download fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				releaseByTag("v1.4.2"),
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesAssetsByOwnerByRepoByAssetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.4.2",
+				"asset_name": "checksums.txt",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to download release asset",
+		},
+		{
+			name:         "missing required parameter tag",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: tag",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReleaseByTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			switch expected := tc.expectedResult.(type) {
+			case mcp.TextResourceContents:
+				assert.Equal(t, expected, getTextResourceResult(t, result))
+			case mcp.BlobResourceContents:
+				assert.Equal(t, expected, getBlobResourceResult(t, result))
+			case release:
+				var response release
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+				assert.Equal(t, expected, response)
+			}
+		})
+	}
+}
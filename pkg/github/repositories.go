@@ -1,22 +1,37 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
 )
 
+// truncatedCommit is a commit whose files may have been capped by max_files
+type truncatedCommit struct {
+	*github.RepositoryCommit
+	FilesTruncated int `json:"files_truncated,omitempty"`
+}
+
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_commit",
 			mcp.WithDescription(t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository")),
@@ -36,6 +51,13 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
+			mcp.WithBoolean("include_patches",
+				mcp.Description("Whether to include the patch of each file (default true). The stats of the commit are always included"),
+			),
+			mcp.WithNumber("max_files",
+				mcp.Description("Maximum number of files to return, the number of files left out is returned as files_truncated"),
+				mcp.Min(1),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -51,6 +73,20 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			includePatches, ok, err := OptionalParamOK[bool](request, "include_patches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includePatches = true
+			}
+			maxFiles, err := OptionalIntParam(request, "max_files")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, hasMaxFiles := request.GetArguments()["max_files"]; hasMaxFiles && maxFiles < 1 {
+				return mcp.NewToolResultError("max_files must be at least 1"), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -79,7 +115,31 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(commit)
+			// The stats are computed from the files when GitHub doesn't return them, before the files are capped
+			if commit.Stats == nil {
+				var additions, deletions int
+				for _, file := range commit.Files {
+					additions += file.GetAdditions()
+					deletions += file.GetDeletions()
+				}
+				commit.Stats = &github.CommitStats{
+					Additions: github.Ptr(additions),
+					Deletions: github.Ptr(deletions),
+					Total:     github.Ptr(additions + deletions),
+				}
+			}
+			result := truncatedCommit{RepositoryCommit: commit}
+			if maxFiles > 0 && len(commit.Files) > maxFiles {
+				result.FilesTruncated = len(commit.Files) - maxFiles
+				commit.Files = commit.Files[:maxFiles]
+			}
+			if !includePatches {
+				for _, file := range commit.Files {
+					file.Patch = nil
+				}
+			}
+
+			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -91,7 +151,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 // ListCommits creates a tool to get commits of a branch in a repository.
 func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_commits",
-			mcp.WithDescription(t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository")),
+			mcp.WithDescription(t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository, optionally filtered by author, path and date range")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -107,6 +167,18 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("sha",
 				mcp.Description("SHA or Branch name"),
 			),
+			mcp.WithString("author",
+				mcp.Description("Only commits by this GitHub login or email address"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Only commits touching this file or directory path"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only commits after this date (RFC3339, like 2025-06-01T00:00:00Z)"),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only commits before this date (RFC3339, like 2025-06-01T00:00:00Z)"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -122,19 +194,66 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			author, err := OptionalParam[string](request, "author")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.CommitsListOptions{
-				SHA: sha,
+				SHA:    sha,
+				Author: author,
+				Path:   path,
 				ListOptions: github.ListOptions{
 					Page:    pagination.page,
 					PerPage: pagination.perPage,
 				},
 			}
 
+			// When filters are given they are returned with the commits, so that an empty list can be told apart
+			// from a too narrow filter. Without filters the commits are returned as a bare array, as they always were.
+			filters := map[string]string{}
+			if author != "" {
+				filters["author"] = author
+			}
+			if path != "" {
+				filters["path"] = path
+			}
+			if since != "" {
+				sinceTime, err := time.Parse(time.RFC3339, since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since time format, should be RFC3339/ISO8601: %v", err)), nil
+				}
+				opts.Since = sinceTime
+				filters["since"] = since
+			}
+			if until != "" {
+				untilTime, err := time.Parse(time.RFC3339, until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until time format, should be RFC3339/ISO8601: %v", err)), nil
+				}
+				opts.Until = untilTime
+				filters["until"] = until
+			}
+			if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+				return mcp.NewToolResultError(fmt.Sprintf("until (%s) cannot be before since (%s)", until, since)), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -153,7 +272,21 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(commits)
+			if len(filters) == 0 {
+				r, err := json.Marshal(commits)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			if commits == nil {
+				commits = []*github.RepositoryCommit{}
+			}
+			r, err := json.Marshal(map[string]any{
+				"filters": filters,
+				"commits": commits,
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -229,6 +362,9 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
+// maxBase64FileBytes is the maximum decoded size of the base64 content create_or_update_file accepts
+const maxBase64FileBytes = 1024 * 1024
+
 // CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
 func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_or_update_file",
@@ -253,6 +389,10 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Content of the file"),
 			),
+			mcp.WithString("encoding",
+				mcp.Description(fmt.Sprintf("Encoding of the content, base64 for binary files of at most %d bytes (default utf-8)", maxBase64FileBytes)),
+				mcp.Enum("utf-8", "base64"),
+			),
 			mcp.WithString("message",
 				mcp.Required(),
 				mcp.Description("Commit message"),
@@ -291,8 +431,27 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			encoding, err := OptionalParam[string](request, "encoding")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// json.Marshal encodes byte arrays with base64, which is required for the API.
-			contentBytes := []byte(content)
+			var contentBytes []byte
+			switch encoding {
+			case "", "utf-8":
+				contentBytes = []byte(content)
+			case "base64":
+				contentBytes, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("content is not valid base64: %s", err.Error())), nil
+				}
+				if len(contentBytes) > maxBase64FileBytes {
+					return mcp.NewToolResultError(fmt.Sprintf("decoded content is %d bytes, more than the maximum of %d bytes", len(contentBytes), maxBase64FileBytes)), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid encoding: %s, must be utf-8 or base64", encoding)), nil
+			}
 
 			// Create the file options
 			opts := &github.RepositoryContentFileOptions{
@@ -412,6 +571,110 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// CreateRepositoryFromTemplate creates a tool to create a new repository from a template repository.
+func CreateRepositoryFromTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_from_template",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_DESCRIPTION", "Create a new GitHub repository from a template repository, with the files and directories of the template")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_FROM_TEMPLATE_USER_TITLE", "Create repository from template"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("template_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the template repository"),
+			),
+			mcp.WithString("template_repo",
+				mcp.Required(),
+				mcp.Description("Name of the template repository"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("User or organization owning the new repository, the authenticated user when not given"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the new repository"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the new repository"),
+			),
+			mcp.WithBoolean("include_all_branches",
+				mcp.Description("Copy all the branches of the template, not only its default branch"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether the new repository is private"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			templateOwner, err := RequiredParam[string](request, "template_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			templateRepo, err := RequiredParam[string](request, "template_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if strings.ContainsFunc(name, unicode.IsSpace) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid repository name %q, it cannot contain spaces", name)), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAllBranches, err := OptionalParam[bool](request, "include_all_branches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			templateRequest := &github.TemplateRepoRequest{
+				Name:               github.Ptr(name),
+				IncludeAllBranches: github.Ptr(includeAllBranches),
+				Private:            github.Ptr(private),
+			}
+			if owner != "" {
+				templateRequest.Owner = github.Ptr(owner)
+			}
+			if description != "" {
+				templateRequest.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdRepo, resp, err := client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, templateRequest)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create repository %s from template %s/%s, a repository with that name may already exist or the repository is not a template: %s", name, templateOwner, templateRepo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create repository from template: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"full_name":      createdRepo.GetFullName(),
+				"html_url":       createdRepo.GetHTMLURL(),
+				"default_branch": createdRepo.GetDefaultBranch(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
 func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_file_contents",
@@ -430,11 +693,26 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			),
 			mcp.WithString("path",
 				mcp.Required(),
-				mcp.Description("Path to file/directory (directories must end with a slash '/')"),
+				mcp.Description("Path to file/directory, use '/' for the root directory. Directories are listed with the name, path, type, size and sha of their entries"),
 			),
 			mcp.WithString("branch",
 				mcp.Description("Branch to get contents from"),
 			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description("Maximum number of bytes of file content to return, the rest of the file is truncated"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line of a text file to return, starting at 1 (default the first line)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line of a text file to return, included (default the last line)"),
+				mcp.Min(1),
+			),
+			mcp.WithBoolean("follow_symlinks",
+				mcp.Description("Whether to return the contents of the target of a symbolic link, following one link at most. By default, the target of the link is returned"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -453,10 +731,66 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			maxBytes, err := OptionalIntParam(request, "max_bytes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasStartLine := request.GetArguments()["start_line"]
+			_, hasEndLine := request.GetArguments()["end_line"]
+			if (hasStartLine && startLine < 1) || (hasEndLine && endLine < 1) {
+				return mcp.NewToolResultError("start_line and end_line must be at least 1"), nil
+			}
+			if hasStartLine && hasEndLine && startLine > endLine {
+				return mcp.NewToolResultError(fmt.Sprintf("start_line (%d) cannot be after end_line (%d)", startLine, endLine)), nil
+			}
+			hasLineRange := hasStartLine || hasEndLine
+			followSymlinks, err := OptionalParam[bool](request, "follow_symlinks")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentOpts := &github.RepositoryContentGetOptions{Ref: branch}
+
+			// describeSpecialEntry returns a symlink or a submodule, and follows a symlink when asked to by calling
+			// this handler again, which is the named result of GetFileContents
+			describeSpecialEntry := func(entry *specialEntry) (*mcp.CallToolResult, error) {
+				if entry.Type == "symlink" && followSymlinks && entry.TargetPath != "" {
+					arguments := maps.Clone(request.GetArguments())
+					arguments["path"] = entry.TargetPath
+					arguments["follow_symlinks"] = false
+					followed := request
+					followed.Params.Arguments = arguments
+					result, err := handler(ctx, followed)
+					if err != nil || result.IsError {
+						return result, err
+					}
+					if message, ok := result.Content[0].(mcp.TextContent); ok {
+						message.Text = fmt.Sprintf("followed symbolic link %s to %s, ", entry.Path, entry.TargetPath) + message.Text
+						result.Content[0] = message
+					}
+					return result, nil
+				}
+				if entry.Type == "symlink" && entry.TargetPath != "" {
+					entry.Message += ", set follow_symlinks to get the contents of the target"
+				}
+
+				r, err := json.Marshal(entry)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal response: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
 
 			// If the path is (most likely) not to be a directory, we will first try to get the raw content from the GitHub raw content API.
 			if path != "" && !strings.HasSuffix(path, "/") {
-				rawOpts := &raw.RawContentOpts{}
+				rawOpts := &raw.RawContentOpts{MaxBytes: int64(maxBytes)}
 				if branch != "" {
 					rawOpts.Ref = "refs/heads/" + branch
 				}
@@ -464,23 +798,27 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				if err != nil {
 					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
 				}
-				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+				result, err := rawClient.GetRawContentResult(ctx, owner, repo, path, rawOpts)
 				if err != nil {
 					return mcp.NewToolResultError("failed to get raw repository content"), nil
 				}
-				defer func() {
-					_ = resp.Body.Close()
-				}()
 
-				if resp.StatusCode != http.StatusOK {
-					// If the raw content is not found, we will fall back to the GitHub API (in case it is a directory)
-				} else {
-					// If the raw content is found, return it directly
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
+				// The raw content of a symlink is its target, the contents API tells whether it is one. Regular files
+				// are returned as is when it fails.
+				if result.Found() && mayBeSymlink(result) {
+					if client, err := getClient(ctx); err == nil {
+						if entry, err := getSpecialEntry(ctx, client, owner, repo, path, contentOpts); err == nil && entry != nil {
+							return describeSpecialEntry(entry)
+						}
+					}
+				}
+
+				// If the raw content is not found, we will fall back to the GitHub API (in case it is a directory)
+				if result.Found() {
+					truncatedNote := ""
+					if result.Truncated {
+						truncatedNote = fmt.Sprintf(" (truncated to %d of %d bytes)", len(result.Content), result.Size)
 					}
-					contentType := resp.Header.Get("Content-Type")
 
 					var resourceURI string
 					if branch == "" {
@@ -495,52 +833,204 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 							return nil, fmt.Errorf("failed to create resource URI: %w", err)
 						}
 					}
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
-						return mcp.NewToolResultResource("successfully downloaded text file", mcp.TextResourceContents{
+					if result.IsText() {
+						if !hasLineRange {
+							return mcp.NewToolResultResource("successfully downloaded text file"+truncatedNote, mcp.TextResourceContents{
+								URI:      resourceURI,
+								Text:     string(result.Content),
+								MIMEType: result.ContentType,
+							}), nil
+						}
+
+						window, first, last, total := sliceLines(string(result.Content), startLine, endLine)
+						metadata, err := json.Marshal(map[string]any{
+							"message":        "successfully downloaded text file" + truncatedNote,
+							"total_lines":    total,
+							"returned_range": []int{first, last},
+						})
+						if err != nil {
+							return nil, fmt.Errorf("failed to marshal response: %w", err)
+						}
+						return mcp.NewToolResultResource(string(metadata), mcp.TextResourceContents{
 							URI:      resourceURI,
-							Text:     string(body),
-							MIMEType: contentType,
+							Text:     window,
+							MIMEType: result.ContentType,
 						}), nil
 					}
 
-					return mcp.NewToolResultResource("successfully downloaded binary file", mcp.BlobResourceContents{
+					if hasLineRange {
+						truncatedNote += ", start_line and end_line are ignored for binary files"
+					}
+					return mcp.NewToolResultResource("successfully downloaded binary file"+truncatedNote, mcp.BlobResourceContents{
 						URI:      resourceURI,
-						Blob:     base64.StdEncoding.EncodeToString(body),
-						MIMEType: contentType,
+						Blob:     base64.StdEncoding.EncodeToString(result.Content),
+						MIMEType: result.ContentType,
 					}), nil
-
 				}
 			}
 
+			// The path is a directory, or the raw content is not found, which is also the case for directories
 			client, err := getClient(ctx)
 			if err != nil {
 				return mcp.NewToolResultError("failed to get GitHub client"), nil
 			}
 
-			if strings.HasSuffix(path, "/") {
-				opts := &github.RepositoryContentGetOptions{Ref: branch}
-				_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get file contents"), nil
+			entries, err := listDirectory(ctx, client, owner, repo, path, contentOpts)
+			if errors.Is(err, errNotDirectory) {
+				// The raw content of a submodule is not found
+				entry, specialErr := getSpecialEntry(ctx, client, owner, repo, path, contentOpts)
+				if specialErr == nil && entry != nil {
+					return describeSpecialEntry(entry)
 				}
-				defer func() { _ = resp.Body.Close() }()
-
-				if resp.StatusCode != 200 {
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
-					}
-					return mcp.NewToolResultError(fmt.Sprintf("failed to get file contents: %s", string(body))), nil
+			}
+			if err != nil {
+				var errResp *github.ErrorResponse
+				if errors.Is(err, errNotDirectory) || (errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound) {
+					return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
 				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get file contents: %s", err)), nil
+			}
 
-				r, err := json.Marshal(dirContent)
-				if err != nil {
-					return mcp.NewToolResultError("failed to marshal response"), nil
-				}
-				return mcp.NewToolResultText(string(r)), nil
+			r, err := json.Marshal(entries)
+			if err != nil {
+				return mcp.NewToolResultError("failed to marshal response"), nil
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// sliceLines returns the lines startLine to endLine of text, both included and starting at 1, with the range
+// actually returned and the total number of lines. The range is clamped to the lines of the text, and a zero
+// startLine or endLine stands for the first or last line. The range of an empty text is 0-0.
+func sliceLines(text string, startLine, endLine int) (window string, first, last, total int) {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	total = len(lines)
+	if total == 0 {
+		return "", 0, 0, 0
+	}
+
+	first = max(startLine, 1)
+	last = endLine
+	if last == 0 || last > total {
+		last = total
+	}
+	first = min(first, last)
+	return strings.Join(lines[first-1:last], ""), first, last, total
+}
+
+// errNotDirectory is returned by listDirectory when the path points to a file
+var errNotDirectory = errors.New("path is not a directory")
+
+// directoryEntry is an entry of a directory listing
+type directoryEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size"`
+	SHA  string `json:"sha"`
+}
+
+// listDirectory lists the entries of a directory of a repository, the root directory when the path is empty or "/".
+func listDirectory(ctx context.Context, client *github.Client, owner, repo, path string, opts *github.RepositoryContentGetOptions) ([]directoryEntry, error) {
+	fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	if fileContent != nil {
+		return nil, errNotDirectory
+	}
+
+	entries := make([]directoryEntry, 0, len(dirContent))
+	for _, content := range dirContent {
+		entries = append(entries, directoryEntry{
+			Name: content.GetName(),
+			Path: content.GetPath(),
+			Type: content.GetType(),
+			Size: content.GetSize(),
+			SHA:  content.GetSHA(),
+		})
+	}
+	return entries, nil
+}
+
+// maxSymlinkTargetLen is the longest symlink target GetFileContents checks for, the usual PATH_MAX
+const maxSymlinkTargetLen = 4096
+
+// specialEntry is a path of a repository that is a symlink or a submodule rather than a regular file
+type specialEntry struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	// Target is the path a symlink points to as stored in git, which may be relative to the symlink
+	Target string `json:"target,omitempty"`
+	// TargetPath is the path of the target of a symlink in the repository, empty when it points outside of it
+	TargetPath      string `json:"target_path,omitempty"`
+	SubmoduleGitURL string `json:"submodule_git_url,omitempty"`
+	// SHA is the commit a submodule is pinned at, or the blob of a symlink
+	SHA     string `json:"sha,omitempty"`
+	Message string `json:"message"`
+}
+
+// mayBeSymlink reports whether the raw content of a path may be the target of a symlink rather than the content of
+// a file: the raw content of a symlink is the path it points to, a short text on a single line.
+func mayBeSymlink(result *raw.RawContentResult) bool {
+	return !result.Truncated && len(result.Content) > 0 && len(result.Content) <= maxSymlinkTargetLen &&
+		!bytes.ContainsAny(result.Content, "\n\x00")
+}
+
+// getSpecialEntry asks the contents API whether the path is a symlink or a submodule, and returns nil when it is a
+// regular file or a directory. The contents API follows symlinks to files, which is how they are told apart from
+// the files themselves.
+func getSpecialEntry(ctx context.Context, client *github.Client, owner, repo, filePath string, opts *github.RepositoryContentGetOptions) (*specialEntry, error) {
+	fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, filePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if fileContent == nil {
+		return nil, nil
+	}
+
+	filePath = strings.Trim(filePath, "/")
+	switch {
+	case fileContent.GetType() == "submodule":
+		return &specialEntry{
+			Type:            "submodule",
+			Path:            filePath,
+			SubmoduleGitURL: fileContent.GetSubmoduleGitURL(),
+			SHA:             fileContent.GetSHA(),
+			Message:         fmt.Sprintf("%s is a git submodule of %s pinned at %s, get its contents from that repository", filePath, fileContent.GetSubmoduleGitURL(), fileContent.GetSHA()),
+		}, nil
+	case fileContent.GetType() == "symlink":
+		entry := &specialEntry{
+			Type:   "symlink",
+			Path:   filePath,
+			Target: fileContent.GetTarget(),
+			SHA:    fileContent.GetSHA(),
+		}
+		if !strings.HasPrefix(entry.Target, "/") {
+			targetPath := path.Join(path.Dir(filePath), entry.Target)
+			if targetPath != ".." && !strings.HasPrefix(targetPath, "../") {
+				entry.TargetPath = targetPath
 			}
-			return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
 		}
+		entry.Message = fmt.Sprintf("%s is a symbolic link to %s", filePath, entry.Target)
+		return entry, nil
+	case fileContent.GetPath() != filePath:
+		// A symlink to a file, which the contents API has followed
+		return &specialEntry{
+			Type:       "symlink",
+			Path:       filePath,
+			Target:     fileContent.GetPath(),
+			TargetPath: fileContent.GetPath(),
+			Message:    fmt.Sprintf("%s is a symbolic link to %s", filePath, fileContent.GetPath()),
+		}, nil
+	}
+	return nil, nil
 }
 
 // ForkRepository creates a tool to fork a repository.
@@ -863,12 +1353,12 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
-// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
-func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+// MergeBranch creates a tool to merge a branch into another branch without a pull request.
+func MergeBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("merge_branch",
+			mcp.WithDescription(t("TOOL_MERGE_BRANCH_DESCRIPTION", "Merge a branch or commit into a branch of a GitHub repository without a pull request, like merging the default branch into a feature branch to keep it up to date. Fails on merge conflicts, open a pull request to resolve them instead")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
+				Title:        t("TOOL_MERGE_BRANCH_USER_TITLE", "Merge branch"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
@@ -879,33 +1369,16 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("branch",
+			mcp.WithString("base",
 				mcp.Required(),
-				mcp.Description("Branch to push to"),
+				mcp.Description("The branch to merge into"),
 			),
-			mcp.WithArray("files",
+			mcp.WithString("head",
 				mcp.Required(),
-				mcp.Items(
-					map[string]interface{}{
-						"type":                 "object",
-						"additionalProperties": false,
-						"required":             []string{"path", "content"},
-						"properties": map[string]interface{}{
-							"path": map[string]interface{}{
-								"type":        "string",
-								"description": "path to the file",
-							},
-							"content": map[string]interface{}{
-								"type":        "string",
-								"description": "file content",
-							},
-						},
-					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+				mcp.Description("The branch or commit SHA to merge"),
 			),
-			mcp.WithString("message",
-				mcp.Required(),
-				mcp.Description("Commit message"),
+			mcp.WithString("commit_message",
+				mcp.Description("Message of the merge commit, a default message is used when not given"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -917,68 +1390,314 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := RequiredParam[string](request, "branch")
+			base, err := RequiredParam[string](request, "base")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			message, err := RequiredParam[string](request, "message")
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitMessage, err := OptionalParam[string](request, "commit_message")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Parse files parameter - this should be an array of objects with path and content
-			filesObj, ok := request.GetArguments()["files"].([]interface{})
-			if !ok {
-				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			mergeRequest := &github.RepositoryMergeRequest{
+				Base: github.Ptr(base),
+				Head: github.Ptr(head),
+			}
+			if commitMessage != "" {
+				mergeRequest.CommitMessage = github.Ptr(commitMessage)
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-
-			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			commit, resp, err := client.Repositories.Merge(ctx, owner, repo, mergeRequest)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return mcp.NewToolResultError(fmt.Sprintf("merging %s into %s has conflicts, open a pull request from %s to %s to resolve them", head, base, head, base)), nil
+				}
+				return nil, fmt.Errorf("failed to merge branch: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			var result map[string]any
+			if resp.StatusCode == http.StatusNoContent {
+				// Nothing to merge, base already contains head
+				result = map[string]any{
+					"merged":  false,
+					"message": fmt.Sprintf("%s is already up to date with %s", base, head),
+				}
+			} else {
+				result = map[string]any{
+					"merged":   true,
+					"message":  fmt.Sprintf("%s has been merged into %s", head, base),
+					"sha":      commit.GetSHA(),
+					"html_url": commit.GetHTMLURL(),
+				}
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			// Create tree entries for all files
-			var entries []*github.TreeEntry
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
 
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
-				}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
 
-				path, ok := fileMap["path"].(string)
+// SyncFork creates a tool to update a branch of a fork with the changes of its upstream repository.
+func SyncFork(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("sync_fork",
+			mcp.WithDescription(t("TOOL_SYNC_FORK_DESCRIPTION", "Update a branch of a forked GitHub repository with the changes of the same branch of its upstream repository. merge_type is fast-forward, merge, or none when the branch is already up to date")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SYNC_FORK_USER_TITLE", "Sync fork with upstream"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Owner of the fork"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the fork"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("The branch of the fork to update, like main"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			synced, resp, err := client.Repositories.MergeUpstream(ctx, owner, repo, &github.RepoMergeUpstreamRequest{
+				Branch: github.Ptr(branch),
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusConflict {
+					return mcp.NewToolResultError(fmt.Sprintf("branch %s of %s/%s has conflicts with its upstream, merge the upstream branch into it locally or through a pull request to resolve them", branch, owner, repo)), nil
+				}
+				return nil, fmt.Errorf("failed to sync fork: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"branch":      branch,
+				"merge_type":  synced.GetMergeType(),
+				"base_branch": synced.GetBaseBranch(),
+				"message":     synced.GetMessage(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
+func PushFiles(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("push_files",
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit, including binary files and deletions")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to push to"),
+			),
+			mcp.WithArray("files",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "file content, cannot be combined with delete",
+							},
+							"encoding": map[string]interface{}{
+								"type":        "string",
+								"description": "encoding of the content, base64 for binary files (default utf-8)",
+								"enum":        []string{"utf-8", "base64"},
+							},
+							"delete": map[string]interface{}{
+								"type":        "boolean",
+								"description": "delete the file instead of writing it, cannot be combined with content",
+							},
+						},
+					}),
+				mcp.Description("Array of file objects to push, each object with path (string), and either content (string) with an optional encoding, or delete (true)"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithBoolean("signed",
+				mcp.Description("Create the commit with the GraphQL API so that it is signed by GitHub, for branches requiring verified signatures (default false)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			signed, err := OptionalParam[bool](request, "signed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Parse files parameter - this should be an array of objects with path and content
+			filesObj, ok := request.GetArguments()["files"].([]interface{})
+			if !ok {
+				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			}
+
+			files := make([]pushedFile, 0, len(filesObj))
+			for _, file := range filesObj {
+				fileMap, ok := file.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+				}
+
+				path, ok := fileMap["path"].(string)
 				if !ok || path == "" {
 					return mcp.NewToolResultError("each file must have a path"), nil
 				}
 
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
+				content, hasContent := fileMap["content"].(string)
+				isDelete, _ := fileMap["delete"].(bool)
+				if hasContent == isDelete {
+					return mcp.NewToolResultError(fmt.Sprintf("file %s must have exactly one of content or delete", path)), nil
+				}
+
+				encoding, _ := fileMap["encoding"].(string)
+				switch encoding {
+				case "", "utf-8":
+				case "base64":
+					if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("content of file %s is not valid base64: %s", path, err.Error())), nil
+					}
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("invalid encoding of file %s: %s, must be utf-8 or base64", path, encoding)), nil
 				}
 
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
+				files = append(files, pushedFile{
+					path:     path,
+					content:  content,
+					isBase64: encoding == "base64",
+					isDelete: isDelete,
 				})
 			}
 
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the reference for the branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if signed {
+				return pushSignedFiles(ctx, getGQLClient, owner, repo, branch, ref.GetObject().GetSHA(), message, files)
+			}
+
+			// Get the commit object that the branch points to
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create tree entries for all files
+			var entries []*github.TreeEntry
+
+			for _, file := range files {
+				switch {
+				case file.isDelete:
+					// A tree entry without a SHA nor content deletes the file
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(file.path),
+						Mode: github.Ptr("100644"),
+						Type: github.Ptr("blob"),
+					})
+				case file.isBase64:
+					// Tree entries can only hold text, binary content has to be created as a blob first
+					blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+						Content:  github.Ptr(file.content),
+						Encoding: github.Ptr("base64"),
+					})
+					if err != nil {
+						return nil, fmt.Errorf("failed to create blob for %s: %w", file.path, err)
+					}
+					_ = resp.Body.Close()
+
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(file.path),
+						Mode: github.Ptr("100644"),
+						Type: github.Ptr("blob"),
+						SHA:  blob.SHA,
+					})
+				default:
+					// Create a tree entry for the file
+					entries = append(entries, &github.TreeEntry{
+						Path:    github.Ptr(file.path),
+						Mode:    github.Ptr("100644"), // Regular file mode
+						Type:    github.Ptr("blob"),
+						Content: github.Ptr(file.content),
+					})
+				}
+			}
+
 			// Create a new tree with the file entries
 			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
 			if err != nil {
@@ -1015,6 +1734,56 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 		}
 }
 
+// pushedFile is a file of push_files, written or deleted
+type pushedFile struct {
+	path     string
+	content  string
+	isBase64 bool
+	isDelete bool
+}
+
+// pushSignedFiles pushes the files as a commit signed by GitHub on top of headSHA, and returns the updated ref of the
+// branch like the unsigned push does.
+func pushSignedFiles(ctx context.Context, getGQLClient GetGQLClientFn, owner, repo, branch, headSHA, message string, files []pushedFile) (*mcp.CallToolResult, error) {
+	var additions []githubv4.FileAddition
+	var deletions []githubv4.FileDeletion
+	for _, file := range files {
+		switch {
+		case file.isDelete:
+			deletions = append(deletions, githubv4.FileDeletion{Path: githubv4.String(file.path)})
+		case file.isBase64:
+			additions = append(additions, githubv4.FileAddition{Path: githubv4.String(file.path), Contents: githubv4.Base64String(file.content)})
+		default:
+			additions = append(additions, githubv4.FileAddition{
+				Path:     githubv4.String(file.path),
+				Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte(file.content))),
+			})
+		}
+	}
+
+	commit, err := createCommitOnBranch(ctx, NewGQLClient(getGQLClient), owner, repo, branch, headSHA, message, additions, deletions)
+	if errors.Is(err, errHeadOIDMismatch) {
+		return headOIDMismatchResult(branch, headSHA, err), nil
+	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to create signed commit: %s", err.Error())), nil
+	}
+
+	r, err := json.Marshal(&github.Reference{
+		Ref: github.Ptr("refs/heads/" + branch),
+		Object: &github.GitObject{
+			Type: github.Ptr("commit"),
+			SHA:  github.Ptr(commit.OID),
+			URL:  github.Ptr(commit.URL),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
 // ListTags creates a tool to list tags in a GitHub repository.
 func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_tags",
@@ -1158,3 +1927,1062 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// commitSHAPattern matches a full commit SHA, anything else is taken for a branch name
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// CreateTag creates a tool to create a tag in a GitHub repository.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create a git tag in a GitHub repository pointing at a commit or at the head of a branch. The tag is annotated unless annotated is false")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name"),
+			),
+			mcp.WithString("target",
+				mcp.Required(),
+				mcp.Description("Full SHA of the commit to tag, or the name of a branch to tag its head"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Message of an annotated tag (defaults to the tag name)"),
+			),
+			mcp.WithBoolean("annotated",
+				mcp.Description("Create an annotated tag object, or only a lightweight tag reference when false (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := RequiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			target, err := RequiredParam[string](request, "target")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			annotated, hasAnnotated, err := OptionalParamOK[bool](request, "annotated")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !hasAnnotated {
+				annotated = true
+			}
+			if !annotated && message != "" {
+				return mcp.NewToolResultError("message can only be given for an annotated tag"), nil
+			}
+			if message == "" {
+				message = tag
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			targetSHA := target
+			if !commitSHAPattern.MatchString(target) {
+				branch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, target, 1)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return mcp.NewToolResultError(fmt.Sprintf("target %s is neither a full commit SHA nor a branch of %s/%s", target, owner, repo)), nil
+					}
+					return nil, fmt.Errorf("failed to get branch: %w", err)
+				}
+				_ = resp.Body.Close()
+				targetSHA = branch.GetCommit().GetSHA()
+			}
+
+			// A lightweight tag is only a reference to the commit, an annotated one references a tag object
+			refSHA := targetSHA
+			if annotated {
+				tagObj, resp, err := client.Git.CreateTag(ctx, owner, repo, &github.Tag{
+					Tag:     github.Ptr(tag),
+					Message: github.Ptr(message),
+					Object: &github.GitObject{
+						Type: github.Ptr("commit"),
+						SHA:  github.Ptr(targetSHA),
+					},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create tag object: %w", err)
+				}
+				_ = resp.Body.Close()
+				refSHA = tagObj.GetSHA()
+			}
+
+			_, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tag),
+				Object: &github.GitObject{SHA: github.Ptr(refSHA)},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create tag %s in %s/%s, the tag may already exist: %s", tag, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create tag reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"tag":        tag,
+				"target_sha": targetSHA,
+				"annotated":  annotated,
+			}
+			if annotated {
+				result["tag_sha"] = refSHA
+			}
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// repositoryDetails is the metadata of a repository returned by get_repository
+type repositoryDetails struct {
+	FullName        string          `json:"full_name"`
+	Description     string          `json:"description,omitempty"`
+	HTMLURL         string          `json:"html_url"`
+	DefaultBranch   string          `json:"default_branch"`
+	Visibility      string          `json:"visibility"`
+	Private         bool            `json:"private"`
+	Fork            bool            `json:"fork"`
+	Archived        bool            `json:"archived"`
+	Disabled        bool            `json:"disabled"`
+	Topics          []string        `json:"topics"`
+	License         string          `json:"license,omitempty"`
+	OpenIssuesCount int             `json:"open_issues_count"`
+	Permissions     map[string]bool `json:"permissions,omitempty"`
+}
+
+// newRepositoryDetails picks the metadata of a repository returned by the repository tools
+func newRepositoryDetails(repository *github.Repository) repositoryDetails {
+	topics := repository.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return repositoryDetails{
+		FullName:        repository.GetFullName(),
+		Description:     repository.GetDescription(),
+		HTMLURL:         repository.GetHTMLURL(),
+		DefaultBranch:   repository.GetDefaultBranch(),
+		Visibility:      repository.GetVisibility(),
+		Private:         repository.GetPrivate(),
+		Fork:            repository.GetFork(),
+		Archived:        repository.GetArchived(),
+		Disabled:        repository.GetDisabled(),
+		Topics:          topics,
+		License:         repository.GetLicense().GetSPDXID(),
+		OpenIssuesCount: repository.GetOpenIssuesCount(),
+		Permissions:     repository.Permissions,
+	}
+}
+
+// GetRepository creates a tool to get the metadata of a repository
+func GetRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_DESCRIPTION", "Get the metadata of a GitHub repository, like its default branch, visibility, topics, license, whether it is archived, and the permissions of the authenticated user on it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_USER_TITLE", "Get repository details"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newRepositoryDetails(repository))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+var repositoryVisibilities = []string{"public", "private", "internal"}
+
+// UpdateRepository creates a tool to change the settings of a repository
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_repository",
+			mcp.WithDescription(t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Change the settings of a GitHub repository. Only the given settings are changed, and the resulting repository metadata is returned. Changing the visibility can expose a private repository or remove the access of users to it")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_REPOSITORY_USER_TITLE", "Update repository settings"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithString("homepage",
+				mcp.Description("URL of the homepage of the repository"),
+			),
+			mcp.WithString("default_branch",
+				mcp.Description("Name of the default branch, the branch must exist"),
+			),
+			mcp.WithString("visibility",
+				mcp.Description("Visibility of the repository"),
+				mcp.Enum(repositoryVisibilities...),
+			),
+			mcp.WithArray("topics",
+				mcp.Description("Topics of the repository, replacing all the current topics"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithBoolean("has_issues",
+				mcp.Description("Whether issues are enabled"),
+			),
+			mcp.WithBoolean("has_wiki",
+				mcp.Description("Whether the wiki is enabled"),
+			),
+			mcp.WithBoolean("has_projects",
+				mcp.Description("Whether projects are enabled"),
+			),
+			mcp.WithBoolean("allow_squash_merge",
+				mcp.Description("Whether pull requests can be squash merged"),
+			),
+			mcp.WithBoolean("allow_merge_commit",
+				mcp.Description("Whether pull requests can be merged with a merge commit"),
+			),
+			mcp.WithBoolean("allow_rebase_merge",
+				mcp.Description("Whether pull requests can be rebase merged"),
+			),
+			mcp.WithBoolean("delete_branch_on_merge",
+				mcp.Description("Whether head branches are deleted automatically when pull requests are merged"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Only the given settings are sent, the others are left as they are
+			edit := &github.Repository{}
+			changed := false
+			for _, setting := range []struct {
+				name  string
+				field **string
+			}{
+				{"description", &edit.Description},
+				{"homepage", &edit.Homepage},
+				{"default_branch", &edit.DefaultBranch},
+				{"visibility", &edit.Visibility},
+			} {
+				value, ok, err := OptionalParamOK[string](request, setting.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*setting.field = github.Ptr(value)
+					changed = true
+				}
+			}
+			for _, setting := range []struct {
+				name  string
+				field **bool
+			}{
+				{"has_issues", &edit.HasIssues},
+				{"has_wiki", &edit.HasWiki},
+				{"has_projects", &edit.HasProjects},
+				{"allow_squash_merge", &edit.AllowSquashMerge},
+				{"allow_merge_commit", &edit.AllowMergeCommit},
+				{"allow_rebase_merge", &edit.AllowRebaseMerge},
+				{"delete_branch_on_merge", &edit.DeleteBranchOnMerge},
+			} {
+				value, ok, err := OptionalParamOK[bool](request, setting.name)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if ok {
+					*setting.field = github.Ptr(value)
+					changed = true
+				}
+			}
+			if edit.Visibility != nil && !slices.Contains(repositoryVisibilities, edit.GetVisibility()) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid visibility: %s, must be one of public, private or internal", edit.GetVisibility())), nil
+			}
+			if edit.DefaultBranch != nil && edit.GetDefaultBranch() == "" {
+				return mcp.NewToolResultError("default_branch cannot be empty"), nil
+			}
+			_, hasTopics := request.GetArguments()["topics"]
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !changed && !hasTopics {
+				return mcp.NewToolResultError("at least one setting to change must be given"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var repository *github.Repository
+			if changed {
+				edited, resp, err := client.Repositories.Edit(ctx, owner, repo, edit)
+				if err != nil {
+					return nil, fmt.Errorf("failed to update repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				repository = edited
+			} else {
+				current, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				repository = current
+			}
+
+			// Topics can't be changed by editing the repository, they have their own endpoint
+			if hasTopics {
+				replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+				if err != nil {
+					return nil, fmt.Errorf("failed to replace repository topics: %w", err)
+				}
+				_ = resp.Body.Close()
+				repository.Topics = replaced
+			}
+
+			r, err := json.Marshal(newRepositoryDetails(repository))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// defaultCompareMaxFiles is the number of changed files compare_refs returns unless max_files is given
+const defaultCompareMaxFiles = 100
+
+// comparedCommit is a commit of a comparison, with the first line of its message only
+type comparedCommit struct {
+	SHA     string `json:"sha"`
+	Author  string `json:"author"`
+	Message string `json:"message"`
+}
+
+// comparedFile is the change of a file in a comparison
+type comparedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+	Patch            string `json:"patch,omitempty"`
+}
+
+// CompareRefs creates a tool to compare two refs of a repository
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two refs of a GitHub repository, like tags, branches or commit SHAs. Returns how far head is ahead of and behind base, their merge base, the commits of head that are not in base and the changes of each file")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("The base ref: a branch name, tag name or commit SHA"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("The head ref: a branch name, tag name or commit SHA"),
+			),
+			mcp.WithBoolean("include_patches",
+				mcp.Description("Include the patch of each file, which can be very large (default false)"),
+			),
+			mcp.WithNumber("max_files",
+				mcp.Description(fmt.Sprintf("The maximum number of changed files to return (default %d)", defaultCompareMaxFiles)),
+				mcp.Min(1),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatches, err := OptionalParam[bool](request, "include_patches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxFiles, err := OptionalIntParamWithDefault(request, "max_files", defaultCompareMaxFiles)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxFiles <= 0 {
+				return mcp.NewToolResultError("max_files must be positive"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare refs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			commits := make([]comparedCommit, 0, len(comparison.Commits))
+			for _, commit := range comparison.Commits {
+				author := commit.GetAuthor().GetLogin()
+				if author == "" {
+					author = commit.GetCommit().GetAuthor().GetName()
+				}
+				message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+				commits = append(commits, comparedCommit{
+					SHA:     commit.GetSHA(),
+					Author:  author,
+					Message: message,
+				})
+			}
+
+			files := make([]comparedFile, 0, min(len(comparison.Files), maxFiles))
+			for _, file := range comparison.Files[:min(len(comparison.Files), maxFiles)] {
+				changed := comparedFile{
+					Filename:         file.GetFilename(),
+					PreviousFilename: file.GetPreviousFilename(),
+					Status:           file.GetStatus(),
+					Additions:        file.GetAdditions(),
+					Deletions:        file.GetDeletions(),
+					Changes:          file.GetChanges(),
+				}
+				if includePatches {
+					changed.Patch = file.GetPatch()
+				}
+				files = append(files, changed)
+			}
+
+			result := map[string]any{
+				"status":            comparison.GetStatus(),
+				"ahead_by":          comparison.GetAheadBy(),
+				"behind_by":         comparison.GetBehindBy(),
+				"total_commits":     comparison.GetTotalCommits(),
+				"html_url":          comparison.GetHTMLURL(),
+				"merge_base_commit": comparison.GetMergeBaseCommit().GetSHA(),
+				"commits":           commits,
+				"files":             files,
+				"total_files":       len(comparison.Files),
+				"files_truncated":   len(comparison.Files) > len(files),
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// treeEntry is an entry of the tree of a repository
+type treeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+}
+
+// GetRepositoryTree creates a tool to list the files of a repository recursively
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_tree",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "List all the files and directories of a GitHub repository recursively in a single call, optionally filtered by a path prefix or a glob pattern. Use it to find files instead of listing directories one by one. When truncated is true, the repository is too large to be listed at once and path_prefix should be used to narrow the listing")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch name, tag name or commit SHA to list the tree of, the default branch when not given"),
+			),
+			mcp.WithString("path_prefix",
+				mcp.Description("Only return the entries under this directory, like 'cmd/' or 'pkg/github'"),
+			),
+			mcp.WithString("pattern",
+				mcp.Description("Only return the entries matching this glob pattern, like '*.proto'. A pattern without a slash is matched against the file name, a pattern with a slash against the whole path"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pathPrefix, err := OptionalParam[string](request, "path_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := OptionalParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Check the pattern before calling the API, path.Match only reports a bad pattern when matching
+			if _, err := path.Match(pattern, ""); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern %q: %s", pattern, err)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository tree: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			entries := []treeEntry{}
+			for _, entry := range tree.Entries {
+				if !matchesTreeFilter(entry.GetPath(), pathPrefix, pattern) {
+					continue
+				}
+				entries = append(entries, treeEntry{
+					Path: entry.GetPath(),
+					Type: entry.GetType(),
+					Size: entry.GetSize(),
+					SHA:  entry.GetSHA(),
+				})
+			}
+
+			result := map[string]any{
+				"ref":           ref,
+				"sha":           tree.GetSHA(),
+				"truncated":     tree.GetTruncated(),
+				"total_entries": len(tree.Entries),
+				"entries":       entries,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// matchesTreeFilter reports whether a tree entry is under the directory prefix and matches the glob pattern.
+// An empty prefix or pattern matches everything.
+func matchesTreeFilter(entryPath, prefix, pattern string) bool {
+	if prefix = strings.Trim(prefix, "/"); prefix != "" && entryPath != prefix && !strings.HasPrefix(entryPath, prefix+"/") {
+		return false
+	}
+	if pattern == "" {
+		return true
+	}
+	name := entryPath
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(entryPath)
+	}
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+// contributor is a contributor of a repository with the number of their contributions
+type contributor struct {
+	Login         string `json:"login,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Contributions int    `json:"contributions"`
+	Type          string `json:"type"`
+}
+
+// ListContributors creates a tool to list the contributors of a repository.
+func ListContributors(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_contributors",
+			mcp.WithDescription(t("TOOL_LIST_CONTRIBUTORS_DESCRIPTION", "List the contributors of a GitHub repository by number of commits, most active first. Useful to find who knows a repository. pending is true while GitHub is still computing the contributors, retry a few seconds later")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CONTRIBUTORS_USER_TITLE", "List repository contributors"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("include_anonymous",
+				mcp.Description("Include the contributors whose commits are not linked to a GitHub account"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAnonymous, err := OptionalParam[bool](request, "include_anonymous")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListContributorsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if includeAnonymous {
+				opts.Anon = "true"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			contributors, resp, err := client.Repositories.ListContributors(ctx, owner, repo, opts)
+			var result map[string]any
+			switch {
+			case resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err):
+				// The contributors of large repositories are computed in the background, the request is to be retried
+				_ = resp.Body.Close()
+				result = map[string]any{
+					"pending": true,
+					"message": fmt.Sprintf("GitHub is computing the contributors of %s/%s, retry in a few seconds", owner, repo),
+				}
+			case err != nil:
+				return nil, fmt.Errorf("failed to list contributors: %w", err)
+			default:
+				_ = resp.Body.Close()
+				entries := make([]contributor, 0, len(contributors))
+				for _, c := range contributors {
+					entries = append(entries, contributor{
+						Login:         c.GetLogin(),
+						Name:          c.GetName(),
+						Contributions: c.GetContributions(),
+						Type:          c.GetType(),
+					})
+				}
+				result = map[string]any{
+					"pending":      false,
+					"contributors": entries,
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRepositoryLicense creates a tool to get the license detected in a repository.
+func GetRepositoryLicense(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_license",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_LICENSE_DESCRIPTION", "Get the license GitHub detected in a repository, with its SPDX ID and the path of the license file. found is false when no license is detected")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_LICENSE_USER_TITLE", "Get repository license"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("include_content",
+				mcp.Description("Also return the text of the license file"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeContent, err := OptionalParam[bool](request, "include_content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repositoryLicense, resp, err := client.Repositories.License(ctx, owner, repo)
+			var result map[string]any
+			switch {
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// The repository has no license file GitHub recognizes, which is not an error
+				_ = resp.Body.Close()
+				result = map[string]any{
+					"found":   false,
+					"message": fmt.Sprintf("no license detected in %s/%s", owner, repo),
+				}
+			case err != nil:
+				return nil, fmt.Errorf("failed to get repository license: %w", err)
+			default:
+				_ = resp.Body.Close()
+				license := repositoryLicense.GetLicense()
+				result = map[string]any{
+					"found":    true,
+					"spdx_id":  license.GetSPDXID(),
+					"key":      license.GetKey(),
+					"name":     license.GetName(),
+					"path":     repositoryLicense.GetPath(),
+					"html_url": repositoryLicense.GetHTMLURL(),
+				}
+
+				if includeContent {
+					rawClient, err := getRawClient(ctx)
+					if err != nil {
+						return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+					}
+					content, err := rawClient.GetRawContentResult(ctx, owner, repo, repositoryLicense.GetPath(), nil)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get license content: %w", err)
+					}
+					if !content.Found() {
+						return nil, fmt.Errorf("failed to get license content: status %d", content.StatusCode)
+					}
+					result["content"] = string(content.Content)
+				}
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// TransferRepository creates a tool to transfer a repository to another user or organization.
+func TransferRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_repository",
+			mcp.WithDescription(t("TOOL_TRANSFER_REPOSITORY_DESCRIPTION", "Transfer a GitHub repository to another user or organization. The transfer happens in the background, and a transfer to a user has to be accepted by that user")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_TRANSFER_REPOSITORY_USER_TITLE", "Transfer repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("new_owner",
+				mcp.Required(),
+				mcp.Description("The user or organization to transfer the repository to"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("The new name of the repository, the current name is kept when not given"),
+			),
+			mcp.WithArray("team_ids",
+				mcp.Description("The IDs of the teams of the new owner organization to give access to the repository"),
+				mcp.Items(map[string]any{
+					"type": "number",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newOwner, err := RequiredParam[string](request, "new_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamIDs, err := OptionalIntArrayParam(request, "team_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			transfer := github.TransferRequest{NewOwner: newOwner}
+			expectedName := repo
+			if newName != "" {
+				transfer.NewName = github.Ptr(newName)
+				expectedName = newName
+			}
+			for _, id := range teamIDs {
+				transfer.TeamID = append(transfer.TeamID, int64(id))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Repositories.Transfer(ctx, owner, repo, transfer)
+			if err != nil && !isAcceptedError(err) {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot transfer %s/%s to %s, the new owner may already have a repository named %s: %s", owner, repo, newOwner, expectedName, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to transfer repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The API accepts the transfer with a 202, the repository keeps its current name until it is done
+			result := map[string]any{
+				"message":     fmt.Sprintf("Repository %s/%s is being transferred to %s, it will be available as %s/%s once the transfer is complete", owner, repo, newOwner, newOwner, expectedName),
+				"full_name":   fmt.Sprintf("%s/%s", newOwner, expectedName),
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ArchiveRepository creates a tool to archive a repository.
+func ArchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("archive_repository",
+			mcp.WithDescription(t("TOOL_ARCHIVE_REPOSITORY_DESCRIPTION", "Archive a GitHub repository, making it read-only: no pushes, issues, pull requests or comments are possible until it is unarchived. Archiving an archived repository does nothing")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_ARCHIVE_REPOSITORY_USER_TITLE", "Archive repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setRepositoryArchived(ctx, getClient, request, true)
+		}
+}
+
+// UnarchiveRepository creates a tool to unarchive a repository.
+func UnarchiveRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("unarchive_repository",
+			mcp.WithDescription(t("TOOL_UNARCHIVE_REPOSITORY_DESCRIPTION", "Unarchive an archived GitHub repository, making it writable again. Unarchiving a repository that is not archived does nothing")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UNARCHIVE_REPOSITORY_USER_TITLE", "Unarchive repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setRepositoryArchived(ctx, getClient, request, false)
+		}
+}
+
+// setRepositoryArchived handles archive_repository and unarchive_repository. A repository already in the
+// requested state is left as is.
+func setRepositoryArchived(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, archive bool) (*mcp.CallToolResult, error) {
+	owner, err := RequiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	action, state := "unarchive", "unarchived"
+	if archive {
+		action, state = "archive", "archived"
+	}
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	result := map[string]any{
+		"full_name": repository.GetFullName(),
+		"archived":  archive,
+		"changed":   false,
+	}
+	if repository.GetArchived() == archive {
+		result["message"] = fmt.Sprintf("Repository %s/%s is already %s", owner, repo, state)
+	} else {
+		edited, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{Archived: github.Ptr(archive)})
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusForbidden && !archive {
+				return mcp.NewToolResultError(fmt.Sprintf("not allowed to unarchive %s/%s, it requires admin access to the repository, and some plans only allow unarchiving from the repository settings on GitHub: %s", owner, repo, err.Error())), nil
+			}
+			if resp != nil && resp.StatusCode == http.StatusForbidden {
+				return mcp.NewToolResultError(fmt.Sprintf("not allowed to archive %s/%s, it requires admin access to the repository: %s", owner, repo, err.Error())), nil
+			}
+			return nil, fmt.Errorf("failed to %s repository: %w", action, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		result["archived"] = edited.GetArchived()
+		result["changed"] = true
+		result["message"] = fmt.Sprintf("Repository %s/%s has been %s", owner, repo, state)
+	}
+
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,10 +36,15 @@ func Test_GetFileContents(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "max_bytes")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.Contains(t, tool.InputSchema.Properties, "follow_symlinks")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
 
 	// Mock response for raw content
 	mockRawContent := []byte("# Test Repository\n\nThis is a test repository.")
+	mockSourceContent := []byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n")
 
 	// Setup mock directory content for success case
 	mockDirContent := []*github.RepositoryContent{
@@ -64,6 +73,8 @@ func Test_GetFileContents(t *testing.T) {
 		expectedResult interface{}
 		expectedErrMsg string
 		expectStatus   int
+		// expectedMessage is the JSON metadata returned with a line range
+		expectedMessage string
 	}{
 		{
 			name: "successful text content fetch",
@@ -89,6 +100,153 @@ func Test_GetFileContents(t *testing.T) {
 				MIMEType: "text/markdown",
 			},
 		},
+		{
+			name: "text content fetch limited by max_bytes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "bytes=0-16", r.Header.Get("Range"))
+						w.Header().Set("Content-Type", "text/markdown")
+						w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-16/%d", len(mockRawContent)))
+						w.WriteHeader(http.StatusPartialContent)
+						_, _ = w.Write(mockRawContent[:17])
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"path":      "README.md",
+				"branch":    "main",
+				"max_bytes": float64(17),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/README.md",
+				Text:     "# Test Repository",
+				MIMEType: "text/markdown",
+			},
+		},
+		{
+			name: "text content fetch of a line range",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					expectPath(t, "/owner/repo/refs/heads/main/main.go").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Content-Type", "text/plain")
+							_, _ = w.Write(mockSourceContent)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"branch":     "main",
+				"start_line": float64(5),
+				"end_line":   float64(6),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/main.go",
+				Text:     "func main() {\n\tfmt.Println(\"hello\")\n",
+				MIMEType: "text/plain",
+			},
+			expectedMessage: `{"message": "successfully downloaded text file", "total_lines": 7, "returned_range": [5, 6]}`,
+		},
+		{
+			name: "text content fetch of a line range clamped at the end of the file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write(mockSourceContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"start_line": float64(6),
+				"end_line":   float64(100),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/contents/main.go",
+				Text:     "\tfmt.Println(\"hello\")\n}\n",
+				MIMEType: "text/plain",
+			},
+			expectedMessage: `{"message": "successfully downloaded text file", "total_lines": 7, "returned_range": [6, 7]}`,
+		},
+		{
+			name: "text content fetch of a line range starting after the end of the file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write(mockSourceContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"start_line": float64(50),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/contents/main.go",
+				Text:     "}\n",
+				MIMEType: "text/plain",
+			},
+			expectedMessage: `{"message": "successfully downloaded text file", "total_lines": 7, "returned_range": [7, 7]}`,
+		},
+		{
+			name:         "line range with start_line after end_line",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"start_line": float64(6),
+				"end_line":   float64(5),
+			},
+			expectError:    false,
+			expectedResult: mcp.NewToolResultError("start_line (6) cannot be after end_line (5)"),
+		},
+		{
+			name: "line range ignored for binary files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "image/png")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "test.png",
+				"branch":     "main",
+				"start_line": float64(2),
+			},
+			expectError: false,
+			expectedResult: mcp.BlobResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/test.png",
+				Blob:     base64.StdEncoding.EncodeToString(mockRawContent),
+				MIMEType: "image/png",
+			},
+			expectedMessage: "successfully downloaded binary file, start_line and end_line are ignored for binary files",
+		},
 		{
 			name: "successful file blob content fetch",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -139,6 +297,265 @@ func Test_GetFileContents(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockDirContent,
 		},
+		{
+			name: "root directory listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/").andThen(
+						mockResponse(t, http.StatusOK, mockDirContent),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "/",
+			},
+			expectError: false,
+			expectedResult: []directoryEntry{
+				{Name: "README.md", Path: "README.md", Type: "file", Size: 42, SHA: "abc123"},
+				{Name: "src", Path: "src", Type: "dir", SHA: "def456"},
+			},
+		},
+		{
+			name: "nested directory without a trailing slash",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/cmd/server").andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+							{
+								Type: github.Ptr("file"),
+								Name: github.Ptr("main.go"),
+								Path: github.Ptr("cmd/server/main.go"),
+								SHA:  github.Ptr("fff000"),
+								Size: github.Ptr(1024),
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "cmd/server",
+				"branch": "main",
+			},
+			expectError: false,
+			expectedResult: []directoryEntry{
+				{Name: "main.go", Path: "cmd/server/main.go", Type: "file", Size: 1024, SHA: "fff000"},
+			},
+		},
+		{
+			name: "regular file is returned without using the contents API",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/markdown")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						t.Error("the contents API should not be used for a regular file")
+						w.WriteHeader(http.StatusInternalServerError)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/contents/README.md",
+				Text:     "# Test Repository\n\nThis is a test repository.",
+				MIMEType: "text/markdown",
+			},
+		},
+		{
+			name: "single line file that is not a symlink",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte("1.2.3"))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type: github.Ptr("file"),
+						Name: github.Ptr("VERSION"),
+						Path: github.Ptr("VERSION"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "VERSION",
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/contents/VERSION",
+				Text:     "1.2.3",
+				MIMEType: "text/plain",
+			},
+		},
+		{
+			name: "symlink to a file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte("docs/guide.md"))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{
+							Type: github.Ptr("file"),
+							Name: github.Ptr("guide.md"),
+							Path: github.Ptr("docs/guide.md"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"path":   "GUIDE.md",
+				"branch": "main",
+			},
+			expectError: false,
+			expectedResult: specialEntry{
+				Type:       "symlink",
+				Path:       "GUIDE.md",
+				Target:     "docs/guide.md",
+				TargetPath: "docs/guide.md",
+				Message:    "GUIDE.md is a symbolic link to docs/guide.md, set follow_symlinks to get the contents of the target",
+			},
+		},
+		{
+			name: "symlink to a directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte("../shared"))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:   github.Ptr("symlink"),
+						Name:   github.Ptr("shared"),
+						Path:   github.Ptr("web/shared"),
+						Target: github.Ptr("../shared"),
+						SHA:    github.Ptr("aaa111"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "web/shared",
+			},
+			expectError: false,
+			expectedResult: specialEntry{
+				Type:       "symlink",
+				Path:       "web/shared",
+				Target:     "../shared",
+				TargetPath: "shared",
+				SHA:        "aaa111",
+				Message:    "web/shared is a symbolic link to ../shared, set follow_symlinks to get the contents of the target",
+			},
+		},
+		{
+			name: "symlink followed to its target",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Set("Content-Type", "text/markdown")
+						if r.URL.Path == "/owner/repo/refs/heads/main/GUIDE.md" {
+							_, _ = w.Write([]byte("docs/guide.md"))
+							return
+						}
+						_, _ = w.Write([]byte("# Guide\n\nRead me."))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/GUIDE.md").andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryContent{
+							Type: github.Ptr("file"),
+							Name: github.Ptr("guide.md"),
+							Path: github.Ptr("docs/guide.md"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"path":            "GUIDE.md",
+				"branch":          "main",
+				"follow_symlinks": true,
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/docs/guide.md",
+				Text:     "# Guide\n\nRead me.",
+				MIMEType: "text/markdown",
+			},
+			expectedMessage: "followed symbolic link GUIDE.md to docs/guide.md, successfully downloaded text file",
+		},
+		{
+			name: "submodule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:            github.Ptr("submodule"),
+						Name:            github.Ptr("lib"),
+						Path:            github.Ptr("third_party/lib"),
+						SHA:             github.Ptr("0123456789abcdef"),
+						SubmoduleGitURL: github.Ptr("https://github.com/other/lib.git"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "third_party/lib",
+			},
+			expectError: false,
+			expectedResult: specialEntry{
+				Type:            "submodule",
+				Path:            "third_party/lib",
+				SubmoduleGitURL: "https://github.com/other/lib.git",
+				SHA:             "0123456789abcdef",
+				Message:         "third_party/lib is a git submodule of https://github.com/other/lib.git pinned at 0123456789abcdef, get its contents from that repository",
+			},
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -189,6 +606,15 @@ func Test_GetFileContents(t *testing.T) {
 			}
 
 			require.NoError(t, err)
+			if tc.expectedMessage != "" {
+				message, ok := result.Content[0].(mcp.TextContent)
+				require.True(t, ok)
+				if json.Valid([]byte(tc.expectedMessage)) {
+					assert.JSONEq(t, tc.expectedMessage, message.Text)
+				} else {
+					assert.Equal(t, tc.expectedMessage, message.Text)
+				}
+			}
 			// Use the correct result helper based on the expected type
 			switch expected := tc.expectedResult.(type) {
 			case mcp.TextResourceContents:
@@ -209,9 +635,21 @@ func Test_GetFileContents(t *testing.T) {
 					assert.Equal(t, *expected[i].Path, *content.Path)
 					assert.Equal(t, *expected[i].Type, *content.Type)
 				}
-			case mcp.TextContent:
-				textContent := getErrorResult(t, result)
-				require.Equal(t, textContent, expected)
+			case []directoryEntry:
+				textContent := getTextResult(t, result)
+				var returnedEntries []directoryEntry
+				err = json.Unmarshal([]byte(textContent.Text), &returnedEntries)
+				require.NoError(t, err)
+				assert.Equal(t, expected, returnedEntries)
+			case specialEntry:
+				textContent := getTextResult(t, result)
+				var returnedEntry specialEntry
+				err = json.Unmarshal([]byte(textContent.Text), &returnedEntry)
+				require.NoError(t, err)
+				assert.Equal(t, expected, returnedEntry)
+			case *mcp.CallToolResult:
+				require.True(t, result.IsError)
+				assert.Equal(t, getErrorResult(t, expected), getErrorResult(t, result))
 			}
 		})
 	}
@@ -519,6 +957,8 @@ func Test_GetCommit(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "include_patches")
+	assert.Contains(t, tool.InputSchema.Properties, "max_files")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
 
 	mockCommit := &github.RepositoryCommit{
@@ -552,13 +992,32 @@ func Test_GetCommit(t *testing.T) {
 		},
 	}
 
+	// A commit touching vendored code, without stats
+	mockVendorCommit := &github.RepositoryCommit{
+		SHA:     github.Ptr("fed987cba654"),
+		Commit:  &github.Commit{Message: github.Ptr("Vendor dependencies")},
+		Author:  &github.User{Login: github.Ptr("testuser")},
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/fed987cba654"),
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("vendor/a.go"), Status: github.Ptr("added"), Additions: github.Ptr(100), Patch: github.Ptr("@@ -0,0 +1,100 @@")},
+			{Filename: github.Ptr("vendor/b.go"), Status: github.Ptr("added"), Additions: github.Ptr(50), Patch: github.Ptr("@@ -0,0 +1,50 @@")},
+			{Filename: github.Ptr("vendor/c.go"), Status: github.Ptr("removed"), Deletions: github.Ptr(20), Patch: github.Ptr("@@ -1,20 +0,0 @@")},
+		},
+	}
+
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedCommit *github.RepositoryCommit
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedCommit  *github.RepositoryCommit
+		expectedErrMsg  string
+		// expectedStats, expectedFiles and expectPatches are only checked when expectedStats is set
+		expectedStats     *github.CommitStats
+		expectedFiles     []string
+		expectPatches     bool
+		expectedTruncated int
 	}{
 		{
 			name: "successful commit fetch",
@@ -575,6 +1034,67 @@ func Test_GetCommit(t *testing.T) {
 			},
 			expectError:    false,
 			expectedCommit: mockCommit,
+			expectedStats:  mockCommit.Stats,
+			expectedFiles:  []string{"file1.go"},
+			expectPatches:  true,
+		},
+		{
+			name: "commit without patches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusOK, mockCommit),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"sha":             "abc123def456",
+				"include_patches": false,
+			},
+			expectError:    false,
+			expectedCommit: mockCommit,
+			expectedStats:  mockCommit.Stats,
+			expectedFiles:  []string{"file1.go"},
+			expectPatches:  false,
+		},
+		{
+			name: "commit with files capped and stats computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusOK, mockVendorCommit),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"sha":             "fed987cba654",
+				"include_patches": false,
+				"max_files":       float64(2),
+			},
+			expectError:    false,
+			expectedCommit: mockVendorCommit,
+			expectedStats: &github.CommitStats{
+				Additions: github.Ptr(150),
+				Deletions: github.Ptr(20),
+				Total:     github.Ptr(170),
+			},
+			expectedFiles:     []string{"vendor/a.go", "vendor/b.go"},
+			expectPatches:     false,
+			expectedTruncated: 1,
+		},
+		{
+			name:         "invalid max_files",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"sha":       "abc123def456",
+				"max_files": float64(-1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "max_files must be at least 1",
 		},
 		{
 			name: "commit fetch fails",
@@ -618,6 +1138,12 @@ func Test_GetCommit(t *testing.T) {
 
 			require.NoError(t, err)
 
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
@@ -630,6 +1156,23 @@ func Test_GetCommit(t *testing.T) {
 			assert.Equal(t, *tc.expectedCommit.Commit.Message, *returnedCommit.Commit.Message)
 			assert.Equal(t, *tc.expectedCommit.Author.Login, *returnedCommit.Author.Login)
 			assert.Equal(t, *tc.expectedCommit.HTMLURL, *returnedCommit.HTMLURL)
+
+			if tc.expectedStats == nil {
+				return
+			}
+			assert.Equal(t, tc.expectedStats, returnedCommit.Stats)
+			filenames := make([]string, 0, len(returnedCommit.Files))
+			for _, file := range returnedCommit.Files {
+				filenames = append(filenames, file.GetFilename())
+				assert.Equal(t, tc.expectPatches, file.Patch != nil, "unexpected patch of %s", file.GetFilename())
+			}
+			assert.Equal(t, tc.expectedFiles, filenames)
+
+			var truncation struct {
+				FilesTruncated int `json:"files_truncated"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &truncation))
+			assert.Equal(t, tc.expectedTruncated, truncation.FilesTruncated)
 		})
 	}
 }
@@ -645,6 +1188,10 @@ func Test_ListCommits(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "author")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
@@ -688,7 +1235,9 @@ func Test_ListCommits(t *testing.T) {
 		mockedClient    *http.Client
 		requestArgs     map[string]interface{}
 		expectError     bool
+		expectToolError bool
 		expectedCommits []*github.RepositoryCommit
+		expectedFilters map[string]string
 		expectedErrMsg  string
 	}{
 		{
@@ -750,6 +1299,63 @@ func Test_ListCommits(t *testing.T) {
 			expectError:     false,
 			expectedCommits: mockCommits,
 		},
+		{
+			name: "successful commits fetch with filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"author":   "alice",
+						"path":     "pkg/github",
+						"since":    "2025-06-03T00:00:00Z",
+						"until":    "2025-06-10T12:00:00Z",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"author": "alice",
+				"path":   "pkg/github",
+				"since":  "2025-06-03T00:00:00Z",
+				"until":  "2025-06-10T12:00:00Z",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+			expectedFilters: map[string]string{
+				"author": "alice",
+				"path":   "pkg/github",
+				"since":  "2025-06-03T00:00:00Z",
+				"until":  "2025-06-10T12:00:00Z",
+			},
+		},
+		{
+			name:         "invalid since date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "last tuesday",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid since time format, should be RFC3339/ISO8601",
+		},
+		{
+			name:         "until before since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2025-06-10T00:00:00Z",
+				"until": "2025-06-03T00:00:00Z",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "until (2025-06-03T00:00:00Z) cannot be before since (2025-06-10T00:00:00Z)",
+		},
 		{
 			name: "commits fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -791,13 +1397,31 @@ func Test_ListCommits(t *testing.T) {
 
 			require.NoError(t, err)
 
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
 			var returnedCommits []*github.RepositoryCommit
-			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
-			require.NoError(t, err)
+			if tc.expectedFilters == nil {
+				// Without filters the commits are a bare array
+				err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
+				require.NoError(t, err)
+			} else {
+				var response struct {
+					Filters map[string]string          `json:"filters"`
+					Commits []*github.RepositoryCommit `json:"commits"`
+				}
+				err = json.Unmarshal([]byte(textContent.Text), &response)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedFilters, response.Filters)
+				returnedCommits = response.Commits
+			}
 			assert.Len(t, returnedCommits, len(tc.expectedCommits))
 			for i, commit := range returnedCommits {
 				assert.Equal(t, *tc.expectedCommits[i].SHA, *commit.SHA)
@@ -821,6 +1445,7 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "encoding")
 	assert.Contains(t, tool.InputSchema.Properties, "message")
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
@@ -848,11 +1473,15 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		},
 	}
 
+	// A 1x1 transparent PNG
+	pixelPNG := "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
+
 	tests := []struct {
 		name            string
 		mockedClient    *http.Client
 		requestArgs     map[string]interface{}
 		expectError     bool
+		expectToolError bool
 		expectedContent *github.RepositoryContentResponse
 		expectedErrMsg  string
 	}{
@@ -908,6 +1537,62 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 			expectError:     false,
 			expectedContent: mockFileResponse,
 		},
+		{
+			name: "successful binary file creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add pixel",
+						"content": pixelPNG, // The decoded bytes are encoded again as is
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"path":     "docs/pixel.png",
+				"content":  pixelPNG,
+				"encoding": "base64",
+				"message":  "Add pixel",
+				"branch":   "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name:         "invalid base64 content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"path":     "docs/pixel.png",
+				"content":  "not base64!",
+				"encoding": "base64",
+				"message":  "Add pixel",
+				"branch":   "main",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "content is not valid base64",
+		},
+		{
+			name:         "base64 content too large",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"path":     "docs/large.bin",
+				"content":  base64.StdEncoding.EncodeToString(make([]byte, maxBase64FileBytes+1)),
+				"encoding": "base64",
+				"message":  "Add large file",
+				"branch":   "main",
+			},
+			expectToolError: true,
+			expectedErrMsg:  fmt.Sprintf("decoded content is %d bytes, more than the maximum of %d bytes", maxBase64FileBytes+1, maxBase64FileBytes),
+		},
 		{
 			name: "file creation fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -953,6 +1638,12 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 
 			require.NoError(t, err)
 
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
@@ -1123,7 +1814,7 @@ func Test_CreateRepository(t *testing.T) {
 func Test_PushFiles(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := PushFiles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := PushFiles(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "push_files", tool.Name)
@@ -1133,6 +1824,7 @@ func Test_PushFiles(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
 	assert.Contains(t, tool.InputSchema.Properties, "files")
 	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "signed")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
 
 	// Setup mock objects
@@ -1171,13 +1863,117 @@ func Test_PushFiles(t *testing.T) {
 
 	// Define test cases
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedRef    *github.Reference
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		mockedGQLClient *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedRef     *github.Reference
+		expectedErrMsg  string
 	}{
+		{
+			name: "successful signed push",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				createCommitOnBranchMatcher(
+					githubv4.CreateCommitOnBranchInput{
+						Branch: githubv4.CommittableBranch{
+							RepositoryNameWithOwner: githubv4.NewString("owner/repo"),
+							BranchName:              githubv4.NewString("main"),
+						},
+						Message:         githubv4.CommitMessage{Headline: "Update multiple files"},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Additions: &[]githubv4.FileAddition{
+								{Path: "README.md", Contents: githubv4.Base64String(base64.StdEncoding.EncodeToString([]byte("# Updated README")))},
+								{Path: "logo.png", Contents: "iVBORw0KGgo="},
+							},
+							Deletions: &[]githubv4.FileDeletion{{Path: "old.txt"}},
+						},
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"createCommitOnBranch": map[string]any{
+							"commit": map[string]any{
+								"oid": "jkl012",
+								"url": "https://github.com/owner/repo/commit/jkl012",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# Updated README",
+					},
+					map[string]interface{}{
+						"path":     "logo.png",
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					},
+					map[string]interface{}{
+						"path":   "old.txt",
+						"delete": true,
+					},
+				},
+				"message": "Update multiple files",
+				"signed":  true,
+			},
+			expectedRef: &github.Reference{
+				Ref: github.Ptr("refs/heads/main"),
+				Object: &github.GitObject{
+					SHA: github.Ptr("jkl012"),
+				},
+			},
+		},
+		{
+			name: "signed push after the branch moved",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				createCommitOnBranchMatcher(
+					githubv4.CreateCommitOnBranchInput{
+						Branch: githubv4.CommittableBranch{
+							RepositoryNameWithOwner: githubv4.NewString("owner/repo"),
+							BranchName:              githubv4.NewString("main"),
+						},
+						Message:         githubv4.CommitMessage{Headline: "Delete file"},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Deletions: &[]githubv4.FileDeletion{{Path: "old.txt"}},
+						},
+					},
+					githubv4mock.ErrorResponse(`Expected branch to point to "abc123" but it did not. Pull and try again.`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":   "old.txt",
+						"delete": true,
+					},
+				},
+				"message": "Delete file",
+				"signed":  true,
+			},
+			expectedErrMsg: "expected head OID mismatch: the head of branch main is no longer abc123",
+		},
 		{
 			name: "successful push of multiple files",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1325,43 +2121,112 @@ func Test_PushFiles(t *testing.T) {
 				"message": "Update file",
 			},
 			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "each file must have content",
+			expectedErrMsg: "file README.md must have exactly one of content or delete",
 		},
 		{
-			name: "fails to get branch reference",
+			name: "successful push of added, deleted and binary files",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
+				// Get branch reference
+				mock.WithRequestMatch(
 					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockResponse(t, http.StatusNotFound, nil),
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Create blob for the binary file
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob123")}),
+					),
+				),
+				// Create tree
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "docs/new.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# New",
+							},
+							map[string]interface{}{
+								"path": "docs/old.md",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+							map[string]interface{}{
+								"path": "docs/logo.png",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob123",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				// Create commit
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Move docs",
+						"tree":    "ghi789",
+						"parents": []interface{}{"abc123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				// Update reference
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":  "owner",
 				"repo":   "repo",
-				"branch": "non-existent-branch",
+				"branch": "main",
 				"files": []interface{}{
 					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
+						"path":     "docs/new.md",
+						"content":  "# New",
+						"encoding": "utf-8",
+					},
+					map[string]interface{}{
+						"path":   "docs/old.md",
+						"delete": true,
+					},
+					map[string]interface{}{
+						"path":     "docs/logo.png",
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
 					},
 				},
-				"message": "Update file",
+				"message": "Move docs",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get branch reference",
+			expectError: false,
+			expectedRef: mockUpdatedRef,
 		},
 		{
-			name: "fails to get base commit",
+			name: "fails when a file has both content and delete",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
 				mock.WithRequestMatch(
 					mock.GetReposGitRefByOwnerByRepoByRef,
 					mockRef,
 				),
-				// Fail to get commit
-				mock.WithRequestMatchHandler(
+				mock.WithRequestMatch(
 					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockResponse(t, http.StatusNotFound, nil),
+					mockCommit,
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -1372,15 +2237,96 @@ func Test_PushFiles(t *testing.T) {
 					map[string]interface{}{
 						"path":    "README.md",
 						"content": "# README",
+						"delete":  true,
 					},
 				},
 				"message": "Update file",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get base commit",
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "file README.md must have exactly one of content or delete",
 		},
 		{
-			name: "fails to create tree",
+			name: "fails when base64 content is invalid",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "logo.png",
+						"content":  "not base64!",
+						"encoding": "base64",
+					},
+				},
+				"message": "Add logo",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "content of file logo.png is not valid base64",
+		},
+		{
+			name: "fails to get branch reference",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "non-existent-branch",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch reference",
+		},
+		{
+			name: "fails to get base commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Fail to get commit
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get base commit",
+		},
+		{
+			name: "fails to create tree",
 			mockedClient: mock.NewMockedHTTPClient(
 				// Get branch reference
 				mock.WithRequestMatch(
@@ -1419,7 +2365,7 @@ func Test_PushFiles(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := PushFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := PushFiles(stubGetClientFn(client), stubGetGQLClientFn(githubv4.NewClient(tc.mockedGQLClient)), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -2016,3 +2962,2092 @@ func Test_GetTag(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "annotated")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag", "target"})
+
+	commitSHA := "0123456789abcdef0123456789abcdef01234567"
+	mockBranch := &github.Branch{
+		Name:   github.Ptr("main"),
+		Commit: &github.RepositoryCommit{SHA: github.Ptr(commitSHA)},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "annotated tag of a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/branches/main").andThen(
+						mockResponse(t, http.StatusOK, mockBranch),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag":     "v1.0.0",
+						"message": "Release v1.0.0",
+						"object":  commitSHA,
+						"type":    "commit",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tag{SHA: github.Ptr("tag-object-sha")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "refs/tags/v1.0.0",
+						"sha": "tag-object-sha",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"tag":     "v1.0.0",
+				"target":  "main",
+				"message": "Release v1.0.0",
+			},
+			expectedResult: map[string]any{
+				"tag":        "v1.0.0",
+				"target_sha": commitSHA,
+				"annotated":  true,
+				"tag_sha":    "tag-object-sha",
+			},
+		},
+		{
+			name: "lightweight tag of a commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "refs/tags/v1.0.0",
+						"sha": commitSHA,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"tag":       "v1.0.0",
+				"target":    commitSHA,
+				"annotated": false,
+			},
+			expectedResult: map[string]any{
+				"tag":        "v1.0.0",
+				"target_sha": commitSHA,
+				"annotated":  false,
+			},
+		},
+		{
+			name: "tag already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Reference already exists"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"tag":       "v1.0.0",
+				"target":    commitSHA,
+				"annotated": false,
+			},
+			expectedErrMsg: "cannot create tag v1.0.0 in owner/repo, the tag may already exist",
+		},
+		{
+			name: "target is neither a commit nor a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"tag":    "v1.0.0",
+				"target": "abc123",
+			},
+			expectedErrMsg: "target abc123 is neither a full commit SHA nor a branch of owner/repo",
+		},
+		{
+			name:         "message of a lightweight tag",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"tag":       "v1.0.0",
+				"target":    commitSHA,
+				"message":   "Release v1.0.0",
+				"annotated": false,
+			},
+			expectedErrMsg: "message can only be given for an annotated tag",
+		},
+		{
+			name: "tag object creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"tag":    "v1.0.0",
+				"target": commitSHA,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create tag object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockRepo := &github.Repository{
+		FullName:        github.Ptr("owner/repo"),
+		Description:     github.Ptr("Test repository"),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo"),
+		DefaultBranch:   github.Ptr("develop"),
+		Visibility:      github.Ptr("internal"),
+		Private:         github.Ptr(true),
+		Archived:        github.Ptr(true),
+		Topics:          []string{"go", "mcp"},
+		License:         &github.License{Key: github.Ptr("mit"), SPDXID: github.Ptr("MIT")},
+		OpenIssuesCount: github.Ptr(7),
+		Permissions:     map[string]bool{"admin": false, "push": true, "pull": true},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedDetails repositoryDetails
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful repository retrieval",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					expectPath(
+						t,
+						"/repos/owner/repo",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedDetails: repositoryDetails{
+				FullName:        "owner/repo",
+				Description:     "Test repository",
+				HTMLURL:         "https://github.com/owner/repo",
+				DefaultBranch:   "develop",
+				Visibility:      "internal",
+				Private:         true,
+				Archived:        true,
+				Topics:          []string{"go", "mcp"},
+				License:         "MIT",
+				OpenIssuesCount: 7,
+				Permissions:     map[string]bool{"admin": false, "push": true, "pull": true},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var returnedDetails repositoryDetails
+			err = json.Unmarshal([]byte(textContent.Text), &returnedDetails)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDetails, returnedDetails)
+		})
+	}
+}
+
+func Test_UpdateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "visibility")
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.Contains(t, tool.InputSchema.Properties, "delete_branch_on_merge")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	mockRepo := &github.Repository{
+		FullName:            github.Ptr("owner/repo"),
+		Description:         github.Ptr("Updated description"),
+		DefaultBranch:       github.Ptr("main"),
+		Visibility:          github.Ptr("private"),
+		Private:             github.Ptr(true),
+		Topics:              []string{"go"},
+		AllowSquashMerge:    github.Ptr(true),
+		DeleteBranchOnMerge: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectToolError    bool
+		expectedErrMsg     string
+		expectedVisibility string
+		expectedTopics     []string
+	}{
+		{
+			name: "change the visibility only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"visibility": "private",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "private",
+			},
+			expectedVisibility: "private",
+			expectedTopics:     []string{"go"},
+		},
+		{
+			name: "change the merge policy and clear the description",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"description":            "",
+						"allow_merge_commit":     false,
+						"allow_squash_merge":     true,
+						"delete_branch_on_merge": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"description":            "",
+				"allow_merge_commit":     false,
+				"allow_squash_merge":     true,
+				"delete_branch_on_merge": true,
+			},
+			expectedVisibility: "private",
+			expectedTopics:     []string{"go"},
+		},
+		{
+			name: "replace the topics only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"names": []interface{}{"mcp", "github"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]interface{}{"names": []string{"mcp", "github"}}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []interface{}{"mcp", "github"},
+			},
+			expectedVisibility: "private",
+			expectedTopics:     []string{"mcp", "github"},
+		},
+		{
+			name: "update rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Visibility can't be internal for a repository owned by a user"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "internal",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update repository",
+		},
+		{
+			name:         "invalid visibility",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"visibility": "secret",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid visibility: secret",
+		},
+		{
+			name:         "nothing to change",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at least one setting to change must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var returnedDetails repositoryDetails
+			err = json.Unmarshal([]byte(textContent.Text), &returnedDetails)
+			require.NoError(t, err)
+			assert.Equal(t, "owner/repo", returnedDetails.FullName)
+			assert.Equal(t, tc.expectedVisibility, returnedDetails.Visibility)
+			assert.Equal(t, tc.expectedTopics, returnedDetails.Topics)
+		})
+	}
+}
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_patches")
+	assert.Contains(t, tool.InputSchema.Properties, "max_files")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("ahead"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(0),
+		TotalCommits:    github.Ptr(2),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base-sha")},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.Ptr("abc123"),
+				Author: &github.User{Login: github.Ptr("octocat")},
+				Commit: &github.Commit{Message: github.Ptr("Fix the build\n\nThe long explanation")},
+			},
+			{
+				SHA:    github.Ptr("def456"),
+				Commit: &github.Commit{Message: github.Ptr("Bump version"), Author: &github.CommitAuthor{Name: github.Ptr("Release Bot")}},
+			},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(3), Deletions: github.Ptr(1), Changes: github.Ptr(4), Patch: github.Ptr("@@ -1 +1 @@")},
+			{Filename: github.Ptr("new.go"), Status: github.Ptr("added"), Additions: github.Ptr(10), Changes: github.Ptr(10), Patch: github.Ptr("@@ -0,0 +1,10 @@")},
+			{Filename: github.Ptr("VERSION"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Changes: github.Ptr(2)},
+		},
+	}
+	identicalComparison := &github.CommitsComparison{
+		Status:          github.Ptr("identical"),
+		AheadBy:         github.Ptr(0),
+		BehindBy:        github.Ptr(0),
+		TotalCommits:    github.Ptr(0),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+		Commits:         []*github.RepositoryCommit{},
+		Files:           []*github.CommitFile{},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedStatus  string
+		expectedAheadBy float64
+		expectedCommits []comparedCommit
+		expectedFiles   []comparedFile
+		expectTruncated bool
+	}{
+		{
+			name: "identical refs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(
+						t,
+						"/repos/owner/repo/compare/v1.2.0...v1.2.0",
+					).andThen(
+						mockResponse(t, http.StatusOK, identicalComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.2.0",
+				"head":  "v1.2.0",
+			},
+			expectedStatus:  "identical",
+			expectedAheadBy: 0,
+			expectedCommits: []comparedCommit{},
+			expectedFiles:   []comparedFile{},
+		},
+		{
+			name: "files are capped and patches are left out",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"base":      "v1.2.0",
+				"head":      "main",
+				"max_files": float64(2),
+			},
+			expectedStatus:  "ahead",
+			expectedAheadBy: 2,
+			expectedCommits: []comparedCommit{
+				{SHA: "abc123", Author: "octocat", Message: "Fix the build"},
+				{SHA: "def456", Author: "Release Bot", Message: "Bump version"},
+			},
+			expectedFiles: []comparedFile{
+				{Filename: "main.go", Status: "modified", Additions: 3, Deletions: 1, Changes: 4},
+				{Filename: "new.go", Status: "added", Additions: 10, Changes: 10},
+			},
+			expectTruncated: true,
+		},
+		{
+			name: "patches are included on request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockComparison,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"base":            "v1.2.0",
+				"head":            "main",
+				"max_files":       float64(1),
+				"include_patches": true,
+			},
+			expectedStatus:  "ahead",
+			expectedAheadBy: 2,
+			expectedCommits: []comparedCommit{
+				{SHA: "abc123", Author: "octocat", Message: "Fix the build"},
+				{SHA: "def456", Author: "Release Bot", Message: "Bump version"},
+			},
+			expectedFiles: []comparedFile{
+				{Filename: "main.go", Status: "modified", Additions: 3, Deletions: 1, Changes: 4, Patch: "@@ -1 +1 @@"},
+			},
+			expectTruncated: true,
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v9.9.9",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare refs",
+		},
+		{
+			name:         "invalid max_files",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"base":      "v1.2.0",
+				"head":      "main",
+				"max_files": float64(-1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "max_files must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response struct {
+				Status         string           `json:"status"`
+				AheadBy        float64          `json:"ahead_by"`
+				Commits        []comparedCommit `json:"commits"`
+				Files          []comparedFile   `json:"files"`
+				FilesTruncated bool             `json:"files_truncated"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, response.Status)
+			assert.Equal(t, tc.expectedAheadBy, response.AheadBy)
+			assert.Equal(t, tc.expectedCommits, response.Commits)
+			assert.Equal(t, tc.expectedFiles, response.Files)
+			assert.Equal(t, tc.expectTruncated, response.FilesTruncated)
+		})
+	}
+}
+
+func Test_GetRepositoryTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "path_prefix")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("tree-sha"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Size: github.Ptr(100), SHA: github.Ptr("a1")},
+			{Path: github.Ptr("api"), Type: github.Ptr("tree"), SHA: github.Ptr("a2")},
+			{Path: github.Ptr("api/v1"), Type: github.Ptr("tree"), SHA: github.Ptr("a3")},
+			{Path: github.Ptr("api/v1/service.proto"), Type: github.Ptr("blob"), Size: github.Ptr(2048), SHA: github.Ptr("a4")},
+			{Path: github.Ptr("api/v1/service.pb.go"), Type: github.Ptr("blob"), Size: github.Ptr(4096), SHA: github.Ptr("a5")},
+			{Path: github.Ptr("internal/types.proto"), Type: github.Ptr("blob"), Size: github.Ptr(512), SHA: github.Ptr("a6")},
+			{Path: github.Ptr("apidocs/index.md"), Type: github.Ptr("blob"), Size: github.Ptr(64), SHA: github.Ptr("a7")},
+		},
+		Truncated: github.Ptr(false),
+	}
+	truncatedTree := &github.Tree{
+		SHA:       github.Ptr("tree-sha"),
+		Entries:   mockTree.Entries[:2],
+		Truncated: github.Ptr(true),
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedRef       string
+		expectedPaths     []string
+		expectedTruncated bool
+	}{
+		{
+			name: "default branch tree filtered by pattern",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("develop")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectPath(t, "/repos/owner/repo/git/trees/develop").andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "*.proto",
+			},
+			expectedRef:   "develop",
+			expectedPaths: []string{"api/v1/service.proto", "internal/types.proto"},
+		},
+		{
+			name: "tree of a ref filtered by prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectQueryParams(t, map[string]string{
+						"recursive": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "v1.0.0",
+				"path_prefix": "api/",
+			},
+			expectedRef:   "v1.0.0",
+			expectedPaths: []string{"api", "api/v1", "api/v1/service.proto", "api/v1/service.pb.go"},
+		},
+		{
+			name: "pattern with a slash matches the whole path",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockTree,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"ref":     "main",
+				"pattern": "api/*/*.go",
+			},
+			expectedRef:   "main",
+			expectedPaths: []string{"api/v1/service.pb.go"},
+		},
+		{
+			name: "truncated tree",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					truncatedTree,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedRef:       "main",
+			expectedPaths:     []string{"README.md", "api"},
+			expectedTruncated: true,
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository tree",
+		},
+		{
+			name:         "invalid pattern",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "[",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid pattern",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response struct {
+				Ref       string      `json:"ref"`
+				Truncated bool        `json:"truncated"`
+				Entries   []treeEntry `json:"entries"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, response.Ref)
+			assert.Equal(t, tc.expectedTruncated, response.Truncated)
+			paths := make([]string, 0, len(response.Entries))
+			for _, entry := range response.Entries {
+				paths = append(paths, entry.Path)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func Test_ListContributors(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListContributors(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_contributors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_anonymous")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockContributors := []*github.Contributor{
+		{Login: github.Ptr("octocat"), Contributions: github.Ptr(120), Type: github.Ptr("User")},
+		{Login: github.Ptr("dependabot[bot]"), Contributions: github.Ptr(40), Type: github.Ptr("Bot")},
+		{Name: github.Ptr("Jane Doe"), Contributions: github.Ptr(3), Type: github.Ptr("Anonymous")},
+	}
+
+	tests := []struct {
+		name                 string
+		mockedClient         *http.Client
+		requestArgs          map[string]interface{}
+		expectError          bool
+		expectToolError      bool
+		expectedErrMsg       string
+		expectedPending      bool
+		expectedContributors []contributor
+	}{
+		{
+			name: "contributors with an account",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockContributors[:2]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedContributors: []contributor{
+				{Login: "octocat", Contributions: 120, Type: "User"},
+				{Login: "dependabot[bot]", Contributions: 40, Type: "Bot"},
+			},
+		},
+		{
+			name: "anonymous contributors included",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"anon":     "true",
+						"page":     "2",
+						"per_page": "3",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockContributors),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"include_anonymous": true,
+				"page":              float64(2),
+				"perPage":           float64(3),
+			},
+			expectedContributors: []contributor{
+				{Login: "octocat", Contributions: 120, Type: "User"},
+				{Login: "dependabot[bot]", Contributions: 40, Type: "Bot"},
+				{Name: "Jane Doe", Contributions: 3, Type: "Anonymous"},
+			},
+		},
+		{
+			name: "contributors still being computed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusAccepted)
+						_, _ = w.Write([]byte(`{}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedPending: true,
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContributorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list contributors",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListContributors(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response struct {
+				Pending      bool          `json:"pending"`
+				Message      string        `json:"message"`
+				Contributors []contributor `json:"contributors"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPending, response.Pending)
+			if tc.expectedPending {
+				assert.Contains(t, response.Message, "retry in a few seconds")
+				return
+			}
+			assert.Equal(t, tc.expectedContributors, response.Contributors)
+		})
+	}
+}
+
+func Test_GetRepositoryLicense(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GetRepositoryLicense(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_license", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "include_content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockLicense := &github.RepositoryLicense{
+		Name:    github.Ptr("LICENSE"),
+		Path:    github.Ptr("LICENSE"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/LICENSE"),
+		License: &github.License{
+			Key:    github.Ptr("mit"),
+			Name:   github.Ptr("MIT License"),
+			SPDXID: github.Ptr("MIT"),
+		},
+	}
+	mockLicenseText := "MIT License\n\nCopyright (c) 2025 owner\n"
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResult  map[string]interface{}
+	}{
+		{
+			name: "MIT license detected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLicenseByOwnerByRepo,
+					mockLicense,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedResult: map[string]interface{}{
+				"found":    true,
+				"spdx_id":  "MIT",
+				"key":      "mit",
+				"name":     "MIT License",
+				"path":     "LICENSE",
+				"html_url": "https://github.com/owner/repo/blob/main/LICENSE",
+			},
+		},
+		{
+			name: "MIT license with its content",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposLicenseByOwnerByRepo,
+					mockLicense,
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/owner/repo/HEAD/LICENSE").andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Content-Type", "text/plain")
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte(mockLicenseText))
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"include_content": true,
+			},
+			expectedResult: map[string]interface{}{
+				"found":    true,
+				"spdx_id":  "MIT",
+				"key":      "mit",
+				"name":     "MIT License",
+				"path":     "LICENSE",
+				"html_url": "https://github.com/owner/repo/blob/main/LICENSE",
+				"content":  mockLicenseText,
+			},
+		},
+		{
+			name: "no license detected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"include_content": true,
+			},
+			expectedResult: map[string]interface{}{
+				"found":   false,
+				"message": "no license detected in owner/repo",
+			},
+		},
+		{
+			name: "license lookup fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLicenseByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository license",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetRepositoryLicense(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_TransferRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := TransferRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "transfer_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.Contains(t, tool.InputSchema.Properties, "team_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "new_owner"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectToolError  bool
+		expectedErrMsg   string
+		expectedFullName string
+	}{
+		{
+			name: "transfer accepted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"new_owner": "new-org",
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.Repository{
+							Name:     github.Ptr("repo"),
+							FullName: github.Ptr("owner/repo"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+			},
+			expectedFullName: "new-org/repo",
+		},
+		{
+			name: "transfer with a new name and teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"new_owner": "new-org",
+						"new_name":  "renamed",
+						"team_ids":  []interface{}{float64(12), float64(34)},
+					}).andThen(
+						mockResponse(t, http.StatusAccepted, &github.Repository{
+							Name:     github.Ptr("repo"),
+							FullName: github.Ptr("owner/repo"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+				"new_name":  "renamed",
+				"team_ids":  []interface{}{float64(12), float64(34)},
+			},
+			expectedFullName: "new-org/renamed",
+		},
+		{
+			name: "new owner already has the repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository has already been taken"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot transfer owner/repo to new-org, the new owner may already have a repository named repo",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposTransferByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to transfer repository",
+		},
+		{
+			name:         "missing required parameter new_owner",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: new_owner",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := TransferRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFullName, response["full_name"])
+			assert.Equal(t, float64(http.StatusAccepted), response["status_code"])
+			assert.Contains(t, response["message"], "is being transferred")
+		})
+	}
+}
+
+func Test_ArchiveUnarchiveRepository(t *testing.T) {
+	tools := []struct {
+		name    string
+		archive bool
+		create  func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		action  string
+		state   string
+	}{
+		{
+			name:    "archive_repository",
+			archive: true,
+			create:  ArchiveRepository,
+			action:  "archive",
+			state:   "archived",
+		},
+		{
+			name:    "unarchive_repository",
+			archive: false,
+			create:  UnarchiveRepository,
+			action:  "unarchive",
+			state:   "unarchived",
+		},
+	}
+
+	for _, tt := range tools {
+		t.Run(tt.name, func(t *testing.T) {
+			// Verify tool definition once
+			tool, _ := tt.create(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+			require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+			assert.Equal(t, tt.name, tool.Name)
+			assert.NotEmpty(t, tool.Description)
+			assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+			assert.False(t, *tool.Annotations.ReadOnlyHint)
+			assert.True(t, *tool.Annotations.DestructiveHint)
+
+			currentRepo := func(archived bool) mock.MockBackendOption {
+				return mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(archived)},
+				)
+			}
+
+			tests := []struct {
+				name            string
+				mockedClient    *http.Client
+				expectError     bool
+				expectToolError bool
+				expectedErrMsg  string
+				expectedChanged bool
+				expectedMessage string
+			}{
+				{
+					name: "change the archived state",
+					mockedClient: mock.NewMockedHTTPClient(
+						currentRepo(!tt.archive),
+						mock.WithRequestMatchHandler(
+							mock.PatchReposByOwnerByRepo,
+							expectRequestBody(t, map[string]interface{}{
+								"archived": tt.archive,
+							}).andThen(
+								mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo"), Archived: github.Ptr(tt.archive)}),
+							),
+						),
+					),
+					expectedChanged: true,
+					expectedMessage: "Repository owner/repo has been " + tt.state,
+				},
+				{
+					name:            "already in the requested state",
+					mockedClient:    mock.NewMockedHTTPClient(currentRepo(tt.archive)),
+					expectedMessage: "Repository owner/repo is already " + tt.state,
+				},
+				{
+					name: "not allowed",
+					mockedClient: mock.NewMockedHTTPClient(
+						currentRepo(!tt.archive),
+						mock.WithRequestMatchHandler(
+							mock.PatchReposByOwnerByRepo,
+							http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+								w.WriteHeader(http.StatusForbidden)
+								_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+							}),
+						),
+					),
+					expectToolError: true,
+					expectedErrMsg:  "not allowed to " + tt.action + " owner/repo, it requires admin access to the repository",
+				},
+				{
+					name: "repository not found",
+					mockedClient: mock.NewMockedHTTPClient(
+						mock.WithRequestMatchHandler(
+							mock.GetReposByOwnerByRepo,
+							http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+								w.WriteHeader(http.StatusNotFound)
+								_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+							}),
+						),
+					),
+					expectError:    true,
+					expectedErrMsg: "failed to get repository",
+				},
+			}
+
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					_, handler := tt.create(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+					result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+						"owner": "owner",
+						"repo":  "repo",
+					}))
+					if tc.expectError {
+						require.Error(t, err)
+						assert.Contains(t, err.Error(), tc.expectedErrMsg)
+						return
+					}
+					require.NoError(t, err)
+
+					if tc.expectToolError {
+						require.True(t, result.IsError)
+						assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+						return
+					}
+
+					require.False(t, result.IsError)
+					var response map[string]interface{}
+					require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+					assert.Equal(t, tc.expectedMessage, response["message"])
+					assert.Equal(t, tc.expectedChanged, response["changed"])
+					assert.Equal(t, tt.archive, response["archived"])
+					assert.Equal(t, "owner/repo", response["full_name"])
+				})
+			}
+
+			t.Run("missing required parameter repo", func(t *testing.T) {
+				_, handler := tt.create(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), translations.NullTranslationHelper)
+				result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"owner": "owner"}))
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				assert.Equal(t, "missing required parameter: repo", getErrorResult(t, result).Text)
+			})
+		})
+	}
+}
+
+func Test_CreateRepositoryFromTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryFromTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository_from_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "include_all_branches")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"template_owner", "template_repo", "name"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	mockRepo := &github.Repository{
+		Name:          github.Ptr("payments-service"),
+		FullName:      github.Ptr("platform/payments-service"),
+		HTMLURL:       github.Ptr("https://github.com/platform/payments-service"),
+		DefaultBranch: github.Ptr("main"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "repository created in an organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectPath(t, "/repos/platform/service-template/generate").andThen(
+						expectRequestBody(t, map[string]interface{}{
+							"name":                 "payments-service",
+							"owner":                "platform",
+							"description":          "Payments service",
+							"include_all_branches": true,
+							"private":              true,
+						}).andThen(
+							mockResponse(t, http.StatusCreated, mockRepo),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner":       "platform",
+				"template_repo":        "service-template",
+				"owner":                "platform",
+				"name":                 "payments-service",
+				"description":          "Payments service",
+				"include_all_branches": true,
+				"private":              true,
+			},
+		},
+		{
+			name: "repository created for the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":                 "payments-service",
+						"include_all_branches": false,
+						"private":              false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"name":           "payments-service",
+			},
+		},
+		{
+			name: "repository name already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Name already exists on this account"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"name":           "payments-service",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot create repository payments-service from template platform/service-template, a repository with that name may already exist",
+		},
+		{
+			name: "template not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGenerateByTemplateOwnerByTemplateRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "missing-template",
+				"name":           "payments-service",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository from template",
+		},
+		{
+			name:         "name with spaces",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+				"name":           "payments service",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `invalid repository name "payments service", it cannot contain spaces`,
+		},
+		{
+			name:         "missing required parameter name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"template_owner": "platform",
+				"template_repo":  "service-template",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: name",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryFromTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{
+				"full_name":      "platform/payments-service",
+				"html_url":       "https://github.com/platform/payments-service",
+				"default_branch": "main",
+			}, response)
+		})
+	}
+}
+
+func Test_MergeBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := MergeBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "merge_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResult  map[string]interface{}
+	}{
+		{
+			name: "merge commit created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base":           "feature",
+						"head":           "main",
+						"commit_message": "Merge main into feature",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepositoryCommit{
+							SHA:     github.Ptr("abc123"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"base":           "feature",
+				"head":           "main",
+				"commit_message": "Merge main into feature",
+			},
+			expectedResult: map[string]interface{}{
+				"merged":   true,
+				"message":  "main has been merged into feature",
+				"sha":      "abc123",
+				"html_url": "https://github.com/owner/repo/commit/abc123",
+			},
+		},
+		{
+			name: "already up to date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base": "feature",
+						"head": "main",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "feature",
+				"head":  "main",
+			},
+			expectedResult: map[string]interface{}{
+				"merged":  false,
+				"message": "feature is already up to date with main",
+			},
+		},
+		{
+			name: "merge conflict",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Merge Conflict"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "feature",
+				"head":  "main",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "merging main into feature has conflicts, open a pull request from main to feature to resolve them",
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Base does not exist"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "missing",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to merge branch",
+		},
+		{
+			name:         "missing required parameter head",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "feature",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: head",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := MergeBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
+
+func Test_SyncFork(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SyncFork(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "sync_fork", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResult  map[string]interface{}
+	}{
+		{
+			name: "fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"branch": "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepoMergeUpstreamResult{
+							Message:    github.Ptr("Successfully fetched and fast-forwarded from upstream upstream:main."),
+							MergeType:  github.Ptr("fast-forward"),
+							BaseBranch: github.Ptr("upstream:main"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "contributor",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedResult: map[string]interface{}{
+				"branch":      "main",
+				"merge_type":  "fast-forward",
+				"base_branch": "upstream:main",
+				"message":     "Successfully fetched and fast-forwarded from upstream upstream:main.",
+			},
+		},
+		{
+			name: "already up to date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					&github.RepoMergeUpstreamResult{
+						Message:    github.Ptr("This branch is not behind the upstream upstream:main."),
+						MergeType:  github.Ptr("none"),
+						BaseBranch: github.Ptr("upstream:main"),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "contributor",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectedResult: map[string]interface{}{
+				"branch":      "main",
+				"merge_type":  "none",
+				"base_branch": "upstream:main",
+				"message":     "This branch is not behind the upstream upstream:main.",
+			},
+		},
+		{
+			name: "conflicts with upstream",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "There are merge conflicts"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "contributor",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "branch main of contributor/repo has conflicts with its upstream",
+		},
+		{
+			name: "not a fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMergeUpstreamByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "This repository is not a fork"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to sync fork",
+		},
+		{
+			name:         "missing required parameter branch",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "contributor",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SyncFork(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var response map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, response)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var (
+	repositoryActivityTypes       = []string{"push", "force_push", "branch_creation", "branch_deletion", "pr_merge", "merge_queue_merge"}
+	repositoryActivityTimePeriods = []string{"day", "week", "month", "quarter", "year"}
+)
+
+// repositoryActivityResponse is an activity as returned by the API, which go-github has no type for
+type repositoryActivityResponse struct {
+	ID           int64     `json:"id"`
+	Before       string    `json:"before"`
+	After        string    `json:"after"`
+	Ref          string    `json:"ref"`
+	Timestamp    time.Time `json:"timestamp"`
+	ActivityType string    `json:"activity_type"`
+	Actor        *struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+}
+
+// repositoryActivity is a change to a ref of a repository, such as a push or a merged pull request
+type repositoryActivity struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Actor        string    `json:"actor,omitempty"`
+	ActivityType string    `json:"activity_type"`
+	Ref          string    `json:"ref"`
+	Before       string    `json:"before"`
+	After        string    `json:"after"`
+}
+
+// GetRepositoryActivity creates a tool to list the changes to the refs of a repository
+func GetRepositoryActivity(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_activity",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_ACTIVITY_DESCRIPTION", "List the activity of a GitHub repository, newest first: pushes, force pushes, branch creations and deletions, and pull request merges, with who made them and the SHAs of the ref before and after. Useful to find out what happened in a repository over a period of time")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_ACTIVITY_USER_TITLE", "Get repository activity"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("activity_type",
+				mcp.Description("Only list activity of this type"),
+				mcp.Enum(repositoryActivityTypes...),
+			),
+			mcp.WithString("actor",
+				mcp.Description("Only list activity of this user"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Only list activity of this ref, a branch name or a full ref such as refs/tags/v1.0.0"),
+			),
+			mcp.WithString("time_period",
+				mcp.Description("Only list activity of the last day, week, month, quarter or year"),
+				mcp.Enum(repositoryActivityTimePeriods...),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor to list the next page of activity, as returned in next_cursor"),
+			),
+			mcp.WithString("before",
+				mcp.Description("Cursor to list the previous page of activity, as returned in previous_cursor"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page for pagination (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			activityType, err := OptionalParam[string](request, "activity_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if activityType != "" && !slices.Contains(repositoryActivityTypes, activityType) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid activity_type: %s, must be one of %s", activityType, strings.Join(repositoryActivityTypes, ", "))), nil
+			}
+			actor, err := OptionalParam[string](request, "actor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			timePeriod, err := OptionalParam[string](request, "time_period")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if timePeriod != "" && !slices.Contains(repositoryActivityTimePeriods, timePeriod) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid time_period: %s, must be one of %s", timePeriod, strings.Join(repositoryActivityTimePeriods, ", "))), nil
+			}
+			after, err := OptionalParam[string](request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			before, err := OptionalParam[string](request, "before")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if after != "" && before != "" {
+				return mcp.NewToolResultError("only one of after and before can be given"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			query := url.Values{}
+			for param, value := range map[string]string{
+				"activity_type": activityType,
+				"actor":         actor,
+				"ref":           ref,
+				"time_period":   timePeriod,
+				"after":         after,
+				"before":        before,
+			} {
+				if value != "" {
+					query.Set(param, value)
+				}
+			}
+			query.Set("per_page", strconv.Itoa(pagination.perPage))
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github has no method for this endpoint yet
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/activity?%s", owner, repo, query.Encode()), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var activities []repositoryActivityResponse
+			resp, err := client.Do(ctx, req, &activities)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository activity: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]repositoryActivity, 0, len(activities))
+			for _, activity := range activities {
+				a := repositoryActivity{
+					Timestamp:    activity.Timestamp,
+					ActivityType: activity.ActivityType,
+					Ref:          activity.Ref,
+					Before:       activity.Before,
+					After:        activity.After,
+				}
+				if activity.Actor != nil {
+					a.Actor = activity.Actor.Login
+				}
+				result = append(result, a)
+			}
+
+			response := map[string]any{
+				"activities": result,
+			}
+			// The cursors come from the Link header, and are only there when there is such a page
+			if resp.After != "" {
+				response["next_cursor"] = resp.After
+			}
+			if resp.Before != "" {
+				response["previous_cursor"] = resp.Before
+			}
+
+			r, err := json.Marshal(response)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
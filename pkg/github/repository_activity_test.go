@@ -0,0 +1,205 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryActivity(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryActivity(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_activity", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "activity_type")
+	assert.Contains(t, tool.InputSchema.Properties, "actor")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "time_period")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "before")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	timestamp := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	forcePush := map[string]any{
+		"id":            1,
+		"before":        "abc123",
+		"after":         "def456",
+		"ref":           "refs/heads/main",
+		"timestamp":     timestamp,
+		"activity_type": "force_push",
+		"actor":         map[string]any{"login": "octocat", "id": 1},
+	}
+
+	// activityWithLinks responds with a page of activity, and a Link header with the cursors of the pages around it
+	activityWithLinks := func(links string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if links != "" {
+				w.Header().Set("Link", links)
+			}
+			mockResponse(t, http.StatusOK, []map[string]any{forcePush})(w, r)
+		}
+	}
+
+	tests := []struct {
+		name                   string
+		mockedClient           *http.Client
+		requestArgs            map[string]any
+		expectError            bool
+		expectToolError        bool
+		expectedErrMsg         string
+		expectedActivities     []repositoryActivity
+		expectedNextCursor     string
+		expectedPreviousCursor string
+	}{
+		{
+			name: "filtered activity of the last day",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActivityByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"activity_type": "force_push",
+						"actor":         "octocat",
+						"ref":           "main",
+						"time_period":   "day",
+						"per_page":      "10",
+					}).andThen(
+						activityWithLinks(`<https://api.github.com/repositories/1/activity?per_page=10&after=Y3Vyc29yOjI%3D>; rel="next"`),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"activity_type": "force_push",
+				"actor":         "octocat",
+				"ref":           "main",
+				"time_period":   "day",
+				"perPage":       float64(10),
+			},
+			expectedActivities: []repositoryActivity{{
+				Timestamp:    timestamp,
+				Actor:        "octocat",
+				ActivityType: "force_push",
+				Ref:          "refs/heads/main",
+				Before:       "abc123",
+				After:        "def456",
+			}},
+			expectedNextCursor: "Y3Vyc29yOjI=",
+		},
+		{
+			name: "cursor is passed through",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActivityByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"after":    "Y3Vyc29yOjI=",
+						"per_page": "30",
+					}).andThen(
+						activityWithLinks(`<https://api.github.com/repositories/1/activity?per_page=30&before=Y3Vyc29yOjM%3D>; rel="prev"`),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"after": "Y3Vyc29yOjI=",
+			},
+			expectedActivities: []repositoryActivity{{
+				Timestamp:    timestamp,
+				Actor:        "octocat",
+				ActivityType: "force_push",
+				Ref:          "refs/heads/main",
+				Before:       "abc123",
+				After:        "def456",
+			}},
+			expectedPreviousCursor: "Y3Vyc29yOjM=",
+		},
+		{
+			name: "no activity",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActivityByOwnerByRepo,
+					[]map[string]any{},
+				),
+			),
+			expectedActivities: []repositoryActivity{},
+		},
+		{
+			name:         "invalid time period",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"time_period": "hour",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid time_period: hour, must be one of day, week, month, quarter, year",
+		},
+		{
+			name:         "both cursors",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"after":  "a",
+				"before": "b",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "only one of after and before can be given",
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActivityByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository activity",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryActivity(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Activities     []repositoryActivity `json:"activities"`
+				NextCursor     string               `json:"next_cursor"`
+				PreviousCursor string               `json:"previous_cursor"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedActivities, response.Activities)
+			assert.Equal(t, tc.expectedNextCursor, response.NextCursor)
+			assert.Equal(t, tc.expectedPreviousCursor, response.PreviousCursor)
+		})
+	}
+}
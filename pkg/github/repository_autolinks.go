@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// autolinkPlaceholder is replaced by the reference number in the URL template of an autolink
+const autolinkPlaceholder = "<num>"
+
+// ListAutolinks creates a tool to list the autolink references of a repository
+func ListAutolinks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_autolinks",
+			mcp.WithDescription(t("TOOL_LIST_AUTOLINKS_DESCRIPTION", "List the autolink references of a GitHub repository, which turn references like ABC-123 into links to external systems. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_AUTOLINKS_USER_TITLE", "List autolinks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolinks, resp, err := client.Repositories.ListAutolinks(ctx, owner, repo, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list autolinks: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if autolinks == nil {
+				autolinks = []*github.Autolink{}
+			}
+			r, err := json.Marshal(autolinks)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateAutolink creates a tool to add an autolink reference to a repository
+func CreateAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_autolink",
+			mcp.WithDescription(t("TOOL_CREATE_AUTOLINK_DESCRIPTION", "Add an autolink reference to a GitHub repository, so that references made of the key prefix and a number, like ABC-123, link to an external system. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AUTOLINK_USER_TITLE", "Create autolink"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("key_prefix",
+				mcp.Required(),
+				mcp.Description("The prefix of the references to link, like ABC- for ABC-123"),
+			),
+			mcp.WithString("url_template",
+				mcp.Required(),
+				mcp.Description("The URL the references link to, with <num> where the reference number goes, like https://jira.example.com/browse/ABC-<num>"),
+			),
+			mcp.WithBoolean("is_alphanumeric",
+				mcp.Description("Whether the reference after the prefix may contain letters as well as numbers (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keyPrefix, err := RequiredParam[string](request, "key_prefix")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			urlTemplate, err := RequiredParam[string](request, "url_template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !strings.Contains(urlTemplate, autolinkPlaceholder) {
+				return mcp.NewToolResultError(fmt.Sprintf("url_template must contain %s where the reference number goes, like https://jira.example.com/browse/%s%s", autolinkPlaceholder, keyPrefix, autolinkPlaceholder)), nil
+			}
+			isAlphanumeric, hasIsAlphanumeric, err := OptionalParamOK[bool](request, "is_alphanumeric")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.AutolinkOptions{
+				KeyPrefix:   github.Ptr(keyPrefix),
+				URLTemplate: github.Ptr(urlTemplate),
+			}
+			if hasIsAlphanumeric {
+				opts.IsAlphanumeric = github.Ptr(isAlphanumeric)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			autolink, resp, err := client.Repositories.AddAutolink(ctx, owner, repo, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create autolink %s in %s/%s, an autolink with the same prefix, or a prefix starting with it, may already exist: %s", keyPrefix, owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create autolink: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(autolink)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteAutolink creates a tool to delete an autolink reference of a repository
+func DeleteAutolink(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_autolink",
+			mcp.WithDescription(t("TOOL_DELETE_AUTOLINK_DESCRIPTION", "Delete an autolink reference of a GitHub repository. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_AUTOLINK_USER_TITLE", "Delete autolink"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("autolink_id",
+				mcp.Required(),
+				mcp.Description("The ID of the autolink, as returned by list_autolinks"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			autolinkID, err := RequiredInt(request, "autolink_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteAutolink(ctx, owner, repo, int64(autolinkID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete autolink: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":     "Autolink has been deleted",
+				"autolink_id": autolinkID,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,325 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAutolinks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListAutolinks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_autolinks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	jira := &github.Autolink{
+		ID:             github.Ptr(int64(1)),
+		KeyPrefix:      github.Ptr("ABC-"),
+		URLTemplate:    github.Ptr("https://jira.example.com/browse/ABC-<num>"),
+		IsAlphanumeric: github.Ptr(false),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       []*github.Autolink
+	}{
+		{
+			name: "repository with autolinks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposAutolinksByOwnerByRepo,
+					[]*github.Autolink{jira},
+				),
+			),
+			expected: []*github.Autolink{jira},
+		},
+		{
+			name: "repository without autolinks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposAutolinksByOwnerByRepo,
+					[]*github.Autolink{},
+				),
+			),
+			expected: []*github.Autolink{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutolinksByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list autolinks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListAutolinks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var autolinks []*github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &autolinks))
+			assert.Equal(t, tc.expected, autolinks)
+		})
+	}
+}
+
+func Test_CreateAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "is_alphanumeric")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "key_prefix", "url_template"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        *github.Autolink
+	}{
+		{
+			name: "numeric Jira references",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"key_prefix":      "ABC-",
+						"url_template":    "https://jira.example.com/browse/ABC-<num>",
+						"is_alphanumeric": false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Autolink{
+							ID:             github.Ptr(int64(2)),
+							KeyPrefix:      github.Ptr("ABC-"),
+							URLTemplate:    github.Ptr("https://jira.example.com/browse/ABC-<num>"),
+							IsAlphanumeric: github.Ptr(false),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"key_prefix":      "ABC-",
+				"url_template":    "https://jira.example.com/browse/ABC-<num>",
+				"is_alphanumeric": false,
+			},
+			expected: &github.Autolink{
+				ID:             github.Ptr(int64(2)),
+				KeyPrefix:      github.Ptr("ABC-"),
+				URLTemplate:    github.Ptr("https://jira.example.com/browse/ABC-<num>"),
+				IsAlphanumeric: github.Ptr(false),
+			},
+		},
+		{
+			name: "alphanumeric by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"key_prefix":   "TICKET-",
+						"url_template": "https://support.example.com/tickets/<num>",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Autolink{
+							ID:             github.Ptr(int64(3)),
+							KeyPrefix:      github.Ptr("TICKET-"),
+							URLTemplate:    github.Ptr("https://support.example.com/tickets/<num>"),
+							IsAlphanumeric: github.Ptr(true),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"key_prefix":   "TICKET-",
+				"url_template": "https://support.example.com/tickets/<num>",
+			},
+			expected: &github.Autolink{
+				ID:             github.Ptr(int64(3)),
+				KeyPrefix:      github.Ptr("TICKET-"),
+				URLTemplate:    github.Ptr("https://support.example.com/tickets/<num>"),
+				IsAlphanumeric: github.Ptr(true),
+			},
+		},
+		{
+			name:         "url template without the placeholder",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"key_prefix":   "ABC-",
+				"url_template": "https://jira.example.com/browse/ABC-",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "url_template must contain <num> where the reference number goes, like https://jira.example.com/browse/ABC-<num>",
+		},
+		{
+			name: "duplicate key prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]any{
+						"message": "Validation Failed",
+						"errors": []map[string]string{
+							{"resource": "KeyLink", "code": "already_exists", "field": "key_prefix"},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"key_prefix":   "ABC-",
+				"url_template": "https://jira.example.com/browse/ABC-<num>",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot create autolink ABC- in owner/repo, an autolink with the same prefix, or a prefix starting with it, may already exist",
+		},
+		{
+			name: "creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposAutolinksByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			requestArgs: map[string]any{
+				"key_prefix":   "ABC-",
+				"url_template": "https://jira.example.com/browse/ABC-<num>",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create autolink",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var autolink github.Autolink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &autolink))
+			assert.Equal(t, tc.expected, &autolink)
+		})
+	}
+}
+
+func Test_DeleteAutolink(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteAutolink(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_autolink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "autolink_id"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "delete an autolink",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+					expectPath(t, "/repos/owner/repo/autolinks/2").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "autolink not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposAutolinksByOwnerByRepoByAutolinkId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete autolink",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteAutolink(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"autolink_id": float64(2),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(2), response["autolink_id"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// codeownersError is a syntax error in a CODEOWNERS file
+type codeownersError struct {
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	Kind       string `json:"kind"`
+	Message    string `json:"message,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Path       string `json:"path"`
+}
+
+// GetCodeownersErrors creates a tool to get the syntax errors of the CODEOWNERS file of a repository
+func GetCodeownersErrors(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_codeowners_errors",
+			mcp.WithDescription(t("TOOL_GET_CODEOWNERS_ERRORS_DESCRIPTION", "Get the syntax errors of the CODEOWNERS file of a GitHub repository, which can keep code owners from being requested to review pull requests")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODEOWNERS_ERRORS_USER_TITLE", "Get CODEOWNERS errors"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to check the CODEOWNERS file of, defaults to the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := map[string]any{}
+			if ref != "" {
+				result["ref"] = ref
+			}
+
+			codeownersErrors, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: ref})
+			if err != nil {
+				// The API answers 404 when there is no CODEOWNERS file at the ref
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					result["has_codeowners"] = false
+					result["message"] = "no CODEOWNERS file was found in the root, .github/ or docs/ directory"
+					r, err := json.Marshal(result)
+					if err != nil {
+						return nil, fmt.Errorf("failed to marshal response: %w", err)
+					}
+					return mcp.NewToolResultText(string(r)), nil
+				}
+				return nil, fmt.Errorf("failed to get CODEOWNERS errors: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			errs := make([]codeownersError, 0, len(codeownersErrors.Errors))
+			for _, e := range codeownersErrors.Errors {
+				errs = append(errs, codeownersError{
+					Line:       e.Line,
+					Column:     e.Column,
+					Kind:       e.Kind,
+					Message:    e.Message,
+					Suggestion: e.GetSuggestion(),
+					Path:       e.Path,
+				})
+			}
+
+			result["has_codeowners"] = true
+			result["errors"] = errs
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
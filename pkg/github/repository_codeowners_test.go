@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCodeownersErrors(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeownersErrors(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_codeowners_errors", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectError       bool
+		expectedErrMsg    string
+		expectedHasFile   bool
+		expectedRef       string
+		expectedErrors    []codeownersError
+		expectedMessageIn string
+	}{
+		{
+			name: "errors at a ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeownersErrorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"ref": "feature",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.CodeownersErrors{
+							Errors: []*github.CodeownersError{
+								{
+									Line:       3,
+									Column:     1,
+									Kind:       "Invalid pattern",
+									Source:     "***/*.rb @monalisa",
+									Suggestion: github.Ptr("Did you mean `**/*.rb`?"),
+									Message:    "Invalid pattern on line 3: Did you mean `**/*.rb`?",
+									Path:       ".github/CODEOWNERS",
+								},
+								{
+									Line:    7,
+									Column:  7,
+									Kind:    "Unknown owner",
+									Source:  "*.go @ghost",
+									Message: "Unknown owner on line 7: make sure @ghost exists and has write access to the repository",
+									Path:    ".github/CODEOWNERS",
+								},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"ref": "feature",
+			},
+			expectedHasFile: true,
+			expectedRef:     "feature",
+			expectedErrors: []codeownersError{
+				{
+					Line:       3,
+					Column:     1,
+					Kind:       "Invalid pattern",
+					Message:    "Invalid pattern on line 3: Did you mean `**/*.rb`?",
+					Suggestion: "Did you mean `**/*.rb`?",
+					Path:       ".github/CODEOWNERS",
+				},
+				{
+					Line:    7,
+					Column:  7,
+					Kind:    "Unknown owner",
+					Message: "Unknown owner on line 7: make sure @ghost exists and has write access to the repository",
+					Path:    ".github/CODEOWNERS",
+				},
+			},
+		},
+		{
+			name: "valid CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeownersErrorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{}).andThen(
+						mockResponse(t, http.StatusOK, &github.CodeownersErrors{Errors: []*github.CodeownersError{}}),
+					),
+				),
+			),
+			expectedHasFile: true,
+			expectedErrors:  []codeownersError{},
+		},
+		{
+			name: "no CODEOWNERS file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeownersErrorsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectedHasFile:   false,
+			expectedMessageIn: "no CODEOWNERS file",
+		},
+		{
+			name: "getting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeownersErrorsByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Server Error"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get CODEOWNERS errors",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeownersErrors(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				HasCodeowners bool              `json:"has_codeowners"`
+				Ref           string            `json:"ref"`
+				Message       string            `json:"message"`
+				Errors        []codeownersError `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedHasFile, response.HasCodeowners)
+			assert.Equal(t, tc.expectedRef, response.Ref)
+			assert.Equal(t, tc.expectedErrors, response.Errors)
+			assert.Contains(t, response.Message, tc.expectedMessageIn)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// commitCommentResponse is a commit comment as returned by the API. go-github's RepositoryComment has no line, the
+// line of the file the comment is on, so the requests of ListCommitComments and CreateComment are made here.
+type commitCommentResponse struct {
+	github.RepositoryComment
+	Line *int `json:"line,omitempty"`
+}
+
+// commitComment is a comment on a commit, or on a line of a file changed in it
+type commitComment struct {
+	ID        int64      `json:"id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	Path      string     `json:"path,omitempty"`
+	Line      int        `json:"line,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+func newCommitComment(comment *commitCommentResponse) commitComment {
+	result := commitComment{
+		ID:     comment.GetID(),
+		Author: comment.GetUser().GetLogin(),
+		Body:   comment.GetBody(),
+		Path:   comment.GetPath(),
+	}
+	if comment.Line != nil {
+		result.Line = *comment.Line
+	}
+	if comment.CreatedAt != nil {
+		result.CreatedAt = &comment.CreatedAt.Time
+	}
+	return result
+}
+
+// ListCommitComments creates a tool to list the comments on a commit
+func ListCommitComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_comments",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_COMMENTS_DESCRIPTION", "List the comments made directly on a commit of a GitHub repository, as opposed to pull request review comments")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COMMIT_COMMENTS_USER_TITLE", "List commit comments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/commits/%s/comments?page=%d&per_page=%d", owner, repo, sha, pagination.page, pagination.perPage), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var comments []*commitCommentResponse
+			resp, err := client.Do(ctx, req, &comments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commit comments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]commitComment, 0, len(comments))
+			for _, comment := range comments {
+				result = append(result, newCommitComment(comment))
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateCommitComment creates a tool to comment on a commit
+func CreateCommitComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_comment",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_COMMENT_DESCRIPTION", "Comment on a commit of a GitHub repository, or on a line of a file changed in it when path and position are given")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_COMMENT_USER_TITLE", "Create commit comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Comment content"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path of the file to comment on, requires position"),
+			),
+			mcp.WithNumber("position",
+				mcp.Description("Line index in the diff of the file to comment on, requires path"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			position, err := OptionalIntParam(request, "position")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (path == "") != (position == 0) {
+				return mcp.NewToolResultError("path and position must be given together to comment on a line of a file"), nil
+			}
+
+			comment := &github.RepositoryComment{Body: github.Ptr(body)}
+			if path != "" {
+				comment.Path = github.Ptr(path)
+				comment.Position = github.Ptr(position)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/commits/%s/comments", owner, repo, sha), comment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			created := &commitCommentResponse{}
+			resp, err := client.Do(ctx, req, created)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot comment on commit %s, the path may not be changed in it or the position may be outside its diff: %s", sha, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create commit comment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newCommitComment(created))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
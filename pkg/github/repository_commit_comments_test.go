@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCommitComments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_commit_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	createdAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedComments []commitComment
+	}{
+		{
+			name: "comments on the commit and on a line",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectPath(t, "/repos/owner/repo/commits/abc123/comments").andThen(
+						mockResponse(t, http.StatusOK, []map[string]any{
+							{
+								"id":         1,
+								"body":       "Why was this reverted?",
+								"user":       map[string]any{"login": "octocat"},
+								"created_at": createdAt,
+								"commit_id":  "abc123",
+							},
+							{
+								"id":         2,
+								"body":       "This leaks the token",
+								"user":       map[string]any{"login": "hubot"},
+								"path":       "pkg/client.go",
+								"position":   4,
+								"line":       42,
+								"created_at": createdAt,
+							},
+						}),
+					),
+				),
+			),
+			expectedComments: []commitComment{
+				{ID: 1, Author: "octocat", Body: "Why was this reverted?", CreatedAt: &createdAt},
+				{ID: 2, Author: "hubot", Body: "This leaks the token", Path: "pkg/client.go", Line: 42, CreatedAt: &createdAt},
+			},
+		},
+		{
+			name: "commit without comments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					[]map[string]any{},
+				),
+			),
+			expectedComments: []commitComment{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list commit comments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitComments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var comments []commitComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comments))
+			assert.Equal(t, tc.expectedComments, comments)
+		})
+	}
+}
+
+func Test_CreateCommitComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "position")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "body"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedComment commitComment
+	}{
+		{
+			name: "comment on the commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectRequestBody(t, map[string]any{
+						"body": "Why was this reverted?",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]any{
+							"id":   1,
+							"body": "Why was this reverted?",
+							"user": map[string]any{"login": "octocat"},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"body": "Why was this reverted?",
+			},
+			expectedComment: commitComment{ID: 1, Author: "octocat", Body: "Why was this reverted?"},
+		},
+		{
+			name: "comment on a line of a file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectRequestBody(t, map[string]any{
+						"body":     "This leaks the token",
+						"path":     "pkg/client.go",
+						"position": float64(4),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]any{
+							"id":       2,
+							"body":     "This leaks the token",
+							"user":     map[string]any{"login": "octocat"},
+							"path":     "pkg/client.go",
+							"position": 4,
+							"line":     42,
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"body":     "This leaks the token",
+				"path":     "pkg/client.go",
+				"position": float64(4),
+			},
+			expectedComment: commitComment{ID: 2, Author: "octocat", Body: "This leaks the token", Path: "pkg/client.go", Line: 42},
+		},
+		{
+			name:         "path without position",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"body": "This leaks the token",
+				"path": "pkg/client.go",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "path and position must be given together",
+		},
+		{
+			name:         "position without path",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"body":     "This leaks the token",
+				"position": float64(4),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "path and position must be given together",
+		},
+		{
+			name: "position outside the diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"body":     "This leaks the token",
+				"path":     "pkg/client.go",
+				"position": float64(400),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot comment on commit abc123",
+		},
+		{
+			name: "commenting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Forbidden"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"body": "Why was this reverted?",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var comment commitComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &comment))
+			assert.Equal(t, tc.expectedComment, comment)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// customPropertyValue reads the value of a custom property from a tool argument, which is a string, an array of
+// strings for multi select properties, or null to remove the value
+func customPropertyValue(name string, v any) (any, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return v, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("value of %s must be a string, an array of strings or null", name)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("value of %s must be a string, an array of strings or null", name)
+	}
+}
+
+// GetRepositoryCustomProperties creates a tool to get the custom property values of a repository
+func GetRepositoryCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_custom_properties",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_CUSTOM_PROPERTIES_DESCRIPTION", "Get the values of the custom properties, defined by the organization, of a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_CUSTOM_PROPERTIES_USER_TITLE", "Get repository custom properties"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			values, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get custom properties: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if values == nil {
+				values = []*github.CustomPropertyValue{}
+			}
+			r, err := json.Marshal(values)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetRepositoryCustomProperties creates a tool to set custom property values of a repository
+func SetRepositoryCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repository_custom_properties",
+			mcp.WithDescription(t("TOOL_SET_REPOSITORY_CUSTOM_PROPERTIES_DESCRIPTION", "Set the values of custom properties of a GitHub repository. Properties that are not given keep their current value")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPOSITORY_CUSTOM_PROPERTIES_USER_TITLE", "Set repository custom properties"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithArray("properties",
+				mcp.Required(),
+				mcp.Description("Custom property values to set"),
+				mcp.Items(map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"property_name", "value"},
+					"properties": map[string]any{
+						"property_name": map[string]any{
+							"type":        "string",
+							"description": "name of the custom property",
+						},
+						"value": map[string]any{
+							"type":        []string{"string", "array", "null"},
+							"items":       map[string]any{"type": "string"},
+							"description": "the new value, an array of strings for multi select properties, or null to remove the value",
+						},
+					},
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			propertiesObj, ok := request.GetArguments()["properties"].([]any)
+			if !ok || len(propertiesObj) == 0 {
+				return mcp.NewToolResultError("properties must be a non-empty array of objects with property_name and value"), nil
+			}
+			properties := make([]*github.CustomPropertyValue, 0, len(propertiesObj))
+			seen := map[string]bool{}
+			for _, property := range propertiesObj {
+				propertyMap, ok := property.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("each property must be an object with property_name and value"), nil
+				}
+				name, _ := propertyMap["property_name"].(string)
+				if name == "" {
+					return mcp.NewToolResultError("each property must have a property_name"), nil
+				}
+				if seen[name] {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is set more than once", name)), nil
+				}
+				seen[name] = true
+				value, err := customPropertyValue(name, propertyMap["value"])
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				properties = append(properties, &github.CustomPropertyValue{PropertyName: name, Value: value})
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Only the properties whose value changes are sent, so the others are left untouched
+			current, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get custom properties: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			currentValues := make(map[string]any, len(current))
+			for _, value := range current {
+				currentValues[value.PropertyName] = value.Value
+			}
+			var changed []*github.CustomPropertyValue
+			var changedNames []string
+			for _, property := range properties {
+				if value, ok := currentValues[property.PropertyName]; ok && reflect.DeepEqual(value, property.Value) {
+					continue
+				}
+				changed = append(changed, property)
+				changedNames = append(changedNames, property.PropertyName)
+			}
+
+			if len(changed) > 0 {
+				resp, err = client.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repo, changed)
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot set custom properties %s in %s/%s, a property may not exist or a value may not be one of its allowed values: %s", strings.Join(changedNames, ", "), owner, repo, err.Error())), nil
+					}
+					return nil, fmt.Errorf("failed to set custom properties: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+			}
+
+			// Report the values as they are now, in the order returned by the API
+			for _, property := range changed {
+				currentValues[property.PropertyName] = property.Value
+			}
+			values := make([]*github.CustomPropertyValue, 0, len(current)+len(changed))
+			for _, value := range current {
+				values = append(values, &github.CustomPropertyValue{PropertyName: value.PropertyName, Value: currentValues[value.PropertyName]})
+				delete(currentValues, value.PropertyName)
+			}
+			for _, property := range changed {
+				if _, ok := currentValues[property.PropertyName]; ok {
+					values = append(values, property)
+				}
+			}
+			if changedNames == nil {
+				changedNames = []string{}
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"updated":    changedNames,
+				"properties": values,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,299 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       []*github.CustomPropertyValue
+	}{
+		{
+			name: "repository with custom properties",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/properties/values").andThen(
+						mockResponse(t, http.StatusOK, []map[string]any{
+							{"property_name": "team", "value": "platform"},
+							{"property_name": "data-classification", "value": []string{"pii", "internal"}},
+							{"property_name": "tier", "value": nil},
+						}),
+					),
+				),
+			),
+			expected: []*github.CustomPropertyValue{
+				{PropertyName: "team", Value: "platform"},
+				{PropertyName: "data-classification", Value: []string{"pii", "internal"}},
+				{PropertyName: "tier", Value: nil},
+			},
+		},
+		{
+			name: "repository without custom properties",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					[]*github.CustomPropertyValue{},
+				),
+			),
+			expected: []*github.CustomPropertyValue{},
+		},
+		{
+			name: "getting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get custom properties",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var values []*github.CustomPropertyValue
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &values))
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func Test_SetRepositoryCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepositoryCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_repository_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "properties"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	currentValues := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposPropertiesValuesByOwnerByRepo,
+			[]map[string]any{
+				{"property_name": "team", "value": "platform"},
+				{"property_name": "tier", "value": "2"},
+				{"property_name": "data-classification", "value": []string{"internal"}},
+			},
+		)
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectToolError  bool
+		expectedErrMsg   string
+		expectedUpdated  []string
+		expectedProperty []*github.CustomPropertyValue
+	}{
+		{
+			name: "only changed properties are sent",
+			mockedClient: mock.NewMockedHTTPClient(
+				currentValues(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"properties": []any{
+							map[string]any{"property_name": "tier", "value": "1"},
+							map[string]any{"property_name": "data-classification", "value": []any{"internal", "pii"}},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "team", "value": "platform"},
+					map[string]any{"property_name": "tier", "value": "1"},
+					map[string]any{"property_name": "data-classification", "value": []any{"internal", "pii"}},
+				},
+			},
+			expectedUpdated: []string{"tier", "data-classification"},
+			expectedProperty: []*github.CustomPropertyValue{
+				{PropertyName: "team", Value: "platform"},
+				{PropertyName: "tier", Value: "1"},
+				{PropertyName: "data-classification", Value: []string{"internal", "pii"}},
+			},
+		},
+		{
+			name: "remove a value",
+			mockedClient: mock.NewMockedHTTPClient(
+				currentValues(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"properties": []any{
+							map[string]any{"property_name": "tier", "value": nil},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "tier", "value": nil},
+				},
+			},
+			expectedUpdated: []string{"tier"},
+			expectedProperty: []*github.CustomPropertyValue{
+				{PropertyName: "team", Value: "platform"},
+				{PropertyName: "tier", Value: nil},
+				{PropertyName: "data-classification", Value: []string{"internal"}},
+			},
+		},
+		{
+			name:         "nothing changes",
+			mockedClient: mock.NewMockedHTTPClient(currentValues()),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "team", "value": "platform"},
+				},
+			},
+			expectedUpdated: []string{},
+			expectedProperty: []*github.CustomPropertyValue{
+				{PropertyName: "team", Value: "platform"},
+				{PropertyName: "tier", Value: "2"},
+				{PropertyName: "data-classification", Value: []string{"internal"}},
+			},
+		},
+		{
+			name: "value not in the allowed values",
+			mockedClient: mock.NewMockedHTTPClient(
+				currentValues(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Invalid property tier: 5 is not a valid value"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "tier", "value": "5"},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot set custom properties tier in owner/repo, a property may not exist or a value may not be one of its allowed values",
+		},
+		{
+			name:         "value that is not a string",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "tier", "value": float64(1)},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "value of tier must be a string, an array of strings or null",
+		},
+		{
+			name:         "property set twice",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "tier", "value": "1"},
+					map[string]any{"property_name": "tier", "value": "2"},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "tier is set more than once",
+		},
+		{
+			name: "getting current values fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"properties": []any{
+					map[string]any{"property_name": "tier", "value": "1"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get custom properties",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepositoryCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Updated    []string                      `json:"updated"`
+				Properties []*github.CustomPropertyValue `json:"properties"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedUpdated, response.Updated)
+			assert.Equal(t, tc.expectedProperty, response.Properties)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListRepositoryEvents creates a tool to list the events feed of a repository
+func ListRepositoryEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_events",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_EVENTS_DESCRIPTION", "List the recent events of a GitHub repository, newest first, with who triggered them and a summary of each, such as the branch and number of commits of a push. Useful to check whether a push, comment or release actually happened")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_EVENTS_USER_TITLE", "List repository events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			events, resp, err := client.Activity.ListRepositoryEvents(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository events: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			activity := make([]activityEvent, 0, len(events))
+			for _, event := range events {
+				e := normalizeEvent(event)
+				// All the events are of the repository, so there is no need to repeat it
+				e.Repo = ""
+				activity = append(activity, e)
+			}
+
+			r, err := json.Marshal(activity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
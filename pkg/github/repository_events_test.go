@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	createdAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedEvents []activityEvent
+	}{
+		{
+			name: "push and issue events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Event{
+							newTestEvent(t, "PushEvent", createdAt, &github.PushEvent{
+								Ref:  github.Ptr("refs/heads/main"),
+								Size: github.Ptr(2),
+							}),
+							newTestEvent(t, "IssuesEvent", createdAt, &github.IssuesEvent{
+								Action: github.Ptr("closed"),
+								Issue:  &github.Issue{Number: github.Ptr(42), Title: github.Ptr("Crash on start")},
+							}),
+						}),
+					),
+				),
+			),
+			expectedEvents: []activityEvent{
+				{Type: "PushEvent", Actor: "monalisa", CreatedAt: createdAt, Summary: "pushed 2 commit(s) to main"},
+				{Type: "IssuesEvent", Actor: "monalisa", CreatedAt: createdAt, Summary: "closed issue #42: Crash on start"},
+			},
+		},
+		{
+			name: "repository without events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposEventsByOwnerByRepo,
+					[]*github.Event{},
+				),
+			),
+			expectedEvents: []activityEvent{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEventsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list repository events",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var events []activityEvent
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &events))
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
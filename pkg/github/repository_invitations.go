@@ -0,0 +1,312 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repositoryInvitation is a pending invitation to collaborate on a repository
+type repositoryInvitation struct {
+	ID          int64      `json:"id"`
+	Repository  string     `json:"repository"`
+	Invitee     string     `json:"invitee,omitempty"`
+	Inviter     string     `json:"inviter,omitempty"`
+	Permissions string     `json:"permissions"`
+	Expired     bool       `json:"expired"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	HTMLURL     string     `json:"html_url,omitempty"`
+}
+
+func newRepositoryInvitations(invitations []*github.RepositoryInvitation) []repositoryInvitation {
+	result := make([]repositoryInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		i := repositoryInvitation{
+			ID:          invitation.GetID(),
+			Repository:  invitation.GetRepo().GetFullName(),
+			Invitee:     invitation.GetInvitee().GetLogin(),
+			Inviter:     invitation.GetInviter().GetLogin(),
+			Permissions: invitation.GetPermissions(),
+			Expired:     invitation.GetExpired(),
+			HTMLURL:     invitation.GetHTMLURL(),
+		}
+		if invitation.CreatedAt != nil {
+			i.CreatedAt = &invitation.CreatedAt.Time
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+// findUserInvitation looks for a pending invitation of the authenticated user, going through all the pages of them.
+// It returns nil when the user has no pending invitation with the ID.
+func findUserInvitation(ctx context.Context, client *github.Client, invitationID int64) (*github.RepositoryInvitation, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		invitations, resp, err := client.Users.ListInvitations(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+
+		for _, invitation := range invitations {
+			if invitation.GetID() == invitationID {
+				return invitation, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ListRepositoryInvitations creates a tool to list the pending invitations to collaborate on a repository
+func ListRepositoryInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_invitations",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_INVITATIONS_DESCRIPTION", "List the pending invitations to collaborate on a GitHub repository, with the invited user and the permissions they will get")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_INVITATIONS_USER_TITLE", "List repository invitations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitations, resp, err := client.Repositories.ListInvitations(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository invitations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newRepositoryInvitations(invitations))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRepositoryInvitation creates a tool to withdraw an invitation to collaborate on a repository
+func DeleteRepositoryInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository_invitation",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_INVITATION_DESCRIPTION", "Withdraw a pending invitation to collaborate on a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPOSITORY_INVITATION_USER_TITLE", "Delete repository invitation"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("The ID of the invitation, as returned by list_repository_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			invitationID, err := RequiredInt(request, "invitation_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteInvitation(ctx, owner, repo, int64(invitationID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete repository invitation: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":       "Repository invitation has been deleted",
+				"invitation_id": invitationID,
+				"status":        resp.Status,
+				"status_code":   resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListUserRepositoryInvitations creates a tool to list the pending repository invitations of the authenticated user
+func ListUserRepositoryInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_repository_invitations",
+			mcp.WithDescription(t("TOOL_LIST_USER_REPOSITORY_INVITATIONS_DESCRIPTION", "List the pending invitations of the authenticated user to collaborate on GitHub repositories")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_REPOSITORY_INVITATIONS_USER_TITLE", "List my repository invitations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitations, resp, err := client.Users.ListInvitations(ctx, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository invitations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newRepositoryInvitations(invitations))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AcceptRepositoryInvitation creates a tool to accept a repository invitation of the authenticated user
+func AcceptRepositoryInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("accept_repository_invitation",
+			mcp.WithDescription(t("TOOL_ACCEPT_REPOSITORY_INVITATION_DESCRIPTION", "Accept a pending invitation of the authenticated user to collaborate on a GitHub repository. Returns the full name of the repository, which can be used right away")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ACCEPT_REPOSITORY_INVITATION_USER_TITLE", "Accept repository invitation"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("The ID of the invitation, as returned by list_user_repository_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return respondToRepositoryInvitation(ctx, getClient, request, true)
+		}
+}
+
+// DeclineRepositoryInvitation creates a tool to decline a repository invitation of the authenticated user
+func DeclineRepositoryInvitation(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("decline_repository_invitation",
+			mcp.WithDescription(t("TOOL_DECLINE_REPOSITORY_INVITATION_DESCRIPTION", "Decline a pending invitation of the authenticated user to collaborate on a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DECLINE_REPOSITORY_INVITATION_USER_TITLE", "Decline repository invitation"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithNumber("invitation_id",
+				mcp.Required(),
+				mcp.Description("The ID of the invitation, as returned by list_user_repository_invitations"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return respondToRepositoryInvitation(ctx, getClient, request, false)
+		}
+}
+
+// respondToRepositoryInvitation accepts or declines a repository invitation of the authenticated user. The
+// invitation is looked up first, since the response of the API doesn't tell which repository it was for.
+func respondToRepositoryInvitation(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, accept bool) (*mcp.CallToolResult, error) {
+	invitationID, err := RequiredInt(request, "invitation_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	invitation, err := findUserInvitation(ctx, client, int64(invitationID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository invitations: %w", err)
+	}
+	if invitation == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invitation %d is not a pending invitation of the authenticated user, list them with list_user_repository_invitations", invitationID)), nil
+	}
+
+	var resp *github.Response
+	message := "Repository invitation has been accepted"
+	if accept {
+		resp, err = client.Users.AcceptInvitation(ctx, int64(invitationID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to accept repository invitation: %w", err)
+		}
+	} else {
+		message = "Repository invitation has been declined"
+		resp, err = client.Users.DeclineInvitation(ctx, int64(invitationID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decline repository invitation: %w", err)
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	r, err := json.Marshal(map[string]any{
+		"message":       message,
+		"invitation_id": invitationID,
+		"full_name":     invitation.GetRepo().GetFullName(),
+		"permissions":   invitation.GetPermissions(),
+		"status":        resp.Status,
+		"status_code":   resp.StatusCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
@@ -0,0 +1,390 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_invitations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	createdAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		expectError         bool
+		expectedErrMsg      string
+		expectedInvitations []repositoryInvitation
+	}{
+		{
+			name: "pending invitation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposInvitationsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryInvitation{
+							{
+								ID:          github.Ptr(int64(7)),
+								Repo:        &github.Repository{FullName: github.Ptr("owner/repo")},
+								Invitee:     &github.User{Login: github.Ptr("octocat")},
+								Inviter:     &github.User{Login: github.Ptr("owner")},
+								Permissions: github.Ptr("write"),
+								CreatedAt:   &github.Timestamp{Time: createdAt},
+								HTMLURL:     github.Ptr("https://github.com/owner/repo/invitations"),
+							},
+						}),
+					),
+				),
+			),
+			expectedInvitations: []repositoryInvitation{{
+				ID:          7,
+				Repository:  "owner/repo",
+				Invitee:     "octocat",
+				Inviter:     "owner",
+				Permissions: "write",
+				CreatedAt:   &createdAt,
+				HTMLURL:     "https://github.com/owner/repo/invitations",
+			}},
+		},
+		{
+			name: "no pending invitations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposInvitationsByOwnerByRepo,
+					[]*github.RepositoryInvitation{},
+				),
+			),
+			expectedInvitations: []repositoryInvitation{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposInvitationsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list repository invitations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var invitations []repositoryInvitation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &invitations))
+			assert.Equal(t, tc.expectedInvitations, invitations)
+		})
+	}
+}
+
+func Test_DeleteRepositoryInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepositoryInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repository_invitation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "invitation_id"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposInvitationsByOwnerByRepoByInvitationId,
+			expectPath(t, "/repos/owner/repo/invitations/7").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	_, handler := DeleteRepositoryInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":         "owner",
+		"repo":          "repo",
+		"invitation_id": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, float64(7), response["invitation_id"])
+	assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+}
+
+func Test_ListUserRepositoryInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserRepositoryInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_user_repository_invitations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		expectError         bool
+		expectedErrMsg      string
+		expectedInvitations []repositoryInvitation
+	}{
+		{
+			name: "pending invitation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUserRepositoryInvitations,
+					[]*github.RepositoryInvitation{
+						{
+							ID:          github.Ptr(int64(7)),
+							Repo:        &github.Repository{FullName: github.Ptr("owner/repo")},
+							Inviter:     &github.User{Login: github.Ptr("owner")},
+							Permissions: github.Ptr("read"),
+						},
+					},
+				),
+			),
+			expectedInvitations: []repositoryInvitation{{
+				ID:          7,
+				Repository:  "owner/repo",
+				Inviter:     "owner",
+				Permissions: "read",
+			}},
+		},
+		{
+			name: "no pending invitations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUserRepositoryInvitations,
+					[]*github.RepositoryInvitation{},
+				),
+			),
+			expectedInvitations: []repositoryInvitation{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserRepositoryInvitations,
+					mockResponse(t, http.StatusUnauthorized, map[string]string{"message": "Requires authentication"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list repository invitations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserRepositoryInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var invitations []repositoryInvitation
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &invitations))
+			assert.Equal(t, tc.expectedInvitations, invitations)
+		})
+	}
+}
+
+func Test_AcceptRepositoryInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AcceptRepositoryInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "accept_repository_invitation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"invitation_id"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	pendingInvitations := mock.WithRequestMatchHandler(
+		mock.GetUserRepositoryInvitations,
+		expectQueryParams(t, map[string]string{
+			"per_page": "100",
+		}).andThen(
+			mockResponse(t, http.StatusOK, []*github.RepositoryInvitation{
+				{
+					ID:          github.Ptr(int64(7)),
+					Repo:        &github.Repository{FullName: github.Ptr("owner/repo")},
+					Permissions: github.Ptr("write"),
+				},
+			}),
+		),
+	)
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		invitationID     float64
+		expectError      bool
+		expectToolError  bool
+		expectedErrMsg   string
+		expectedFullName string
+	}{
+		{
+			name: "accept an invitation",
+			mockedClient: mock.NewMockedHTTPClient(
+				pendingInvitations,
+				mock.WithRequestMatchHandler(
+					mock.PatchUserRepositoryInvitationsByInvitationId,
+					expectPath(t, "/user/repository_invitations/7").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			invitationID:     7,
+			expectedFullName: "owner/repo",
+		},
+		{
+			name: "invitation that is not pending",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUserRepositoryInvitations,
+					[]*github.RepositoryInvitation{},
+				),
+			),
+			invitationID:    8,
+			expectToolError: true,
+			expectedErrMsg:  "invitation 8 is not a pending invitation of the authenticated user",
+		},
+		{
+			name: "accepting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUserRepositoryInvitations,
+					[]*github.RepositoryInvitation{{ID: github.Ptr(int64(7))}},
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchUserRepositoryInvitationsByInvitationId,
+					mockResponse(t, http.StatusConflict, map[string]string{"message": "Conflict"}),
+				),
+			),
+			invitationID:   7,
+			expectError:    true,
+			expectedErrMsg: "failed to accept repository invitation",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AcceptRepositoryInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"invitation_id": tc.invitationID,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedFullName, response["full_name"])
+			assert.Equal(t, "Repository invitation has been accepted", response["message"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
+
+func Test_DeclineRepositoryInvitation(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeclineRepositoryInvitation(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "decline_repository_invitation", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"invitation_id"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetUserRepositoryInvitations,
+			[]*github.RepositoryInvitation{
+				{
+					ID:   github.Ptr(int64(7)),
+					Repo: &github.Repository{FullName: github.Ptr("owner/repo")},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.DeleteUserRepositoryInvitationsByInvitationId,
+			expectPath(t, "/user/repository_invitations/7").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	_, handler := DeclineRepositoryInvitation(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"invitation_id": float64(7),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, "owner/repo", response["full_name"])
+	assert.Equal(t, "Repository invitation has been declined", response["message"])
+}
@@ -0,0 +1,294 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const descriptionFullRef = "The fully qualified name of the ref, like refs/heads/main, refs/tags/v1.0.0 or refs/notes/commits"
+
+// requiredFullRef returns the ref parameter, which must be a fully qualified ref so that a branch and a tag of the same
+// name are never mixed up
+func requiredFullRef(request mcp.CallToolRequest) (string, error) {
+	ref, err := RequiredParam[string](request, "ref")
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(ref, "refs/") || len(ref) == len("refs/") {
+		return "", fmt.Errorf("ref must be fully qualified and start with refs/, like refs/heads/%s", strings.TrimPrefix(ref, "refs/"))
+	}
+	return ref, nil
+}
+
+// GetRef creates a tool to get a git ref of a repository
+func GetRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ref",
+			mcp.WithDescription(t("TOOL_GET_REF_DESCRIPTION", "Get a git ref of a GitHub repository and the object it points at")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REF_USER_TITLE", "Get git ref"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description(descriptionFullRef),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredFullRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.GetRef(ctx, owner, repo, ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ref: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(reference)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateRef creates a tool to create a git ref in a repository
+func CreateRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_ref",
+			mcp.WithDescription(t("TOOL_CREATE_REF_DESCRIPTION", "Create a git ref in a GitHub repository pointing at an object")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REF_USER_TITLE", "Create git ref"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description(descriptionFullRef),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The SHA of the object the ref points at"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredFullRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(ref),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return mcp.NewToolResultError(fmt.Sprintf("cannot create %s in %s/%s, the ref may already exist or %s may not be an object of the repository: %s", ref, owner, repo, sha, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to create ref: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(reference)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// UpdateRef creates a tool to move a git ref of a repository to another object
+func UpdateRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ref",
+			mcp.WithDescription(t("TOOL_UPDATE_REF_DESCRIPTION", "Move a git ref of a GitHub repository to another object. Only fast forward moves are allowed unless force is true")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_REF_USER_TITLE", "Update git ref"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description(descriptionFullRef),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The SHA of the object to move the ref to"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Move the ref even when it is not a fast forward, which may lose commits (default false)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredFullRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			reference, resp, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(ref),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			}, force)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					if !force && strings.Contains(strings.ToLower(err.Error()), "fast forward") {
+						return mcp.NewToolResultError(fmt.Sprintf("cannot move %s to %s, it is not a fast forward. Set force to true to move it anyway, the commits only reachable from the current position of the ref may be lost", ref, sha)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("cannot move %s to %s: %s", ref, sha, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to update ref: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(reference)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRef creates a tool to delete a git ref of a repository
+func DeleteRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_ref",
+			mcp.WithDescription(t("TOOL_DELETE_REF_DESCRIPTION", "Delete a git ref of a GitHub repository, like a branch or a tag")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REF_USER_TITLE", "Delete git ref"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description(descriptionFullRef),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredFullRef(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Git.DeleteRef(ctx, owner, repo, ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete ref: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":     fmt.Sprintf("%s has been deleted", ref),
+				"ref":         ref,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,444 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockReference(ref, sha string) *github.Reference {
+	return &github.Reference{
+		Ref:    github.Ptr(ref),
+		Object: &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr(sha)},
+	}
+}
+
+func Test_GetRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		ref             string
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        *github.Reference
+	}{
+		{
+			name: "get a branch ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/ref/heads/production-pin").andThen(
+						mockResponse(t, http.StatusOK, mockReference("refs/heads/production-pin", "abc123")),
+					),
+				),
+			),
+			ref:      "refs/heads/production-pin",
+			expected: mockReference("refs/heads/production-pin", "abc123"),
+		},
+		{
+			name:            "ref that is not fully qualified",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ref:             "main",
+			expectToolError: true,
+			expectedErrMsg:  "ref must be fully qualified and start with refs/, like refs/heads/main",
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			ref:            "refs/notes/commits",
+			expectError:    true,
+			expectedErrMsg: "failed to get ref",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   tc.ref,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var reference github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reference))
+			assert.Equal(t, tc.expected, &reference)
+		})
+	}
+}
+
+func Test_CreateRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		ref             string
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        *github.Reference
+	}{
+		{
+			name: "create a notes ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "refs/notes/commits",
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockReference("refs/notes/commits", "abc123")),
+					),
+				),
+			),
+			ref:      "refs/notes/commits",
+			expected: mockReference("refs/notes/commits", "abc123"),
+		},
+		{
+			name:            "ref that is only the prefix",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ref:             "refs/",
+			expectToolError: true,
+			expectedErrMsg:  "ref must be fully qualified and start with refs/",
+		},
+		{
+			name: "ref already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Reference already exists"}),
+				),
+			),
+			ref:             "refs/heads/main",
+			expectToolError: true,
+			expectedErrMsg:  "cannot create refs/heads/main in owner/repo, the ref may already exist",
+		},
+		{
+			name: "creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			ref:            "refs/heads/main",
+			expectError:    true,
+			expectedErrMsg: "failed to create ref",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   tc.ref,
+				"sha":   "abc123",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var reference github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reference))
+			assert.Equal(t, tc.expected, &reference)
+		})
+	}
+}
+
+func Test_UpdateRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "force")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	notFastForward := mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Update is not a fast forward"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        *github.Reference
+	}{
+		{
+			name: "fast forward move",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/heads/production-pin").andThen(
+						expectRequestBody(t, map[string]any{
+							"sha":   "def456",
+							"force": false,
+						}).andThen(
+							mockResponse(t, http.StatusOK, mockReference("refs/heads/production-pin", "def456")),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"ref": "refs/heads/production-pin",
+				"sha": "def456",
+			},
+			expected: mockReference("refs/heads/production-pin", "def456"),
+		},
+		{
+			name: "forced move",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]any{
+						"sha":   "abc000",
+						"force": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockReference("refs/heads/production-pin", "abc000")),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"ref":   "refs/heads/production-pin",
+				"sha":   "abc000",
+				"force": true,
+			},
+			expected: mockReference("refs/heads/production-pin", "abc000"),
+		},
+		{
+			name: "move that is not a fast forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					notFastForward,
+				),
+			),
+			requestArgs: map[string]any{
+				"ref": "refs/heads/production-pin",
+				"sha": "abc000",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot move refs/heads/production-pin to abc000, it is not a fast forward. Set force to true to move it anyway",
+		},
+		{
+			name: "move to an object that does not exist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Object does not exist"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"ref":   "refs/heads/production-pin",
+				"sha":   "fff999",
+				"force": true,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "cannot move refs/heads/production-pin to fff999",
+		},
+		{
+			name:         "ref that is not fully qualified",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"ref": "heads/production-pin",
+				"sha": "def456",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "ref must be fully qualified and start with refs/",
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"ref": "refs/heads/production-pin",
+				"sha": "def456",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update ref",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var reference github.Reference
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reference))
+			assert.Equal(t, tc.expected, &reference)
+		})
+	}
+}
+
+func Test_DeleteRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		ref             string
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "delete a tag ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/tags/v1.0.0").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			ref: "refs/tags/v1.0.0",
+		},
+		{
+			name:            "ref that is not fully qualified",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ref:             "v1.0.0",
+			expectToolError: true,
+			expectedErrMsg:  "ref must be fully qualified and start with refs/",
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Reference does not exist"}),
+				),
+			),
+			ref:            "refs/tags/v1.0.0",
+			expectError:    true,
+			expectedErrMsg: "failed to delete ref",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   tc.ref,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.ref, response["ref"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
@@ -3,16 +3,15 @@ package github
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"mime"
 	"net/http"
-	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -20,52 +19,53 @@ import (
 )
 
 // GetRepositoryResourceContent defines the resource template and handler for getting repository content.
-func GetRepositoryResourceContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+func GetRepositoryResourceContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, limits ContentLimits, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
-			"repo://{owner}/{repo}/contents{/path*}", // Resource template
+			"repo://{owner}/{repo}/contents{/path*}{?max_bytes}", // Resource template
 			t("RESOURCE_REPOSITORY_CONTENT_DESCRIPTION", "Repository Content"),
 		),
-		RepositoryResourceContentsHandler(getClient, getRawClient)
+		RepositoryResourceContentsHandler(getClient, getRawClient, limits)
 }
 
 // GetRepositoryResourceBranchContent defines the resource template and handler for getting repository content for a branch.
-func GetRepositoryResourceBranchContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+func GetRepositoryResourceBranchContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, limits ContentLimits, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
-			"repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}", // Resource template
+			"repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}{?max_bytes}", // Resource template
 			t("RESOURCE_REPOSITORY_CONTENT_BRANCH_DESCRIPTION", "Repository Content for specific branch"),
 		),
-		RepositoryResourceContentsHandler(getClient, getRawClient)
+		RepositoryResourceContentsHandler(getClient, getRawClient, limits)
 }
 
 // GetRepositoryResourceCommitContent defines the resource template and handler for getting repository content for a commit.
-func GetRepositoryResourceCommitContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+func GetRepositoryResourceCommitContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, limits ContentLimits, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
-			"repo://{owner}/{repo}/sha/{sha}/contents{/path*}", // Resource template
+			"repo://{owner}/{repo}/sha/{sha}/contents{/path*}{?max_bytes}", // Resource template
 			t("RESOURCE_REPOSITORY_CONTENT_COMMIT_DESCRIPTION", "Repository Content for specific commit"),
 		),
-		RepositoryResourceContentsHandler(getClient, getRawClient)
+		RepositoryResourceContentsHandler(getClient, getRawClient, limits)
 }
 
 // GetRepositoryResourceTagContent defines the resource template and handler for getting repository content for a tag.
-func GetRepositoryResourceTagContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+func GetRepositoryResourceTagContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, limits ContentLimits, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
-			"repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}", // Resource template
+			"repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}{?max_bytes}", // Resource template
 			t("RESOURCE_REPOSITORY_CONTENT_TAG_DESCRIPTION", "Repository Content for specific tag"),
 		),
-		RepositoryResourceContentsHandler(getClient, getRawClient)
+		RepositoryResourceContentsHandler(getClient, getRawClient, limits)
 }
 
 // GetRepositoryResourcePrContent defines the resource template and handler for getting repository content for a pull request.
-func GetRepositoryResourcePrContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+func GetRepositoryResourcePrContent(getClient GetClientFn, getRawClient raw.GetRawClientFn, limits ContentLimits, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
 	return mcp.NewResourceTemplate(
-			"repo://{owner}/{repo}/refs/pull/{prNumber}/head/contents{/path*}", // Resource template
+			"repo://{owner}/{repo}/refs/pull/{prNumber}/head/contents{/path*}{?max_bytes}", // Resource template
 			t("RESOURCE_REPOSITORY_CONTENT_PR_DESCRIPTION", "Repository Content for specific pull request"),
 		),
-		RepositoryResourceContentsHandler(getClient, getRawClient)
+		RepositoryResourceContentsHandler(getClient, getRawClient, limits)
 }
 
 // RepositoryResourceContentsHandler returns a handler function for repository content requests.
-func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.GetRawClientFn) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+// Binary files larger than the limits allow are described instead of being returned.
+func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.GetRawClientFn, limits ContentLimits) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 		// the matcher will give []string with one element
 		// https://github.com/mark3labs/mcp-go/pull/54
@@ -127,9 +127,24 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			rawOpts.SHA = sha
 			opts.Ref = sha
 		}
+		maxBytes, ok := request.Params.Arguments["max_bytes"].([]string)
+		if ok && len(maxBytes) > 0 && maxBytes[0] != "" {
+			n, err := strconv.ParseInt(maxBytes[0], 10, 64)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid max_bytes: %s", maxBytes[0])
+			}
+			rawOpts.MaxBytes = n
+		}
+		// Never read more than the blob limit, so that a large file is not held in memory. The limit is
+		// one byte over, so that a file of exactly the limit isn't mistaken for a larger one.
+		blobLimit := limits.maxResourceBlobBytes()
+		if rawOpts.MaxBytes == 0 || rawOpts.MaxBytes > blobLimit {
+			rawOpts.MaxBytes = blobLimit + 1
+		}
+
 		//  if it's a directory
 		if path == "" || strings.HasSuffix(path, "/") {
-			return nil, fmt.Errorf("directories are not supported: %s", path)
+			return directoryResourceContents(ctx, getClient, request.Params.URI, owner, repo, path, opts)
 		}
 		rawClient, err := getRawClient(ctx)
 
@@ -137,56 +152,288 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
 		}
 
-		resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-		defer func() {
-			_ = resp.Body.Close()
-		}()
-		// If the raw content is not found, we will fall back to the GitHub API (in case it is a directory)
+		result, err := rawClient.GetRawContentResult(ctx, owner, repo, path, rawOpts)
+		if err == nil && result.Found() && mayBeSymlink(result) {
+			// The raw content of a symlink is its target, regular files are returned as is when the check fails
+			if client, err := getClient(ctx); err == nil {
+				if entry, err := getSpecialEntry(ctx, client, owner, repo, path, opts); err == nil && entry != nil {
+					return specialEntryResourceContents(request.Params.URI, entry)
+				}
+			}
+		}
 		switch {
 		case err != nil:
-			return nil, fmt.Errorf("failed to get raw content: %w", err)
-		case resp.StatusCode == http.StatusOK:
-			ext := filepath.Ext(path)
-			mimeType := resp.Header.Get("Content-Type")
-			if ext == ".md" {
-				mimeType = "text/markdown"
-			} else if mimeType == "" {
-				mimeType = mime.TypeByExtension(ext)
+			return nil, fmt.Errorf("failed to get raw content of %s/%s/%s: %w", owner, repo, path, err)
+		case result.Found() && result.IsText():
+			text := result.Content
+			if int64(len(text)) > blobLimit {
+				text = text[:blobLimit]
 			}
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: result.ContentType,
+					// Avoid returning a multi-byte character cut in half by the limit
+					Text: strings.ToValidUTF8(string(text), ""),
+				},
+			}, nil
+		case result.Found() && int64(len(result.Content)) > blobLimit:
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: "text/plain",
+					Text:     describeLargeFile(path, result, blobLimit, rawClient.URLFromOpts(rawOpts, owner, repo, path)),
+				},
+			}, nil
+		case result.Found():
+			return []mcp.ResourceContents{
+				mcp.BlobResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: result.ContentType,
+					Blob:     base64.StdEncoding.EncodeToString(result.Content),
+				},
+			}, nil
+		case result.StatusCode != http.StatusNotFound:
+			// If we got a response but it is not 200 OK, we return an error
+			return nil, fmt.Errorf("failed to fetch raw content of %s/%s/%s: %s", owner, repo, path, string(result.Content))
+		default:
+			// The raw content of a directory is not found, list it instead
+			return directoryResourceContents(ctx, getClient, request.Params.URI, owner, repo, path, opts)
+		}
+	}
+}
 
-			content, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read file content: %w", err)
-			}
+// directoryResourceContents returns the listing of a directory as a JSON text resource.
+func directoryResourceContents(ctx context.Context, getClient GetClientFn, uri, owner, repo, path string, opts *github.RepositoryContentGetOptions) ([]mcp.ResourceContents, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
 
-			switch {
-			case strings.HasPrefix(mimeType, "text"), strings.HasPrefix(mimeType, "application"):
-				return []mcp.ResourceContents{
-					mcp.TextResourceContents{
-						URI:      request.Params.URI,
-						MIMEType: mimeType,
-						Text:     string(content),
-					},
-				}, nil
-			default:
-				return []mcp.ResourceContents{
-					mcp.BlobResourceContents{
-						URI:      request.Params.URI,
-						MIMEType: mimeType,
-						Blob:     base64.StdEncoding.EncodeToString(content),
-					},
-				}, nil
-			}
-		case resp.StatusCode != http.StatusNotFound:
-			// If we got a response but it is not 200 OK, we return an error
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-			return nil, fmt.Errorf("failed to fetch raw content: %s", string(body))
+	entries, err := listDirectory(ctx, client, owner, repo, path, opts)
+	if errors.Is(err, errNotDirectory) {
+		// The raw content of a submodule is not found
+		entry, specialErr := getSpecialEntry(ctx, client, owner, repo, path, opts)
+		if specialErr == nil && entry != nil {
+			return specialEntryResourceContents(uri, entry)
+		}
+	}
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.Is(err, errNotDirectory) || (errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound) {
+			return nil, fmt.Errorf("404 Not Found: %s/%s/%s", owner, repo, path)
+		}
+		return nil, fmt.Errorf("failed to list directory %s/%s/%s: %w", owner, repo, path, err)
+	}
+
+	listing, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory listing: %w", err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(listing),
+		},
+	}, nil
+}
+
+// specialEntryResourceContents returns the description of a symlink or a submodule as a JSON text resource.
+func specialEntryResourceContents(uri string, entry *specialEntry) ([]mcp.ResourceContents, error) {
+	description, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s description: %w", entry.Type, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(description),
+		},
+	}, nil
+}
+
+// describeLargeFile describes a binary file that is too large to be returned as a resource.
+func describeLargeFile(path string, result *raw.RawContentResult, limit int64, downloadURL string) string {
+	size := fmt.Sprintf("%d bytes", result.Size)
+	if result.Size <= int64(len(result.Content)) {
+		// The size is unknown, we only know that the file is larger than the limit
+		size = fmt.Sprintf("more than %d bytes", limit)
+	}
+	mimeType := result.ContentType
+	if mimeType == "" {
+		mimeType = "unknown"
+	}
+	return fmt.Sprintf(
+		"The binary file %s is too large to be returned as a resource.\n\nSize: %s\nMIME type: %s\nLimit: %d bytes\n\nDownload the file from %s instead.",
+		path, size, mimeType, limit, downloadURL,
+	)
+}
+
+// maxCompletionValues caps the number of values returned for a single argument completion.
+const maxCompletionValues = 20
+
+// RepositoryResourceCompletionHandler returns a completion function for the arguments of the repository
+// content resource templates: owners, repos, branches and paths.
+func RepositoryResourceCompletionHandler(getClient GetClientFn) toolsets.ResourceCompletionFunc {
+	return func(ctx context.Context, argument string, value string, resolved map[string]string) (*mcp.CompleteResult, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		var candidates []string
+		switch argument {
+		case "owner":
+			candidates, err = completeOwners(ctx, client)
+		case "repo":
+			candidates, err = completeRepos(ctx, client, resolved["owner"])
+		case "branch":
+			candidates, err = completeBranches(ctx, client, resolved["owner"], resolved["repo"])
+		case "path":
+			return completePaths(ctx, client, value, resolved)
 		default:
-			// This should be unreachable because GetContents should return an error if neither file nor directory content is found.
-			return nil, errors.New("404 Not Found")
+			return completionResult(nil), nil
 		}
+		if err != nil {
+			return nil, err
+		}
+
+		return completionResult(filterByPrefix(candidates, value)), nil
+	}
+}
+
+func completeOwners(ctx context.Context, client *github.Client) ([]string, error) {
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	orgs, _, err := client.Organizations.List(ctx, "", &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	owners := []string{user.GetLogin()}
+	for _, org := range orgs {
+		owners = append(owners, org.GetLogin())
+	}
+	return owners, nil
+}
+
+func completeRepos(ctx context.Context, client *github.Client, owner string) ([]string, error) {
+	if owner == "" {
+		return nil, nil
+	}
+
+	user, _, err := client.Users.Get(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	var repos []*github.Repository
+	if user.GetType() == "Organization" {
+		repos, _, err = client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{
+			Sort:        "full_name",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+	} else {
+		repos, _, err = client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{
+			Sort:        "full_name",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.GetName())
+	}
+	return names, nil
+}
+
+func completeBranches(ctx context.Context, client *github.Client, owner, repo string) ([]string, error) {
+	if owner == "" || repo == "" {
+		return nil, nil
+	}
+
+	branches, _, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		names = append(names, branch.GetName())
+	}
+	return names, nil
+}
+
+// completePaths lists the directory of the typed path and returns the entries matching the typed file name.
+// Directories are suffixed with a slash so that completion can continue into them.
+func completePaths(ctx context.Context, client *github.Client, value string, resolved map[string]string) (*mcp.CompleteResult, error) {
+	owner, repo := resolved["owner"], resolved["repo"]
+	if owner == "" || repo == "" {
+		return completionResult(nil), nil
+	}
+
+	dir := ""
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		dir = value[:i]
+	}
+
+	opts := &github.RepositoryContentGetOptions{}
+	switch {
+	case resolved["sha"] != "":
+		opts.Ref = resolved["sha"]
+	case resolved["branch"] != "":
+		opts.Ref = "refs/heads/" + resolved["branch"]
+	case resolved["tag"] != "":
+		opts.Ref = "refs/tags/" + resolved["tag"]
+	}
+
+	_, entries, _, err := client.Repositories.GetContents(ctx, owner, repo, dir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory contents: %w", err)
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		path := entry.GetPath()
+		if entry.GetType() == "dir" {
+			path += "/"
+		}
+		paths = append(paths, path)
+	}
+	return completionResult(filterByPrefix(paths, value)), nil
+}
+
+// filterByPrefix returns the candidates starting with the prefix, ignoring case.
+func filterByPrefix(candidates []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	filtered := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), prefix) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// completionResult builds a completion result, capping the values at maxCompletionValues.
+func completionResult(values []string) *mcp.CompleteResult {
+	result := &mcp.CompleteResult{}
+	result.Completion.Values = []string{}
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
 	}
+	result.Completion.Values = append(result.Completion.Values, values...)
+	return result
 }
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
@@ -14,12 +15,22 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// failingTransport fails every request, as a DNS failure or a cancelled context would.
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
 func Test_repositoryResourceContentsHandler(t *testing.T) {
 	base, _ := url.Parse("https://raw.example.com/")
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
 		requestArgs    map[string]any
+		limits         ContentLimits
 		expectError    string
 		expectedResult any
 	}{
@@ -226,13 +237,246 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 			},
 			expectError: "404 Not Found",
 		},
+		{
+			name: "root directory listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/").andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+							{Name: github.Ptr("README.md"), Path: github.Ptr("README.md"), Type: github.Ptr("file"), Size: github.Ptr(42), SHA: github.Ptr("abc123")},
+							{Name: github.Ptr("cmd"), Path: github.Ptr("cmd"), Type: github.Ptr("dir"), SHA: github.Ptr("def456")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"README.md","path":"README.md","type":"file","size":42,"sha":"abc123"},{"name":"cmd","path":"cmd","type":"dir","size":0,"sha":"def456"}]`,
+				MIMEType: "application/json",
+			}},
+		},
+		{
+			name: "nested directory listing after the raw content is not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{
+						"ref": "refs/heads/main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+							{Name: github.Ptr("main.go"), Path: github.Ptr("cmd/server/main.go"), Type: github.Ptr("file"), Size: github.Ptr(1024), SHA: github.Ptr("fff000")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"path":   []string{"cmd", "server"},
+				"branch": []string{"main"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `[{"name":"main.go","path":"cmd/server/main.go","type":"file","size":1024,"sha":"fff000"}]`,
+				MIMEType: "application/json",
+			}},
+		},
+		{
+			name: "symlink is described",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, err := w.Write([]byte("../shared"))
+						require.NoError(t, err)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:   github.Ptr("symlink"),
+						Path:   github.Ptr("web/shared"),
+						Target: github.Ptr("../shared"),
+						SHA:    github.Ptr("abc123"),
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"web", "shared"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `{"type":"symlink","path":"web/shared","target":"../shared","target_path":"shared","sha":"abc123","message":"web/shared is a symbolic link to ../shared"}`,
+				MIMEType: "application/json",
+			}},
+		},
+		{
+			name: "submodule is described",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:            github.Ptr("submodule"),
+						Path:            github.Ptr("vendor/lib"),
+						SubmoduleGitURL: github.Ptr("https://github.com/other/lib.git"),
+						SHA:             github.Ptr("def456"),
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"vendor", "lib"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     `{"type":"submodule","path":"vendor/lib","submodule_git_url":"https://github.com/other/lib.git","sha":"def456","message":"vendor/lib is a git submodule of https://github.com/other/lib.git pinned at def456, get its contents from that repository"}`,
+				MIMEType: "application/json",
+			}},
+		},
+		{
+			name: "content limited by max_bytes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "bytes=0-6", r.Header.Get("Range"))
+						w.Header().Set("Content-Type", "text/plain")
+						w.Header().Set("Content-Range", "bytes 0-6/44")
+						w.WriteHeader(http.StatusPartialContent)
+						_, err := w.Write([]byte("package"))
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":     []string{"owner"},
+				"repo":      []string{"repo"},
+				"path":      []string{"main.go"},
+				"max_bytes": []string{"7"},
+			},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     "package",
+				MIMEType: "text/plain",
+			}},
+		},
+		{
+			name: "large binary content is described",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						require.Equal(t, "bytes=0-100", r.Header.Get("Range"))
+						w.Header().Set("Content-Type", "image/png")
+						w.Header().Set("Content-Range", "bytes 0-100/4096")
+						w.WriteHeader(http.StatusPartialContent)
+						_, err := w.Write(make([]byte, 101))
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"logo.png"},
+			},
+			limits: ContentLimits{MaxResourceBlobBytes: 100},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     "The binary file logo.png is too large to be returned as a resource.\n\nSize: 4096 bytes\nMIME type: image/png\nLimit: 100 bytes\n\nDownload the file from https://raw.example.com/owner/repo/HEAD/logo.png instead.",
+				MIMEType: "text/plain",
+			}},
+		},
+		{
+			name: "large binary content of unknown size is described",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						// Ignore the Range header and stream the content without a Content-Length
+						w.Header().Set("Content-Type", "application/octet-stream")
+						for i := 0; i < 64; i++ {
+							_, err := w.Write(make([]byte, 1024))
+							require.NoError(t, err)
+							w.(http.Flusher).Flush()
+						}
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"data.bin"},
+			},
+			limits: ContentLimits{MaxResourceBlobBytes: 100},
+			expectedResult: []mcp.TextResourceContents{{
+				Text:     "The binary file data.bin is too large to be returned as a resource.\n\nSize: more than 100 bytes\nMIME type: application/octet-stream\nLimit: 100 bytes\n\nDownload the file from https://raw.example.com/owner/repo/HEAD/data.bin instead.",
+				MIMEType: "text/plain",
+			}},
+		},
+		{
+			name: "binary content served as text is returned as a blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, err := w.Write([]byte{'G', 'I', 'F', 0, 1})
+						require.NoError(t, err)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"image.txt"},
+			},
+			expectedResult: []mcp.BlobResourceContents{{
+				Blob:     "R0lGAAE=",
+				MIMEType: "text/plain",
+			}},
+		},
+		{
+			name: "raw request fails",
+			mockedClient: &http.Client{
+				Transport: failingTransport{err: errors.New("dial tcp: lookup raw.example.com: no such host")},
+			},
+			requestArgs: map[string]any{
+				"owner": []string{"owner"},
+				"repo":  []string{"repo"},
+				"path":  []string{"README.md"},
+			},
+			expectError: "failed to get raw content of owner/repo/README.md: ",
+		},
+		{
+			name:         "invalid max_bytes",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":     []string{"owner"},
+				"repo":      []string{"repo"},
+				"path":      []string{"main.go"},
+				"max_bytes": []string{"lots"},
+			},
+			expectError: "invalid max_bytes",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			client := github.NewClient(tc.mockedClient)
 			mockRawClient := raw.NewClient(client, base)
-			handler := RepositoryResourceContentsHandler((stubGetClientFn(client)), stubGetRawClientFn(mockRawClient))
+			handler := RepositoryResourceContentsHandler((stubGetClientFn(client)), stubGetRawClientFn(mockRawClient), tc.limits)
 
 			request := mcp.ReadResourceRequest{
 				Params: struct {
@@ -258,23 +502,115 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 
 func Test_GetRepositoryResourceContent(t *testing.T) {
 	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
-	tmpl, _ := GetRepositoryResourceContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
-	require.Equal(t, "repo://{owner}/{repo}/contents{/path*}", tmpl.URITemplate.Raw())
+	tmpl, _ := GetRepositoryResourceContent(nil, stubGetRawClientFn(mockRawClient), ContentLimits{}, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/contents{/path*}{?max_bytes}", tmpl.URITemplate.Raw())
 }
 
 func Test_GetRepositoryResourceBranchContent(t *testing.T) {
 	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
-	tmpl, _ := GetRepositoryResourceBranchContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
-	require.Equal(t, "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}", tmpl.URITemplate.Raw())
+	tmpl, _ := GetRepositoryResourceBranchContent(nil, stubGetRawClientFn(mockRawClient), ContentLimits{}, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}{?max_bytes}", tmpl.URITemplate.Raw())
 }
 func Test_GetRepositoryResourceCommitContent(t *testing.T) {
 	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
-	tmpl, _ := GetRepositoryResourceCommitContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
-	require.Equal(t, "repo://{owner}/{repo}/sha/{sha}/contents{/path*}", tmpl.URITemplate.Raw())
+	tmpl, _ := GetRepositoryResourceCommitContent(nil, stubGetRawClientFn(mockRawClient), ContentLimits{}, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/sha/{sha}/contents{/path*}{?max_bytes}", tmpl.URITemplate.Raw())
 }
 
 func Test_GetRepositoryResourceTagContent(t *testing.T) {
 	mockRawClient := raw.NewClient(github.NewClient(nil), &url.URL{})
-	tmpl, _ := GetRepositoryResourceTagContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
-	require.Equal(t, "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}", tmpl.URITemplate.Raw())
+	tmpl, _ := GetRepositoryResourceTagContent(nil, stubGetRawClientFn(mockRawClient), ContentLimits{}, translations.NullTranslationHelper)
+	require.Equal(t, "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}{?max_bytes}", tmpl.URITemplate.Raw())
+}
+
+func Test_RepositoryResourceCompletionHandler(t *testing.T) {
+	mockOwner := &github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")}
+	mockRepos := []*github.Repository{
+		{Name: github.Ptr("Hello-World")},
+		{Name: github.Ptr("hello-api")},
+		{Name: github.Ptr("docs")},
+	}
+	mockBranches := []*github.Branch{
+		{Name: github.Ptr("main")},
+		{Name: github.Ptr("feature/completion")},
+		{Name: github.Ptr("feature/prompts")},
+	}
+	mockDir := []*github.RepositoryContent{
+		{Path: github.Ptr("pkg/github"), Type: github.Ptr("dir")},
+		{Path: github.Ptr("pkg/toolsets"), Type: github.Ptr("dir")},
+		{Path: github.Ptr("pkg/go.mod"), Type: github.Ptr("file")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		argument       string
+		value          string
+		resolved       map[string]string
+		expectedValues []string
+	}{
+		{
+			name: "repos of an organization filtered by prefix ignoring case",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, mockOwner),
+				mock.WithRequestMatch(mock.GetOrgsReposByOrg, mockRepos),
+			),
+			argument:       "repo",
+			value:          "hel",
+			resolved:       map[string]string{"owner": "octo-org"},
+			expectedValues: []string{"Hello-World", "hello-api"},
+		},
+		{
+			name: "branches filtered by prefix",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, mockBranches),
+			),
+			argument:       "branch",
+			value:          "feature/",
+			resolved:       map[string]string{"owner": "octo-org", "repo": "hello-api"},
+			expectedValues: []string{"feature/completion", "feature/prompts"},
+		},
+		{
+			name: "paths within the typed directory",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposContentsByOwnerByRepoByPath, mockDir),
+			),
+			argument:       "path",
+			value:          "pkg/t",
+			resolved:       map[string]string{"owner": "octo-org", "repo": "hello-api", "branch": "main"},
+			expectedValues: []string{"pkg/toolsets/"},
+		},
+		{
+			name:           "unknown argument",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			argument:       "number",
+			value:          "1",
+			expectedValues: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			handler := RepositoryResourceCompletionHandler(stubGetClientFn(client))
+
+			result, err := handler(context.Background(), tc.argument, tc.value, tc.resolved)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedValues, result.Completion.Values)
+			require.Equal(t, len(tc.expectedValues), result.Completion.Total)
+			require.False(t, result.Completion.HasMore)
+		})
+	}
+}
+
+func Test_completionResult(t *testing.T) {
+	values := make([]string, maxCompletionValues+5)
+	for i := range values {
+		values[i] = "value"
+	}
+
+	result := completionResult(values)
+	require.Len(t, result.Completion.Values, maxCompletionValues)
+	require.Equal(t, maxCompletionValues+5, result.Completion.Total)
+	require.True(t, result.Completion.HasMore)
 }
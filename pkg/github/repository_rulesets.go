@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requiredWorkflow is a workflow that must pass before a ref matched by a ruleset can be updated
+type requiredWorkflow struct {
+	Path         string `json:"path"`
+	Ref          string `json:"ref,omitempty"`
+	RepositoryID int64  `json:"repository_id,omitempty"`
+	SHA          string `json:"sha,omitempty"`
+}
+
+// requiredStatusCheck is a status check that must pass before a ref matched by a ruleset can be updated
+type requiredStatusCheck struct {
+	Context       string `json:"context"`
+	IntegrationID int64  `json:"integration_id,omitempty"`
+}
+
+// ListRepoRulesets creates a tool to list the rulesets of a repository
+func ListRepoRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_REPO_RULESETS_DESCRIPTION", "List the rulesets of a GitHub repository. Use get_repo_ruleset to see the rules of a ruleset, like the workflows and status checks it requires")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPO_RULESETS_USER_TITLE", "List repository rulesets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("Include the rulesets configured at the organization or enterprise level that apply to the repository (default true)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, ok, err := OptionalParamOK[bool](request, "includes_parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includesParents = true
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.RepositoryListRulesetsOptions{
+				IncludesParents: github.Ptr(includesParents),
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository rulesets: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(rulesets)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRepoRuleset creates a tool to get a ruleset of a repository, with the workflows and status checks it requires
+func GetRepoRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_ruleset",
+			mcp.WithDescription(t("TOOL_GET_REPO_RULESET_DESCRIPTION", "Get a ruleset of a GitHub repository with its rules. The workflows and status checks that the ruleset requires to pass are listed in required_workflows and required_status_checks")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_RULESET_USER_TITLE", "Get repository ruleset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("The ID of the ruleset"),
+			),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("Also look up the rulesets configured at the organization or enterprise level that apply to the repository (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, ok, err := OptionalParamOK[bool](request, "includes_parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includesParents = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), includesParents)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			workflows, statusChecks, strict := rulesetRequiredChecks(ruleset.Rules)
+			result := map[string]any{
+				"ruleset":                              ruleset,
+				"required_workflows":                   workflows,
+				"required_status_checks":               statusChecks,
+				"strict_required_status_checks_policy": strict,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// rulesetRequiredChecks extracts the workflows and status checks required by the rules of a ruleset.
+// The slices are never nil, so that an empty list is returned for rulesets that don't require any.
+func rulesetRequiredChecks(rules *github.RepositoryRulesetRules) ([]requiredWorkflow, []requiredStatusCheck, bool) {
+	workflows := []requiredWorkflow{}
+	statusChecks := []requiredStatusCheck{}
+	if rules == nil {
+		return workflows, statusChecks, false
+	}
+
+	if rules.Workflows != nil {
+		for _, w := range rules.Workflows.Workflows {
+			workflows = append(workflows, requiredWorkflow{
+				Path:         w.Path,
+				Ref:          w.GetRef(),
+				RepositoryID: w.GetRepositoryID(),
+				SHA:          w.GetSHA(),
+			})
+		}
+	}
+
+	strict := false
+	if rules.RequiredStatusChecks != nil {
+		strict = rules.RequiredStatusChecks.StrictRequiredStatusChecksPolicy
+		for _, c := range rules.RequiredStatusChecks.RequiredStatusChecks {
+			statusChecks = append(statusChecks, requiredStatusCheck{
+				Context:       c.Context,
+				IntegrationID: c.GetIntegrationID(),
+			})
+		}
+	}
+
+	return workflows, statusChecks, strict
+}
@@ -0,0 +1,330 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepoRulesets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoRulesets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repo_rulesets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "includes_parents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	rulesets := []*github.RepositoryRuleset{
+		{
+			ID:          github.Ptr(int64(1)),
+			Name:        "Require CI",
+			Target:      github.Ptr(github.RulesetTargetBranch),
+			SourceType:  github.Ptr(github.RulesetSourceTypeOrganization),
+			Source:      "owner",
+			Enforcement: github.RulesetEnforcementActive,
+		},
+		{
+			ID:          github.Ptr(int64(2)),
+			Name:        "Protect main",
+			Target:      github.Ptr(github.RulesetTargetBranch),
+			SourceType:  github.Ptr(github.RulesetSourceTypeRepository),
+			Source:      "owner/repo",
+			Enforcement: github.RulesetEnforcementActive,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedCount   int
+	}{
+		{
+			name: "includes parents by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "true",
+						"page":             "1",
+						"per_page":         "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, rulesets),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "repository rulesets only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "false",
+						"page":             "2",
+						"per_page":         "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, rulesets[1:]),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"includes_parents": false,
+				"page":             float64(2),
+				"perPage":          float64(10),
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository rulesets",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response []*github.RepositoryRuleset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Len(t, response, tc.expectedCount)
+		})
+	}
+}
+
+func Test_GetRepoRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repo_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "includes_parents")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	workflowRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(1)),
+		Name:        "Require CI",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		SourceType:  github.Ptr(github.RulesetSourceTypeOrganization),
+		Source:      "owner",
+		Enforcement: github.RulesetEnforcementActive,
+		Rules: &github.RepositoryRulesetRules{
+			Workflows: &github.WorkflowsRuleParameters{
+				Workflows: []*github.RuleWorkflow{
+					{
+						Path:         ".github/workflows/ci.yml",
+						Ref:          github.Ptr("refs/heads/main"),
+						RepositoryID: github.Ptr(int64(42)),
+					},
+				},
+			},
+			RequiredStatusChecks: &github.RequiredStatusChecksRuleParameters{
+				RequiredStatusChecks: []*github.RuleStatusCheck{
+					{Context: "build", IntegrationID: github.Ptr(int64(15368))},
+				},
+				StrictRequiredStatusChecksPolicy: true,
+			},
+		},
+	}
+	plainRuleset := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(2)),
+		Name:        "Protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		SourceType:  github.Ptr(github.RulesetSourceTypeRepository),
+		Source:      "owner/repo",
+		Enforcement: github.RulesetEnforcementActive,
+		Rules: &github.RepositoryRulesetRules{
+			Deletion:       &github.EmptyRuleParameters{},
+			NonFastForward: &github.EmptyRuleParameters{},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		mockedClient         *http.Client
+		requestArgs          map[string]any
+		expectError          bool
+		expectToolError      bool
+		expectedErrMsg       string
+		expectedName         string
+		expectedWorkflows    []requiredWorkflow
+		expectedStatusChecks []requiredStatusCheck
+		expectedStrict       bool
+	}{
+		{
+			name: "ruleset with a workflow rule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "true",
+					}).andThen(
+						mockResponse(t, http.StatusOK, workflowRuleset),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(1),
+			},
+			expectedName: "Require CI",
+			expectedWorkflows: []requiredWorkflow{
+				{Path: ".github/workflows/ci.yml", Ref: "refs/heads/main", RepositoryID: 42},
+			},
+			expectedStatusChecks: []requiredStatusCheck{
+				{Context: "build", IntegrationID: 15368},
+			},
+			expectedStrict: true,
+		},
+		{
+			name: "ruleset without a workflow rule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					expectQueryParams(t, map[string]string{
+						"includes_parents": "false",
+					}).andThen(
+						mockResponse(t, http.StatusOK, plainRuleset),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"ruleset_id":       float64(2),
+				"includes_parents": false,
+			},
+			expectedName:         "Protect main",
+			expectedWorkflows:    []requiredWorkflow{},
+			expectedStatusChecks: []requiredStatusCheck{},
+		},
+		{
+			name: "ruleset not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ruleset_id": float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository ruleset",
+		},
+		{
+			name:         "missing required parameter ruleset_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: ruleset_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Ruleset                          *github.RepositoryRuleset `json:"ruleset"`
+				RequiredWorkflows                []requiredWorkflow        `json:"required_workflows"`
+				RequiredStatusChecks             []requiredStatusCheck     `json:"required_status_checks"`
+				StrictRequiredStatusChecksPolicy bool                      `json:"strict_required_status_checks_policy"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedName, response.Ruleset.Name)
+			assert.Equal(t, tc.expectedWorkflows, response.RequiredWorkflows)
+			assert.Equal(t, tc.expectedStatusChecks, response.RequiredStatusChecks)
+			assert.Equal(t, tc.expectedStrict, response.StrictRequiredStatusChecksPolicy)
+		})
+	}
+}
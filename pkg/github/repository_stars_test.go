@@ -0,0 +1,328 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListStargazers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStargazers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_stargazers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	starredAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectError        bool
+		expectedErrMsg     string
+		expectedStargazers []stargazer
+	}{
+		{
+			name: "stargazers with when they starred",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						// starred_at is only returned for the star media type
+						assert.Equal(t, "application/vnd.github.v3.star+json", r.Header.Get("Accept"))
+						assert.Equal(t, "2", r.URL.Query().Get("page"))
+						mockResponse(t, http.StatusOK, []map[string]any{
+							{
+								"starred_at": starredAt,
+								"user":       map[string]any{"login": "octocat", "html_url": "https://github.com/octocat"},
+							},
+						})(w, r)
+					}),
+				),
+			),
+			expectedStargazers: []stargazer{{
+				Login:      "octocat",
+				ProfileURL: "https://github.com/octocat",
+				StarredAt:  &starredAt,
+			}},
+		},
+		{
+			name: "repository without stargazers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposStargazersByOwnerByRepo,
+					[]*github.Stargazer{},
+				),
+			),
+			expectedStargazers: []stargazer{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list stargazers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListStargazers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  float64(2),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var stargazers []stargazer
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &stargazers))
+			assert.Equal(t, tc.expectedStargazers, stargazers)
+		})
+	}
+}
+
+func Test_ListWatchers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_watchers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposSubscribersByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{
+						Login:   github.Ptr("octocat"),
+						ID:      github.Ptr(int64(1)),
+						HTMLURL: github.Ptr("https://github.com/octocat"),
+					},
+				}),
+			),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	_, handler := ListWatchers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var watchers []MinimalUser
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watchers))
+	assert.Equal(t, []MinimalUser{{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat"}}, watchers)
+}
+
+func Test_IsRepositoryStarred(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := IsRepositoryStarred(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "is_repository_starred", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedStarred bool
+	}{
+		{
+			name: "starred",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			expectedStarred: true,
+		},
+		{
+			name: "not starred",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectedStarred: false,
+		},
+		{
+			name: "check fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusUnauthorized, map[string]string{"message": "Requires authentication"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to check whether the repository is starred",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := IsRepositoryStarred(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "owner/repo", response["repository"])
+			assert.Equal(t, tc.expectedStarred, response["starred"])
+		})
+	}
+}
+
+func Test_StarRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "star_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "star a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserStarredByOwnerByRepo,
+					expectPath(t, "/user/starred/owner/repo").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutUserStarredByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to star repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := StarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Repository has been starred", response["message"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
+
+func Test_UnstarRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UnstarRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unstar_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteUserStarredByOwnerByRepo,
+			expectPath(t, "/user/starred/owner/repo").andThen(
+				mockResponse(t, http.StatusNoContent, nil),
+			),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	_, handler := UnstarRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, "Repository has been unstarred", response["message"])
+	assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+}
@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var commitStatusStates = []string{"error", "failure", "pending", "success"}
+
+// maxCommitStatusDescription is the longest description the API accepts for a commit status
+const maxCommitStatusDescription = 140
+
+// commitStatus is a status set on a commit by an external system
+type commitStatus struct {
+	ID          int64      `json:"id"`
+	Context     string     `json:"context"`
+	State       string     `json:"state"`
+	Description string     `json:"description,omitempty"`
+	TargetURL   string     `json:"target_url,omitempty"`
+	Creator     string     `json:"creator,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+func newCommitStatus(status *github.RepoStatus) commitStatus {
+	result := commitStatus{
+		ID:          status.GetID(),
+		Context:     status.GetContext(),
+		State:       status.GetState(),
+		Description: status.GetDescription(),
+		TargetURL:   status.GetTargetURL(),
+		Creator:     status.GetCreator().GetLogin(),
+	}
+	if status.CreatedAt != nil {
+		result.CreatedAt = &status.CreatedAt.Time
+	}
+	if status.UpdatedAt != nil {
+		result.UpdatedAt = &status.UpdatedAt.Time
+	}
+	return result
+}
+
+func newCommitStatuses(statuses []*github.RepoStatus) []commitStatus {
+	result := make([]commitStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, newCommitStatus(status))
+	}
+	return result
+}
+
+// ListCommitStatuses creates a tool to list the statuses of a commit along with their combined state
+func ListCommitStatuses(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_statuses",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_STATUSES_DESCRIPTION", "List the statuses external systems set on a commit of a GitHub repository. Returns the combined state of the commit and the latest status of each context, along with every status set, most recent first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COMMIT_STATUSES_USER_TITLE", "List commit statuses"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The SHA of the commit, or a branch or tag name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			combined, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get combined status: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			statuses, resp, err := client.Repositories.ListStatuses(ctx, owner, repo, ref, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commit statuses: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// The combined status only has the latest status of each context, which is what its state rolls up
+			counts := map[string]int{}
+			for _, state := range commitStatusStates {
+				counts[state] = 0
+			}
+			for _, status := range combined.Statuses {
+				counts[status.GetState()]++
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"sha":         combined.GetSHA(),
+				"state":       combined.GetState(),
+				"total_count": combined.GetTotalCount(),
+				"counts":      counts,
+				"latest":      newCommitStatuses(combined.Statuses),
+				"statuses":    newCommitStatuses(statuses),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateCommitStatus creates a tool to set a status on a commit
+func CreateCommitStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_status",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_STATUS_DESCRIPTION", "Set a status on a commit of a GitHub repository. A new status replaces the previous status of the same context")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_STATUS_USER_TITLE", "Create commit status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The SHA of the commit"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("The state of the status"),
+				mcp.Enum(commitStatusStates...),
+			),
+			mcp.WithString("context",
+				mcp.Description("The label telling this status apart from the statuses of other systems, like ci/build (default \"default\")"),
+			),
+			mcp.WithString("description",
+				mcp.Description("A short description of the status, at most 140 characters"),
+				mcp.MaxLength(maxCommitStatusDescription),
+			),
+			mcp.WithString("target_url",
+				mcp.Description("The URL of the details of the status"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(commitStatusStates, state) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state: %s, must be one of error, failure, pending or success", state)), nil
+			}
+			statusContext, err := OptionalParam[string](request, "context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if n := utf8.RuneCountInString(description); n > maxCommitStatusDescription {
+				return mcp.NewToolResultError(fmt.Sprintf("description is %d characters, more than the maximum of %d characters", n, maxCommitStatusDescription)), nil
+			}
+			targetURL, err := OptionalParam[string](request, "target_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			status := &github.RepoStatus{State: github.Ptr(state)}
+			if statusContext != "" {
+				status.Context = github.Ptr(statusContext)
+			}
+			if description != "" {
+				status.Description = github.Ptr(description)
+			}
+			if targetURL != "" {
+				status.TargetURL = github.Ptr(targetURL)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			created, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create commit status: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newCommitStatus(created))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,313 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCommitStatuses(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitStatuses(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_commit_statuses", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	build := &github.RepoStatus{
+		ID:          github.Ptr(int64(3)),
+		Context:     github.Ptr("ci/build"),
+		State:       github.Ptr("failure"),
+		Description: github.Ptr("Build failed"),
+		TargetURL:   github.Ptr("https://ci.example.com/builds/3"),
+		Creator:     &github.User{Login: github.Ptr("ci-bot")},
+	}
+	review := &github.RepoStatus{
+		ID:      github.Ptr(int64(2)),
+		Context: github.Ptr("agent-review/approved"),
+		State:   github.Ptr("success"),
+	}
+	pendingBuild := &github.RepoStatus{
+		ID:      github.Ptr(int64(1)),
+		Context: github.Ptr("ci/build"),
+		State:   github.Ptr("pending"),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedState    string
+		expectedCounts   map[string]int
+		expectedLatest   []string
+		expectedStatuses []int64
+	}{
+		{
+			name: "failing status rolls up the combined state",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/main/status").andThen(
+						mockResponse(t, http.StatusOK, &github.CombinedStatus{
+							SHA:        github.Ptr("abc123"),
+							State:      github.Ptr("failure"),
+							TotalCount: github.Ptr(2),
+							Statuses:   []*github.RepoStatus{build, review},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepoStatus{build, review, pendingBuild}),
+					),
+				),
+			),
+			expectedState:    "failure",
+			expectedCounts:   map[string]int{"error": 0, "failure": 1, "pending": 0, "success": 1},
+			expectedLatest:   []string{"ci/build", "agent-review/approved"},
+			expectedStatuses: []int64{3, 2, 1},
+		},
+		{
+			name: "commit without statuses",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{SHA: github.Ptr("abc123"), State: github.Ptr("pending"), TotalCount: github.Ptr(0)},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+					[]*github.RepoStatus{},
+				),
+			),
+			expectedState:    "pending",
+			expectedCounts:   map[string]int{"error": 0, "failure": 0, "pending": 0, "success": 0},
+			expectedLatest:   []string{},
+			expectedStatuses: []int64{},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "No commit found for SHA: main"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitStatuses(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				SHA      string         `json:"sha"`
+				State    string         `json:"state"`
+				Counts   map[string]int `json:"counts"`
+				Latest   []commitStatus `json:"latest"`
+				Statuses []commitStatus `json:"statuses"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "abc123", response.SHA)
+			assert.Equal(t, tc.expectedState, response.State)
+			assert.Equal(t, tc.expectedCounts, response.Counts)
+
+			latest := []string{}
+			for _, status := range response.Latest {
+				latest = append(latest, status.Context)
+			}
+			assert.Equal(t, tc.expectedLatest, latest)
+
+			ids := []int64{}
+			for _, status := range response.Statuses {
+				ids = append(ids, status.ID)
+			}
+			assert.Equal(t, tc.expectedStatuses, ids)
+		})
+	}
+}
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        commitStatus
+	}{
+		{
+			name: "approve a commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectPath(t, "/repos/owner/repo/statuses/abc123").andThen(
+						expectRequestBody(t, map[string]any{
+							"state":       "success",
+							"context":     "agent-review/approved",
+							"description": "Reviewed by the agent",
+							"target_url":  "https://example.com/reviews/1",
+						}).andThen(
+							mockResponse(t, http.StatusCreated, &github.RepoStatus{
+								ID:          github.Ptr(int64(7)),
+								Context:     github.Ptr("agent-review/approved"),
+								State:       github.Ptr("success"),
+								Description: github.Ptr("Reviewed by the agent"),
+								TargetURL:   github.Ptr("https://example.com/reviews/1"),
+								Creator:     &github.User{Login: github.Ptr("agent")},
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state":       "success",
+				"context":     "agent-review/approved",
+				"description": "Reviewed by the agent",
+				"target_url":  "https://example.com/reviews/1",
+			},
+			expected: commitStatus{
+				ID:          7,
+				Context:     "agent-review/approved",
+				State:       "success",
+				Description: "Reviewed by the agent",
+				TargetURL:   "https://example.com/reviews/1",
+				Creator:     "agent",
+			},
+		},
+		{
+			name: "only the state",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]any{
+						"state": "pending",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:      github.Ptr(int64(8)),
+							Context: github.Ptr("default"),
+							State:   github.Ptr("pending"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"state": "pending",
+			},
+			expected: commitStatus{
+				ID:      8,
+				Context: "default",
+				State:   "pending",
+			},
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state": "approved",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid state: approved, must be one of error, failure, pending or success",
+		},
+		{
+			name:         "description too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"state":       "failure",
+				"description": strings.Repeat("é", 141),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "description is 141 characters, more than the maximum of 140 characters",
+		},
+		{
+			name: "creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "No commit found for SHA: abc123"}),
+				),
+			),
+			requestArgs: map[string]any{
+				"state": "success",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var status commitStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
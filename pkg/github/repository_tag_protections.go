@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// tagProtectionPattern matches the tag name patterns GitHub accepts: no whitespace and none of the characters git
+// forbids in ref names, the glob characters * ? and [ are allowed
+const tagProtectionPattern = `^[^\s~^:\\]{1,255}$`
+
+var tagProtectionPatternRegexp = regexp.MustCompile(tagProtectionPattern)
+
+// tagProtectionError turns a 403 into a tool error, managing tag protections requires admin access to the repository
+func tagProtectionError(owner, repo, what string, resp *github.Response, err error) (*mcp.CallToolResult, error) {
+	if resp != nil && resp.StatusCode == http.StatusForbidden {
+		return mcp.NewToolResultError(fmt.Sprintf("not allowed to manage the tag protections of %s/%s, it requires admin access to the repository: %s", owner, repo, err.Error())), nil
+	}
+	return nil, fmt.Errorf("failed to %s: %w", what, err)
+}
+
+// ListTagProtections creates a tool to list the tag protections of a repository
+func ListTagProtections(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_tag_protections",
+			mcp.WithDescription(t("TOOL_LIST_TAG_PROTECTIONS_DESCRIPTION", "List the tag protections of a GitHub repository, the patterns of the tags only users with admin or maintain access can create or delete. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TAG_PROTECTIONS_USER_TITLE", "List tag protections"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protections, resp, err := client.Repositories.ListTagProtection(ctx, owner, repo) //nolint:staticcheck // rulesets replace tag protections, which are still served for existing repositories
+			if err != nil {
+				return tagProtectionError(owner, repo, "list tag protections", resp, err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if protections == nil {
+				protections = []*github.TagProtection{}
+			}
+			r, err := json.Marshal(protections)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateTagProtection creates a tool to protect the tags of a repository matching a pattern
+func CreateTagProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag_protection",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_PROTECTION_DESCRIPTION", "Protect the tags of a GitHub repository matching a pattern, only users with admin or maintain access can then create or delete them. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TAG_PROTECTION_USER_TITLE", "Create tag protection"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description("The pattern of the tags to protect, with * ? and [] wildcards, like v*"),
+				mcp.Pattern(tagProtectionPattern),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := RequiredParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !tagProtectionPatternRegexp.MatchString(pattern) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern: %q, it must have at most 255 characters and no whitespace or ~ ^ : \\ characters", pattern)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.CreateTagProtection(ctx, owner, repo, pattern) //nolint:staticcheck // rulesets replace tag protections, which are still served for existing repositories
+			if err != nil {
+				return tagProtectionError(owner, repo, "create tag protection", resp, err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(protection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteTagProtection creates a tool to delete a tag protection of a repository
+func DeleteTagProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_tag_protection",
+			mcp.WithDescription(t("TOOL_DELETE_TAG_PROTECTION_DESCRIPTION", "Delete a tag protection of a GitHub repository, anyone with write access can then create or delete the tags it matched. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_TAG_PROTECTION_USER_TITLE", "Delete tag protection"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("tag_protection_id",
+				mcp.Required(),
+				mcp.Description("The ID of the tag protection, as returned by list_tag_protections"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			protectionID, err := RequiredInt(request, "tag_protection_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteTagProtection(ctx, owner, repo, int64(protectionID)) //nolint:staticcheck // rulesets replace tag protections, which are still served for existing repositories
+			if err != nil {
+				return tagProtectionError(owner, repo, "delete tag protection", resp, err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":           "Tag protection has been deleted",
+				"tag_protection_id": protectionID,
+				"status":            resp.Status,
+				"status_code":       resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
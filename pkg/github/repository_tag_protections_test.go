@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	getReposTagsProtectionByOwnerByRepo = mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/tags/protection",
+		Method:  "GET",
+	}
+	postReposTagsProtectionByOwnerByRepo = mock.EndpointPattern{
+		Pattern: "/repos/{owner}/{repo}/tags/protection",
+		Method:  "POST",
+	}
+)
+
+func mockAdminRequired(t *testing.T) http.HandlerFunc {
+	return mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."})
+}
+
+func Test_ListTagProtections(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTagProtections(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_tag_protections", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        []*github.TagProtection
+	}{
+		{
+			name: "repository with tag protections",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getReposTagsProtectionByOwnerByRepo,
+					mockResponse(t, http.StatusOK, []*github.TagProtection{
+						{ID: github.Ptr(int64(1)), Pattern: github.Ptr("v*")},
+					}),
+				),
+			),
+			expected: []*github.TagProtection{
+				{ID: github.Ptr(int64(1)), Pattern: github.Ptr("v*")},
+			},
+		},
+		{
+			name: "repository without tag protections",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getReposTagsProtectionByOwnerByRepo,
+					mockResponse(t, http.StatusOK, []*github.TagProtection{}),
+				),
+			),
+			expected: []*github.TagProtection{},
+		},
+		{
+			name: "token without admin rights",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getReposTagsProtectionByOwnerByRepo,
+					mockAdminRequired(t),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "not allowed to manage the tag protections of owner/repo, it requires admin access to the repository",
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					getReposTagsProtectionByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list tag protections",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTagProtections(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var protections []*github.TagProtection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protections))
+			assert.Equal(t, tc.expected, protections)
+		})
+	}
+}
+
+func Test_CreateTagProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTagProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tag_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pattern"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		pattern         string
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        *github.TagProtection
+	}{
+		{
+			name: "protect release tags",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postReposTagsProtectionByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"pattern": "v*",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.TagProtection{ID: github.Ptr(int64(2)), Pattern: github.Ptr("v*")}),
+					),
+				),
+			),
+			pattern:  "v*",
+			expected: &github.TagProtection{ID: github.Ptr(int64(2)), Pattern: github.Ptr("v*")},
+		},
+		{
+			name:            "pattern with whitespace",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			pattern:         "v 1.*",
+			expectToolError: true,
+			expectedErrMsg:  `invalid pattern: "v 1.*"`,
+		},
+		{
+			name:            "pattern with a character git forbids",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			pattern:         "release:*",
+			expectToolError: true,
+			expectedErrMsg:  `invalid pattern: "release:*"`,
+		},
+		{
+			name: "token without admin rights",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postReposTagsProtectionByOwnerByRepo,
+					mockAdminRequired(t),
+				),
+			),
+			pattern:         "v*",
+			expectToolError: true,
+			expectedErrMsg:  "not allowed to manage the tag protections of owner/repo, it requires admin access to the repository",
+		},
+		{
+			name: "creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					postReposTagsProtectionByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, map[string]string{"message": "Internal Server Error"}),
+				),
+			),
+			pattern:        "v*",
+			expectError:    true,
+			expectedErrMsg: "failed to create tag protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTagProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": tc.pattern,
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var protection github.TagProtection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protection))
+			assert.Equal(t, tc.expected, &protection)
+		})
+	}
+}
+
+func Test_DeleteTagProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteTagProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_tag_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_protection_id"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "delete a tag protection",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposTagsProtectionByOwnerByRepoByTagProtectionId,
+					expectPath(t, "/repos/owner/repo/tags/protection/2").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "token without admin rights",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposTagsProtectionByOwnerByRepoByTagProtectionId,
+					mockAdminRequired(t),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "not allowed to manage the tag protections of owner/repo, it requires admin access to the repository",
+		},
+		{
+			name: "tag protection not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposTagsProtectionByOwnerByRepoByTagProtectionId,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to delete tag protection",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteTagProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"tag_protection_id": float64(2),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, float64(2), response["tag_protection_id"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// topicPattern matches the topics GitHub accepts: lowercase letters, numbers and hyphens, starting with a
+// letter or a number, and at most 50 characters
+var topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// ListRepositoryTopics creates a tool to list the topics of a repository
+func ListRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_topics",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_TOPICS_DESCRIPTION", "List the topics of a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_TOPICS_USER_TITLE", "List repository topics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			topics, resp, err := client.Repositories.ListAllTopics(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository topics: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if topics == nil {
+				topics = []string{}
+			}
+			r, err := json.Marshal(map[string]any{
+				"topics": topics,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReplaceRepositoryTopics creates a tool to replace the topics of a repository, or to add and remove some of them
+func ReplaceRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("replace_repository_topics",
+			mcp.WithDescription(t("TOOL_REPLACE_REPOSITORY_TOPICS_DESCRIPTION", "Replace all the topics of a GitHub repository with topics, or add and remove some topics while keeping the others. Topics are lowercased, and must contain only letters, numbers and hyphens, start with a letter or a number, and have at most 50 characters")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPLACE_REPOSITORY_TOPICS_USER_TITLE", "Replace repository topics"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithArray("topics",
+				mcp.Description("All the topics of the repository, an empty array removes all topics. Cannot be combined with add or remove"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithArray("add",
+				mcp.Description("Topics to add to the current topics"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithArray("remove",
+				mcp.Description("Topics to remove from the current topics"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasTopics := request.GetArguments()["topics"]
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			add, err := OptionalStringArrayParam(request, "add")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			remove, err := OptionalStringArrayParam(request, "remove")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			isDelta := len(add) > 0 || len(remove) > 0
+			if hasTopics && isDelta {
+				return mcp.NewToolResultError("topics cannot be combined with add or remove"), nil
+			}
+			if !hasTopics && !isDelta {
+				return mcp.NewToolResultError("either topics, or add or remove must be given"), nil
+			}
+
+			// Only the topics to set are validated, removing a topic that doesn't exist is harmless
+			topics, invalid := normalizeTopics(append(topics, add...))
+			if len(invalid) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid topics: %s. Topics must contain only letters, numbers and hyphens, start with a letter or a number, and have at most 50 characters", strings.Join(invalid, ", "))), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if isDelta {
+				current, resp, err := client.Repositories.ListAllTopics(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list repository topics: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				removed, _ := normalizeTopics(remove)
+				merged := make([]string, 0, len(current)+len(topics))
+				for _, topic := range append(current, topics...) {
+					if !slices.Contains(removed, topic) && !slices.Contains(merged, topic) {
+						merged = append(merged, topic)
+					}
+				}
+				topics = merged
+			}
+
+			replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+			if err != nil {
+				return nil, fmt.Errorf("failed to replace repository topics: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if replaced == nil {
+				replaced = []string{}
+			}
+			r, err := json.Marshal(map[string]any{
+				"topics": replaced,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// normalizeTopics lowercases and deduplicates topics, keeping their order, and returns the topics GitHub
+// would reject separately.
+func normalizeTopics(topics []string) (normalized []string, invalid []string) {
+	normalized = make([]string, 0, len(topics))
+	for _, original := range topics {
+		topic := strings.ToLower(strings.TrimSpace(original))
+		if !topicPattern.MatchString(topic) {
+			invalid = append(invalid, fmt.Sprintf("%q", original))
+			continue
+		}
+		if !slices.Contains(normalized, topic) {
+			normalized = append(normalized, topic)
+		}
+	}
+	return normalized, invalid
+}
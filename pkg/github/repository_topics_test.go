@@ -0,0 +1,301 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTopics(t *testing.T, names ...string) http.HandlerFunc {
+	return mockResponse(t, http.StatusOK, map[string]any{"names": names})
+}
+
+func Test_ListRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedTopics  []string
+	}{
+		{
+			name: "repository with topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTopicsByOwnerByRepo,
+					mockTopics(t, "go", "mcp"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedTopics: []string{"go", "mcp"},
+		},
+		{
+			name: "repository without topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTopicsByOwnerByRepo,
+					mockTopics(t),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedTopics: []string{},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTopicsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository topics",
+		},
+		{
+			name:         "missing required parameter repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Topics []string `json:"topics"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedTopics, response.Topics)
+		})
+	}
+}
+
+func Test_ReplaceRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReplaceRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "replace_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedTopics  []string
+	}{
+		{
+			name: "replace all topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"names": []any{"go", "mcp-server"},
+					}).andThen(
+						mockTopics(t, "go", "mcp-server"),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []any{"Go", " mcp-server ", "go"},
+			},
+			expectedTopics: []string{"go", "mcp-server"},
+		},
+		{
+			name: "remove all topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"names": []any{},
+					}).andThen(
+						mockTopics(t),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []any{},
+			},
+			expectedTopics: []string{},
+		},
+		{
+			name: "add and remove topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTopicsByOwnerByRepo,
+					mockTopics(t, "go", "legacy", "mcp"),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"names": []any{"go", "mcp", "github"},
+					}).andThen(
+						mockTopics(t, "go", "mcp", "github"),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"add":    []any{"GitHub", "mcp"},
+				"remove": []any{"Legacy", "unknown"},
+			},
+			expectedTopics: []string{"go", "mcp", "github"},
+		},
+		{
+			name: "listing the current topics fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTopicsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"add":   []any{"go"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository topics",
+		},
+		{
+			name:         "invalid topics",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []any{"go", "model context", "-mcp", "a123456789a123456789a123456789a123456789a123456789a"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  `invalid topics: "model context", "-mcp", "a123456789a123456789a123456789a123456789a123456789a"`,
+		},
+		{
+			name:         "invalid topics to add",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"add":   []any{"c++"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  `invalid topics: "c++"`,
+		},
+		{
+			name:         "topics combined with add",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"topics": []any{"go"},
+				"add":    []any{"mcp"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "topics cannot be combined with add or remove",
+		},
+		{
+			name:         "nothing to change",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "either topics, or add or remove must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReplaceRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Topics []string `json:"topics"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedTopics, response.Topics)
+		})
+	}
+}
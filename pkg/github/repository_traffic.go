@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var trafficPeriods = []string{"day", "week"}
+
+// GetRepositoryTraffic creates a tool to get the traffic of a repository over the last 14 days
+func GetRepositoryTraffic(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_traffic",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TRAFFIC_DESCRIPTION", "Get the traffic of a GitHub repository over the last 14 days: its views and clones, its most popular paths and its top referrers. Requires push access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TRAFFIC_USER_TITLE", "Get repository traffic"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("per",
+				mcp.Description("The period the views and clones are counted per (default day)"),
+				mcp.Enum(trafficPeriods...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			per, err := OptionalParam[string](request, "per")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if per == "" {
+				per = "day"
+			}
+			if !slices.Contains(trafficPeriods, per) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid per: %s, must be day or week", per)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The traffic endpoints all need push access, a 403 is a permission problem rather than a failure
+			trafficError := func(what string, resp *github.Response, err error) (*mcp.CallToolResult, error) {
+				if resp != nil && resp.StatusCode == http.StatusForbidden {
+					return mcp.NewToolResultError(fmt.Sprintf("not allowed to get the traffic of %s/%s, it requires push access to the repository: %s", owner, repo, err.Error())), nil
+				}
+				return nil, fmt.Errorf("failed to get repository traffic %s: %w", what, err)
+			}
+
+			breakdown := &github.TrafficBreakdownOptions{Per: per}
+			views, resp, err := client.Repositories.ListTrafficViews(ctx, owner, repo, breakdown)
+			if err != nil {
+				return trafficError("views", resp, err)
+			}
+			_ = resp.Body.Close()
+
+			clones, resp, err := client.Repositories.ListTrafficClones(ctx, owner, repo, breakdown)
+			if err != nil {
+				return trafficError("clones", resp, err)
+			}
+			_ = resp.Body.Close()
+
+			paths, resp, err := client.Repositories.ListTrafficPaths(ctx, owner, repo)
+			if err != nil {
+				return trafficError("paths", resp, err)
+			}
+			_ = resp.Body.Close()
+
+			referrers, resp, err := client.Repositories.ListTrafficReferrers(ctx, owner, repo)
+			if err != nil {
+				return trafficError("referrers", resp, err)
+			}
+			_ = resp.Body.Close()
+
+			if paths == nil {
+				paths = []*github.TrafficPath{}
+			}
+			if referrers == nil {
+				referrers = []*github.TrafficReferrer{}
+			}
+			r, err := json.Marshal(map[string]any{
+				"per":               per,
+				"views":             views,
+				"clones":            clones,
+				"popular_paths":     paths,
+				"popular_referrers": referrers,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTraffic(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTraffic(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_traffic", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "per")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	week := &github.Timestamp{Time: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)}
+	views := &github.TrafficViews{
+		Count:   github.Ptr(300),
+		Uniques: github.Ptr(40),
+		Views: []*github.TrafficData{
+			{Timestamp: week, Count: github.Ptr(300), Uniques: github.Ptr(40)},
+		},
+	}
+	clones := &github.TrafficClones{
+		Count:   github.Ptr(25),
+		Uniques: github.Ptr(5),
+		Clones: []*github.TrafficData{
+			{Timestamp: week, Count: github.Ptr(25), Uniques: github.Ptr(5)},
+		},
+	}
+	paths := []*github.TrafficPath{
+		{Path: github.Ptr("/owner/repo"), Title: github.Ptr("owner/repo"), Count: github.Ptr(200), Uniques: github.Ptr(30)},
+	}
+	referrers := []*github.TrafficReferrer{
+		{Referrer: github.Ptr("github.com"), Count: github.Ptr(120), Uniques: github.Ptr(20)},
+		{Referrer: github.Ptr("google.com"), Count: github.Ptr(60), Uniques: github.Ptr(15)},
+	}
+
+	// The views and clones are counted per the given period
+	trafficEndpoints := func(per string) []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.GetReposTrafficViewsByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"per": per}).andThen(
+					mockResponse(t, http.StatusOK, views),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposTrafficClonesByOwnerByRepo,
+				expectQueryParams(t, map[string]string{"per": per}).andThen(
+					mockResponse(t, http.StatusOK, clones),
+				),
+			),
+			mock.WithRequestMatch(
+				mock.GetReposTrafficPopularPathsByOwnerByRepo,
+				paths,
+			),
+			mock.WithRequestMatch(
+				mock.GetReposTrafficPopularReferrersByOwnerByRepo,
+				referrers,
+			),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedPer     string
+	}{
+		{
+			name:         "daily traffic by default",
+			mockedClient: mock.NewMockedHTTPClient(trafficEndpoints("day")...),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedPer: "day",
+		},
+		{
+			name:         "weekly traffic",
+			mockedClient: mock.NewMockedHTTPClient(trafficEndpoints("week")...),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"per":   "week",
+			},
+			expectedPer: "week",
+		},
+		{
+			name: "no push access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTrafficViewsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have push access to repository"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "not allowed to get the traffic of owner/repo, it requires push access to the repository",
+		},
+		{
+			name: "referrers fail",
+			mockedClient: mock.NewMockedHTTPClient(
+				append(trafficEndpoints("day")[:3],
+					mock.WithRequestMatchHandler(
+						mock.GetReposTrafficPopularReferrersByOwnerByRepo,
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusInternalServerError)
+							_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+						}),
+					),
+				)...,
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository traffic referrers",
+		},
+		{
+			name:         "invalid period",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"per":   "month",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid per: month, must be day or week",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTraffic(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Per              string                    `json:"per"`
+				Views            *github.TrafficViews      `json:"views"`
+				Clones           *github.TrafficClones     `json:"clones"`
+				PopularPaths     []*github.TrafficPath     `json:"popular_paths"`
+				PopularReferrers []*github.TrafficReferrer `json:"popular_referrers"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedPer, response.Per)
+			assert.Equal(t, views, response.Views)
+			assert.Equal(t, clones, response.Clones)
+			assert.Equal(t, paths, response.PopularPaths)
+			assert.Equal(t, referrers, response.PopularReferrers)
+		})
+	}
+}
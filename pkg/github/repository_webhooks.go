@@ -0,0 +1,455 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var webhookContentTypes = []string{"json", "form"}
+
+// webhook is a webhook of a repository. The secret of the webhook is never part of it, only whether it has one.
+type webhook struct {
+	ID           int64            `json:"id"`
+	URL          string           `json:"url"`
+	ContentType  string           `json:"content_type,omitempty"`
+	InsecureSSL  bool             `json:"insecure_ssl"`
+	HasSecret    bool             `json:"has_secret"`
+	Events       []string         `json:"events"`
+	Active       bool             `json:"active"`
+	LastResponse *webhookResponse `json:"last_response,omitempty"`
+	CreatedAt    *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time       `json:"updated_at,omitempty"`
+}
+
+// webhookResponse is the response of the receiver of a webhook to its last delivery
+type webhookResponse struct {
+	Code    int    `json:"code,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// webhookDelivery is a delivery of a webhook
+type webhookDelivery struct {
+	ID          int64      `json:"id"`
+	Event       string     `json:"event"`
+	Action      string     `json:"action,omitempty"`
+	Status      string     `json:"status"`
+	StatusCode  int        `json:"status_code"`
+	Redelivery  bool       `json:"redelivery"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+}
+
+func newWebhook(hook *github.Hook) webhook {
+	events := hook.Events
+	if events == nil {
+		events = []string{}
+	}
+	config := hook.GetConfig()
+	result := webhook{
+		ID:          hook.GetID(),
+		URL:         config.GetURL(),
+		ContentType: config.GetContentType(),
+		InsecureSSL: config.GetInsecureSSL() == "1",
+		HasSecret:   config.GetSecret() != "",
+		Events:      events,
+		Active:      hook.GetActive(),
+	}
+	if hook.LastResponse != nil {
+		response := &webhookResponse{}
+		if code, ok := hook.LastResponse["code"].(float64); ok {
+			response.Code = int(code)
+		}
+		response.Status, _ = hook.LastResponse["status"].(string)
+		response.Message, _ = hook.LastResponse["message"].(string)
+		result.LastResponse = response
+	}
+	if hook.CreatedAt != nil {
+		result.CreatedAt = &hook.CreatedAt.Time
+	}
+	if hook.UpdatedAt != nil {
+		result.UpdatedAt = &hook.UpdatedAt.Time
+	}
+	return result
+}
+
+// ListRepositoryWebhooks creates a tool to list the webhooks of a repository
+func ListRepositoryWebhooks(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_webhooks",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_WEBHOOKS_DESCRIPTION", "List the webhooks of a GitHub repository, with the events they are sent for and the last response of their receiver")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_WEBHOOKS_USER_TITLE", "List repository webhooks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hooks, resp, err := client.Repositories.ListHooks(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository webhooks: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			webhooks := make([]webhook, 0, len(hooks))
+			for _, hook := range hooks {
+				webhooks = append(webhooks, newWebhook(hook))
+			}
+
+			r, err := json.Marshal(webhooks)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetRepositoryWebhook creates a tool to get a webhook of a repository with its last delivery
+func GetRepositoryWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_webhook",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_WEBHOOK_DESCRIPTION", "Get a webhook of a GitHub repository with its last delivery, including the status code its receiver responded with. Useful to find out why a webhook didn't trigger anything")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_WEBHOOK_USER_TITLE", "Get repository webhook"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hook, resp, err := client.Repositories.GetHook(ctx, owner, repo, int64(hookID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository webhook: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			// The deliveries are listed most recent first
+			deliveries, resp, err := client.Repositories.ListHookDeliveries(ctx, owner, repo, int64(hookID), &github.ListCursorOptions{PerPage: 1})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository webhook deliveries: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := map[string]any{
+				"webhook":       newWebhook(hook),
+				"last_delivery": nil,
+			}
+			if len(deliveries) > 0 {
+				delivery := deliveries[0]
+				lastDelivery := webhookDelivery{
+					ID:         delivery.GetID(),
+					Event:      delivery.GetEvent(),
+					Action:     delivery.GetAction(),
+					Status:     delivery.GetStatus(),
+					StatusCode: delivery.GetStatusCode(),
+					Redelivery: delivery.GetRedelivery(),
+				}
+				if delivery.DeliveredAt != nil {
+					lastDelivery.DeliveredAt = &delivery.DeliveredAt.Time
+				}
+				result["last_delivery"] = lastDelivery
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// CreateRepositoryWebhook creates a tool to create a webhook in a repository
+func CreateRepositoryWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_webhook",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_WEBHOOK_DESCRIPTION", "Create a webhook in a GitHub repository, sending the given events to a URL. GitHub sends a ping event to the URL once it is created")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_WEBHOOK_USER_TITLE", "Create repository webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("url",
+				mcp.Required(),
+				mcp.Description("The URL the payloads are sent to"),
+			),
+			mcp.WithString("content_type",
+				mcp.Description("The media type of the payloads (default json)"),
+				mcp.Enum(webhookContentTypes...),
+			),
+			mcp.WithString("secret",
+				mcp.Description("The secret the payloads are signed with, in the X-Hub-Signature-256 header"),
+			),
+			mcp.WithArray("events",
+				mcp.Description("The events the webhook is sent for, like push or pull_request (default push). Use * for all events"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithBoolean("active",
+				mcp.Description("Whether the webhook is sent (default true)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			url, err := RequiredParam[string](request, "url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			contentType, err := OptionalParam[string](request, "content_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if contentType == "" {
+				contentType = "json"
+			}
+			if !slices.Contains(webhookContentTypes, contentType) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid content_type: %s, must be json or form", contentType)), nil
+			}
+			secret, err := OptionalParam[string](request, "secret")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			events, err := OptionalStringArrayParam(request, "events")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(events) == 0 {
+				events = []string{"push"}
+			}
+			active, ok, err := OptionalParamOK[bool](request, "active")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				active = true
+			}
+
+			config := &github.HookConfig{
+				URL:         github.Ptr(url),
+				ContentType: github.Ptr(contentType),
+			}
+			if secret != "" {
+				config.Secret = github.Ptr(secret)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			hook, resp, err := client.Repositories.CreateHook(ctx, owner, repo, &github.Hook{
+				Config: config,
+				Events: events,
+				Active: github.Ptr(active),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create repository webhook: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(newWebhook(hook))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRepositoryWebhook creates a tool to delete a webhook of a repository
+func DeleteRepositoryWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository_webhook",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_WEBHOOK_DESCRIPTION", "Delete a webhook of a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPOSITORY_WEBHOOK_USER_TITLE", "Delete repository webhook"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.DeleteHook(ctx, owner, repo, int64(hookID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete repository webhook: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":     "Webhook has been deleted",
+				"hook_id":     hookID,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// PingRepositoryWebhook creates a tool to send a ping event to a webhook of a repository
+func PingRepositoryWebhook(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("ping_repository_webhook",
+			mcp.WithDescription(t("TOOL_PING_REPOSITORY_WEBHOOK_DESCRIPTION", "Send a ping event to a webhook of a GitHub repository, to check that its receiver is reachable. Use get_repository_webhook afterwards to see the response of the receiver")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PING_REPOSITORY_WEBHOOK_USER_TITLE", "Ping repository webhook"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("hook_id",
+				mcp.Required(),
+				mcp.Description("The ID of the webhook"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			hookID, err := RequiredInt(request, "hook_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Repositories.PingHook(ctx, owner, repo, int64(hookID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to ping repository webhook: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(map[string]any{
+				"message":     "Ping event has been sent to the webhook",
+				"hook_id":     hookID,
+				"status":      resp.Status,
+				"status_code": resp.StatusCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,624 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRepositoryWebhooks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryWebhooks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_webhooks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	hooks := []*github.Hook{
+		{
+			ID:     github.Ptr(int64(1)),
+			Events: []string{"push", "pull_request"},
+			Active: github.Ptr(true),
+			Config: &github.HookConfig{
+				URL:         github.Ptr("https://example.com/hook"),
+				ContentType: github.Ptr("json"),
+				InsecureSSL: github.Ptr("0"),
+				Secret:      github.Ptr("********"),
+			},
+			LastResponse: map[string]any{"code": 200, "status": "active", "message": "OK"},
+		},
+		{
+			ID:     github.Ptr(int64(2)),
+			Events: []string{"*"},
+			Active: github.Ptr(false),
+			Config: &github.HookConfig{
+				URL:         github.Ptr("http://example.com/insecure"),
+				ContentType: github.Ptr("form"),
+				InsecureSSL: github.Ptr("1"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectError      bool
+		expectedErrMsg   string
+		expectedWebhooks []webhook
+	}{
+		{
+			name: "list webhooks",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, hooks),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedWebhooks: []webhook{
+				{
+					ID:           1,
+					URL:          "https://example.com/hook",
+					ContentType:  "json",
+					HasSecret:    true,
+					Events:       []string{"push", "pull_request"},
+					Active:       true,
+					LastResponse: &webhookResponse{Code: 200, Status: "active", Message: "OK"},
+				},
+				{
+					ID:          2,
+					URL:         "http://example.com/insecure",
+					ContentType: "form",
+					InsecureSSL: true,
+					Events:      []string{"*"},
+				},
+			},
+		},
+		{
+			name: "list webhooks fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository webhooks",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryWebhooks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var webhooks []webhook
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &webhooks))
+			assert.Equal(t, tc.expectedWebhooks, webhooks)
+		})
+	}
+}
+
+func Test_GetRepositoryWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "hook_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "hook_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	hook := &github.Hook{
+		ID:     github.Ptr(int64(42)),
+		Events: []string{"push"},
+		Active: github.Ptr(true),
+		Config: &github.HookConfig{
+			URL:         github.Ptr("https://example.com/hook"),
+			ContentType: github.Ptr("json"),
+		},
+		LastResponse: map[string]any{"code": 502, "status": "failed", "message": "Bad Gateway"},
+	}
+	deliveredAt := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	deliveries := []*github.HookDelivery{
+		{
+			ID:          github.Ptr(int64(7)),
+			Event:       github.Ptr("push"),
+			Status:      github.Ptr("Bad Gateway"),
+			StatusCode:  github.Ptr(502),
+			Redelivery:  github.Ptr(false),
+			DeliveredAt: &github.Timestamp{Time: deliveredAt},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		mockedClient         *http.Client
+		requestArgs          map[string]any
+		expectError          bool
+		expectToolError      bool
+		expectedErrMsg       string
+		expectedLastDelivery *webhookDelivery
+	}{
+		{
+			name: "webhook with its last delivery",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepoByHookId,
+					expectPath(t, "/repos/owner/repo/hooks/42").andThen(
+						mockResponse(t, http.StatusOK, hook),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+					expectQueryParams(t, map[string]string{"per_page": "1"}).andThen(
+						mockResponse(t, http.StatusOK, deliveries),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+			expectedLastDelivery: &webhookDelivery{
+				ID:          7,
+				Event:       "push",
+				Status:      "Bad Gateway",
+				StatusCode:  502,
+				DeliveredAt: &deliveredAt,
+			},
+		},
+		{
+			name: "webhook never delivered",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposHooksByOwnerByRepoByHookId,
+					hook,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposHooksDeliveriesByOwnerByRepoByHookId,
+					[]*github.HookDelivery{},
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+		},
+		{
+			name: "webhook not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposHooksByOwnerByRepoByHookId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository webhook",
+		},
+		{
+			name:         "missing hook_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: hook_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Webhook      webhook          `json:"webhook"`
+				LastDelivery *webhookDelivery `json:"last_delivery"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(42), response.Webhook.ID)
+			assert.Equal(t, &webhookResponse{Code: 502, Status: "failed", Message: "Bad Gateway"}, response.Webhook.LastResponse)
+			assert.Equal(t, tc.expectedLastDelivery, response.LastDelivery)
+		})
+	}
+}
+
+func Test_CreateRepositoryWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "url")
+	assert.Contains(t, tool.InputSchema.Properties, "content_type")
+	assert.Contains(t, tool.InputSchema.Properties, "secret")
+	assert.Contains(t, tool.InputSchema.Properties, "events")
+	assert.Contains(t, tool.InputSchema.Properties, "active")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "url"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	const secret = "s3cr3t-value"
+
+	// createdHook returns the created hook with its secret, so that the tests can check that the secret never
+	// makes it into the result
+	createdHook := func(contentType string, events []string, active bool) http.HandlerFunc {
+		return mockResponse(t, http.StatusCreated, &github.Hook{
+			ID:     github.Ptr(int64(99)),
+			Name:   github.Ptr("web"),
+			Events: events,
+			Active: github.Ptr(active),
+			Config: &github.HookConfig{
+				URL:         github.Ptr("https://example.com/hook"),
+				ContentType: github.Ptr(contentType),
+				Secret:      github.Ptr(secret),
+			},
+		})
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedWebhook webhook
+	}{
+		{
+			name: "create webhook with a secret",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"config": map[string]any{
+							"url":          "https://example.com/hook",
+							"content_type": "form",
+							"secret":       secret,
+						},
+						"name":   "web",
+						"events": []any{"push", "release"},
+						"active": false,
+					}).andThen(createdHook("form", []string{"push", "release"}, false)),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"url":          "https://example.com/hook",
+				"content_type": "form",
+				"secret":       secret,
+				"events":       []any{"push", "release"},
+				"active":       false,
+			},
+			expectedWebhook: webhook{
+				ID:          99,
+				URL:         "https://example.com/hook",
+				ContentType: "form",
+				HasSecret:   true,
+				Events:      []string{"push", "release"},
+			},
+		},
+		{
+			name: "create webhook with defaults",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"config": map[string]any{
+							"url":          "https://example.com/hook",
+							"content_type": "json",
+						},
+						"name":   "web",
+						"events": []any{"push"},
+						"active": true,
+					}).andThen(createdHook("json", []string{"push"}, true)),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"url":   "https://example.com/hook",
+			},
+			expectedWebhook: webhook{
+				ID:          99,
+				URL:         "https://example.com/hook",
+				ContentType: "json",
+				HasSecret:   true,
+				Events:      []string{"push"},
+				Active:      true,
+			},
+		},
+		{
+			name: "create webhook fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"url":    "https://example.com/hook",
+				"secret": secret,
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository webhook",
+		},
+		{
+			name:         "invalid content type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"url":          "https://example.com/hook",
+				"content_type": "xml",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid content_type: xml, must be json or form",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				assert.NotContains(t, err.Error(), secret)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			text := getTextResult(t, result).Text
+			assert.NotContains(t, text, secret)
+
+			var created webhook
+			require.NoError(t, json.Unmarshal([]byte(text), &created))
+			assert.Equal(t, tc.expectedWebhook, created)
+		})
+	}
+}
+
+func Test_DeleteRepositoryWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepositoryWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repository_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "hook_id"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "delete webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposHooksByOwnerByRepoByHookId,
+					expectPath(t, "/repos/owner/repo/hooks/42").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+		},
+		{
+			name: "delete webhook fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposHooksByOwnerByRepoByHookId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete repository webhook",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRepositoryWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Webhook has been deleted", response["message"])
+			assert.Equal(t, float64(42), response["hook_id"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
+
+func Test_PingRepositoryWebhook(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PingRepositoryWebhook(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "ping_repository_webhook", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "hook_id"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "ping webhook",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksPingsByOwnerByRepoByHookId,
+					expectPath(t, "/repos/owner/repo/hooks/42/pings").andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+		},
+		{
+			name: "ping webhook fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposHooksPingsByOwnerByRepoByHookId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"hook_id": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to ping repository webhook",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PingRepositoryWebhook(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "Ping event has been sent to the webhook", response["message"])
+			assert.Equal(t, float64(42), response["hook_id"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+)
+
+// RetryPolicy bounds the retries of transient GitHub API errors.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, zero disables retries
+	MaxRetries int
+	// BaseDelay is the wait before the first retry, doubled for every following retry
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff between two attempts
+	MaxDelay time.Duration
+	// MaxTotalDelay caps the time spent waiting across all retries of a request, including
+	// Retry-After waits. A retry that would exceed it is not attempted.
+	MaxTotalDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by the server unless configured otherwise.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:    3,
+		BaseDelay:     500 * time.Millisecond,
+		MaxDelay:      10 * time.Second,
+		MaxTotalDelay: 30 * time.Second,
+	}
+}
+
+// RetryError is returned by RetryTransport when a request still fails after being retried.
+type RetryError struct {
+	// Retries is the number of retries made after the first attempt
+	Retries int
+	// StatusCode and Status are those of the last response, if the last attempt got one
+	StatusCode int
+	Status     string
+	// Err is the error of the last attempt, if it did not get a response
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v (retried %d times)", e.Err, e.Retries)
+	}
+	return fmt.Sprintf("GitHub API returned %s (retried %d times)", e.Status, e.Retries)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryTransport retries idempotent requests that fail with a transient error: a 500, 502 or 503,
+// a reset connection, or a 403 or 429 rate limit response, honouring its Retry-After header.
+// Waits back off exponentially within the limits of the policy, and stop when the request context
+// is done. Retries are reported to the instrumenter of the tool call in progress.
+type RetryTransport struct {
+	Transport http.RoundTripper
+	Policy    RetryPolicy
+	// RetryPOST reports whether a POST request may be retried, for example IsGraphQLQuery for the
+	// GraphQL endpoint. POST requests are not retried if it is nil.
+	RetryPOST func(req *http.Request) bool
+
+	// wait blocks for the duration or until the context is done, it is replaced in tests
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Policy.MaxRetries <= 0 || !t.retryable(req) {
+		return t.Transport.RoundTrip(req)
+	}
+	wait := t.wait
+	if wait == nil {
+		wait = waitContext
+	}
+
+	ctx := req.Context()
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay request body: %w", err)
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.Transport.RoundTrip(attemptReq)
+		transient := transientResponse(resp, err)
+		if !transient || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+		}
+		if attempt >= t.Policy.MaxRetries || waited+delay > t.Policy.MaxTotalDelay {
+			if attempt == 0 {
+				return resp, err
+			}
+			return nil, newRetryError(attempt, resp, err)
+		}
+
+		if resp != nil {
+			// Drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := wait(ctx, delay); err != nil {
+			return nil, err
+		}
+		waited += delay
+		toolsets.RecordAPIRetry(ctx)
+	}
+}
+
+// retryable reports whether the request is idempotent, or a POST accepted by RetryPOST, and its body
+// can be replayed.
+func (t *RetryTransport) retryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if t.RetryPOST == nil || !t.RetryPOST(req) {
+			return false
+		}
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// backoff returns the exponential backoff before the retry following the attempt.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	delay := t.Policy.BaseDelay
+	for i := 0; i < attempt && delay < t.Policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, t.Policy.MaxDelay)
+}
+
+func newRetryError(retries int, resp *http.Response, err error) *RetryError {
+	if resp == nil {
+		return &RetryError{Retries: retries, Err: err}
+	}
+	_ = resp.Body.Close()
+	return &RetryError{Retries: retries, StatusCode: resp.StatusCode, Status: resp.Status}
+}
+
+// transientResponse reports whether the outcome of an attempt is worth retrying.
+func transientResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		// Only secondary rate limits ask to retry, other 403s are permission errors, and an
+		// exhausted primary rate limit does not reset soon enough to wait for it
+		return resp.Header.Get("Retry-After") != "" && resp.Header.Get("X-RateLimit-Remaining") != "0"
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, given in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// IsGraphQLQuery reports whether a GraphQL request is a query rather than a mutation, so that it can be
+// retried safely.
+func IsGraphQLQuery(req *http.Request) bool {
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer func() { _ = body.Close() }()
+
+	var payload struct {
+		Query string `json:"query"`
+	}
+	data, err := io.ReadAll(body)
+	if err != nil || json.Unmarshal(data, &payload) != nil {
+		return false
+	}
+	query := strings.TrimSpace(payload.Query)
+	return query != "" && !strings.HasPrefix(query, "mutation")
+}
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedResponse is the outcome of one attempt of a scriptedTransport.
+type scriptedResponse struct {
+	status int
+	header http.Header
+	err    error
+}
+
+// scriptedTransport returns the scripted responses in order and records the bodies of the requests.
+type scriptedTransport struct {
+	responses []scriptedResponse
+	bodies    []string
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.bodies) >= len(t.responses) {
+		return nil, fmt.Errorf("unexpected request %d", len(t.bodies)+1)
+	}
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	t.bodies = append(t.bodies, string(body))
+
+	scripted := t.responses[len(t.bodies)-1]
+	if scripted.err != nil {
+		return nil, scripted.err
+	}
+	header := scripted.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: scripted.status,
+		Status:     fmt.Sprintf("%d %s", scripted.status, http.StatusText(scripted.status)),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+		Request:    req,
+	}, nil
+}
+
+// newTestRetryTransport returns a RetryTransport around the scripted transport that records its
+// waits instead of sleeping.
+func newTestRetryTransport(scripted *scriptedTransport, waits *[]time.Duration) *RetryTransport {
+	return &RetryTransport{
+		Transport: scripted,
+		Policy:    DefaultRetryPolicy(),
+		RetryPOST: IsGraphQLQuery,
+		wait: func(_ context.Context, d time.Duration) error {
+			*waits = append(*waits, d)
+			return nil
+		},
+	}
+}
+
+func Test_RetryTransport(t *testing.T) {
+	retryAfter := func(value string) http.Header {
+		return http.Header{"Retry-After": []string{value}}
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		responses      []scriptedResponse
+		expectedStatus int
+		expectedErrMsg string
+		expectedWaits  []time.Duration
+	}{
+		{
+			name:           "502 then 200",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{status: 502}, {status: 200}},
+			expectedStatus: 200,
+			expectedWaits:  []time.Duration{500 * time.Millisecond},
+		},
+		{
+			name:           "403 with Retry-After waits as requested",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{status: 403, header: retryAfter("2")}, {status: 200}},
+			expectedStatus: 200,
+			expectedWaits:  []time.Duration{2 * time.Second},
+		},
+		{
+			name:           "429 without Retry-After backs off exponentially",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{status: 429}, {status: 429}, {status: 200}},
+			expectedStatus: 200,
+			expectedWaits:  []time.Duration{500 * time.Millisecond, time.Second},
+		},
+		{
+			name:           "connection reset then 200",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{err: syscall.ECONNRESET}, {status: 200}},
+			expectedStatus: 200,
+			expectedWaits:  []time.Duration{500 * time.Millisecond},
+		},
+		{
+			name:           "403 without Retry-After is not retried",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{status: 403}},
+			expectedStatus: 403,
+		},
+		{
+			name:   "exhausted primary rate limit is not retried",
+			method: http.MethodGet,
+			responses: []scriptedResponse{{status: 403, header: http.Header{
+				"Retry-After":           []string{"1"},
+				"X-Ratelimit-Remaining": []string{"0"},
+			}}},
+			expectedStatus: 403,
+		},
+		{
+			name:           "Retry-After beyond the total delay is returned to the caller",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{status: 429, header: retryAfter("60")}},
+			expectedStatus: 429,
+		},
+		{
+			name:           "404 is not retried",
+			method:         http.MethodGet,
+			responses:      []scriptedResponse{{status: 404}},
+			expectedStatus: 404,
+		},
+		{
+			name:   "exhausted retries report the retry count",
+			method: http.MethodGet,
+			responses: []scriptedResponse{
+				{status: 503}, {status: 503}, {status: 503}, {status: 503},
+			},
+			expectedErrMsg: "GitHub API returned 503 Service Unavailable (retried 3 times)",
+			expectedWaits:  []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second},
+		},
+		{
+			name:   "exhausted retries after connection resets",
+			method: http.MethodGet,
+			responses: []scriptedResponse{
+				{err: io.ErrUnexpectedEOF}, {err: io.ErrUnexpectedEOF}, {err: io.ErrUnexpectedEOF}, {err: io.ErrUnexpectedEOF},
+			},
+			expectedErrMsg: "unexpected EOF (retried 3 times)",
+			expectedWaits:  []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second},
+		},
+		{
+			name:           "POST is not retried",
+			method:         http.MethodPost,
+			body:           `{"title":"new issue"}`,
+			responses:      []scriptedResponse{{status: 502}},
+			expectedStatus: 502,
+		},
+		{
+			name:           "GraphQL mutation is not retried",
+			method:         http.MethodPost,
+			body:           `{"query":"mutation($input:AddCommentInput!){addComment(input:$input){clientMutationId}}"}`,
+			responses:      []scriptedResponse{{status: 502}},
+			expectedStatus: 502,
+		},
+		{
+			name:           "GraphQL query is retried with its body",
+			method:         http.MethodPost,
+			body:           `{"query":"query{viewer{login}}"}`,
+			responses:      []scriptedResponse{{status: 502}, {status: 200}},
+			expectedStatus: 200,
+			expectedWaits:  []time.Duration{500 * time.Millisecond},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scripted := &scriptedTransport{responses: tc.responses}
+			var waits []time.Duration
+			transport := newTestRetryTransport(scripted, &waits)
+
+			var body io.Reader
+			if tc.body != "" {
+				body = bytes.NewBufferString(tc.body)
+			}
+			req, err := http.NewRequest(tc.method, "https://api.github.com/repos/owner/repo", body)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				_ = resp.Body.Close()
+			}
+			assert.Equal(t, tc.expectedWaits, waits)
+			assert.Len(t, scripted.bodies, len(tc.responses), "all scripted responses should be used")
+			for _, sent := range scripted.bodies {
+				assert.Equal(t, tc.body, sent)
+			}
+		})
+	}
+}
+
+func Test_RetryTransport_ContextCancelled(t *testing.T) {
+	scripted := &scriptedTransport{responses: []scriptedResponse{{status: 502}}}
+	transport := &RetryTransport{Transport: scripted, Policy: DefaultRetryPolicy()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err = transport.RoundTrip(req)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), DefaultRetryPolicy().BaseDelay)
+}
+
+func Test_RetryTransport_ThroughClient(t *testing.T) {
+	scripted := &scriptedTransport{responses: []scriptedResponse{{status: 502}, {status: 502}}}
+	var waits []time.Duration
+	transport := newTestRetryTransport(scripted, &waits)
+	transport.Policy.MaxRetries = 1
+	client := github.NewClient(&http.Client{Transport: transport})
+
+	// Retries are reported to the instrumenter of the tool call
+	instrumenter := &toolsets.InMemoryInstrumenter{}
+	tool := toolsets.InstrumentTool(server.ServerTool{
+		Tool: mcp.NewTool("get_me"),
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			_, _, err := client.Users.Get(ctx, "")
+			return nil, err
+		},
+	}, instrumenter)
+
+	_, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GitHub API returned 502 Bad Gateway (retried 1 times)")
+
+	var retryErr *RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 1, retryErr.Retries)
+	assert.Equal(t, http.StatusBadGateway, retryErr.StatusCode)
+
+	invocations := instrumenter.Invocations()
+	require.Len(t, invocations, 1)
+	assert.Equal(t, 1, invocations[0].Retries)
+}
+
+func Test_RetryTransport_Backoff(t *testing.T) {
+	transport := &RetryTransport{Policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}}
+	var delays []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		delays = append(delays, transport.backoff(attempt))
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+}
@@ -73,7 +73,7 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 // SearchCode creates a tool to search for code across GitHub repositories.
 func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_code",
-			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Search for code across GitHub repositories")),
+			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Search for code across GitHub repositories. Each result has the fragments of the file that matched the query")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -83,7 +83,8 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Search query using GitHub code search syntax"),
 			),
 			mcp.WithString("sort",
-				mcp.Description("Sort field ('indexed' only)"),
+				mcp.Description("Sort field ('indexed' only), results are sorted by best match by default"),
+				mcp.Enum("indexed"),
 			),
 			mcp.WithString("order",
 				mcp.Description("Sort order"),
@@ -110,8 +111,9 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 
 			opts := &github.SearchOptions{
-				Sort:  sort,
-				Order: order,
+				Sort:      sort,
+				Order:     order,
+				TextMatch: true,
 				ListOptions: github.ListOptions{
 					PerPage: pagination.perPage,
 					Page:    pagination.page,
@@ -137,7 +139,30 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(result)
+			minimalResults := make([]MinimalCodeResult, 0, len(result.CodeResults))
+			for _, code := range result.CodeResults {
+				fragments := make([]string, 0, len(code.TextMatches))
+				for _, match := range code.TextMatches {
+					fragments = append(fragments, match.GetFragment())
+				}
+
+				minimalResults = append(minimalResults, MinimalCodeResult{
+					Repository: code.GetRepository().GetFullName(),
+					Path:       code.GetPath(),
+					HTMLURL:    code.GetHTMLURL(),
+					Fragments:  fragments,
+				})
+			}
+
+			minimalResp := MinimalSearchCodeResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Page:              pagination.page,
+				PerPage:           pagination.perPage,
+				Items:             minimalResults,
+			}
+
+			r, err := json.Marshal(minimalResp)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
@@ -146,6 +171,22 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 		}
 }
 
+// MinimalCodeResult is a code search result, with the fragments of the file that matched the query
+type MinimalCodeResult struct {
+	Repository string   `json:"repository"`
+	Path       string   `json:"path"`
+	HTMLURL    string   `json:"html_url"`
+	Fragments  []string `json:"fragments"`
+}
+
+type MinimalSearchCodeResult struct {
+	TotalCount        int                 `json:"total_count"`
+	IncompleteResults bool                `json:"incomplete_results"`
+	Page              int                 `json:"page"`
+	PerPage           int                 `json:"per_page"`
+	Items             []MinimalCodeResult `json:"items"`
+}
+
 type MinimalUser struct {
 	Login      string `json:"login"`
 	ID         int64  `json:"id,omitempty"`
@@ -188,23 +188,56 @@ func Test_SearchCode(t *testing.T) {
 				SHA:        github.Ptr("abc123def456"),
 				HTMLURL:    github.Ptr("https://github.com/owner/repo/blob/main/path/to/file1.go"),
 				Repository: &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo")},
+				TextMatches: []*github.TextMatch{
+					{
+						ObjectType: github.Ptr("FileContent"),
+						Property:   github.Ptr("content"),
+						Fragment:   github.Ptr("func main() {\n\tfmt.Println(\"hello\")\n}"),
+						Matches: []*github.Match{
+							{Text: github.Ptr("fmt.Println"), Indices: []int{15, 26}},
+						},
+					},
+				},
 			},
 			{
 				Name:       github.Ptr("file2.go"),
 				Path:       github.Ptr("path/to/file2.go"),
 				SHA:        github.Ptr("def456abc123"),
 				HTMLURL:    github.Ptr("https://github.com/owner/repo/blob/main/path/to/file2.go"),
-				Repository: &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo")},
+				Repository: &github.Repository{Name: github.Ptr("other"), FullName: github.Ptr("owner/other")},
 			},
 		},
 	}
 
+	expectedItems := []MinimalCodeResult{
+		{
+			Repository: "owner/repo",
+			Path:       "path/to/file1.go",
+			HTMLURL:    "https://github.com/owner/repo/blob/main/path/to/file1.go",
+			Fragments:  []string{"func main() {\n\tfmt.Println(\"hello\")\n}"},
+		},
+		{
+			Repository: "owner/other",
+			Path:       "path/to/file2.go",
+			HTMLURL:    "https://github.com/owner/repo/blob/main/path/to/file2.go",
+			Fragments:  []string{},
+		},
+	}
+
+	// The text matches are only returned with the text-match media type
+	expectTextMatch := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.github.v3.text-match+json")
+			next(w, r)
+		}
+	}
+
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedResult *github.CodeSearchResult
+		expectedResult *MinimalSearchCodeResult
 		expectedErrMsg string
 	}{
 		{
@@ -216,10 +249,10 @@ func Test_SearchCode(t *testing.T) {
 						"q":        "fmt.Println language:go",
 						"sort":     "indexed",
 						"order":    "desc",
-						"page":     "1",
-						"per_page": "30",
+						"page":     "2",
+						"per_page": "10",
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockSearchResult),
+						expectTextMatch(mockResponse(t, http.StatusOK, mockSearchResult)),
 					),
 				),
 			),
@@ -227,11 +260,16 @@ func Test_SearchCode(t *testing.T) {
 				"q":       "fmt.Println language:go",
 				"sort":    "indexed",
 				"order":   "desc",
-				"page":    float64(1),
-				"perPage": float64(30),
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectError: false,
+			expectedResult: &MinimalSearchCodeResult{
+				TotalCount: 2,
+				Page:       2,
+				PerPage:    10,
+				Items:      expectedItems,
 			},
-			expectError:    false,
-			expectedResult: mockSearchResult,
 		},
 		{
 			name: "code search with minimal parameters",
@@ -243,15 +281,20 @@ func Test_SearchCode(t *testing.T) {
 						"page":     "1",
 						"per_page": "30",
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockSearchResult),
+						expectTextMatch(mockResponse(t, http.StatusOK, mockSearchResult)),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"q": "fmt.Println language:go",
 			},
-			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectError: false,
+			expectedResult: &MinimalSearchCodeResult{
+				TotalCount: 2,
+				Page:       1,
+				PerPage:    30,
+				Items:      expectedItems,
+			},
 		},
 		{
 			name: "search code fails",
@@ -297,19 +340,10 @@ func Test_SearchCode(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedResult github.CodeSearchResult
+			var returnedResult MinimalSearchCodeResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
 			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedResult.Total, *returnedResult.Total)
-			assert.Equal(t, *tc.expectedResult.IncompleteResults, *returnedResult.IncompleteResults)
-			assert.Len(t, returnedResult.CodeResults, len(tc.expectedResult.CodeResults))
-			for i, code := range returnedResult.CodeResults {
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Name, *code.Name)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Path, *code.Path)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].SHA, *code.SHA)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].HTMLURL, *code.HTMLURL)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Repository.FullName, *code.Repository.FullName)
-			}
+			assert.Equal(t, *tc.expectedResult, returnedResult)
 		})
 	}
 }
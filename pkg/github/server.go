@@ -1,9 +1,15 @@
 package github
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -89,14 +95,51 @@ func RequiredParam[T comparable](r mcp.CallToolRequest, p string) (T, error) {
 // RequiredInt is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
 // 1. Checks if the parameter is present in the request.
-// 2. Checks if the parameter is of the expected type.
+// 2. Checks if the parameter is a whole number, or a string holding one, see toInt.
 // 3. Checks if the parameter is not empty, i.e: non-zero value
 func RequiredInt(r mcp.CallToolRequest, p string) (int, error) {
-	v, err := RequiredParam[float64](r, p)
+	val, ok := r.GetArguments()[p]
+	if !ok || val == nil || val == "" {
+		return 0, fmt.Errorf("missing required parameter: %s", p)
+	}
+
+	v, err := toInt(p, val)
 	if err != nil {
 		return 0, err
 	}
-	return int(v), nil
+
+	if v == 0 {
+		return 0, fmt.Errorf("missing required parameter: %s", p)
+	}
+
+	return v, nil
+}
+
+// toInt converts a numeric parameter to an int. Clients often send numbers as strings, so a string
+// holding a base 10 integer is accepted too. Numbers with a fractional part and numbers that don't fit
+// in an int are rejected rather than rounded.
+func toInt(p string, val any) (int, error) {
+	switch v := val.(type) {
+	case float64:
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return 0, fmt.Errorf("parameter %s must be an integer, is %v", p, v)
+		}
+		if v < math.MinInt || v >= math.MaxInt {
+			return 0, fmt.Errorf("parameter %s is out of range: %v", p, v)
+		}
+		return int(v), nil
+	case string:
+		n, err := strconv.Atoi(v)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("parameter %s is out of range: %s", p, v)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("parameter %s must be an integer, is %q", p, v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("parameter %s is not of type %T, is %T", p, float64(0), val)
+	}
 }
 
 // OptionalParam is a helper function that can be used to fetch a requested parameter from the request.
@@ -121,14 +164,15 @@ func OptionalParam[T any](r mcp.CallToolRequest, p string) (T, error) {
 
 // OptionalIntParam is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
-// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
-// 2. If it is present, it checks if the parameter is of the expected type and returns it
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value. An empty
+// string counts as not present.
+// 2. If it is present, it checks if the parameter is a whole number, or a string holding one, see toInt
 func OptionalIntParam(r mcp.CallToolRequest, p string) (int, error) {
-	v, err := OptionalParam[float64](r, p)
-	if err != nil {
-		return 0, err
+	val, ok := r.GetArguments()[p]
+	if !ok || val == nil || val == "" {
+		return 0, nil
 	}
-	return int(v), nil
+	return toInt(p, val)
 }
 
 // OptionalIntParamWithDefault is a helper function that can be used to fetch a requested parameter from the request
@@ -174,6 +218,31 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// OptionalIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
+// 2. If it is present, iterates the elements and checks each is a whole number, see toInt
+func OptionalIntArrayParam(r mcp.CallToolRequest, p string) ([]int, error) {
+	switch v := r.GetArguments()[p].(type) {
+	case nil:
+		return []int{}, nil
+	case []int:
+		return v, nil
+	case []any:
+		intSlice := make([]int, len(v))
+		for i, elem := range v {
+			n, err := toInt(p, elem)
+			if err != nil {
+				return []int{}, err
+			}
+			intSlice[i] = n
+		}
+		return intSlice, nil
+	default:
+		return []int{}, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, r.GetArguments()[p])
+	}
+}
+
 // WithPagination returns a ToolOption that adds "page" and "perPage" parameters to the tool.
 // The "page" parameter is optional, min 1. The "perPage" parameter is optional, min 1, max 100.
 func WithPagination() mcp.ToolOption {
@@ -196,31 +265,260 @@ type PaginationParams struct {
 	perPage int
 }
 
+// maxPerPage is the largest page size the GitHub API accepts.
+const maxPerPage = 100
+
 // OptionalPaginationParams returns the "page" and "perPage" parameters from the request,
 // or their default values if not present, "page" default is 1, "perPage" default is 30.
 // In future, we may want to make the default values configurable, or even have this
 // function returned from `withPagination`, where the defaults are provided alongside
 // the min/max values.
 func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
-	page, err := OptionalIntParamWithDefault(r, "page", 1)
+	return OptionalNamedPaginationParams(r, "page", "perPage")
+}
+
+// OptionalNamedPaginationParams is OptionalPaginationParams for tools whose pagination parameters
+// have other names, such as "per_page". A page size above 100 is lowered to 100, since GitHub would
+// reject it, while a negative page or page size is an error.
+func OptionalNamedPaginationParams(r mcp.CallToolRequest, pageParam, perPageParam string) (PaginationParams, error) {
+	page, err := optionalPositiveIntParam(r, pageParam, 1)
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	perPage, err := OptionalIntParamWithDefault(r, "perPage", 30)
+	perPage, err := optionalPositiveIntParam(r, perPageParam, 30)
 	if err != nil {
 		return PaginationParams{}, err
 	}
 	return PaginationParams{
 		page:    page,
-		perPage: perPage,
+		perPage: min(perPage, maxPerPage),
 	}, nil
 }
 
-func MarshalledTextResult(v any) *mcp.CallToolResult {
-	data, err := json.Marshal(v)
+// optionalPositiveIntParam returns an optional integer parameter that must not be negative, or d
+// when it is not given or zero, like OptionalIntParamWithDefault.
+func optionalPositiveIntParam(r mcp.CallToolRequest, p string, d int) (int, error) {
+	v, err := OptionalIntParamWithDefault(r, p, d)
+	if err != nil {
+		return 0, err
+	}
+	if v < 1 {
+		return 0, fmt.Errorf("parameter %s must be at least 1, is %d", p, v)
+	}
+	return v, nil
+}
+
+// ResultOptions controls how MarshalledTextResult renders a value.
+type ResultOptions struct {
+	// Indent pretty-prints the JSON with two space indentation.
+	Indent bool
+	// MaxBytes, if positive, caps the size of the JSON text. Larger results are truncated and followed
+	// by a truncation notice.
+	MaxBytes int
+}
+
+// ResultOption configures MarshalledTextResult.
+type ResultOption func(*ResultOptions)
+
+// WithIndent enables or disables the indentation of the JSON text.
+func WithIndent(indent bool) ResultOption {
+	return func(o *ResultOptions) {
+		o.Indent = indent
+	}
+}
+
+// WithMaxBytes caps the size of the JSON text, zero removes the cap.
+func WithMaxBytes(maxBytes int) ResultOption {
+	return func(o *ResultOptions) {
+		o.MaxBytes = maxBytes
+	}
+}
+
+// TruncationNotice is appended as a second text content to results that exceeded their byte budget.
+type TruncationNotice struct {
+	Truncated bool `json:"truncated"`
+	// InvalidJSON is set when no array could be shortened enough and the text was cut at the budget
+	InvalidJSON  bool   `json:"invalid_json,omitempty"`
+	OriginalSize int    `json:"original_size"`
+	ReturnedSize int    `json:"returned_size"`
+	Message      string `json:"message"`
+}
+
+// MarshalledTextResult returns the JSON encoding of v as a text result. The options are applied in
+// order, so options given per call override defaults given before them.
+//
+// When the JSON exceeds ResultOptions.MaxBytes, a JSON array is cut after the last element that fits,
+// and a JSON object has its largest arrays cut the same way, so that the text stays valid JSON. Other
+// values are cut at the budget. The result then carries a second text content with a TruncationNotice.
+func MarshalledTextResult(v any, opts ...ResultOption) *mcp.CallToolResult {
+	var o ResultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data, err := marshalResult(v, o.Indent)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("failed to marshal text result to json", err)
 	}
 
-	return mcp.NewToolResultText(string(data))
+	if o.MaxBytes <= 0 || len(data) <= o.MaxBytes {
+		return mcp.NewToolResultText(string(data))
+	}
+
+	truncated, valid := truncateJSON(data, o.MaxBytes, o.Indent)
+	message := "The result was truncated to fit the response size limit, use pagination or filters to get the rest"
+	if !valid {
+		message = "The result was cut at the response size limit and is not valid JSON, use pagination or filters to get a smaller result"
+	}
+	notice, err := json.Marshal(TruncationNotice{
+		Truncated:    true,
+		InvalidJSON:  !valid,
+		OriginalSize: len(data),
+		ReturnedSize: len(truncated),
+		Message:      message,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to marshal truncation notice to json", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(string(truncated)),
+			mcp.NewTextContent(string(notice)),
+		},
+	}
+}
+
+func marshalResult(v any, indent bool) ([]byte, error) {
+	if indent {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
+}
+
+// truncateJSON cuts data to at most maxBytes bytes and reports whether the result is still valid JSON.
+// A JSON array keeps as many leading elements as fit. A JSON object keeps all its fields, with its
+// arrays, the largest first, emptied or shortened until the object fits. Anything else is cut at the
+// last complete UTF-8 character within the budget.
+func truncateJSON(data []byte, maxBytes int, indent bool) ([]byte, bool) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err == nil {
+		if prefix, ok := truncateArray(elements, maxBytes, func(elements []json.RawMessage) ([]byte, error) {
+			return marshalResult(elements, indent)
+		}); ok {
+			return prefix, true
+		}
+	} else if fields, err := decodeObject(data); err == nil {
+		if truncated, ok := truncateObject(fields, maxBytes, indent); ok {
+			return truncated, true
+		}
+	}
+
+	cut := data[:maxBytes]
+	for len(cut) > 0 && !utf8.Valid(cut) {
+		cut = cut[:len(cut)-1]
+	}
+	return cut, false
+}
+
+// truncateArray returns the rendering of the largest prefix of elements that fits in maxBytes, or false
+// if not even the empty array fits. The size of the rendering grows with the number of elements.
+func truncateArray(elements []json.RawMessage, maxBytes int, render func([]json.RawMessage) ([]byte, error)) ([]byte, bool) {
+	n := sort.Search(len(elements)+1, func(i int) bool {
+		rendered, err := render(elements[:i])
+		return err != nil || len(rendered) > maxBytes
+	}) - 1
+	if n < 0 {
+		return nil, false
+	}
+	rendered, err := render(elements[:n])
+	if err != nil {
+		return nil, false
+	}
+	return rendered, true
+}
+
+// objectField is a field of a JSON object, objects are kept as lists of fields to keep their order.
+type objectField struct {
+	name  string
+	value json.RawMessage
+}
+
+// decodeObject returns the fields of a JSON object in their order.
+func decodeObject(data []byte) ([]objectField, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, errors.New("not a JSON object")
+	}
+	var fields []objectField
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, objectField{name: name, value: value})
+	}
+	return fields, nil
+}
+
+func marshalObject(fields []objectField, indent bool) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(field.value)
+	}
+	buf.WriteByte('}')
+	return marshalResult(json.RawMessage(buf.Bytes()), indent)
+}
+
+// truncateObject shortens the array fields of an object, the largest first, until the object fits in
+// maxBytes. It returns false if the object does not fit even with all its arrays emptied.
+func truncateObject(fields []objectField, maxBytes int, indent bool) ([]byte, bool) {
+	type arrayField struct {
+		index    int
+		elements []json.RawMessage
+	}
+	var arrays []arrayField
+	for i, field := range fields {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(field.value, &elements); err == nil && len(elements) > 0 {
+			arrays = append(arrays, arrayField{index: i, elements: elements})
+		}
+	}
+	sort.SliceStable(arrays, func(a, b int) bool {
+		return len(fields[arrays[a].index].value) > len(fields[arrays[b].index].value)
+	})
+
+	fields = slices.Clone(fields)
+	for _, array := range arrays {
+		i := array.index
+		truncated, ok := truncateArray(array.elements, maxBytes, func(elements []json.RawMessage) ([]byte, error) {
+			value, err := json.Marshal(elements)
+			if err != nil {
+				return nil, err
+			}
+			fields[i].value = value
+			return marshalObject(fields, indent)
+		})
+		if ok {
+			return truncated, true
+		}
+		// Even without elements the object is too large, empty this array and shorten the next one
+		fields[i].value = json.RawMessage("[]")
+	}
+	return nil, false
 }
@@ -10,8 +10,10 @@ import (
 
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stubGetClientFn(client *github.Client) GetClientFn {
@@ -230,6 +232,84 @@ func Test_RequiredInt(t *testing.T) {
 			expected:    0,
 			expectError: true,
 		},
+		{
+			name:      "numeric string",
+			params:    map[string]interface{}{"count": "12345"},
+			paramName: "count",
+			expected:  12345,
+		},
+		{
+			name:      "negative numeric string",
+			params:    map[string]interface{}{"count": "-7"},
+			paramName: "count",
+			expected:  -7,
+		},
+		{
+			name:      "whole float",
+			params:    map[string]interface{}{"count": float64(3)},
+			paramName: "count",
+			expected:  3,
+		},
+		{
+			name:        "float with a fraction",
+			params:      map[string]interface{}{"count": 1.5},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string float with a fraction",
+			params:      map[string]interface{}{"count": "1.5"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string float without a fraction",
+			params:      map[string]interface{}{"count": "1.0"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string with spaces",
+			params:      map[string]interface{}{"count": " 42"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string in exponent notation",
+			params:      map[string]interface{}{"count": "1e3"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string overflow",
+			params:      map[string]interface{}{"count": "99999999999999999999"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "float overflow",
+			params:      map[string]interface{}{"count": 1e20},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "boolean",
+			params:      map[string]interface{}{"count": true},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "empty string",
+			params:      map[string]interface{}{"count": ""},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "zero string",
+			params:      map[string]interface{}{"count": "0"},
+			paramName:   "count",
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -282,6 +362,84 @@ func Test_OptionalIntParam(t *testing.T) {
 			expected:    0,
 			expectError: true,
 		},
+		{
+			name:      "numeric string",
+			params:    map[string]interface{}{"count": "12345"},
+			paramName: "count",
+			expected:  12345,
+		},
+		{
+			name:      "negative numeric string",
+			params:    map[string]interface{}{"count": "-7"},
+			paramName: "count",
+			expected:  -7,
+		},
+		{
+			name:      "whole float",
+			params:    map[string]interface{}{"count": float64(3)},
+			paramName: "count",
+			expected:  3,
+		},
+		{
+			name:        "float with a fraction",
+			params:      map[string]interface{}{"count": 1.5},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string float with a fraction",
+			params:      map[string]interface{}{"count": "1.5"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string float without a fraction",
+			params:      map[string]interface{}{"count": "1.0"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string with spaces",
+			params:      map[string]interface{}{"count": " 42"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string in exponent notation",
+			params:      map[string]interface{}{"count": "1e3"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "string overflow",
+			params:      map[string]interface{}{"count": "99999999999999999999"},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "float overflow",
+			params:      map[string]interface{}{"count": 1e20},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:        "boolean",
+			params:      map[string]interface{}{"count": true},
+			paramName:   "count",
+			expectError: true,
+		},
+		{
+			name:      "empty string",
+			params:    map[string]interface{}{"count": ""},
+			paramName: "count",
+			expected:  0,
+		},
+		{
+			name:      "null",
+			params:    map[string]interface{}{"count": nil},
+			paramName: "count",
+			expected:  0,
+		},
 	}
 
 	for _, tc := range tests {
@@ -478,6 +636,77 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestOptionalIntArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		paramName   string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:      "parameter not in request",
+			params:    map[string]any{},
+			paramName: "ids",
+			expected:  []int{},
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), "2"},
+			},
+			paramName: "ids",
+			expected:  []int{1, 2},
+		},
+		{
+			name: "valid int array parameter",
+			params: map[string]any{
+				"ids": []int{1, 2},
+			},
+			paramName: "ids",
+			expected:  []int{1, 2},
+		},
+		{
+			name: "wrong type parameter",
+			params: map[string]any{
+				"ids": float64(1),
+			},
+			paramName:   "ids",
+			expectError: true,
+		},
+		{
+			name: "element with a fraction",
+			params: map[string]any{
+				"ids": []any{float64(1), 1.5},
+			},
+			paramName:   "ids",
+			expectError: true,
+		},
+		{
+			name: "wrong element type",
+			params: map[string]any{
+				"ids": []any{"foo"},
+			},
+			paramName:   "ids",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalIntArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -544,6 +773,59 @@ func TestOptionalPaginationParams(t *testing.T) {
 			expected:    PaginationParams{},
 			expectError: true,
 		},
+		{
+			name: "numeric string parameters",
+			params: map[string]any{
+				"page":    "3",
+				"perPage": "50",
+			},
+			expected: PaginationParams{
+				page:    3,
+				perPage: 50,
+			},
+		},
+		{
+			name: "perPage above the maximum is lowered",
+			params: map[string]any{
+				"perPage": float64(500),
+			},
+			expected: PaginationParams{
+				page:    1,
+				perPage: 100,
+			},
+		},
+		{
+			name: "zero values use the defaults",
+			params: map[string]any{
+				"page":    float64(0),
+				"perPage": float64(0),
+			},
+			expected: PaginationParams{
+				page:    1,
+				perPage: 30,
+			},
+		},
+		{
+			name: "negative page",
+			params: map[string]any{
+				"page": float64(-1),
+			},
+			expectError: true,
+		},
+		{
+			name: "negative perPage",
+			params: map[string]any{
+				"perPage": "-10",
+			},
+			expectError: true,
+		},
+		{
+			name: "fractional page",
+			params: map[string]any{
+				"page": 1.5,
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -560,3 +842,166 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionalNamedPaginationParams(t *testing.T) {
+	request := createMCPRequest(map[string]any{
+		"page":     "2",
+		"per_page": float64(250),
+		"perPage":  float64(10),
+	})
+	result, err := OptionalNamedPaginationParams(request, "page", "per_page")
+	require.NoError(t, err)
+	assert.Equal(t, PaginationParams{page: 2, perPage: 100}, result)
+
+	_, err = OptionalNamedPaginationParams(createMCPRequest(map[string]any{"per_page": float64(-5)}), "page", "per_page")
+	assert.EqualError(t, err, "parameter per_page must be at least 1, is -5")
+}
+
+func Test_MarshalledTextResult(t *testing.T) {
+	items := []map[string]any{
+		{"id": 1, "name": "first"},
+		{"id": 2, "name": "second"},
+		{"id": 3, "name": "third"},
+	}
+	type searchResult struct {
+		TotalCount int              `json:"total_count"`
+		Items      []map[string]any `json:"items"`
+	}
+	type taggedItems struct {
+		Items []map[string]any `json:"items"`
+		Tags  []string         `json:"tags"`
+	}
+
+	tests := []struct {
+		name             string
+		value            any
+		opts             []ResultOption
+		expectedText     string
+		expectedOriginal int
+		expectedInvalid  bool
+	}{
+		{
+			name:         "compact by default",
+			value:        items[:1],
+			expectedText: `[{"id":1,"name":"first"}]`,
+		},
+		{
+			name:         "indentation is opt-in",
+			value:        map[string]any{"id": 1},
+			opts:         []ResultOption{WithIndent(true)},
+			expectedText: "{\n  \"id\": 1\n}",
+		},
+		{
+			name:         "result within the budget is not truncated",
+			value:        items[:1],
+			opts:         []ResultOption{WithMaxBytes(len(`[{"id":1,"name":"first"}]`))},
+			expectedText: `[{"id":1,"name":"first"}]`,
+		},
+		{
+			name:             "array is cut after the last element that fits",
+			value:            items,
+			opts:             []ResultOption{WithMaxBytes(60)},
+			expectedText:     `[{"id":1,"name":"first"},{"id":2,"name":"second"}]`,
+			expectedOriginal: 74,
+		},
+		{
+			name:             "array with a budget smaller than one element is empty",
+			value:            items,
+			opts:             []ResultOption{WithMaxBytes(10)},
+			expectedText:     `[]`,
+			expectedOriginal: 74,
+		},
+		{
+			name:             "indented array is cut after the last element that fits",
+			value:            items,
+			opts:             []ResultOption{WithIndent(true), WithMaxBytes(50)},
+			expectedText:     "[\n  {\n    \"id\": 1,\n    \"name\": \"first\"\n  }\n]",
+			expectedOriginal: 129,
+		},
+		{
+			name:             "array wrapped in an object is cut after the last element that fits",
+			value:            searchResult{TotalCount: 3, Items: items},
+			opts:             []ResultOption{WithMaxBytes(80)},
+			expectedText:     `{"total_count":3,"items":[{"id":1,"name":"first"},{"id":2,"name":"second"}]}`,
+			expectedOriginal: 100,
+		},
+		{
+			name:             "array wrapped in an object with a budget smaller than one element is empty",
+			value:            searchResult{TotalCount: 3, Items: items},
+			opts:             []ResultOption{WithMaxBytes(30)},
+			expectedText:     `{"total_count":3,"items":[]}`,
+			expectedOriginal: 100,
+		},
+		{
+			name:             "indented array wrapped in an object is cut after the last element that fits",
+			value:            searchResult{TotalCount: 3, Items: items},
+			opts:             []ResultOption{WithIndent(true), WithMaxBytes(100)},
+			expectedText:     "{\n  \"total_count\": 3,\n  \"items\": [\n    {\n      \"id\": 1,\n      \"name\": \"first\"\n    }\n  ]\n}",
+			expectedOriginal: 190,
+		},
+		{
+			name:             "largest array of an object is emptied before the next one is cut",
+			value:            taggedItems{Items: items, Tags: []string{"a", "b"}},
+			opts:             []ResultOption{WithMaxBytes(25)},
+			expectedText:     `{"items":[],"tags":["a"]}`,
+			expectedOriginal: 101,
+		},
+		{
+			name:             "object without arrays is cut at the budget",
+			value:            map[string]any{"name": "first"},
+			opts:             []ResultOption{WithMaxBytes(10)},
+			expectedText:     `{"name":"f`,
+			expectedOriginal: 16,
+			expectedInvalid:  true,
+		},
+		{
+			name:             "object is not cut inside a character",
+			value:            map[string]any{"name": "日本"},
+			opts:             []ResultOption{WithMaxBytes(12)},
+			expectedText:     `{"name":"日`,
+			expectedOriginal: 17,
+			expectedInvalid:  true,
+		},
+		{
+			name:             "budget smaller than an empty array",
+			value:            items,
+			opts:             []ResultOption{WithMaxBytes(1)},
+			expectedText:     `[`,
+			expectedOriginal: 74,
+			expectedInvalid:  true,
+		},
+		{
+			name:         "later options override earlier ones",
+			value:        items,
+			opts:         append(ContentLimits{MaxResultBytes: 10, IndentResults: true}.resultOptions(), WithIndent(false), WithMaxBytes(0)),
+			expectedText: `[{"id":1,"name":"first"},{"id":2,"name":"second"},{"id":3,"name":"third"}]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := MarshalledTextResult(tc.value, tc.opts...)
+			require.False(t, result.IsError)
+
+			text, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedText, text.Text)
+
+			if tc.expectedOriginal == 0 {
+				assert.Len(t, result.Content, 1)
+				return
+			}
+
+			require.Len(t, result.Content, 2)
+			noticeText, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			var notice TruncationNotice
+			require.NoError(t, json.Unmarshal([]byte(noticeText.Text), &notice))
+			assert.True(t, notice.Truncated)
+			assert.Equal(t, tc.expectedOriginal, notice.OriginalSize)
+			assert.Equal(t, len(tc.expectedText), notice.ReturnedSize)
+			assert.Equal(t, tc.expectedInvalid, notice.InvalidJSON)
+			assert.Equal(t, !tc.expectedInvalid, json.Valid([]byte(text.Text)))
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// errHeadOIDMismatch is returned when the branch moved since its head was read
+var errHeadOIDMismatch = errors.New("the head of the branch is not the expected head")
+
+// signedCommit is a commit created, and signed, by GitHub on a branch
+type signedCommit struct {
+	OID    string `json:"oid"`
+	URL    string `json:"url"`
+	Branch string `json:"branch"`
+}
+
+// createCommitOnBranch commits the additions and deletions on top of expectedHeadOID with the createCommitOnBranch
+// mutation, which signs the commit. The message is split into the headline and body of the commit message.
+func createCommitOnBranch(ctx context.Context, client *GQLClient, owner, repo, branch, expectedHeadOID, message string, additions []githubv4.FileAddition, deletions []githubv4.FileDeletion) (*signedCommit, error) {
+	headline, body, _ := strings.Cut(message, "\n")
+	commitMessage := githubv4.CommitMessage{Headline: githubv4.String(headline)}
+	if body = strings.TrimSpace(body); body != "" {
+		commitMessage.Body = githubv4.NewString(githubv4.String(body))
+	}
+
+	fileChanges := &githubv4.FileChanges{}
+	if len(additions) > 0 {
+		fileChanges.Additions = &additions
+	}
+	if len(deletions) > 0 {
+		fileChanges.Deletions = &deletions
+	}
+
+	var mutation struct {
+		CreateCommitOnBranch struct {
+			Commit struct {
+				OID githubv4.GitObjectID `graphql:"oid"`
+				URL githubv4.URI
+			}
+		} `graphql:"createCommitOnBranch(input: $input)"`
+	}
+	err := client.Mutate(ctx, &mutation, githubv4.CreateCommitOnBranchInput{
+		Branch: githubv4.CommittableBranch{
+			RepositoryNameWithOwner: githubv4.NewString(githubv4.String(owner + "/" + repo)),
+			BranchName:              githubv4.NewString(githubv4.String(branch)),
+		},
+		Message:         commitMessage,
+		ExpectedHeadOid: githubv4.GitObjectID(expectedHeadOID),
+		FileChanges:     fileChanges,
+	}, nil)
+	if err != nil {
+		// The API reports a moved branch as `Expected branch to point to "<oid>" but it did not`
+		if strings.Contains(strings.ToLower(err.Error()), "expected branch to point to") {
+			return nil, fmt.Errorf("%w: %w", errHeadOIDMismatch, err)
+		}
+		return nil, err
+	}
+
+	return &signedCommit{
+		OID:    string(mutation.CreateCommitOnBranch.Commit.OID),
+		URL:    mutation.CreateCommitOnBranch.Commit.URL.String(),
+		Branch: branch,
+	}, nil
+}
+
+// headOIDMismatchResult tells the agent to get the current head of the branch before trying again
+func headOIDMismatchResult(branch, expectedHeadOID string, err error) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("expected head OID mismatch: the head of branch %s is no longer %s. Get its current head with get_ref for refs/heads/%s, check the changes are still right on top of it, and retry with it as the expected head: %s", branch, expectedHeadOID, branch, err.Error()))
+}
+
+// CreateSignedCommit creates a tool to commit changes to a branch as a commit signed by GitHub
+func CreateSignedCommit(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_signed_commit",
+			mcp.WithDescription(t("TOOL_CREATE_SIGNED_COMMIT_DESCRIPTION", "Commit file additions and deletions to a branch of a GitHub repository as a single commit signed by GitHub, which is accepted on branches requiring verified signatures. The commit is only made if the branch still points at expected_head_oid")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_SIGNED_COMMIT_USER_TITLE", "Create signed commit"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("The name of the branch to commit to, without refs/heads/"),
+			),
+			mcp.WithString("expected_head_oid",
+				mcp.Required(),
+				mcp.Description("The SHA of the commit the branch is expected to point at, the new commit is made on top of it"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message, its first line is the headline of the commit"),
+			),
+			mcp.WithArray("additions",
+				mcp.Description("Files to add or replace"),
+				mcp.Items(map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"required":             []string{"path", "contents"},
+					"properties": map[string]any{
+						"path": map[string]any{
+							"type":        "string",
+							"description": "path to the file",
+						},
+						"contents": map[string]any{
+							"type":        "string",
+							"description": "the full contents of the file, base64 encoded with padding",
+						},
+					},
+				}),
+			),
+			mcp.WithArray("deletions",
+				mcp.Description("Paths of the files to delete, which must exist on the branch"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadOID, err := RequiredParam[string](request, "expected_head_oid")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			deletedPaths, err := OptionalStringArrayParam(request, "deletions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var additionsObj []any
+			if v, ok := request.GetArguments()["additions"]; ok && v != nil {
+				if additionsObj, ok = v.([]any); !ok {
+					return mcp.NewToolResultError("additions must be an array of objects with path and contents"), nil
+				}
+			}
+			if len(additionsObj) == 0 && len(deletedPaths) == 0 {
+				return mcp.NewToolResultError("at least one addition or deletion is required"), nil
+			}
+
+			// Paths must be unique across additions and deletions
+			seen := map[string]bool{}
+			additions := make([]githubv4.FileAddition, 0, len(additionsObj))
+			for _, addition := range additionsObj {
+				additionMap, ok := addition.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("each addition must be an object with path and contents"), nil
+				}
+				path, _ := additionMap["path"].(string)
+				contents, hasContents := additionMap["contents"].(string)
+				if path == "" || !hasContents {
+					return mcp.NewToolResultError("each addition must have a path and contents"), nil
+				}
+				if _, err := base64.StdEncoding.DecodeString(contents); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("contents of %s are not valid base64: %s", path, err.Error())), nil
+				}
+				if seen[path] {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is changed more than once", path)), nil
+				}
+				seen[path] = true
+				additions = append(additions, githubv4.FileAddition{
+					Path:     githubv4.String(path),
+					Contents: githubv4.Base64String(contents),
+				})
+			}
+			deletions := make([]githubv4.FileDeletion, 0, len(deletedPaths))
+			for _, path := range deletedPaths {
+				if seen[path] {
+					return mcp.NewToolResultError(fmt.Sprintf("%s is changed more than once", path)), nil
+				}
+				seen[path] = true
+				deletions = append(deletions, githubv4.FileDeletion{Path: githubv4.String(path)})
+			}
+
+			commit, err := createCommitOnBranch(ctx, NewGQLClient(getGQLClient), owner, repo, branch, expectedHeadOID, message, additions, deletions)
+			if errors.Is(err, errHeadOIDMismatch) {
+				return headOIDMismatchResult(branch, expectedHeadOID, err), nil
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create signed commit: %s", err.Error())), nil
+			}
+
+			r, err := json.Marshal(commit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
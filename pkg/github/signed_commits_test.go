@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCommitOnBranchMatcher matches the createCommitOnBranch mutation with the input
+func createCommitOnBranchMatcher(input githubv4.CreateCommitOnBranchInput, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+	return githubv4mock.NewMutationMatcher(
+		struct {
+			CreateCommitOnBranch struct {
+				Commit struct {
+					OID githubv4.GitObjectID `graphql:"oid"`
+					URL githubv4.URI
+				}
+			} `graphql:"createCommitOnBranch(input: $input)"`
+		}{},
+		input,
+		nil,
+		response,
+	)
+}
+
+func Test_CreateSignedCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := CreateSignedCommit(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_signed_commit", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "additions")
+	assert.Contains(t, tool.InputSchema.Properties, "deletions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "expected_head_oid", "message"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	branch := githubv4.CommittableBranch{
+		RepositoryNameWithOwner: githubv4.NewString("owner/repo"),
+		BranchName:              githubv4.NewString("main"),
+	}
+	committed := githubv4mock.DataResponse(map[string]any{
+		"createCommitOnBranch": map[string]any{
+			"commit": map[string]any{
+				"oid": "def456",
+				"url": "https://github.com/owner/repo/commit/def456",
+			},
+		},
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+		expected        signedCommit
+	}{
+		{
+			name: "commit additions and deletions",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				createCommitOnBranchMatcher(
+					githubv4.CreateCommitOnBranchInput{
+						Branch: branch,
+						Message: githubv4.CommitMessage{
+							Headline: "Update the docs",
+							Body:     githubv4.NewString("Move the guide to docs/"),
+						},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Additions: &[]githubv4.FileAddition{
+								{Path: "docs/guide.md", Contents: "IyBHdWlkZQo="},
+							},
+							Deletions: &[]githubv4.FileDeletion{{Path: "guide.md"}},
+						},
+					},
+					committed,
+				),
+			),
+			requestArgs: map[string]any{
+				"message": "Update the docs\n\nMove the guide to docs/",
+				"additions": []any{
+					map[string]any{"path": "docs/guide.md", "contents": "IyBHdWlkZQo="},
+				},
+				"deletions": []any{"guide.md"},
+			},
+			expected: signedCommit{
+				OID:    "def456",
+				URL:    "https://github.com/owner/repo/commit/def456",
+				Branch: "main",
+			},
+		},
+		{
+			name: "expected head OID mismatch",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				createCommitOnBranchMatcher(
+					githubv4.CreateCommitOnBranchInput{
+						Branch:          branch,
+						Message:         githubv4.CommitMessage{Headline: "Remove the guide"},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Deletions: &[]githubv4.FileDeletion{{Path: "guide.md"}},
+						},
+					},
+					githubv4mock.ErrorResponse(`Expected branch to point to "abc123" but it did not. Pull and try again.`),
+				),
+			),
+			requestArgs: map[string]any{
+				"message":   "Remove the guide",
+				"deletions": []any{"guide.md"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "expected head OID mismatch: the head of branch main is no longer abc123. Get its current head with get_ref for refs/heads/main",
+		},
+		{
+			name: "other GraphQL errors",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				createCommitOnBranchMatcher(
+					githubv4.CreateCommitOnBranchInput{
+						Branch:          branch,
+						Message:         githubv4.CommitMessage{Headline: "Remove the guide"},
+						ExpectedHeadOid: "abc123",
+						FileChanges: &githubv4.FileChanges{
+							Deletions: &[]githubv4.FileDeletion{{Path: "guide.md"}},
+						},
+					},
+					githubv4mock.ErrorResponse("A path was requested for deletion which does not exist as of commit oid `abc123`"),
+				),
+			),
+			requestArgs: map[string]any{
+				"message":   "Remove the guide",
+				"deletions": []any{"guide.md"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "failed to create signed commit",
+		},
+		{
+			name:         "no changes",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"message": "Empty",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at least one addition or deletion is required",
+		},
+		{
+			name:         "contents that are not base64",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"message": "Add the guide",
+				"additions": []any{
+					map[string]any{"path": "guide.md", "contents": "# Guide"},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "contents of guide.md are not valid base64",
+		},
+		{
+			name:         "path changed twice",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"message": "Replace the guide",
+				"additions": []any{
+					map[string]any{"path": "guide.md", "contents": "IyBHdWlkZQo="},
+				},
+				"deletions": []any{"guide.md"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "guide.md is changed more than once",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := CreateSignedCommit(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"branch":            "main",
+				"expected_head_oid": "abc123",
+			}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var commit signedCommit
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &commit))
+			assert.Equal(t, tc.expected, commit)
+		})
+	}
+}
@@ -16,53 +16,147 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
-	tsg := toolsets.NewToolsetGroup(readOnly)
+func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, limits ContentLimits, opts ...toolsets.ToolsetGroupOption) *toolsets.ToolsetGroup {
+	// The JSON results of every tool honour the content limits, options given by the caller come after
+	opts = append([]toolsets.ToolsetGroupOption{toolsets.WithToolWrapper(limits.limitJSONResult)}, opts...)
+	tsg := toolsets.NewToolsetGroup(readOnly, opts...)
 
 	// Define all available features with their default state (disabled)
 	// Create toolsets
 	repos := toolsets.NewToolset("repos", "GitHub Repository related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
+			toolsets.NewServerTool(GetRepository(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryTopics(getClient, t)),
+			toolsets.NewServerTool(ListContributors(getClient, t)),
+			toolsets.NewServerTool(ListStargazers(getClient, t)),
+			toolsets.NewServerTool(ListWatchers(getClient, t)),
+			toolsets.NewServerTool(IsRepositoryStarred(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryTraffic(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryActivity(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryEvents(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryLicense(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetCodeownersErrors(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryWebhooks(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryWebhook(getClient, t)),
+			toolsets.NewServerTool(ListTagProtections(getClient, t)),
+			toolsets.NewServerTool(ListAutolinks(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryCustomProperties(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryInvitations(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetRepositoryTree(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(ListCommitComments(getClient, t)),
+			toolsets.NewServerTool(CompareRefs(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(GetRef(getClient, t)),
+			toolsets.NewServerTool(ListCommitStatuses(getClient, t)),
+			toolsets.NewServerTool(ListReleases(getClient, t)),
+			toolsets.NewServerTool(GetRelease(getClient, t)),
+			toolsets.NewServerTool(GetLatestRelease(getClient, t)),
+			toolsets.NewServerTool(GetReleaseByTag(getClient, t)),
+			toolsets.NewServerTool(ListRepoRulesets(getClient, t)),
+			toolsets.NewServerTool(GetRepoRuleset(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryFromTemplate(getClient, t)),
+			toolsets.NewServerTool(UpdateRepository(getClient, t)),
+			toolsets.NewServerTool(ReplaceRepositoryTopics(getClient, t)),
+			toolsets.NewServerTool(StarRepository(getClient, t)),
+			toolsets.NewServerTool(UnstarRepository(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryWebhook(getClient, t)),
+			toolsets.NewServerTool(DeleteRepositoryWebhook(getClient, t)),
+			toolsets.NewServerTool(PingRepositoryWebhook(getClient, t)),
+			toolsets.NewServerTool(CreateTagProtection(getClient, t)),
+			toolsets.NewServerTool(DeleteTagProtection(getClient, t)),
+			toolsets.NewServerTool(CreateAutolink(getClient, t)),
+			toolsets.NewServerTool(DeleteAutolink(getClient, t)),
+			toolsets.NewServerTool(SetRepositoryCustomProperties(getClient, t)),
+			toolsets.NewServerTool(DeleteRepositoryInvitation(getClient, t)),
+			toolsets.NewServerTool(CreateRelease(getClient, t)),
+			toolsets.NewServerTool(UpdateRelease(getClient, t)),
+			toolsets.NewServerTool(DeleteRelease(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
+			toolsets.NewServerTool(TransferRepository(getClient, t)),
+			toolsets.NewServerTool(ArchiveRepository(getClient, t)),
+			toolsets.NewServerTool(UnarchiveRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
-			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
+			toolsets.NewServerTool(CreateRef(getClient, t)),
+			toolsets.NewServerTool(UpdateRef(getClient, t)),
+			toolsets.NewServerTool(DeleteRef(getClient, t)),
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
+			toolsets.NewServerTool(CreateCommitComment(getClient, t)),
+			toolsets.NewServerTool(MergeBranch(getClient, t)),
+			toolsets.NewServerTool(SyncFork(getClient, t)),
+			toolsets.NewServerTool(PushFiles(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(CreateSignedCommit(getGQLClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
 		).
 		AddResourceTemplates(
-			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
-			toolsets.NewServerResourceTemplate(GetRepositoryResourceBranchContent(getClient, getRawClient, t)),
-			toolsets.NewServerResourceTemplate(GetRepositoryResourceCommitContent(getClient, getRawClient, t)),
-			toolsets.NewServerResourceTemplate(GetRepositoryResourceTagContent(getClient, getRawClient, t)),
-			toolsets.NewServerResourceTemplate(GetRepositoryResourcePrContent(getClient, getRawClient, t)),
-		)
+			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, limits, t)).
+				WithCompletion(RepositoryResourceCompletionHandler(getClient)),
+			toolsets.NewServerResourceTemplate(GetRepositoryResourceBranchContent(getClient, getRawClient, limits, t)).
+				WithCompletion(RepositoryResourceCompletionHandler(getClient)),
+			toolsets.NewServerResourceTemplate(GetRepositoryResourceCommitContent(getClient, getRawClient, limits, t)).
+				WithCompletion(RepositoryResourceCompletionHandler(getClient)),
+			toolsets.NewServerResourceTemplate(GetRepositoryResourceTagContent(getClient, getRawClient, limits, t)).
+				WithCompletion(RepositoryResourceCompletionHandler(getClient)),
+			toolsets.NewServerResourceTemplate(GetRepositoryResourcePrContent(getClient, getRawClient, limits, t)).
+				WithCompletion(RepositoryResourceCompletionHandler(getClient)),
+		).
+		// list_contributors asks to be called again while GitHub computes the contributors
+		WithoutMemoization("list_contributors")
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
 			toolsets.NewServerTool(GetIssue(getClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(ListRepositoryLabels(getClient, t)),
+			toolsets.NewServerTool(ListMilestones(getClient, t)),
+			toolsets.NewServerTool(ListSubIssues(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
+			toolsets.NewServerTool(LockIssue(getClient, t)),
+			toolsets.NewServerTool(UnlockIssue(getClient, t)),
+			toolsets.NewServerTool(AddLabelsToIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveLabelFromIssue(getClient, t)),
+			toolsets.NewServerTool(AddAssigneesToIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveAssigneesFromIssue(getClient, t)),
+			toolsets.NewServerTool(SetIssueMilestone(getClient, t)),
+			toolsets.NewServerTool(CreateLabel(getClient, t)),
+			toolsets.NewServerTool(UpdateLabel(getClient, t)),
+			toolsets.NewServerTool(DeleteLabel(getClient, t)),
+			toolsets.NewServerTool(CreateMilestone(getClient, t)),
+			toolsets.NewServerTool(UpdateMilestone(getClient, t)),
+			toolsets.NewServerTool(CloseMilestone(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
+			toolsets.NewServerTool(TransferIssue(getGQLClient, t)),
+			toolsets.NewServerTool(AddSubIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
+			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetIssueResourceContent(getClient, t)),
 		)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(ListUserRepositoryInvitations(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AcceptRepositoryInvitation(getClient, t)),
+			toolsets.NewServerTool(DeclineRepositoryInvitation(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
@@ -70,6 +164,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
+			toolsets.NewServerTool(ListCheckRunsForRef(getClient, t)),
+			toolsets.NewServerTool(GetCheckRunAnnotations(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
@@ -87,6 +183,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddPullRequestReviewCommentToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+		).
+		AddResourceTemplates(
+			toolsets.NewServerResourceTemplate(GetPullRequestResourceContent(getClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
@@ -114,21 +213,60 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
 		AddReadTools(
 			toolsets.NewServerTool(ListWorkflows(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowDispatchInputs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRuns(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowRunAttempt(getClient, t)),
+			toolsets.NewServerTool(WaitForWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowRunAnnotations(getClient, t)),
+			toolsets.NewServerTool(ListOrgWorkflowRuns(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
+			toolsets.NewServerTool(GetLatestArtifactByName(getClient, t)),
+			toolsets.NewServerTool(GetArtifactContent(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(GetWorkflowUsage(getClient, t)),
+			toolsets.NewServerTool(GetPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(ListActionsSecrets(getClient, t)),
+			toolsets.NewServerTool(ListOrgActionsSecrets(getClient, t)),
+			toolsets.NewServerTool(ListActionsVariables(getClient, t)),
+			toolsets.NewServerTool(GetActionsPermissions(getClient, t)),
+			toolsets.NewServerTool(GetOIDCSubClaim(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
+			toolsets.NewServerTool(EnableWorkflow(getClient, t)),
+			toolsets.NewServerTool(DisableWorkflow(getClient, t)),
 			toolsets.NewServerTool(RerunWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(ApproveWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployments(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateActionsSecret(getClient, t)),
+			toolsets.NewServerTool(CreateActionsVariable(getClient, t)),
+			toolsets.NewServerTool(UpdateActionsVariable(getClient, t)),
+			toolsets.NewServerTool(DeleteActionsVariable(getClient, t)),
+			toolsets.NewServerTool(SetActionsPermissions(getClient, t)),
+			toolsets.NewServerTool(SetOIDCSubClaim(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+		).
+		// wait_for_workflow_run polls a run that changes between calls, and can be called again after a timeout
+		WithoutMemoization("wait_for_workflow_run")
+
+	deployments := toolsets.NewToolset("deployments", "Deployment environments and their protection rules").
+		AddReadTools(
+			toolsets.NewServerTool(ListEnvironments(getClient, t)),
+			toolsets.NewServerTool(GetEnvironment(getClient, t)),
+			toolsets.NewServerTool(ListDeploymentBranchPolicies(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateOrUpdateEnvironment(getClient, t)),
+			toolsets.NewServerTool(DeleteEnvironment(getClient, t)),
+			toolsets.NewServerTool(CreateDeploymentBranchPolicy(getClient, t)),
+			toolsets.NewServerTool(DeleteDeploymentBranchPolicy(getClient, t)),
 		)
 
 	// Keep experiments alive so the system doesn't error out when it's always enabled
@@ -136,7 +274,14 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 
 	contextTools := toolsets.NewToolset("context", "Tools that provide context about the current user and GitHub context you are operating in").
 		AddReadTools(
-			toolsets.NewServerTool(GetMe(getClient, t)),
+			toolsets.NewServerTool(GetMe(getClient, limits, t)),
+			toolsets.NewServerTool(ListMyRecentActivity(getClient, t)),
+		)
+
+	// batch_read looks up the other tools when it is called, so it sees toolsets enabled at runtime
+	batch := toolsets.NewToolset("batch", "Execute several read-only tools in one request").
+		AddReadTools(
+			toolsets.NewServerTool(BatchRead(tsg, t)),
 		)
 
 	// Add toolsets to the group
@@ -146,10 +291,12 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(users)
 	tsg.AddToolset(pullRequests)
 	tsg.AddToolset(actions)
+	tsg.AddToolset(deployments)
 	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
 	tsg.AddToolset(notifications)
 	tsg.AddToolset(experiments)
+	tsg.AddToolset(batch)
 
 	return tsg
 }
@@ -162,9 +309,13 @@ func InitDynamicToolset(s *server.MCPServer, tsg *toolsets.ToolsetGroup, t trans
 		AddReadTools(
 			toolsets.NewServerTool(ListAvailableToolsets(tsg, t)),
 			toolsets.NewServerTool(GetToolsetsTools(tsg, t)),
+			toolsets.NewServerTool(DescribeTool(tsg, t)),
+			toolsets.NewServerTool(FindTools(tsg, t)),
 			toolsets.NewServerTool(EnableToolset(s, tsg, t)),
 		)
 
+	dynamicToolSelection.SetToolPrefix(tsg.ToolPrefix())
+	dynamicToolSelection.SetInstrumenter(tsg.Instrumenter())
 	dynamicToolSelection.Enabled = true
 	return dynamicToolSelection
 }
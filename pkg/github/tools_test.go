@@ -0,0 +1,259 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DefaultToolsetGroup_ToolPrefix(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, ContentLimits{}, toolsets.WithToolPrefix("github_"))
+	require.NoError(t, tsg.EnableToolsets([]string{"all"}))
+
+	seen := make(map[string]bool)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			assert.True(t, strings.HasPrefix(tool.Tool.Name, "github_"), "tool %s is missing the prefix", tool.Tool.Name)
+			assert.False(t, seen[tool.Tool.Name], "tool %s is registered more than once", tool.Tool.Name)
+			seen[tool.Tool.Name] = true
+		}
+	}
+	assert.NotEmpty(t, seen)
+
+	dynamic := InitDynamicToolset(nil, tsg, translations.NullTranslationHelper)
+	dynamicTools := make(map[string]server.ServerTool)
+	for _, tool := range dynamic.GetActiveTools() {
+		assert.True(t, strings.HasPrefix(tool.Tool.Name, "github_"), "dynamic tool %s is missing the prefix", tool.Tool.Name)
+		dynamicTools[tool.Tool.Name] = tool
+	}
+
+	// The descriptions of the dynamic tools refer to each other by their prefixed names
+	unprefixed := regexp.MustCompile(`(^|[^_])\b(enable_toolset|get_toolset_tools|list_available_toolsets)\b`)
+	for name, tool := range dynamicTools {
+		assert.False(t, unprefixed.MatchString(tool.Tool.Description), "description of %s names an unprefixed tool: %s", name, tool.Tool.Description)
+	}
+	assert.Contains(t, dynamicTools["github_enable_toolset"].Tool.Description, "github_get_toolset_tools and github_list_available_toolsets")
+	assert.Contains(t, dynamicTools["github_list_available_toolsets"].Tool.Description, "Call github_get_toolset_tools")
+	assert.Contains(t, dynamicTools["github_find_tools"].Tool.Description, "use github_enable_toolset")
+
+	// The hint of find_tools names the prefixed enable_toolset
+	tsg = DefaultToolsetGroup(false, nil, nil, nil, translations.NullTranslationHelper, ContentLimits{}, toolsets.WithToolPrefix("github_"))
+	_, handler := FindTools(tsg, translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{"query": "list branches"}))
+	require.NoError(t, err)
+	var found []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &found))
+	require.NotEmpty(t, found)
+	assert.Equal(t, "github_list_branches", found[0]["name"])
+	assert.Equal(t, "Call github_enable_toolset with toolset repos to use this tool", found[0]["hint"])
+}
+
+func Test_DefaultToolsetGroup_ReadOnly(t *testing.T) {
+	readWrite := DefaultToolsetGroup(false, stubGetClientFn(nil), nil, nil, translations.NullTranslationHelper, ContentLimits{})
+	readOnly := DefaultToolsetGroup(true, stubGetClientFn(nil), nil, nil, translations.NullTranslationHelper, ContentLimits{})
+
+	// Write tools are not available in read-only mode
+	for _, name := range []string{
+		"create_repository_webhook",
+		"delete_repository_webhook",
+		"ping_repository_webhook",
+		"update_release",
+		"delete_release",
+		"update_repository",
+		"transfer_repository",
+		"archive_repository",
+		"unarchive_repository",
+		"create_repository_from_template",
+		"merge_branch",
+		"sync_fork",
+		"enable_workflow",
+		"disable_workflow",
+		"set_actions_permissions",
+		"create_deployment_branch_policy",
+		"set_oidc_sub_claim",
+		"create_release",
+		"replace_repository_topics",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, found := readWrite.FindTool(name)
+			require.True(t, found, "%s should be available outside read-only mode", name)
+			_, _, found = readOnly.FindTool(name)
+			assert.False(t, found, "%s should not be available in read-only mode", name)
+		})
+	}
+}
+
+func Test_DefaultToolsetGroup_TranslationsExport(t *testing.T) {
+	helper, resolved := translations.OverrideTranslationHelper(map[string]string{
+		"TOOL_GET_ME_DESCRIPTION": "Overridden description",
+	})
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, helper, ContentLimits{})
+	require.NoError(t, tsg.EnableToolsets([]string{"all"}))
+
+	exported := make(map[string]bool)
+	for _, value := range resolved() {
+		exported[value] = true
+	}
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			if tool.Tool.Name == "get_me" {
+				assert.Equal(t, "Overridden description", tool.Tool.Description)
+			}
+
+			// Every tool's description and title must be exported so users can override them
+			assert.True(t, exported[tool.Tool.Description], "description of %s is not exported", tool.Tool.Name)
+			assert.True(t, exported[tool.Tool.Annotations.Title], "title of %s is not exported", tool.Tool.Name)
+		}
+	}
+}
+
+func Test_DefaultToolsetGroup_Memoization(t *testing.T) {
+	var getIssueRequests, createCommentRequests int
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				getIssueRequests++
+				mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(42)})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				createCommentRequests++
+				mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))})(w, r)
+			}),
+		),
+	)
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(mockedClient)), nil, nil, translations.NullTranslationHelper, ContentLimits{},
+		toolsets.WithMemoizer(toolsets.NewMemoizer(time.Minute, 10)))
+	require.NoError(t, tsg.EnableToolsets([]string{"issues"}))
+
+	call := func(name string, args map[string]any) {
+		tool, ok := tsg.LookupTool(name)
+		require.True(t, ok)
+		result, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+	}
+
+	getIssue := map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)}
+	for i := 0; i < 2; i++ {
+		call("get_issue", getIssue)
+	}
+	assert.Equal(t, 1, getIssueRequests, "the second identical get_issue call should be memoized")
+
+	for i := 0; i < 2; i++ {
+		call("add_issue_comment", map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42), "body": "LGTM"})
+	}
+	assert.Equal(t, 2, createCommentRequests, "write tools should never be memoized")
+
+	call("get_issue", getIssue)
+	assert.Equal(t, 2, getIssueRequests, "get_issue after a write should not return the memoized result")
+}
+
+func Test_DefaultToolsetGroup_MemoizationOptOut(t *testing.T) {
+	var contributorsRequests, runRequests int
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposContributorsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				contributorsRequests++
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{}`))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsRunsByOwnerByRepoByRunId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				runRequests++
+				mockResponse(t, http.StatusOK, &github.WorkflowRun{
+					ID:         github.Ptr(int64(12345)),
+					Status:     github.Ptr("completed"),
+					Conclusion: github.Ptr("success"),
+				})(w, r)
+			}),
+		),
+	)
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(mockedClient)), nil, nil, translations.NullTranslationHelper, ContentLimits{},
+		toolsets.WithMemoizer(toolsets.NewMemoizer(time.Minute, 10)))
+	require.NoError(t, tsg.EnableToolsets([]string{"repos", "actions"}))
+
+	call := func(name string, args map[string]any) {
+		tool, ok := tsg.LookupTool(name)
+		require.True(t, ok)
+		result, err := tool.Handler(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+	}
+
+	for i := 0; i < 2; i++ {
+		call("list_contributors", map[string]any{"owner": "owner", "repo": "repo"})
+		call("wait_for_workflow_run", map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(12345)})
+	}
+
+	assert.Equal(t, 2, contributorsRequests, "list_contributors asks to be retried and should not be memoized")
+	assert.Equal(t, 2, runRequests, "wait_for_workflow_run polls and should not be memoized")
+}
+
+func Test_DefaultToolsetGroup_ContentLimits(t *testing.T) {
+	mockedClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				&github.Issue{Number: github.Ptr(42), Title: github.Ptr("A title long enough to exceed the budget")},
+			),
+		)
+	}
+	call := func(limits ContentLimits) *mcp.CallToolResult {
+		tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(mockedClient())), nil, nil, translations.NullTranslationHelper, limits)
+		require.NoError(t, tsg.EnableToolsets([]string{"issues"}))
+		tool, ok := tsg.LookupTool("get_issue")
+		require.True(t, ok)
+		result, err := tool.Handler(context.Background(), createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42)}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		return result
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		result := call(ContentLimits{})
+		require.Len(t, result.Content, 1)
+		assert.NotContains(t, getTextResult(t, result).Text, "\n")
+	})
+
+	t.Run("indented results", func(t *testing.T) {
+		result := call(ContentLimits{IndentResults: true})
+		require.Len(t, result.Content, 1)
+		assert.Contains(t, getTextResult(t, result).Text, "\n  \"number\": 42")
+	})
+
+	t.Run("byte budget", func(t *testing.T) {
+		result := call(ContentLimits{MaxResultBytes: 20})
+		require.Len(t, result.Content, 2)
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.LessOrEqual(t, len(text.Text), 20)
+
+		noticeText, ok := result.Content[1].(mcp.TextContent)
+		require.True(t, ok)
+		var notice TruncationNotice
+		require.NoError(t, json.Unmarshal([]byte(noticeText.Text), &notice))
+		assert.True(t, notice.Truncated)
+		assert.Greater(t, notice.OriginalSize, 20)
+	})
+}
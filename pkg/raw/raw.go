@@ -2,9 +2,17 @@
 package raw
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	gogithub "github.com/google/go-github/v72/github"
 )
@@ -55,9 +63,16 @@ func (c *Client) commitURL(owner, repo, sha, path string) string {
 type RawContentOpts struct {
 	Ref string
 	SHA string
+	// MaxBytes, if positive, limits the content returned to MaxBytes bytes. Use Truncated to find
+	// out whether the content was cut short.
+	MaxBytes int64
 }
 
 // GetRawContent fetches the raw content of a file from a GitHub repository.
+//
+// When opts.MaxBytes is set, only the first MaxBytes bytes are requested with a Range header, and the
+// response has the status 206 Partial Content if the server honoured it. Servers that ignore the Range
+// header reply with the full content, whose body is then limited to MaxBytes while reading.
 func (c *Client) GetRawContent(ctx context.Context, owner, repo, path string, opts *RawContentOpts) (*http.Response, error) {
 	url := c.URLFromOpts(opts, owner, repo, path)
 	req, err := c.newRequest("GET", url, nil)
@@ -65,5 +80,204 @@ func (c *Client) GetRawContent(ctx context.Context, owner, repo, path string, op
 		return nil, err
 	}
 
-	return c.client.Client().Do(req)
+	if opts == nil || opts.MaxBytes <= 0 {
+		return c.client.Client().Do(req.WithContext(ctx))
+	}
+
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", opts.MaxBytes-1))
+	resp, err := c.client.Client().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// An empty file can't satisfy any range, so ask for it again without one
+		_ = resp.Body.Close()
+		req.Header.Del("Range")
+		resp, err = c.client.Client().Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limitResponseBody(resp, opts.MaxBytes)
+	return resp, nil
+}
+
+// RawContentResult is the raw content of a file, with the metadata callers need to present it.
+type RawContentResult struct {
+	// Content is the file content. For responses other than 200 OK, it is the body of the error response.
+	Content []byte
+	// StatusCode is the status of the response. A 206 Partial Content response to a MaxBytes request
+	// is reported as 200 OK, since the truncation is reported by Truncated.
+	StatusCode int
+	// ContentType is the MIME type of the content, see ResolveMIMEType.
+	ContentType string
+	// Size is the size of the whole file when it is known, otherwise the size of Content.
+	Size int64
+	// Truncated reports whether Content was cut at RawContentOpts.MaxBytes.
+	Truncated bool
+	// Ref is the commit SHA or ref the content was fetched from, HEAD if none was given.
+	Ref string
+}
+
+// Found reports whether the file content was fetched.
+func (r *RawContentResult) Found() bool {
+	return r.StatusCode == http.StatusOK
+}
+
+// sniffLen is the number of leading bytes of the content inspected to tell text from binary.
+const sniffLen = 512
+
+// IsText reports whether the content should be presented as text rather than as a binary blob.
+// Besides the MIME type, the first bytes of the content are inspected, because the raw API serves
+// many binary files with a text or generic application type.
+func (r *RawContentResult) IsText() bool {
+	sniff := r.Content[:min(len(r.Content), sniffLen)]
+	if !looksLikeText(sniff, len(r.Content) > sniffLen) {
+		return false
+	}
+	if r.ContentType == "" {
+		return strings.HasPrefix(http.DetectContentType(sniff), "text/")
+	}
+	return strings.HasPrefix(r.ContentType, "text") || strings.HasPrefix(r.ContentType, "application")
+}
+
+// looksLikeText reports whether the bytes are valid UTF-8 without NUL bytes. When the bytes are a
+// prefix of the content, a multi-byte character cut at the end is ignored.
+func looksLikeText(b []byte, prefix bool) bool {
+	if bytes.IndexByte(b, 0) >= 0 {
+		return false
+	}
+	if prefix {
+		for i := len(b) - 1; i >= max(0, len(b)-utf8.UTFMax); i-- {
+			if utf8.RuneStart(b[i]) {
+				if !utf8.FullRune(b[i:]) {
+					b = b[:i]
+				}
+				break
+			}
+		}
+	}
+	return utf8.Valid(b)
+}
+
+// GetRawContentResult fetches the raw content of a file from a GitHub repository and reads it, so that
+// callers don't have to handle the response. A missing file is not an error, it is reported by the
+// status code of the result.
+func (c *Client) GetRawContentResult(ctx context.Context, owner, repo, path string, opts *RawContentOpts) (*RawContentResult, error) {
+	resp, err := c.GetRawContent(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read raw content: %w", err)
+	}
+
+	result := &RawContentResult{
+		Content:    content,
+		StatusCode: resp.StatusCode,
+		Size:       int64(len(content)),
+		Truncated:  Truncated(resp),
+		Ref:        "HEAD",
+	}
+	if opts != nil && opts.SHA != "" {
+		result.Ref = opts.SHA
+	} else if opts != nil && opts.Ref != "" {
+		result.Ref = opts.Ref
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		result.ContentType = resp.Header.Get("Content-Type")
+		return result, nil
+	}
+
+	result.StatusCode = http.StatusOK
+	result.ContentType = ResolveMIMEType(path, resp.Header.Get("Content-Type"))
+	if size := contentSize(resp); size > result.Size {
+		result.Size = size
+	}
+	return result, nil
+}
+
+// ResolveMIMEType returns the MIME type of a file served with the given Content-Type. Markdown files are
+// always text/markdown, because the raw API serves them as plain text, and files served without a
+// Content-Type fall back to the type registered for their extension.
+func ResolveMIMEType(path string, contentType string) string {
+	ext := filepath.Ext(path)
+	switch {
+	case ext == ".md":
+		return "text/markdown"
+	case contentType == "":
+		return mime.TypeByExtension(ext)
+	default:
+		return contentType
+	}
+}
+
+// contentSize returns the size of the whole file from a Content-Range or Content-Length header, or -1.
+// The total size in a Content-Range is the part after the slash of "bytes 0-99/1234", it may be "*".
+func contentSize(resp *http.Response) int64 {
+	if resp.StatusCode == http.StatusPartialContent {
+		if _, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/"); ok {
+			if size, err := strconv.ParseInt(total, 10, 64); err == nil {
+				return size
+			}
+		}
+		return -1
+	}
+	return resp.ContentLength
+}
+
+// Truncated reports whether the body of a response returned by GetRawContent was cut at
+// RawContentOpts.MaxBytes. When the size of the content isn't known upfront, the result is only
+// accurate once the body has been read to the end.
+func Truncated(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	body, ok := resp.Body.(*limitedBody)
+	return ok && body.truncated
+}
+
+// limitedBody limits the body of a response to a maximum number of bytes, and records whether
+// content was left unread.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	truncated bool
+}
+
+func limitResponseBody(resp *http.Response, maxBytes int64) {
+	resp.Body = &limitedBody{
+		body:      resp.Body,
+		remaining: maxBytes,
+		truncated: contentSize(resp) > maxBytes,
+	}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		if !b.truncated {
+			// Peek past the limit to find out whether there was more content
+			var next [1]byte
+			n, _ := io.ReadFull(b.body, next[:])
+			b.truncated = n > 0
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
 }
@@ -1,7 +1,11 @@
 package raw
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"testing"
@@ -87,19 +91,42 @@ func TestGetRawContent(t *testing.T) {
 			ghClient := github.NewClient(mockedClient)
 			client := NewClient(ghClient, base)
 			resp, err := client.GetRawContent(context.Background(), tc.owner, tc.repo, tc.path, tc.opts)
-			defer func() {
-				_ = resp.Body.Close()
-			}()
 			if tc.expectError != "" {
 				require.Error(t, err)
 				return
 			}
 			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
 			require.Equal(t, tc.statusCode, resp.StatusCode)
 		})
 	}
 }
 
+// failingTransport fails every request, as a DNS failure or a cancelled context would.
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+func TestGetRawContent_TransportError(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	ghClient := github.NewClient(&http.Client{Transport: failingTransport{err: errors.New("no such host")}})
+	client := NewClient(ghClient, base)
+
+	resp, err := client.GetRawContent(context.Background(), "octocat", "hello", "README.md", &RawContentOpts{MaxBytes: 10})
+	require.ErrorContains(t, err, "no such host")
+	require.Nil(t, resp)
+
+	result, err := client.GetRawContentResult(context.Background(), "octocat", "hello", "README.md", nil)
+	require.ErrorContains(t, err, "no such host")
+	require.Nil(t, result)
+}
+
 func TestUrlFromOpts(t *testing.T) {
 	base, _ := url.Parse("https://raw.example.com/")
 	ghClient := github.NewClient(nil)
@@ -148,3 +175,255 @@ func TestUrlFromOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRawContent_MaxBytes(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	content := "0123456789"
+
+	// honorRange serves the requested range of the content with 206 Partial Content
+	honorRange := func(t *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var start, end int
+			_, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+			require.NoError(t, err)
+			end = min(end, len(content)-1)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(content[start : end+1]))
+		}
+	}
+	// ignoreRange serves the whole content, without a Content-Length so that the size is unknown
+	ignoreRange := func(_ *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Transfer-Encoding", "chunked")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(content))
+		}
+	}
+
+	tests := []struct {
+		name              string
+		handler           func(t *testing.T) http.HandlerFunc
+		maxBytes          int64
+		expectedStatus    int
+		expectedContent   string
+		expectedTruncated bool
+	}{
+		{
+			name:              "server honors range",
+			handler:           honorRange,
+			maxBytes:          4,
+			expectedStatus:    http.StatusPartialContent,
+			expectedContent:   "0123",
+			expectedTruncated: true,
+		},
+		{
+			name:              "server honors range for a small file",
+			handler:           honorRange,
+			maxBytes:          100,
+			expectedStatus:    http.StatusPartialContent,
+			expectedContent:   content,
+			expectedTruncated: false,
+		},
+		{
+			name:              "server ignores range",
+			handler:           ignoreRange,
+			maxBytes:          4,
+			expectedStatus:    http.StatusOK,
+			expectedContent:   "0123",
+			expectedTruncated: true,
+		},
+		{
+			name:              "server ignores range for a small file",
+			handler:           ignoreRange,
+			maxBytes:          100,
+			expectedStatus:    http.StatusOK,
+			expectedContent:   content,
+			expectedTruncated: false,
+		},
+		{
+			name:              "server ignores range for a file of exactly max bytes",
+			handler:           ignoreRange,
+			maxBytes:          int64(len(content)),
+			expectedStatus:    http.StatusOK,
+			expectedContent:   content,
+			expectedTruncated: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(GetRawReposContentsByOwnerByRepoByPath, tc.handler(t)),
+			)
+			client := NewClient(github.NewClient(mockedClient), base)
+
+			resp, err := client.GetRawContent(context.Background(), "octocat", "hello", "data.txt", &RawContentOpts{MaxBytes: tc.maxBytes})
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedStatus, resp.StatusCode)
+			require.Equal(t, tc.expectedContent, string(body))
+			require.Equal(t, tc.expectedTruncated, Truncated(resp))
+		})
+	}
+}
+
+func TestGetRawContent_MaxBytesEmptyFile(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	var ranges []string
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			GetRawReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				if r.Header.Get("Range") != "" {
+					w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}),
+		),
+	)
+	client := NewClient(github.NewClient(mockedClient), base)
+
+	resp, err := client.GetRawContent(context.Background(), "octocat", "hello", "empty.txt", &RawContentOpts{MaxBytes: 10})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Empty(t, body)
+	require.False(t, Truncated(resp))
+	require.Equal(t, []string{"bytes=0-9", ""}, ranges)
+}
+
+func TestResolveMIMEType(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		expected    string
+	}{
+		{name: "markdown served as plain text", path: "README.md", contentType: "text/plain; charset=utf-8", expected: "text/markdown"},
+		{name: "markdown without content type", path: "docs/guide.md", contentType: "", expected: "text/markdown"},
+		{name: "content type is kept", path: "main.go", contentType: "text/plain; charset=utf-8", expected: "text/plain; charset=utf-8"},
+		{name: "extension fallback", path: "logo.png", contentType: "", expected: "image/png"},
+		{name: "unknown extension without content type", path: "Makefile", contentType: "", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ResolveMIMEType(tc.path, tc.contentType))
+		})
+	}
+}
+
+func TestRawContentResult_IsText(t *testing.T) {
+	// A three byte character cut after its first byte at the end of the sniffed prefix
+	cutRune := append(bytes.Repeat([]byte("a"), sniffLen-1), "世界"...)
+
+	tests := []struct {
+		name        string
+		content     []byte
+		contentType string
+		expected    bool
+	}{
+		{name: "text", content: []byte("package main"), contentType: "text/plain", expected: true},
+		{name: "json", content: []byte(`{"a": 1}`), contentType: "application/json", expected: true},
+		{name: "image", content: []byte("GIF89a"), contentType: "image/gif", expected: false},
+		{name: "NUL byte served as text", content: []byte("GIF\x00\x01"), contentType: "text/plain", expected: false},
+		{name: "invalid UTF-8 served as text", content: []byte{0xff, 0xfe, 'a'}, contentType: "text/plain", expected: false},
+		{name: "character cut by the sniffed prefix", content: cutRune, contentType: "text/plain", expected: true},
+		{name: "sniffed text without content type", content: []byte("hello"), contentType: "", expected: true},
+		{name: "sniffed image without content type", content: []byte("\x89PNG\r\n\x1a\n"), contentType: "", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := &RawContentResult{Content: tc.content, ContentType: tc.contentType}
+			require.Equal(t, tc.expected, result.IsText())
+		})
+	}
+}
+
+func TestGetRawContentResult(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+
+	t.Run("found", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetRawReposContentsByOwnerByRepoByBranchByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+					_, _ = w.Write([]byte("# Test file"))
+				}),
+			),
+		)
+		client := NewClient(github.NewClient(mockedClient), base)
+
+		result, err := client.GetRawContentResult(context.Background(), "octocat", "hello", "README.md", &RawContentOpts{Ref: "refs/heads/main"})
+		require.NoError(t, err)
+		require.True(t, result.Found())
+		require.True(t, result.IsText())
+		require.Equal(t, &RawContentResult{
+			Content:     []byte("# Test file"),
+			StatusCode:  http.StatusOK,
+			ContentType: "text/markdown",
+			Size:        11,
+			Ref:         "refs/heads/main",
+		}, result)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetRawReposContentsByOwnerByRepoBySHAByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "image/png")
+					w.Header().Set("Content-Range", "bytes 0-3/2048")
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write([]byte("\x89PNG"))
+				}),
+			),
+		)
+		client := NewClient(github.NewClient(mockedClient), base)
+
+		result, err := client.GetRawContentResult(context.Background(), "octocat", "hello", "logo.png", &RawContentOpts{SHA: "abc123", MaxBytes: 4})
+		require.NoError(t, err)
+		require.True(t, result.Found())
+		require.False(t, result.IsText())
+		require.True(t, result.Truncated)
+		require.Equal(t, http.StatusOK, result.StatusCode)
+		require.Equal(t, int64(2048), result.Size)
+		require.Equal(t, "abc123", result.Ref)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockedClient := mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				GetRawReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "text/plain")
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte("404: Not Found"))
+				}),
+			),
+		)
+		client := NewClient(github.NewClient(mockedClient), base)
+
+		result, err := client.GetRawContentResult(context.Background(), "octocat", "hello", "missing.md", nil)
+		require.NoError(t, err)
+		require.False(t, result.Found())
+		require.Equal(t, &RawContentResult{
+			Content:     []byte("404: Not Found"),
+			StatusCode:  http.StatusNotFound,
+			ContentType: "text/plain",
+			Size:        14,
+			Ref:         "HEAD",
+		}, result)
+	})
+}
@@ -0,0 +1,168 @@
+package toolsets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultAuditRedactedFields are the argument names whose values are hashed in audit entries
+// unless other fields are configured. They commonly carry large or user-provided content.
+var DefaultAuditRedactedFields = []string{"body", "content", "files", "message", "comment", "description"}
+
+// auditIdentifierArguments are the argument names logged in clear in audit entries, alongside
+// numeric identifiers whose name ends in the word number or id, e.g. issue_number or pullNumber.
+var auditIdentifierArguments = []string{"owner", "repo", "ref", "branch", "tag"}
+
+// secretLikeArgumentMarkers identify argument names that are never logged, not even hashed, when
+// the last word of the name is one of them, e.g. github_token or api_key but not key_prefix.
+var secretLikeArgumentMarkers = []string{"secret", "token", "password", "key", "credential", "credentials"}
+
+// secretArgumentNames are argument names never logged because they carry secret values without
+// a secret-like name, such as the plaintext of create_or_update_actions_secret.
+var secretArgumentNames = []string{"value"}
+
+// AuditEntry is a structured record of a single write tool invocation.
+type AuditEntry struct {
+	Time      time.Time      `json:"time"`
+	Tool      string         `json:"tool"`
+	Actor     string         `json:"actor,omitempty"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	Success   bool           `json:"success"`
+	Error     string         `json:"error,omitempty"`
+	Duration  time.Duration  `json:"duration"`
+}
+
+// AuditLogger receives an entry for every write tool invocation. The context is that of the tool
+// call, so implementations can attach request-scoped details such as the caller identity.
+type AuditLogger interface {
+	LogToolCall(ctx context.Context, entry AuditEntry)
+}
+
+// AuditActorFunc returns who is making the tool call of the context, e.g. the login of the
+// authenticated user, recorded as the actor of audit entries.
+type AuditActorFunc func(ctx context.Context) string
+
+// AuditLoggerFunc is an adapter to allow the use of ordinary functions as an AuditLogger.
+type AuditLoggerFunc func(ctx context.Context, entry AuditEntry)
+
+func (f AuditLoggerFunc) LogToolCall(ctx context.Context, entry AuditEntry) {
+	f(ctx, entry)
+}
+
+// AuditTool wraps the tool handler so that every invocation is recorded with the audit logger.
+// Only identifier arguments such as owner, repo or issue_number are logged in clear, arguments named
+// in redactedFields are replaced with a hash of their value, and all other arguments are omitted.
+// If actor is not nil, it fills in who made the call.
+func AuditTool(tool server.ServerTool, logger AuditLogger, redactedFields []string, actor AuditActorFunc) server.ServerTool {
+	name := tool.Tool.Name
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := handler(ctx, request)
+
+		entry := AuditEntry{
+			Time:      start,
+			Tool:      name,
+			Arguments: auditArguments(request.GetArguments(), redactedFields),
+			Success:   err == nil && (result == nil || !result.IsError),
+			Duration:  time.Since(start),
+		}
+		if actor != nil {
+			entry.Actor = actor(ctx)
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		logger.LogToolCall(ctx, entry)
+
+		return result, err
+	}
+	return tool
+}
+
+// auditArguments returns the arguments to log: identifiers as they are, redacted fields hashed, and
+// nothing else.
+func auditArguments(args map[string]any, redactedFields []string) map[string]any {
+	audited := make(map[string]any, len(args))
+	for name, value := range args {
+		switch {
+		case isIdentifierArgument(name):
+			audited[name] = value
+		case isSecretLikeArgument(name), containsFold(secretArgumentNames, name):
+			continue
+		case containsFold(redactedFields, name):
+			audited[name] = hashArgument(value)
+		}
+	}
+	if len(audited) == 0 {
+		return nil
+	}
+	return audited
+}
+
+func isIdentifierArgument(name string) bool {
+	if containsFold(auditIdentifierArguments, name) {
+		return true
+	}
+	words := argumentWords(name)
+	return len(words) > 0 && containsFold([]string{"number", "id"}, words[len(words)-1])
+}
+
+func isSecretLikeArgument(name string) bool {
+	words := argumentWords(name)
+	return len(words) > 0 && containsFold(secretLikeArgumentMarkers, words[len(words)-1])
+}
+
+// argumentWords splits a snake_case or camelCase argument name into its words, e.g. runID into run
+// and ID.
+func argumentWords(name string) []string {
+	var words []string
+	var word []rune
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-':
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = word[:0]
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && unicode.IsLower(word[len(word)-1]):
+			words = append(words, string(word))
+			word = word[:0]
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashArgument returns a stable SHA-256 digest of the JSON encoding of the value, so that audit
+// entries can be correlated without exposing the content.
+func hashArgument(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", value))
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
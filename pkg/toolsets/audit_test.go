@@ -0,0 +1,196 @@
+package toolsets
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type memoryAuditLogger struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+func (l *memoryAuditLogger) LogToolCall(_ context.Context, entry AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+func newAuditedToolsetGroup(logger AuditLogger) *ToolsetGroup {
+	tsg := NewToolsetGroup(false, WithAuditLogger(logger))
+	toolset := NewToolset("issues", "Issue tools").
+		AddReadTools(mockTool("get_issue", true)).
+		AddWriteTools(mockTool("create_issue", false))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+	return tsg
+}
+
+func callActiveTool(t *testing.T, tsg *ToolsetGroup, name string, args map[string]any) {
+	t.Helper()
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			if tool.Tool.Name == name {
+				request := mcp.CallToolRequest{}
+				request.Params.Name = name
+				request.Params.Arguments = args
+				_, _ = tool.Handler(context.Background(), request)
+				return
+			}
+		}
+	}
+	t.Fatalf("tool %s is not active", name)
+}
+
+func TestAuditLogger_RedactsBody(t *testing.T) {
+	logger := &memoryAuditLogger{}
+	tsg := newAuditedToolsetGroup(logger)
+
+	callActiveTool(t, tsg, "create_issue", map[string]any{
+		"owner":        "octocat",
+		"repo":         "hello-world",
+		"title":        "Found a bug",
+		"body":         "the secret sauce is out",
+		"github_token": "ghp_abc",
+	})
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(logger.entries))
+	}
+	entry := logger.entries[0]
+	if entry.Tool != "create_issue" {
+		t.Errorf("Expected audit entry for create_issue, got %s", entry.Tool)
+	}
+	if !entry.Success {
+		t.Error("Expected audit entry to record success")
+	}
+	if entry.Arguments["owner"] != "octocat" || entry.Arguments["repo"] != "hello-world" {
+		t.Errorf("Expected identifying arguments to be kept, got %v", entry.Arguments)
+	}
+	if _, ok := entry.Arguments["title"]; ok {
+		t.Error("Expected non-identifier argument to be omitted")
+	}
+
+	body, ok := entry.Arguments["body"].(string)
+	if !ok || !strings.HasPrefix(body, "sha256:") || strings.Contains(body, "secret sauce") {
+		t.Errorf("Expected body to be hashed, got %v", entry.Arguments["body"])
+	}
+	if _, ok := entry.Arguments["github_token"]; ok {
+		t.Error("Expected secret-like argument to be omitted")
+	}
+}
+
+func TestAuditLogger_OmitsSecretValue(t *testing.T) {
+	logger := &memoryAuditLogger{}
+	tsg := NewToolsetGroup(false, WithAuditLogger(logger))
+	toolset := NewToolset("actions", "Actions tools").AddWriteTools(mockTool("create_or_update_actions_secret", false))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	callActiveTool(t, tsg, "create_or_update_actions_secret", map[string]any{
+		"owner":       "octocat",
+		"repo":        "hello-world",
+		"secret_name": "DEPLOY_KEY",
+		"value":       "hunter2",
+	})
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(logger.entries))
+	}
+	if _, ok := logger.entries[0].Arguments["value"]; ok {
+		t.Error("Expected secret value to be omitted")
+	}
+	if logger.entries[0].Arguments["repo"] != "hello-world" {
+		t.Errorf("Expected identifying arguments to be kept, got %v", logger.entries[0].Arguments)
+	}
+}
+
+func TestAuditLogger_ReadToolsProduceNoEntries(t *testing.T) {
+	logger := &memoryAuditLogger{}
+	tsg := newAuditedToolsetGroup(logger)
+
+	callActiveTool(t, tsg, "get_issue", map[string]any{"owner": "octocat", "repo": "hello-world", "issue_number": float64(1)})
+
+	if len(logger.entries) != 0 {
+		t.Errorf("Expected no audit entries for read tools, got %d", len(logger.entries))
+	}
+}
+
+func TestAuditLogger_CustomRedactedFields(t *testing.T) {
+	var entries []AuditEntry
+	tsg := NewToolsetGroup(false, WithAuditLogger(AuditLoggerFunc(func(_ context.Context, entry AuditEntry) {
+		entries = append(entries, entry)
+	}), "title", "key_prefix"))
+	toolset := NewToolset("issues", "Issue tools").AddWriteTools(mockTool("create_issue", false))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	callActiveTool(t, tsg, "create_issue", map[string]any{"title": "Found a bug", "body": "details", "key_prefix": "TICKET-"})
+
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(entries))
+	}
+	if title, ok := entries[0].Arguments["title"].(string); !ok || !strings.HasPrefix(title, "sha256:") {
+		t.Errorf("Expected title to be hashed, got %v", entries[0].Arguments["title"])
+	}
+	if prefix, ok := entries[0].Arguments["key_prefix"].(string); !ok || !strings.HasPrefix(prefix, "sha256:") {
+		t.Errorf("Expected key_prefix to be hashed rather than treated as a secret, got %v", entries[0].Arguments["key_prefix"])
+	}
+	if _, ok := entries[0].Arguments["body"]; ok {
+		t.Errorf("Expected body to be omitted when not in the redaction list, got %v", entries[0].Arguments["body"])
+	}
+}
+
+func TestAuditLogger_KeepsIdentifiers(t *testing.T) {
+	logger := &memoryAuditLogger{}
+	tsg := newAuditedToolsetGroup(logger)
+
+	args := map[string]any{
+		"owner":         "octocat",
+		"repo":          "hello-world",
+		"issue_number":  float64(1),
+		"pullNumber":    float64(2),
+		"deploy_key_id": float64(3),
+		"runID":         float64(4),
+		"branch":        "main",
+		"ref":           "refs/heads/main",
+		"tag":           "v1.0.0",
+	}
+	callActiveTool(t, tsg, "create_issue", args)
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(logger.entries))
+	}
+	for name, value := range args {
+		if logger.entries[0].Arguments[name] != value {
+			t.Errorf("Expected identifier %s to be kept, got %v", name, logger.entries[0].Arguments[name])
+		}
+	}
+}
+
+func TestAuditLogger_RecordsActor(t *testing.T) {
+	type actorKey struct{}
+	logger := &memoryAuditLogger{}
+	tsg := NewToolsetGroup(false, WithAuditLogger(logger), WithAuditActor(func(ctx context.Context) string {
+		actor, _ := ctx.Value(actorKey{}).(string)
+		return actor
+	}))
+	toolset := NewToolset("issues", "Issue tools").AddWriteTools(mockTool("create_issue", false))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "create_issue"
+	_, _ = toolset.GetActiveTools()[0].Handler(context.WithValue(context.Background(), actorKey{}, "octocat"), request)
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(logger.entries))
+	}
+	if logger.entries[0].Actor != "octocat" {
+		t.Errorf("Expected actor octocat, got %q", logger.entries[0].Actor)
+	}
+}
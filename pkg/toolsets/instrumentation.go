@@ -0,0 +1,159 @@
+package toolsets
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolInvocation describes a single completed tool call.
+type ToolInvocation struct {
+	// Name is the unprefixed name of the tool that was called
+	Name string
+	// Duration is the wall clock time spent in the tool handler
+	Duration time.Duration
+	// IsError is true if the handler returned an error or an error result
+	IsError bool
+	// StatusCodes are the HTTP status codes of the GitHub API responses observed during the call, in order
+	StatusCodes []int
+	// RateLimitRemaining is the GitHub API rate limit remaining as reported by the last response, or -1 if unknown
+	RateLimitRemaining int
+	// CacheHit is true if the result was memoized and the tool handler did not execute
+	CacheHit bool
+	// Retries is the number of GitHub API requests retried after a transient error during the call
+	Retries int
+}
+
+// Instrumenter receives callbacks around every tool invocation, allowing operators to collect
+// per-tool metrics without modifying the handlers.
+type Instrumenter interface {
+	OnToolStart(ctx context.Context, name string)
+	OnToolEnd(ctx context.Context, invocation ToolInvocation)
+}
+
+// NoopInstrumenter is an Instrumenter that does nothing.
+type NoopInstrumenter struct{}
+
+func (NoopInstrumenter) OnToolStart(context.Context, string)       {}
+func (NoopInstrumenter) OnToolEnd(context.Context, ToolInvocation) {}
+
+// InMemoryInstrumenter records tool invocations in memory. It is safe for concurrent use.
+type InMemoryInstrumenter struct {
+	mu          sync.Mutex
+	started     []string
+	invocations []ToolInvocation
+}
+
+func (i *InMemoryInstrumenter) OnToolStart(_ context.Context, name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.started = append(i.started, name)
+}
+
+func (i *InMemoryInstrumenter) OnToolEnd(_ context.Context, invocation ToolInvocation) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.invocations = append(i.invocations, invocation)
+}
+
+// Started returns the names of the tools that have been started, in order.
+func (i *InMemoryInstrumenter) Started() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]string(nil), i.started...)
+}
+
+// Invocations returns the completed tool invocations, in order.
+func (i *InMemoryInstrumenter) Invocations() []ToolInvocation {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]ToolInvocation(nil), i.invocations...)
+}
+
+type apiResponseRecorderKey struct{}
+
+// apiResponseRecorder collects details of the GitHub API responses made while handling a tool call.
+type apiResponseRecorder struct {
+	mu                 sync.Mutex
+	statusCodes        []int
+	rateLimitRemaining int
+	cacheHit           bool
+	retries            int
+}
+
+// RecordAPIResponse records the status code and rate limit of a GitHub API response against the
+// tool invocation in progress for ctx, if any. It is intended to be called from an http.RoundTripper.
+func RecordAPIResponse(ctx context.Context, resp *http.Response) {
+	recorder, ok := ctx.Value(apiResponseRecorderKey{}).(*apiResponseRecorder)
+	if !ok || resp == nil {
+		return
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.statusCodes = append(recorder.statusCodes, resp.StatusCode)
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		recorder.rateLimitRemaining = remaining
+	}
+}
+
+// RecordAPIRetry counts a retried GitHub API request against the tool invocation in progress for ctx,
+// if any. It is intended to be called from a retrying http.RoundTripper.
+func RecordAPIRetry(ctx context.Context) {
+	recorder, ok := ctx.Value(apiResponseRecorderKey{}).(*apiResponseRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.retries++
+}
+
+// recordCacheHit marks the tool invocation in progress for ctx, if any, as answered by a memoized result.
+func recordCacheHit(ctx context.Context) {
+	recorder, ok := ctx.Value(apiResponseRecorderKey{}).(*apiResponseRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.cacheHit = true
+}
+
+// InstrumentTool wraps the tool handler so that the instrumenter is notified when it starts and ends.
+func InstrumentTool(tool server.ServerTool, instrumenter Instrumenter) server.ServerTool {
+	name := tool.Tool.Name
+	handler := tool.Handler
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		recorder := &apiResponseRecorder{rateLimitRemaining: -1}
+		ctx = context.WithValue(ctx, apiResponseRecorderKey{}, recorder)
+
+		instrumenter.OnToolStart(ctx, name)
+		start := time.Now()
+		result, err := handler(ctx, request)
+		duration := time.Since(start)
+
+		recorder.mu.Lock()
+		invocation := ToolInvocation{
+			Name:               name,
+			Duration:           duration,
+			IsError:            err != nil || (result != nil && result.IsError),
+			StatusCodes:        append([]int(nil), recorder.statusCodes...),
+			RateLimitRemaining: recorder.rateLimitRemaining,
+			CacheHit:           recorder.cacheHit,
+			Retries:            recorder.retries,
+		}
+		recorder.mu.Unlock()
+
+		instrumenter.OnToolEnd(ctx, invocation)
+		return result, err
+	}
+	return tool
+}
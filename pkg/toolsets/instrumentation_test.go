@@ -0,0 +1,111 @@
+package toolsets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestInstrumenter_HooksFireWithNamesAndErrorFlags(t *testing.T) {
+	instrumenter := &InMemoryInstrumenter{}
+	tsg := NewToolsetGroup(false, WithInstrumenter(instrumenter), WithToolPrefix("github_"))
+
+	succeeding := mockTool("get_file_contents", true)
+	failing := NewServerTool(
+		mcp.NewTool("create_branch", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(false)})),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultError("branch already exists"), nil
+		},
+	)
+	erroring := NewServerTool(
+		mcp.NewTool("push_files", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(false)})),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return nil, errors.New("boom")
+		},
+	)
+	toolset := NewToolset("repos", "Repository tools").
+		AddReadTools(succeeding).
+		AddWriteTools(failing, erroring)
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	for _, tool := range tsg.Toolsets["repos"].GetActiveTools() {
+		_, _ = tool.Handler(context.Background(), mcp.CallToolRequest{})
+	}
+
+	started := instrumenter.Started()
+	if len(started) != 3 || started[0] != "get_file_contents" || started[1] != "create_branch" || started[2] != "push_files" {
+		t.Errorf("Expected start hooks for all tools by unprefixed name, got %v", started)
+	}
+
+	invocations := instrumenter.Invocations()
+	if len(invocations) != 3 {
+		t.Fatalf("Expected 3 invocations, got %d", len(invocations))
+	}
+	expected := map[string]bool{
+		"get_file_contents": false,
+		"create_branch":     true,
+		"push_files":        true,
+	}
+	for _, invocation := range invocations {
+		isError, ok := expected[invocation.Name]
+		if !ok {
+			t.Errorf("Unexpected invocation for tool %s", invocation.Name)
+			continue
+		}
+		if invocation.IsError != isError {
+			t.Errorf("Expected IsError=%t for %s, got %t", isError, invocation.Name, invocation.IsError)
+		}
+		if invocation.RateLimitRemaining != -1 {
+			t.Errorf("Expected unknown rate limit for %s, got %d", invocation.Name, invocation.RateLimitRemaining)
+		}
+	}
+}
+
+func TestInstrumenter_RecordsAPIResponses(t *testing.T) {
+	instrumenter := &InMemoryInstrumenter{}
+	tool := InstrumentTool(NewServerTool(
+		mcp.NewTool("get_me", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: boolPtr(true)})),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			RecordAPIResponse(ctx, &http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Ratelimit-Remaining": []string{"4999"}}})
+			RecordAPIResponse(ctx, &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{"X-Ratelimit-Remaining": []string{"4998"}}})
+			return mcp.NewToolResultText("ok"), nil
+		},
+	), instrumenter)
+
+	_, _ = tool.Handler(context.Background(), mcp.CallToolRequest{})
+
+	invocations := instrumenter.Invocations()
+	if len(invocations) != 1 {
+		t.Fatalf("Expected 1 invocation, got %d", len(invocations))
+	}
+	if got := invocations[0].StatusCodes; len(got) != 2 || got[0] != http.StatusOK || got[1] != http.StatusNotFound {
+		t.Errorf("Expected status codes [200 404], got %v", got)
+	}
+	if got := invocations[0].RateLimitRemaining; got != 4998 {
+		t.Errorf("Expected rate limit remaining 4998, got %d", got)
+	}
+
+	// Recording outside of an instrumented call is a no-op
+	RecordAPIResponse(context.Background(), &http.Response{StatusCode: http.StatusOK})
+}
+
+func TestInstrumenter_NoopDefault(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	if tsg.Instrumenter() != nil {
+		t.Error("Expected no instrumenter to be configured by default")
+	}
+
+	tool := InstrumentTool(mockTool("get_file_contents", true), NoopInstrumenter{})
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Errorf("Expected no-op instrumenter to pass through the result, got %v, %v", result, err)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,237 @@
+package toolsets
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultMemoizeTTL is how long memoized results are reused when no TTL is given.
+	DefaultMemoizeTTL = 30 * time.Second
+	// DefaultMemoizeMaxEntries is the number of memoized results kept when no size is given.
+	DefaultMemoizeMaxEntries = 256
+)
+
+// MemoizeStats are counters of a Memoizer since it was created.
+type MemoizeStats struct {
+	// Hits is the number of calls answered from memory
+	Hits int64
+	// Misses is the number of calls that executed the tool handler
+	Misses int64
+	// Evictions is the number of results dropped to stay within the maximum number of entries
+	Evictions int64
+	// Entries is the number of results currently held, including expired ones not yet evicted
+	Entries int
+}
+
+// MemoizeScopeFunc returns a value identifying who a call is made for, for example a hash of the
+// token of the request, so that results are only reused for calls of the same scope.
+type MemoizeScopeFunc func(ctx context.Context) string
+
+// MemoizerOption configures optional behaviour of a Memoizer.
+type MemoizerOption func(*Memoizer)
+
+// WithMemoizeScope separates the memoized results by the scope returned for each call, in addition
+// to the MCP session.
+func WithMemoizeScope(scope MemoizeScopeFunc) MemoizerOption {
+	return func(m *Memoizer) {
+		m.scope = scope
+	}
+}
+
+// Memoizer reuses the results of identical read-only tool calls for a short time, so that a model
+// re-reading the same file or pull request does not cost another round trip to the GitHub API.
+// Calls of write tools forget the results memoized for their session and scope, so that reading back
+// after a write sees its effect. It is safe for concurrent use.
+type Memoizer struct {
+	ttl        time.Duration
+	maxEntries int
+	scope      MemoizeScopeFunc
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru holds the memoEntry values, most recently used first
+	lru   *list.List
+	stats MemoizeStats
+}
+
+type memoEntry struct {
+	key     string
+	scope   memoScope
+	result  *mcp.CallToolResult
+	expires time.Time
+}
+
+// NewMemoizer returns a Memoizer keeping up to maxEntries results for ttl. A zero or negative ttl
+// or size selects DefaultMemoizeTTL or DefaultMemoizeMaxEntries.
+func NewMemoizer(ttl time.Duration, maxEntries int, opts ...MemoizerOption) *Memoizer {
+	if ttl <= 0 {
+		ttl = DefaultMemoizeTTL
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoizeMaxEntries
+	}
+	m := &Memoizer{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Stats returns the counters of the memoizer.
+func (m *Memoizer) Stats() MemoizeStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := m.stats
+	stats.Entries = m.lru.Len()
+	return stats
+}
+
+func (m *Memoizer) get(key string) (*mcp.CallToolResult, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		m.stats.Misses++
+		return nil, false
+	}
+	entry := element.Value.(*memoEntry)
+	if !m.now().Before(entry.expires) {
+		m.lru.Remove(element)
+		delete(m.entries, key)
+		m.stats.Misses++
+		return nil, false
+	}
+	m.lru.MoveToFront(element)
+	m.stats.Hits++
+	return entry.result, true
+}
+
+func (m *Memoizer) put(key string, scope memoScope, result *mcp.CallToolResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := &memoEntry{key: key, scope: scope, result: result, expires: m.now().Add(m.ttl)}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.lru.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(entry)
+	for m.lru.Len() > m.maxEntries {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoEntry).key)
+		m.stats.Evictions++
+	}
+}
+
+// forget drops the results memoized for a scope, whether they expired or not.
+func (m *Memoizer) forget(scope memoScope) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for element := m.lru.Front(); element != nil; {
+		next := element.Next()
+		if entry := element.Value.(*memoEntry); entry.scope == scope {
+			m.lru.Remove(element)
+			delete(m.entries, entry.key)
+		}
+		element = next
+	}
+}
+
+// memoScope identifies whose results a memoized result is: the MCP session and the scope returned by
+// the MemoizeScopeFunc.
+type memoScope struct {
+	session string
+	scope   string
+}
+
+func (m *Memoizer) scopeOf(ctx context.Context) memoScope {
+	var scope memoScope
+	if s := server.ClientSessionFromContext(ctx); s != nil {
+		scope.session = s.SessionID()
+	}
+	if m.scope != nil {
+		scope.scope = m.scope(ctx)
+	}
+	return scope
+}
+
+// key returns the memoization key of a call, or false if the call cannot be memoized.
+func (m *Memoizer) key(scope memoScope, name string, request mcp.CallToolRequest) (string, bool) {
+	// Maps are marshalled with sorted keys, which makes the arguments canonical
+	args, err := json.Marshal(request.GetArguments())
+	if err != nil {
+		return "", false
+	}
+	key, err := json.Marshal([]string{scope.session, scope.scope, name, string(args)})
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+type bypassMemoizeKey struct{}
+
+// ContextWithoutMemoization returns a copy of ctx for which tool calls always execute their handler.
+// Their results still replace the memoized ones.
+func ContextWithoutMemoization(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassMemoizeKey{}, true)
+}
+
+func memoizationBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassMemoizeKey{}).(bool)
+	return bypass
+}
+
+// MemoizeTool wraps the handler of a read-only tool so that identical calls within the TTL of the
+// memoizer return the first result. Only successful results are memoized. The results are not
+// refreshed when GitHub changes otherwise, so they can be stale for up to the TTL.
+//
+// The handler of a tool that is not annotated as read-only is never memoized. Instead, once it has run,
+// the results memoized for the session and scope of the call are forgotten, whether the write succeeded
+// or not, as a failed write may still have changed something.
+func MemoizeTool(tool server.ServerTool, memoizer *Memoizer) server.ServerTool {
+	handler := tool.Handler
+	if !IsReadOnlyTool(tool) {
+		tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			defer memoizer.forget(memoizer.scopeOf(ctx))
+			return handler(ctx, request)
+		}
+		return tool
+	}
+	name := tool.Tool.Name
+	tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		scope := memoizer.scopeOf(ctx)
+		key, ok := memoizer.key(scope, name, request)
+		if !ok {
+			return handler(ctx, request)
+		}
+		if !memoizationBypassed(ctx) {
+			if result, hit := memoizer.get(key); hit {
+				recordCacheHit(ctx)
+				return result, nil
+			}
+		}
+
+		result, err := handler(ctx, request)
+		if err == nil && result != nil && !result.IsError {
+			memoizer.put(key, scope, result)
+		}
+		return result, err
+	}
+	return tool
+}
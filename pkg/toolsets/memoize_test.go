@@ -0,0 +1,243 @@
+package toolsets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// countingTool returns a tool that counts its calls, failing when the "fail" argument is set.
+func countingTool(name string, readOnly bool, calls *int) server.ServerTool {
+	return NewServerTool(
+		mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})),
+		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			*calls++
+			if _, fail := request.GetArguments()["fail"]; fail {
+				return mcp.NewToolResultError("failed"), nil
+			}
+			return mcp.NewToolResultText(name), nil
+		},
+	)
+}
+
+func callWithArgs(ctx context.Context, tool server.ServerTool, args map[string]any) (*mcp.CallToolResult, error) {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+	return tool.Handler(ctx, request)
+}
+
+func TestMemoizeTool(t *testing.T) {
+	memoizer := NewMemoizer(time.Minute, 10)
+	now := time.Now()
+	memoizer.now = func() time.Time { return now }
+
+	var calls int
+	tool := MemoizeTool(countingTool("get_file_contents", true, &calls), memoizer)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		result, err := callWithArgs(ctx, tool, map[string]any{"owner": "octocat", "repo": "hello-world"})
+		if err != nil || result.IsError {
+			t.Fatalf("Expected the call to succeed, got %v, %+v", err, result)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the second identical call to be memoized, handler called %d times", calls)
+	}
+
+	// Arguments are canonicalized, so their order does not matter, but their values do
+	_, _ = callWithArgs(ctx, tool, map[string]any{"repo": "hello-world", "owner": "octocat"})
+	if calls != 1 {
+		t.Errorf("Expected arguments in another order to be memoized, handler called %d times", calls)
+	}
+	_, _ = callWithArgs(ctx, tool, map[string]any{"owner": "octocat", "repo": "other"})
+	if calls != 2 {
+		t.Errorf("Expected other arguments to call the handler, handler called %d times", calls)
+	}
+
+	// The escape hatch always calls the handler
+	_, _ = callWithArgs(ContextWithoutMemoization(ctx), tool, map[string]any{"owner": "octocat", "repo": "hello-world"})
+	if calls != 3 {
+		t.Errorf("Expected a bypassed call to call the handler, handler called %d times", calls)
+	}
+
+	// Results expire after the TTL
+	now = now.Add(time.Minute)
+	_, _ = callWithArgs(ctx, tool, map[string]any{"owner": "octocat", "repo": "hello-world"})
+	if calls != 4 {
+		t.Errorf("Expected an expired result to call the handler, handler called %d times", calls)
+	}
+
+	// Error results are not memoized
+	for i := 0; i < 2; i++ {
+		_, _ = callWithArgs(ctx, tool, map[string]any{"fail": true})
+	}
+	if calls != 6 {
+		t.Errorf("Expected error results not to be memoized, handler called %d times", calls)
+	}
+
+	stats := memoizer.Stats()
+	if stats.Hits != 2 || stats.Misses != 5 || stats.Entries != 2 {
+		t.Errorf("Expected 2 hits, 5 misses and 2 entries, got %+v", stats)
+	}
+}
+
+func TestMemoizeTool_WriteToolsAreNotMemoized(t *testing.T) {
+	memoizer := NewMemoizer(time.Minute, 10)
+	var readCalls, writeCalls int
+	tsg := NewToolsetGroup(false, WithMemoizer(memoizer))
+	toolset := NewToolset("repos", "Repository tools").
+		AddReadTools(countingTool("get_file_contents", true, &readCalls)).
+		AddWriteTools(countingTool("create_branch", false, &writeCalls))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	for _, tool := range tsg.Toolsets["repos"].GetActiveTools() {
+		for i := 0; i < 2; i++ {
+			_, _ = callWithArgs(context.Background(), tool, map[string]any{"branch": "main"})
+		}
+	}
+	if readCalls != 1 {
+		t.Errorf("Expected the read tool to be memoized, handler called %d times", readCalls)
+	}
+	if writeCalls != 2 {
+		t.Errorf("Expected the write tool never to be memoized, handler called %d times", writeCalls)
+	}
+
+	// A write tool wrapped directly is not memoized either
+	tool := MemoizeTool(countingTool("delete_file", false, &writeCalls), memoizer)
+	for i := 0; i < 2; i++ {
+		_, _ = callWithArgs(context.Background(), tool, nil)
+	}
+	if writeCalls != 4 {
+		t.Errorf("Expected the write tool never to be memoized, handler called %d times", writeCalls)
+	}
+}
+
+func TestMemoizeTool_WritesForgetResults(t *testing.T) {
+	type userKey struct{}
+	memoizer := NewMemoizer(time.Minute, 10, WithMemoizeScope(func(ctx context.Context) string {
+		user, _ := ctx.Value(userKey{}).(string)
+		return user
+	}))
+	var readCalls, writeCalls int
+	read := MemoizeTool(countingTool("is_repository_starred", true, &readCalls), memoizer)
+	write := MemoizeTool(countingTool("star_repository", false, &writeCalls), memoizer)
+	octocat := context.WithValue(context.Background(), userKey{}, "octocat")
+	monalisa := context.WithValue(context.Background(), userKey{}, "monalisa")
+	args := map[string]any{"owner": "octocat", "repo": "hello-world"}
+
+	_, _ = callWithArgs(octocat, read, args)
+	_, _ = callWithArgs(monalisa, read, args)
+	_, _ = callWithArgs(octocat, write, args)
+	if writeCalls != 1 {
+		t.Errorf("Expected the write tool to be called, handler called %d times", writeCalls)
+	}
+
+	// Reading back after a write sees its effect, the results of other scopes are kept
+	_, _ = callWithArgs(octocat, read, args)
+	if readCalls != 3 {
+		t.Errorf("Expected the read after a write to call the handler, handler called %d times", readCalls)
+	}
+	_, _ = callWithArgs(monalisa, read, args)
+	if readCalls != 3 {
+		t.Errorf("Expected the results of another scope to be kept, handler called %d times", readCalls)
+	}
+
+	// A failed write forgets the results as well, as it may have changed something
+	_, _ = callWithArgs(octocat, write, map[string]any{"fail": true})
+	_, _ = callWithArgs(octocat, read, args)
+	if readCalls != 4 {
+		t.Errorf("Expected the read after a failed write to call the handler, handler called %d times", readCalls)
+	}
+}
+
+func TestMemoizeTool_WithoutMemoization(t *testing.T) {
+	var memoizedCalls, pollingCalls int
+	tsg := NewToolsetGroup(false, WithMemoizer(NewMemoizer(time.Minute, 10)))
+	toolset := NewToolset("actions", "Actions tools").
+		AddReadTools(
+			countingTool("get_workflow_run", true, &memoizedCalls),
+			countingTool("wait_for_workflow_run", true, &pollingCalls),
+		).
+		WithoutMemoization("wait_for_workflow_run")
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	for _, tool := range tsg.Toolsets["actions"].GetActiveTools() {
+		for i := 0; i < 2; i++ {
+			_, _ = callWithArgs(context.Background(), tool, map[string]any{"run_id": 1})
+		}
+	}
+	if memoizedCalls != 1 {
+		t.Errorf("Expected the read tool to be memoized, handler called %d times", memoizedCalls)
+	}
+	if pollingCalls != 2 {
+		t.Errorf("Expected the excluded read tool not to be memoized, handler called %d times", pollingCalls)
+	}
+}
+
+func TestMemoizer_Eviction(t *testing.T) {
+	memoizer := NewMemoizer(time.Minute, 2)
+	var calls int
+	tool := MemoizeTool(countingTool("get_issue", true, &calls), memoizer)
+	ctx := context.Background()
+
+	for _, number := range []float64{1, 2, 1, 3} {
+		_, _ = callWithArgs(ctx, tool, map[string]any{"number": number})
+	}
+	// 1 was used more recently than 2, so 2 is the one evicted by 3
+	_, _ = callWithArgs(ctx, tool, map[string]any{"number": float64(1)})
+	if calls != 3 {
+		t.Errorf("Expected the most recently used result to be kept, handler called %d times", calls)
+	}
+	_, _ = callWithArgs(ctx, tool, map[string]any{"number": float64(2)})
+	if calls != 4 {
+		t.Errorf("Expected the least recently used result to be evicted, handler called %d times", calls)
+	}
+	if stats := memoizer.Stats(); stats.Evictions != 2 || stats.Entries != 2 {
+		t.Errorf("Expected 2 evictions and 2 entries, got %+v", stats)
+	}
+}
+
+func TestMemoizer_Scope(t *testing.T) {
+	type userKey struct{}
+	memoizer := NewMemoizer(time.Minute, 10, WithMemoizeScope(func(ctx context.Context) string {
+		user, _ := ctx.Value(userKey{}).(string)
+		return user
+	}))
+	var calls int
+	tool := MemoizeTool(countingTool("get_me", true, &calls), memoizer)
+
+	for _, user := range []string{"octocat", "monalisa", "octocat"} {
+		_, _ = callWithArgs(context.WithValue(context.Background(), userKey{}, user), tool, nil)
+	}
+	if calls != 2 {
+		t.Errorf("Expected results to be reused within a scope only, handler called %d times", calls)
+	}
+}
+
+func TestMemoizeTool_ReportsCacheHits(t *testing.T) {
+	instrumenter := &InMemoryInstrumenter{}
+	tsg := NewToolsetGroup(false, WithInstrumenter(instrumenter), WithMemoizer(NewMemoizer(time.Minute, 10)))
+	var calls int
+	toolset := NewToolset("repos", "Repository tools").AddReadTools(countingTool("get_file_contents", true, &calls))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	tool := tsg.Toolsets["repos"].GetActiveTools()[0]
+	for i := 0; i < 2; i++ {
+		_, _ = callWithArgs(context.Background(), tool, nil)
+	}
+
+	invocations := instrumenter.Invocations()
+	if len(invocations) != 2 {
+		t.Fatalf("Expected 2 invocations, got %d", len(invocations))
+	}
+	if invocations[0].CacheHit || !invocations[1].CacheHit {
+		t.Errorf("Expected only the second invocation to be a cache hit, got %+v", invocations)
+	}
+}
@@ -1,7 +1,11 @@
 package toolsets
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -29,6 +33,57 @@ func NewToolsetDoesNotExistError(name string) *ToolsetDoesNotExistError {
 	return &ToolsetDoesNotExistError{Name: name}
 }
 
+// UnknownToolsError is returned when a tool filter references tools that are not provided by any toolset.
+type UnknownToolsError struct {
+	Names []string
+}
+
+func (e *UnknownToolsError) Error() string {
+	return fmt.Sprintf("unknown tools in tool filter: %s", strings.Join(e.Names, ", "))
+}
+
+func (e *UnknownToolsError) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	if _, ok := target.(*UnknownToolsError); ok {
+		return true
+	}
+	return false
+}
+
+func NewUnknownToolsError(names []string) *UnknownToolsError {
+	return &UnknownToolsError{Names: names}
+}
+
+// ToolFilter restricts which tools of a toolset are exposed by the server.
+// If Allow is non-empty, only the listed tools are exposed. Tools listed in Deny are never exposed.
+type ToolFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// Allows reports whether the tool with the given name passes the filter.
+func (f *ToolFilter) Allows(name string) bool {
+	if f == nil {
+		return true
+	}
+	for _, denied := range f.Deny {
+		if denied == name {
+			return false
+		}
+	}
+	if len(f.Allow) == 0 {
+		return true
+	}
+	for _, allowed := range f.Allow {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
@@ -44,16 +99,39 @@ func NewServerResourceTemplate(resourceTemplate mcp.ResourceTemplate, handler se
 type ServerResourceTemplate struct {
 	resourceTemplate mcp.ResourceTemplate
 	handler          server.ResourceTemplateHandlerFunc
+	completion       ResourceCompletionFunc
+}
+
+// ResourceCompletionFunc returns candidate values for the named argument of a resource template,
+// given the partial value typed so far and the values of the arguments that are already resolved.
+type ResourceCompletionFunc func(ctx context.Context, argument string, value string, resolved map[string]string) (*mcp.CompleteResult, error)
+
+// WithCompletion returns a copy of the resource template that offers argument completion.
+func (t ServerResourceTemplate) WithCompletion(completion ResourceCompletionFunc) ServerResourceTemplate {
+	t.completion = completion
+	return t
 }
 
 // Toolset represents a collection of MCP functionality that can be enabled or disabled as a group.
 type Toolset struct {
-	Name        string
-	Description string
-	Enabled     bool
-	readOnly    bool
-	writeTools  []server.ServerTool
-	readTools   []server.ServerTool
+	Name         string
+	Description  string
+	Enabled      bool
+	readOnly     bool
+	toolFilter   *ToolFilter
+	toolPrefix   string
+	toolWrapper  ToolWrapper
+	instrumenter Instrumenter
+	memoizer     *Memoizer
+	auditLogger  AuditLogger
+	writeTools   []server.ServerTool
+	readTools    []server.ServerTool
+	// unmemoizedTools are the names of the read tools excluded from memoization
+	unmemoizedTools []string
+	// auditRedactedFields are the argument names hashed in audit entries
+	auditRedactedFields []string
+	// auditActor returns who made the call recorded in audit entries
+	auditActor AuditActorFunc
 	// resources are not tools, but the community seems to be moving towards namespaces as a broader concept
 	// and in order to have multiple servers running concurrently, we want to avoid overlapping resources too.
 	resourceTemplates []ServerResourceTemplate
@@ -61,33 +139,68 @@ type Toolset struct {
 
 func (t *Toolset) GetActiveTools() []server.ServerTool {
 	if t.Enabled {
-		if t.readOnly {
-			return t.readTools
-		}
-		return append(t.readTools, t.writeTools...)
+		return t.GetAvailableTools()
 	}
 	return nil
 }
 
 func (t *Toolset) GetAvailableTools() []server.ServerTool {
-	if t.readOnly {
-		return t.readTools
+	tools := t.prepareTools(t.readTools, false)
+	if !t.readOnly {
+		tools = append(tools, t.prepareTools(t.writeTools, true)...)
 	}
-	return append(t.readTools, t.writeTools...)
+	return tools
 }
 
 func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	if !t.Enabled {
 		return
 	}
-	for _, tool := range t.readTools {
+	for _, tool := range t.GetAvailableTools() {
 		s.AddTool(tool.Tool, tool.Handler)
 	}
-	if !t.readOnly {
-		for _, tool := range t.writeTools {
-			s.AddTool(tool.Tool, tool.Handler)
+}
+
+// prepareTools returns the tools that pass the toolset's tool filter, with the handler wrappers and
+// name prefix of the toolset applied, in a newly allocated slice.
+func (t *Toolset) prepareTools(tools []server.ServerTool, write bool) []server.ServerTool {
+	prepared := make([]server.ServerTool, 0, len(tools))
+	for _, tool := range tools {
+		if !t.toolFilter.Allows(tool.Tool.Name) {
+			continue
+		}
+		if t.toolWrapper != nil {
+			tool = t.toolWrapper(tool)
+		}
+		if t.readOnly {
+			// Guard every handler so that a mis-bucketed write tool still cannot execute in read-only mode
+			tool = EnforceReadOnly(tool)
+		}
+		if t.memoizer != nil && (write || !slices.Contains(t.unmemoizedTools, tool.Tool.Name)) {
+			tool = MemoizeTool(tool, t.memoizer)
+		}
+		if write && t.auditLogger != nil {
+			tool = AuditTool(tool, t.auditLogger, t.auditRedactedFields, t.auditActor)
+		}
+		if t.instrumenter != nil {
+			tool = InstrumentTool(tool, t.instrumenter)
 		}
+		tool.Tool.Name = t.toolPrefix + tool.Tool.Name
+		prepared = append(prepared, tool)
 	}
+	return prepared
+}
+
+// toolNames returns the names of all tools added to the toolset, regardless of read-only mode or filtering.
+func (t *Toolset) toolNames() []string {
+	names := make([]string, 0, len(t.readTools)+len(t.writeTools))
+	for _, tool := range t.readTools {
+		names = append(names, tool.Tool.Name)
+	}
+	for _, tool := range t.writeTools {
+		names = append(names, tool.Tool.Name)
+	}
+	return names
 }
 
 func (t *Toolset) AddResourceTemplates(templates ...ServerResourceTemplate) *Toolset {
@@ -99,18 +212,23 @@ func (t *Toolset) GetActiveResourceTemplates() []ServerResourceTemplate {
 	if !t.Enabled {
 		return nil
 	}
-	return t.resourceTemplates
+	return t.GetAvailableResourceTemplates()
 }
 
 func (t *Toolset) GetAvailableResourceTemplates() []ServerResourceTemplate {
-	return t.resourceTemplates
+	templates := make([]ServerResourceTemplate, len(t.resourceTemplates))
+	for i, resource := range t.resourceTemplates {
+		resource.resourceTemplate.Name = t.toolPrefix + resource.resourceTemplate.Name
+		templates[i] = resource
+	}
+	return templates
 }
 
 func (t *Toolset) RegisterResourcesTemplates(s *server.MCPServer) {
 	if !t.Enabled {
 		return
 	}
-	for _, resource := range t.resourceTemplates {
+	for _, resource := range t.GetAvailableResourceTemplates() {
 		s.AddResourceTemplate(resource.resourceTemplate, resource.handler)
 	}
 }
@@ -120,10 +238,48 @@ func (t *Toolset) SetReadOnly() {
 	t.readOnly = true
 }
 
+// SetToolFilter restricts the tools exposed by the toolset to those passing the filter.
+func (t *Toolset) SetToolFilter(filter *ToolFilter) {
+	t.toolFilter = filter
+}
+
+// SetToolPrefix sets a prefix that is prepended to the name of every tool and resource template
+// exposed by the toolset. Filtering still uses the unprefixed tool names.
+func (t *Toolset) SetToolPrefix(prefix string) {
+	t.toolPrefix = prefix
+}
+
+// SetToolWrapper sets the wrapper applied to every tool of the toolset, nil disables it.
+func (t *Toolset) SetToolWrapper(wrapper ToolWrapper) {
+	t.toolWrapper = wrapper
+}
+
+// SetInstrumenter sets the instrumenter notified around every tool invocation.
+func (t *Toolset) SetInstrumenter(instrumenter Instrumenter) {
+	t.instrumenter = instrumenter
+}
+
+// SetMemoizer sets the memoizer reusing the results of identical read-only tool calls, nil disables memoization.
+func (t *Toolset) SetMemoizer(memoizer *Memoizer) {
+	t.memoizer = memoizer
+}
+
+// SetAuditLogger sets the audit logger notified of every write tool invocation, hashing the values
+// of the given argument names in the logged entries.
+func (t *Toolset) SetAuditLogger(logger AuditLogger, redactedFields []string) {
+	t.auditLogger = logger
+	t.auditRedactedFields = redactedFields
+}
+
+// SetAuditActor sets the function returning who made the call recorded in audit entries.
+func (t *Toolset) SetAuditActor(actor AuditActorFunc) {
+	t.auditActor = actor
+}
+
 func (t *Toolset) AddWriteTools(tools ...server.ServerTool) *Toolset {
 	// Silently ignore if the toolset is read-only to avoid any breach of that contract
 	for _, tool := range tools {
-		if *tool.Tool.Annotations.ReadOnlyHint {
+		if IsReadOnlyTool(tool) {
 			panic(fmt.Sprintf("tool (%s) is incorrectly annotated as read-only", tool.Tool.Name))
 		}
 	}
@@ -133,9 +289,17 @@ func (t *Toolset) AddWriteTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// WithoutMemoization excludes the named read tools of the toolset from memoization. It is meant for
+// tools whose result changes between identical calls by design, such as tools polling for a state or
+// asking to be called again later.
+func (t *Toolset) WithoutMemoization(names ...string) *Toolset {
+	t.unmemoizedTools = append(t.unmemoizedTools, names...)
+	return t
+}
+
 func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	for _, tool := range tools {
-		if !*tool.Tool.Annotations.ReadOnlyHint {
+		if !IsReadOnlyTool(tool) {
 			panic(fmt.Sprintf("tool (%s) must be annotated as read-only", tool.Tool.Name))
 		}
 	}
@@ -143,27 +307,181 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// IsReadOnlyTool reports whether the tool is annotated with ReadOnlyHint=true.
+// Tools without the annotation are treated as write tools, as per the MCP specification default.
+func IsReadOnlyTool(tool server.ServerTool) bool {
+	hint := tool.Tool.Annotations.ReadOnlyHint
+	return hint != nil && *hint
+}
+
+// EnforceReadOnly wraps the tool handler so that it refuses to execute unless the tool is annotated
+// as read-only. It is applied to every tool exposed by a read-only toolset.
+func EnforceReadOnly(tool server.ServerTool) server.ServerTool {
+	if IsReadOnlyTool(tool) {
+		return tool
+	}
+	name := tool.Tool.Name
+	tool.Handler = func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError(fmt.Sprintf("tool %s is not annotated as read-only and cannot be called in read-only mode", name)), nil
+	}
+	return tool
+}
+
 type ToolsetGroup struct {
 	Toolsets     map[string]*Toolset
 	everythingOn bool
 	readOnly     bool
+	toolFilter   *ToolFilter
+	toolPrefix   string
+	toolWrapper  ToolWrapper
+	instrumenter Instrumenter
+	memoizer     *Memoizer
+	auditLogger  AuditLogger
+	// auditRedactedFields are the argument names hashed in audit entries
+	auditRedactedFields []string
+	// auditActor returns who made the call recorded in audit entries
+	auditActor AuditActorFunc
+}
+
+// ToolWrapper returns a tool with its handler wrapped, for example to post-process its results.
+type ToolWrapper func(tool server.ServerTool) server.ServerTool
+
+// ToolsetGroupOption configures optional behaviour of a ToolsetGroup.
+type ToolsetGroupOption func(*ToolsetGroup)
+
+// WithToolFilter restricts the tools registered by the group. If allow is non-empty, only the
+// listed tools are registered, and tools listed in deny are never registered. Names are checked
+// against the available tools by ValidateToolFilter.
+func WithToolFilter(allow []string, deny []string) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		if len(allow) == 0 && len(deny) == 0 {
+			tg.toolFilter = nil
+			return
+		}
+		tg.toolFilter = &ToolFilter{Allow: allow, Deny: deny}
+	}
+}
+
+// WithToolPrefix prefixes the name of every tool and resource template registered by the group,
+// e.g. "github_", so that the server can be composed with other MCP servers without name collisions.
+func WithToolPrefix(prefix string) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		tg.toolPrefix = prefix
+	}
+}
+
+// WithToolWrapper wraps the handler of every tool registered by the group, inside the memoization,
+// audit and instrumentation wrappers, so that memoized results and instrumented calls see the wrapped
+// handler.
+func WithToolWrapper(wrapper ToolWrapper) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		tg.toolWrapper = wrapper
+	}
+}
+
+// WithInstrumenter wraps every tool registered by the group so that the instrumenter is notified
+// around each invocation.
+func WithInstrumenter(instrumenter Instrumenter) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		tg.instrumenter = instrumenter
+	}
+}
+
+// WithMemoizer reuses the results of identical calls of the read-only tools registered by the group,
+// within the TTL of the memoizer. Write tools are never memoized, and calling one forgets the results
+// memoized for its session and scope.
+func WithMemoizer(memoizer *Memoizer) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		tg.memoizer = memoizer
+	}
+}
+
+// WithAuditLogger records an audit entry for every invocation of a write tool registered by the group.
+// The values of the redacted fields are hashed in the entries; if none are given, DefaultAuditRedactedFields
+// is used. Only identifier arguments such as owner, repo or issue_number are logged in clear, other
+// arguments are omitted.
+func WithAuditLogger(logger AuditLogger, redactedFields ...string) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		if len(redactedFields) == 0 {
+			redactedFields = DefaultAuditRedactedFields
+		}
+		tg.auditLogger = logger
+		tg.auditRedactedFields = redactedFields
+	}
+}
+
+// WithAuditActor records who made each audited call, as returned by actor for the context of the call.
+func WithAuditActor(actor AuditActorFunc) ToolsetGroupOption {
+	return func(tg *ToolsetGroup) {
+		tg.auditActor = actor
+	}
 }
 
-func NewToolsetGroup(readOnly bool) *ToolsetGroup {
-	return &ToolsetGroup{
+func NewToolsetGroup(readOnly bool, opts ...ToolsetGroupOption) *ToolsetGroup {
+	tg := &ToolsetGroup{
 		Toolsets:     make(map[string]*Toolset),
 		everythingOn: false,
 		readOnly:     readOnly,
 	}
+	for _, opt := range opts {
+		opt(tg)
+	}
+	return tg
 }
 
 func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
 	if tg.readOnly {
 		ts.SetReadOnly()
 	}
+	ts.SetToolFilter(tg.toolFilter)
+	ts.SetToolPrefix(tg.toolPrefix)
+	ts.SetToolWrapper(tg.toolWrapper)
+	ts.SetInstrumenter(tg.instrumenter)
+	ts.SetMemoizer(tg.memoizer)
+	ts.SetAuditLogger(tg.auditLogger, tg.auditRedactedFields)
+	ts.SetAuditActor(tg.auditActor)
 	tg.Toolsets[ts.Name] = ts
 }
 
+// ToolPrefix returns the prefix applied to the names of tools registered by the group.
+func (tg *ToolsetGroup) ToolPrefix() string {
+	return tg.toolPrefix
+}
+
+// Instrumenter returns the instrumenter configured for the group, or nil if there is none.
+func (tg *ToolsetGroup) Instrumenter() Instrumenter {
+	return tg.instrumenter
+}
+
+// ValidateToolFilter checks that every tool referenced by the group's tool filter is provided by
+// one of its toolsets, returning an UnknownToolsError listing any names that are not.
+func (tg *ToolsetGroup) ValidateToolFilter() error {
+	if tg.toolFilter == nil {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	for _, toolset := range tg.Toolsets {
+		for _, name := range toolset.toolNames() {
+			known[name] = true
+		}
+	}
+
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, name := range append(append([]string{}, tg.toolFilter.Allow...), tg.toolFilter.Deny...) {
+		if !known[name] && !seen[name] {
+			unknown = append(unknown, name)
+			seen[name] = true
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return NewUnknownToolsError(unknown)
+	}
+	return nil
+}
+
 func NewToolset(name string, description string) *Toolset {
 	return &Toolset{
 		Name:        name,
@@ -228,6 +546,25 @@ func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 	}
 }
 
+// CompleteResourceArgument returns completion values for an argument of the active resource template
+// with the given URI template. Templates without completion support yield an empty result.
+func (tg *ToolsetGroup) CompleteResourceArgument(ctx context.Context, uriTemplate string, argument string, value string, resolved map[string]string) (*mcp.CompleteResult, error) {
+	for _, toolset := range tg.Toolsets {
+		for _, resource := range toolset.GetActiveResourceTemplates() {
+			if resource.resourceTemplate.URITemplate == nil || resource.resourceTemplate.URITemplate.Raw() != uriTemplate {
+				continue
+			}
+			if resource.completion == nil {
+				result := &mcp.CompleteResult{}
+				result.Completion.Values = []string{}
+				return result, nil
+			}
+			return resource.completion(ctx, argument, value, resolved)
+		}
+	}
+	return nil, fmt.Errorf("resource template %s does not exist", uriTemplate)
+}
+
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	toolset, exists := tg.Toolsets[name]
 	if !exists {
@@ -235,3 +572,59 @@ func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
 	}
 	return toolset, nil
 }
+
+// LookupTool returns the active tool registered under the given name, with the prefix, filters and
+// handler wrappers of its toolset applied, so that it can be called as the server would call it.
+func (tg *ToolsetGroup) LookupTool(name string) (server.ServerTool, bool) {
+	for _, toolset := range tg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			if tool.Tool.Name == name {
+				return tool, true
+			}
+		}
+	}
+	return server.ServerTool{}, false
+}
+
+// ToolsetTool is a tool together with the toolset providing it.
+type ToolsetTool struct {
+	Toolset *Toolset
+	Tool    server.ServerTool
+}
+
+// AvailableTools returns the tools available in all toolsets of the group, whether the toolsets are
+// enabled or not, sorted by name. Tools removed by the read-only mode or the tool filter are omitted.
+func (tg *ToolsetGroup) AvailableTools() []ToolsetTool {
+	var tools []ToolsetTool
+	for _, toolset := range tg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools = append(tools, ToolsetTool{Toolset: toolset, Tool: tool})
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Tool.Tool.Name < tools[j].Tool.Tool.Name
+	})
+	return tools
+}
+
+// FindTool returns the tool available under the given name and the toolset providing it, whether
+// the toolset is enabled or not. Tools removed by the read-only mode or the tool filter are not found.
+func (tg *ToolsetGroup) FindTool(name string) (*Toolset, server.ServerTool, bool) {
+	for _, tool := range tg.AvailableTools() {
+		if tool.Tool.Tool.Name == name {
+			return tool.Toolset, tool.Tool, true
+		}
+	}
+	return nil, server.ServerTool{}, false
+}
+
+// AvailableToolNames returns the sorted names of the tools available in all toolsets of the group,
+// whether the toolsets are enabled or not.
+func (tg *ToolsetGroup) AvailableToolNames() []string {
+	tools := tg.AvailableTools()
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Tool.Tool.Name
+	}
+	return names
+}
@@ -1,8 +1,13 @@
 package toolsets
 
 import (
+	"context"
 	"errors"
+	"reflect"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -250,3 +255,360 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func mockTool(name string, readOnly bool) server.ServerTool {
+	return NewServerTool(
+		mcp.NewTool(name,
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				ReadOnlyHint: &readOnly,
+			}),
+		),
+		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(name), nil
+		},
+	)
+}
+
+func toolNames(tools []server.ServerTool) []string {
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Tool.Name)
+	}
+	return names
+}
+
+func newFilteredToolsetGroup(opts ...ToolsetGroupOption) *ToolsetGroup {
+	tsg := NewToolsetGroup(false, opts...)
+	toolset := NewToolset("repos", "Repository tools").
+		AddReadTools(mockTool("get_file_contents", true)).
+		AddWriteTools(
+			mockTool("create_branch", false),
+			mockTool("push_files", false),
+			mockTool("delete_file", false),
+		)
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+	return tsg
+}
+
+func TestToolFilter_Allowlist(t *testing.T) {
+	tsg := newFilteredToolsetGroup(WithToolFilter([]string{"get_file_contents", "create_branch"}, nil))
+
+	if err := tsg.ValidateToolFilter(); err != nil {
+		t.Fatalf("Expected no error validating tool filter, got: %v", err)
+	}
+
+	got := toolNames(tsg.Toolsets["repos"].GetActiveTools())
+	want := []string{"get_file_contents", "create_branch"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected active tools %v, got %v", want, got)
+	}
+}
+
+func TestToolFilter_Denylist(t *testing.T) {
+	tsg := newFilteredToolsetGroup(WithToolFilter(nil, []string{"push_files", "delete_file"}))
+
+	if err := tsg.ValidateToolFilter(); err != nil {
+		t.Fatalf("Expected no error validating tool filter, got: %v", err)
+	}
+
+	got := toolNames(tsg.Toolsets["repos"].GetAvailableTools())
+	want := []string{"get_file_contents", "create_branch"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected available tools %v, got %v", want, got)
+	}
+
+	// Denied tools must never reach the MCP server
+	s := server.NewMCPServer("test", "0.0.1")
+	tsg.RegisterAll(s)
+	registered := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	resp, ok := registered.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("Expected JSONRPCResponse, got %T", registered)
+	}
+	result, ok := resp.Result.(mcp.ListToolsResult)
+	if !ok {
+		t.Fatalf("Expected ListToolsResult, got %T", resp.Result)
+	}
+	for _, tool := range result.Tools {
+		if tool.Name == "push_files" || tool.Name == "delete_file" {
+			t.Errorf("Expected denied tool %s not to be registered", tool.Name)
+		}
+	}
+	if len(result.Tools) != 2 {
+		t.Errorf("Expected 2 registered tools, got %d", len(result.Tools))
+	}
+}
+
+func TestToolFilter_DenyTakesPrecedenceOverAllow(t *testing.T) {
+	tsg := newFilteredToolsetGroup(WithToolFilter([]string{"get_file_contents", "push_files"}, []string{"push_files"}))
+
+	got := toolNames(tsg.Toolsets["repos"].GetActiveTools())
+	want := []string{"get_file_contents"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected active tools %v, got %v", want, got)
+	}
+}
+
+func TestToolFilter_UnknownToolNames(t *testing.T) {
+	tsg := newFilteredToolsetGroup(WithToolFilter([]string{"get_file_contents", "not_a_tool"}, []string{"also_not_a_tool"}))
+
+	err := tsg.ValidateToolFilter()
+	if err == nil {
+		t.Fatal("Expected error validating tool filter with unknown tools")
+	}
+	if !errors.Is(err, NewUnknownToolsError(nil)) {
+		t.Errorf("Expected UnknownToolsError, got: %v", err)
+	}
+	if err.Error() != "unknown tools in tool filter: also_not_a_tool, not_a_tool" {
+		t.Errorf("Expected error to list unknown tools, got: %v", err)
+	}
+}
+
+func TestToolFilter_ReadOnlyStillKnowsWriteTools(t *testing.T) {
+	tsg := NewToolsetGroup(true, WithToolFilter(nil, []string{"push_files"}))
+	toolset := NewToolset("repos", "Repository tools").
+		AddReadTools(mockTool("get_file_contents", true)).
+		AddWriteTools(mockTool("push_files", false))
+	tsg.AddToolset(toolset)
+
+	if err := tsg.ValidateToolFilter(); err != nil {
+		t.Errorf("Expected no error validating tool filter in read-only mode, got: %v", err)
+	}
+}
+
+func TestAddTools_MisannotatedToolsAreRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		add  func(ts *Toolset)
+	}{
+		{
+			name: "write tool added as read tool",
+			add:  func(ts *Toolset) { ts.AddReadTools(mockTool("delete_file", false)) },
+		},
+		{
+			name: "read tool added as write tool",
+			add:  func(ts *Toolset) { ts.AddWriteTools(mockTool("get_file_contents", true)) },
+		},
+		{
+			name: "tool without read-only hint added as read tool",
+			add: func(ts *Toolset) {
+				ts.AddReadTools(NewServerTool(mcp.NewTool("no_hint"), nil))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("Expected mis-annotated tool to be rejected")
+				}
+			}()
+			tc.add(NewToolset("test-toolset", "A test toolset"))
+		})
+	}
+}
+
+func TestReadOnlyBlocksWriteHandlerEvenIfRegistered(t *testing.T) {
+	tsg := NewToolsetGroup(true)
+	toolset := NewToolset("repos", "Repository tools").
+		AddReadTools(mockTool("get_file_contents", true))
+	// Simulate a write tool that slipped into the read bucket
+	toolset.readTools = append(toolset.readTools, mockTool("delete_file", false))
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	s := server.NewMCPServer("test", "0.0.1")
+	tsg.RegisterAll(s)
+
+	callTool := func(name string) mcp.CallToolResult {
+		t.Helper()
+		msg := []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + name + `"}}`)
+		resp, ok := s.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("Expected JSONRPCResponse calling %s", name)
+		}
+		result, ok := resp.Result.(mcp.CallToolResult)
+		if !ok {
+			t.Fatalf("Expected CallToolResult, got %T", resp.Result)
+		}
+		return result
+	}
+
+	if result := callTool("get_file_contents"); result.IsError {
+		t.Error("Expected read-only tool to execute in read-only mode")
+	}
+	if result := callTool("delete_file"); !result.IsError {
+		t.Error("Expected write tool to be blocked in read-only mode")
+	}
+}
+
+func TestToolPrefix(t *testing.T) {
+	tsg := newFilteredToolsetGroup(
+		WithToolPrefix("github_"),
+		WithToolFilter(nil, []string{"delete_file"}),
+	)
+	tsg.Toolsets["repos"].AddResourceTemplates(NewServerResourceTemplate(
+		mcp.NewResourceTemplate("repo://{owner}/{repo}/contents{/path*}", "Repository Content"),
+		nil,
+	))
+
+	// Filtering still applies to the unprefixed names
+	if err := tsg.ValidateToolFilter(); err != nil {
+		t.Fatalf("Expected no error validating tool filter, got: %v", err)
+	}
+
+	got := toolNames(tsg.Toolsets["repos"].GetActiveTools())
+	want := []string{"github_get_file_contents", "github_create_branch", "github_push_files"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected prefixed tools %v, got %v", want, got)
+	}
+
+	templates := tsg.Toolsets["repos"].GetActiveResourceTemplates()
+	if len(templates) != 1 || templates[0].resourceTemplate.Name != "github_Repository Content" {
+		t.Errorf("Expected prefixed resource template name, got %+v", templates)
+	}
+
+	// The prefix must not leak into the stored tools
+	if name := tsg.Toolsets["repos"].readTools[0].Tool.Name; name != "get_file_contents" {
+		t.Errorf("Expected stored tool name to be unprefixed, got %s", name)
+	}
+}
+
+func TestToolWrapper(t *testing.T) {
+	var wrapped []string
+	tsg := newFilteredToolsetGroup(
+		WithToolPrefix("github_"),
+		WithToolWrapper(func(tool server.ServerTool) server.ServerTool {
+			// The wrapper sees the unprefixed name
+			wrapped = append(wrapped, tool.Tool.Name)
+			handler := tool.Handler
+			tool.Handler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				if _, err := handler(ctx, request); err != nil {
+					return nil, err
+				}
+				return mcp.NewToolResultText("wrapped"), nil
+			}
+			return tool
+		}),
+	)
+
+	tools := tsg.Toolsets["repos"].GetActiveTools()
+	if !reflect.DeepEqual(wrapped, []string{"get_file_contents", "create_branch", "push_files", "delete_file"}) {
+		t.Errorf("Expected every tool to be wrapped, got %v", wrapped)
+	}
+	for _, tool := range tools {
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error calling %s, got: %v", tool.Tool.Name, err)
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; text != "wrapped" {
+			t.Errorf("Expected the result of %s to be wrapped, got %s", tool.Tool.Name, text)
+		}
+	}
+}
+
+func TestCompleteResourceArgument(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("repos", "Repository tools").AddResourceTemplates(
+		NewServerResourceTemplate(
+			mcp.NewResourceTemplate("repo://{owner}/{repo}/contents{/path*}", "Repository Content"),
+			nil,
+		).WithCompletion(func(_ context.Context, argument string, value string, resolved map[string]string) (*mcp.CompleteResult, error) {
+			result := &mcp.CompleteResult{}
+			result.Completion.Values = []string{argument + ":" + value + ":" + resolved["owner"]}
+			return result, nil
+		}),
+		NewServerResourceTemplate(mcp.NewResourceTemplate("repo://{owner}/{repo}/issues/{number}", "Issue"), nil),
+	)
+	toolset.Enabled = true
+	tsg.AddToolset(toolset)
+
+	result, err := tsg.CompleteResourceArgument(context.Background(), "repo://{owner}/{repo}/contents{/path*}", "repo", "hel", map[string]string{"owner": "octocat"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(result.Completion.Values, []string{"repo:hel:octocat"}) {
+		t.Errorf("Expected completion to receive the argument and resolved values, got %v", result.Completion.Values)
+	}
+
+	result, err = tsg.CompleteResourceArgument(context.Background(), "repo://{owner}/{repo}/issues/{number}", "number", "", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Completion.Values) != 0 {
+		t.Errorf("Expected no values for a template without completion, got %v", result.Completion.Values)
+	}
+
+	if _, err := tsg.CompleteResourceArgument(context.Background(), "repo://{owner}/unknown", "owner", "", nil); err == nil {
+		t.Error("Expected error for an unknown resource template")
+	}
+}
+
+func TestLookupTool(t *testing.T) {
+	tsg := newFilteredToolsetGroup(
+		WithToolPrefix("github_"),
+		WithToolFilter(nil, []string{"delete_file"}),
+	)
+	disabled := NewToolset("issues", "Issue tools").AddReadTools(mockTool("get_issue", true))
+	tsg.AddToolset(disabled)
+
+	tool, ok := tsg.LookupTool("github_get_file_contents")
+	if !ok {
+		t.Fatal("Expected to find github_get_file_contents")
+	}
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("Expected the tool handler to succeed, got %v, %+v", err, result)
+	}
+
+	for _, name := range []string{
+		// Tools are looked up by their registered, prefixed name
+		"get_file_contents",
+		// Denied tools are not registered
+		"github_delete_file",
+		// Tools of disabled toolsets are not registered
+		"github_get_issue",
+		"github_unknown",
+	} {
+		if _, ok := tsg.LookupTool(name); ok {
+			t.Errorf("Expected %s not to be found", name)
+		}
+	}
+}
+
+func TestFindTool(t *testing.T) {
+	tsg := newFilteredToolsetGroup(
+		WithToolPrefix("github_"),
+		WithToolFilter(nil, []string{"delete_file"}),
+	)
+	disabled := NewToolset("issues", "Issue tools").AddReadTools(mockTool("get_issue", true))
+	tsg.AddToolset(disabled)
+
+	toolset, tool, ok := tsg.FindTool("github_get_issue")
+	if !ok {
+		t.Fatal("Expected to find the tool of a disabled toolset")
+	}
+	if toolset.Name != "issues" || tool.Tool.Name != "github_get_issue" {
+		t.Errorf("Expected github_get_issue of the issues toolset, got %s of %s", tool.Tool.Name, toolset.Name)
+	}
+
+	for _, name := range []string{"get_issue", "github_delete_file", "github_unknown"} {
+		if _, _, ok := tsg.FindTool(name); ok {
+			t.Errorf("Expected %s not to be found", name)
+		}
+	}
+
+	want := []string{"github_create_branch", "github_get_file_contents", "github_get_issue", "github_push_files"}
+	if got := tsg.AvailableToolNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected available tools %v, got %v", want, got)
+	}
+	tools := tsg.AvailableTools()
+	if len(tools) != len(want) {
+		t.Fatalf("Expected %d available tools, got %d", len(want), len(tools))
+	}
+	if tools[2].Toolset.Name != "issues" || tools[2].Tool.Tool.Name != "github_get_issue" {
+		t.Errorf("Expected github_get_issue of the issues toolset, got %s of %s", tools[2].Tool.Tool.Name, tools[2].Toolset.Name)
+	}
+}
@@ -3,9 +3,11 @@ package translations
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/spf13/viper"
 )
@@ -56,24 +58,100 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 		}
 }
 
-// DumpTranslationKeyMap writes the translation map to a json file called github-mcp-server-config.json
-func DumpTranslationKeyMap(translationKeyMap map[string]string) error {
-	file, err := os.Create("github-mcp-server-config.json")
+// LoadOverrides reads a JSON object mapping translation keys to strings from r.
+// Keys are normalised to upper case, matching the keys used by the translation helpers.
+func LoadOverrides(r io.Reader) (map[string]string, error) {
+	var raw map[string]string
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("error decoding translations: %w", err)
+	}
+
+	overrides := make(map[string]string, len(raw))
+	for key, value := range raw {
+		overrides[strings.ToUpper(key)] = value
+	}
+	return overrides, nil
+}
+
+// LoadOverridesFile reads a JSON object mapping translation keys to strings from the file at path.
+// A missing file results in an error wrapping os.ErrNotExist.
+func LoadOverridesFile(path string) (map[string]string, error) {
+	file, err := os.Open(path) //nolint:gosec // the path is provided by the operator on purpose
 	if err != nil {
-		return fmt.Errorf("error creating file: %v", err)
+		return nil, fmt.Errorf("error opening translations file: %w", err)
 	}
 	defer func() { _ = file.Close() }()
 
-	// marshal the map to json
+	return LoadOverrides(file)
+}
+
+// OverrideTranslationHelper returns a TranslationHelperFunc that resolves each key from the
+// GITHUB_MCP_ prefixed environment variable, then from the overrides, and finally falls back
+// to the default value. The returned function lists every key requested so far with its
+// resolved value, so that a complete template can be exported once all tools are constructed.
+func OverrideTranslationHelper(overrides map[string]string) (TranslationHelperFunc, func() map[string]string) {
+	var mu sync.Mutex
+	resolved := map[string]string{}
+
+	return func(key string, defaultValue string) string {
+			key = strings.ToUpper(key)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if value, exists := resolved[key]; exists {
+				return value
+			}
+
+			value := defaultValue
+			if override, exists := overrides[key]; exists {
+				value = override
+			}
+			if env, exists := os.LookupEnv("GITHUB_MCP_" + key); exists {
+				value = env
+			}
+			resolved[key] = value
+			return value
+		}, func() map[string]string {
+			mu.Lock()
+			defer mu.Unlock()
+
+			keys := make(map[string]string, len(resolved))
+			for key, value := range resolved {
+				keys[key] = value
+			}
+			return keys
+		}
+}
+
+// ExportTranslations writes the translations to w as an indented JSON object with sorted keys,
+// in the format accepted by LoadOverrides.
+func ExportTranslations(w io.Writer, translationKeyMap map[string]string) error {
+	// json.MarshalIndent sorts map keys, so the output is stable and easy to diff
 	jsonData, err := json.MarshalIndent(translationKeyMap, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling map to JSON: %v", err)
 	}
 
-	// write the json data to the file
-	if _, err := file.Write(jsonData); err != nil {
-		return fmt.Errorf("error writing to file: %v", err)
+	if _, err := w.Write(jsonData); err != nil {
+		return fmt.Errorf("error writing translations: %v", err)
 	}
 
 	return nil
 }
+
+// DumpTranslationKeyMap writes the translation map to a json file called github-mcp-server-config.json
+func DumpTranslationKeyMap(translationKeyMap map[string]string) error {
+	return ExportTranslationsFile("github-mcp-server-config.json", translationKeyMap)
+}
+
+// ExportTranslationsFile writes the translation map to the file at path, replacing any existing content.
+func ExportTranslationsFile(path string, translationKeyMap map[string]string) error {
+	file, err := os.Create(path) //nolint:gosec // the path is provided by the operator on purpose
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return ExportTranslations(file, translationKeyMap)
+}
@@ -0,0 +1,64 @@
+package translations
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOverrides(t *testing.T) {
+	overrides, err := LoadOverrides(strings.NewReader(`{"tool_get_me_description": "Who am I?"}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"TOOL_GET_ME_DESCRIPTION": "Who am I?"}, overrides)
+
+	_, err = LoadOverrides(strings.NewReader(`not json`))
+	assert.Error(t, err)
+}
+
+func TestLoadOverridesFile_MissingFile(t *testing.T) {
+	_, err := LoadOverridesFile(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "expected error to wrap os.ErrNotExist, got %v", err)
+}
+
+func TestOverrideTranslationHelper_Precedence(t *testing.T) {
+	t.Setenv("GITHUB_MCP_TOOL_FROM_ENV_DESCRIPTION", "from env")
+
+	helper, resolved := OverrideTranslationHelper(map[string]string{
+		"TOOL_OVERRIDDEN_DESCRIPTION": "from file",
+		"TOOL_FROM_ENV_DESCRIPTION":   "from file, shadowed by env",
+	})
+
+	assert.Equal(t, "from file", helper("TOOL_OVERRIDDEN_DESCRIPTION", "default"))
+	assert.Equal(t, "from env", helper("TOOL_FROM_ENV_DESCRIPTION", "default"))
+	assert.Equal(t, "default", helper("TOOL_MISSING_DESCRIPTION", "default"))
+	// Keys are case-insensitive
+	assert.Equal(t, "from file", helper("tool_overridden_description", "default"))
+
+	assert.Equal(t, map[string]string{
+		"TOOL_OVERRIDDEN_DESCRIPTION": "from file",
+		"TOOL_FROM_ENV_DESCRIPTION":   "from env",
+		"TOOL_MISSING_DESCRIPTION":    "default",
+	}, resolved())
+}
+
+func TestExportTranslations_RoundTrip(t *testing.T) {
+	translations := map[string]string{
+		"TOOL_B_DESCRIPTION": "b",
+		"TOOL_A_DESCRIPTION": "a",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportTranslations(&buf, translations))
+	assert.Less(t, strings.Index(buf.String(), "TOOL_A_DESCRIPTION"), strings.Index(buf.String(), "TOOL_B_DESCRIPTION"))
+
+	loaded, err := LoadOverrides(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, translations, loaded)
+}